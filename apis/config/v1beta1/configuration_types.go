@@ -21,6 +21,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
 	configv1alpha1 "k8s.io/component-base/config/v1alpha1"
 )
 
@@ -98,6 +99,10 @@ type Configuration struct {
 	// Resources provides additional configuration options for handling the resources.
 	Resources *Resources `json:"resources,omitempty"`
 
+	// TASDefragmentation controls the periodic defragmentation of the free
+	// capacity in the ResourceFlavors using Topology Aware Scheduling.
+	TASDefragmentation *TASDefragmentation `json:"tasDefragmentation,omitempty"`
+
 	// FeatureGates is a map of feature names to bools that allows to override the
 	// default enablement status of a feature. The map cannot be used in conjunction
 	// with passing the list of features via the command line argument "--feature-gates"
@@ -169,6 +174,19 @@ type ControllerMetrics struct {
 	// metrics will be reported.
 	// +optional
 	EnableClusterQueueResources bool `json:"enableClusterQueueResources,omitempty"`
+
+	// EnableTASDomainResources, if true the capacity and usage metrics of the
+	// topology domains of the TAS ResourceFlavors will be reported.
+	// +optional
+	EnableTASDomainResources bool `json:"enableTASDomainResources,omitempty"`
+
+	// TASDomainResourcesMaxDomains is the maximum number of topology domains,
+	// per TAS ResourceFlavor and topology level, for which the capacity and
+	// usage metrics are reported. The domains with the most Pods admitted by
+	// TAS are reported.
+	// Defaults to 100.
+	// +optional
+	TASDomainResourcesMaxDomains *int32 `json:"tasDomainResourcesMaxDomains,omitempty"`
 }
 
 // ControllerHealth defines the health configs.
@@ -265,6 +283,283 @@ type MultiKueue struct {
 	// Defaults to 15 minutes.
 	// +optional
 	WorkerLostTimeout *metav1.Duration `json:"workerLostTimeout,omitempty"`
+
+	// WorkerLostPolicy defines what happens to a workload when the connection
+	// with its reserving worker cluster is lost for longer than WorkerLostTimeout.
+	// Possible values are:
+	// - Requeue: the workload is evicted and requeued in the manager cluster.
+	// - Migrate: the checkpointable workloads, the ones with the
+	//   kueue.x-k8s.io/checkpointable annotation set to "true", keep their quota
+	//   reservation in the manager cluster and are dispatched to another worker
+	//   cluster. They are requeued if no other worker cluster reserves them
+	//   within another WorkerLostTimeout. The other workloads are requeued.
+	//
+	// Defaults to Requeue.
+	// +optional
+	WorkerLostPolicy *MultiKueueWorkerLostPolicy `json:"workerLostPolicy,omitempty"`
+
+	// Dispatcher defines how the workloads are dispatched to the worker clusters.
+	// If not set, the workloads are dispatched to all the worker clusters at once.
+	// +optional
+	Dispatcher *MultiKueueDispatcher `json:"dispatcher,omitempty"`
+
+	// ExternalFrameworks lists the job types, without a built-in MultiKueue
+	// adapter, synced by MultiKueue using unstructured objects.
+	// The job types are usually the ones listed in integrations.externalFrameworks.
+	// +optional
+	ExternalFrameworks []MultiKueueExternalFramework `json:"externalFrameworks,omitempty"`
+
+	// StatusMirroring configures the mirroring, in the manager cluster, of the
+	// events and of the pods status of the jobs running in the worker clusters.
+	// If not set, only the status of the jobs is copied.
+	// +optional
+	StatusMirroring *MultiKueueStatusMirroring `json:"statusMirroring,omitempty"`
+
+	// HealthCheck configures the active probing of the worker clusters. The
+	// workloads are not dispatched to the worker clusters failing the probes
+	// until they recover.
+	// If not set, only the connection to the worker clusters is checked.
+	// +optional
+	HealthCheck *MultiKueueHealthCheck `json:"healthCheck,omitempty"`
+
+	// ClusterProfile configures the connection to the worker clusters described
+	// by a ClusterProfile of the Cluster Inventory API.
+	// Requires the MultiKueueClusterProfile feature gate.
+	// +optional
+	ClusterProfile *MultiKueueClusterProfile `json:"clusterProfile,omitempty"`
+}
+
+type MultiKueueStatusMirroring struct {
+	// Events enables recording the events of the remote jobs, and of their
+	// pods, as events of the local jobs and workloads.
+	// +optional
+	Events bool `json:"events,omitempty"`
+
+	// Pods enables the summary of the phases of the remote pods in the
+	// .status.remotePods field of the local workloads.
+	// +optional
+	Pods bool `json:"pods,omitempty"`
+
+	// Interval is the minimum time between two mirrorings of the status
+	// of a workload.
+	//
+	// Defaults to 30 seconds.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// MaxEventsPerInterval is the maximum number of events mirrored for a
+	// workload in an interval. The most recent events are mirrored.
+	//
+	// Defaults to 10.
+	// +optional
+	MaxEventsPerInterval *int32 `json:"maxEventsPerInterval,omitempty"`
+}
+
+type MultiKueueHealthCheck struct {
+	// Interval is the time between two probes of a healthy worker cluster.
+	//
+	// Defaults to 1 minute.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// Timeout is the maximum duration of a probe.
+	//
+	// Defaults to 10 seconds.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// FailureThreshold is the number of consecutive failed probes after which
+	// the worker cluster is considered unhealthy.
+	//
+	// Defaults to 3.
+	// +optional
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+
+	// InitialBackoff is the time after which an unhealthy worker cluster is
+	// probed again. It's doubled after each failed probe, up to MaxBackoff.
+	//
+	// Defaults to 30 seconds.
+	// +optional
+	InitialBackoff *metav1.Duration `json:"initialBackoff,omitempty"`
+
+	// MaxBackoff is the maximum time after which an unhealthy worker cluster
+	// is probed again.
+	//
+	// Defaults to 10 minutes.
+	// +optional
+	MaxBackoff *metav1.Duration `json:"maxBackoff,omitempty"`
+
+	// CanaryNamespace is the namespace, in the worker clusters, of the canary
+	// Workload created in dry-run mode to probe the Kueue webhooks.
+	//
+	// Defaults to "default".
+	// +optional
+	CanaryNamespace *string `json:"canaryNamespace,omitempty"`
+}
+
+type MultiKueueClusterProfile struct {
+	// CredentialsProviders are the providers of the credentials used to connect
+	// to the worker clusters described by a ClusterProfile. The first provider,
+	// in this list, published in the status of the ClusterProfile is used.
+	// +optional
+	CredentialsProviders []MultiKueueCredentialsProvider `json:"credentialsProviders,omitempty"`
+}
+
+type MultiKueueCredentialsProvider struct {
+	// Name is the name of the provider, as published in the status of the
+	// ClusterProfiles.
+	Name string `json:"name"`
+
+	// ExecConfig is the exec plugin run to get the credentials of the worker
+	// clusters. The credentials are refreshed when they expire.
+	ExecConfig clientcmdv1.ExecConfig `json:"execConfig"`
+}
+
+// MultiKueueExternalFramework defines how MultiKueue syncs a job type without
+// a built-in adapter.
+type MultiKueueExternalFramework struct {
+	// Name is the GVK of the job type in the format `Kind.version.group.com`.
+	Name string `json:"name"`
+
+	// ManagedByPath is the path of the field of the job pointing to the
+	// controller managing the job, like `.spec.managedBy`. A job is synced by
+	// MultiKueue only if the field is set to `kueue.x-k8s.io/multikueue`.
+	// The field is removed in the copy of the job created in the worker cluster.
+	//
+	// Defaults to `.spec.managedBy`.
+	// +optional
+	ManagedByPath *string `json:"managedByPath,omitempty"`
+
+	// StatusPaths are the paths of the fields copied from the job running in
+	// the worker cluster into the job in the manager cluster. The paths must
+	// be in the status of the job, like `.status.conditions`.
+	//
+	// Defaults to `[".status"]`.
+	// +optional
+	StatusPaths []string `json:"statusPaths,omitempty"`
+}
+
+type MultiKueueWorkerLostPolicy string
+
+const (
+	// MultiKueueWorkerLostPolicyRequeue evicts and requeues the workloads
+	// which lost their reserving worker cluster.
+	MultiKueueWorkerLostPolicyRequeue MultiKueueWorkerLostPolicy = "Requeue"
+
+	// MultiKueueWorkerLostPolicyMigrate dispatches the checkpointable workloads
+	// which lost their reserving worker cluster to another worker cluster,
+	// keeping their quota reservation.
+	MultiKueueWorkerLostPolicyMigrate MultiKueueWorkerLostPolicy = "Migrate"
+)
+
+type MultiKueueDispatcherName string
+
+const (
+	// MultiKueueDispatcherAllAtOnce dispatches the workloads to all the worker
+	// clusters at once.
+	MultiKueueDispatcherAllAtOnce MultiKueueDispatcherName = "AllAtOnce"
+
+	// MultiKueueDispatcherIncremental dispatches the workloads to a subset of
+	// the worker clusters, and extends the subset when the workloads don't get
+	// a quota reservation in time.
+	MultiKueueDispatcherIncremental MultiKueueDispatcherName = "Incremental"
+
+	// MultiKueueDispatcherScoring dispatches the workloads to the single worker
+	// cluster with the fewest pending workloads in its ClusterQueue.
+	MultiKueueDispatcherScoring MultiKueueDispatcherName = "Scoring"
+
+	// MultiKueueDispatcherCapacityAware dispatches the workloads to the single
+	// worker cluster most likely to admit them right away, based on the
+	// capacity of its ClusterQueues.
+	MultiKueueDispatcherCapacityAware MultiKueueDispatcherName = "CapacityAware"
+)
+
+type MultiKueueDispatcher struct {
+	// Name is the name of the dispatcher. Possible values are:
+	// - AllAtOnce: the workloads are dispatched to all the worker clusters at once.
+	// - Incremental: the workloads are dispatched to incrementalClusters worker
+	//   clusters, and to incrementalClusters more worker clusters every
+	//   incrementalTimeout, until a worker cluster reserves the quota.
+	// - Scoring: the workloads are dispatched to the single worker cluster with
+	//   the fewest pending workloads in the ClusterQueue pointed by their
+	//   LocalQueue.
+	// - CapacityAware: the workloads are dispatched to the single worker cluster
+	//   most likely to admit them right away, based on the capacity of its
+	//   ClusterQueues, skipping the worker clusters where they can never fit.
+	//
+	// Defaults to AllAtOnce.
+	// +optional
+	Name MultiKueueDispatcherName `json:"name,omitempty"`
+
+	// IncrementalClusters is the number of worker clusters a workload is
+	// dispatched to at a time by the Incremental dispatcher.
+	//
+	// Defaults to 3.
+	// +optional
+	IncrementalClusters *int32 `json:"incrementalClusters,omitempty"`
+
+	// IncrementalTimeout is the time the Incremental dispatcher waits for a
+	// quota reservation before dispatching the workload to more worker clusters.
+	//
+	// Defaults to 5 minutes.
+	// +optional
+	IncrementalTimeout *metav1.Duration `json:"incrementalTimeout,omitempty"`
+
+	// ScoringTimeout is the time the Scoring and CapacityAware dispatchers
+	// wait for a quota reservation in the selected worker cluster before
+	// scoring the other worker clusters again and dispatching the workload to
+	// the best of them.
+	//
+	// Defaults to 5 minutes.
+	// +optional
+	ScoringTimeout *metav1.Duration `json:"scoringTimeout,omitempty"`
+}
+
+type TASDefragmentation struct {
+	// enable indicates whether to run the defragmentation of the free capacity
+	// in the TAS ResourceFlavors. It requires the TopologyAwareScheduling
+	// feature gate.
+	// Defaults to false.
+	Enable bool `json:"enable"`
+
+	// interval defines the time interval between two consecutive
+	// defragmentation runs.
+	// Defaults to 5min.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// dryRun indicates that the planned evictions are only reported as events
+	// on the ResourceFlavors, without evicting the workloads.
+	// Defaults to false.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// level is the topology level, like a rack or a block, at which the
+	// defragmentation frees the topology domains. Topologies not defining
+	// the level are not defragmented.
+	// If not set, the highest level of each topology is used.
+	// +optional
+	Level *string `json:"level,omitempty"`
+
+	// minFreeDomains is the number of topology domains at the level which are
+	// kept free of the TAS workloads. The defragmentation only evicts workloads
+	// when there are less free domains.
+	// Defaults to 1.
+	// +optional
+	MinFreeDomains *int32 `json:"minFreeDomains,omitempty"`
+
+	// maxEvictionsPerInterval is the disruption budget, the maximum number of
+	// workloads evicted in a single defragmentation run.
+	// Defaults to 1.
+	// +optional
+	MaxEvictionsPerInterval *int32 `json:"maxEvictionsPerInterval,omitempty"`
+
+	// maxPriority is the highest priority of the workloads which can be
+	// evicted by the defragmentation. Only the workloads with the
+	// kueue.x-k8s.io/checkpointable annotation set to "true" are evicted.
+	// Defaults to 0.
+	// +optional
+	MaxPriority *int32 `json:"maxPriority,omitempty"`
 }
 
 type RequeuingStrategy struct {
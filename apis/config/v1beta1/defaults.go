@@ -23,6 +23,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	configv1alpha1 "k8s.io/component-base/config/v1alpha1"
 	"k8s.io/utils/ptr"
@@ -31,28 +32,46 @@ import (
 )
 
 const (
-	DefaultNamespace                                    = "kueue-system"
-	DefaultWebhookServiceName                           = "kueue-webhook-service"
-	DefaultWebhookSecretName                            = "kueue-webhook-server-cert"
-	DefaultWebhookPort                                  = 9443
-	DefaultHealthProbeBindAddress                       = ":8081"
-	DefaultMetricsBindAddress                           = ":8443"
-	DefaultLeaderElectionID                             = "c1f6bfd2.kueue.x-k8s.io"
-	DefaultLeaderElectionLeaseDuration                  = 15 * time.Second
-	DefaultLeaderElectionRenewDeadline                  = 10 * time.Second
-	DefaultLeaderElectionRetryPeriod                    = 2 * time.Second
-	DefaultClientConnectionQPS                  float32 = 20.0
-	DefaultClientConnectionBurst                int32   = 30
-	defaultPodsReadyTimeout                             = 5 * time.Minute
-	DefaultQueueVisibilityUpdateIntervalSeconds int32   = 5
-	DefaultClusterQueuesMaxCount                int32   = 10
-	defaultJobFrameworkName                             = "batch/job"
-	DefaultMultiKueueGCInterval                         = time.Minute
-	DefaultMultiKueueOrigin                             = "multikueue"
-	DefaultMultiKueueWorkerLostTimeout                  = 15 * time.Minute
-	DefaultRequeuingBackoffBaseSeconds                  = 60
-	DefaultRequeuingBackoffMaxSeconds                   = 3600
-	DefaultResourceTransformationStrategy               = Retain
+	DefaultNamespace                                     = "kueue-system"
+	DefaultWebhookServiceName                            = "kueue-webhook-service"
+	DefaultWebhookSecretName                             = "kueue-webhook-server-cert"
+	DefaultWebhookPort                                   = 9443
+	DefaultHealthProbeBindAddress                        = ":8081"
+	DefaultMetricsBindAddress                            = ":8443"
+	DefaultLeaderElectionID                              = "c1f6bfd2.kueue.x-k8s.io"
+	DefaultLeaderElectionLeaseDuration                   = 15 * time.Second
+	DefaultLeaderElectionRenewDeadline                   = 10 * time.Second
+	DefaultLeaderElectionRetryPeriod                     = 2 * time.Second
+	DefaultClientConnectionQPS                   float32 = 20.0
+	DefaultClientConnectionBurst                 int32   = 30
+	defaultPodsReadyTimeout                              = 5 * time.Minute
+	DefaultQueueVisibilityUpdateIntervalSeconds  int32   = 5
+	DefaultClusterQueuesMaxCount                 int32   = 10
+	defaultJobFrameworkName                              = "batch/job"
+	DefaultMultiKueueGCInterval                          = time.Minute
+	DefaultMultiKueueOrigin                              = "multikueue"
+	DefaultMultiKueueWorkerLostTimeout                   = 15 * time.Minute
+	DefaultMultiKueueStatusMirroringInterval             = 30 * time.Second
+	DefaultMultiKueueStatusMirroringMaxEvents    int32   = 10
+	DefaultMultiKueueHealthCheckInterval                 = time.Minute
+	DefaultMultiKueueHealthCheckTimeout                  = 10 * time.Second
+	DefaultMultiKueueHealthCheckFailureThreshold int32   = 3
+	DefaultMultiKueueHealthCheckInitialBackoff           = 30 * time.Second
+	DefaultMultiKueueHealthCheckMaxBackoff               = 10 * time.Minute
+	DefaultMultiKueueHealthCheckCanaryNamespace          = "default"
+	DefaultMultiKueueIncrementalClusters         int32   = 3
+	DefaultMultiKueueIncrementalTimeout                  = 5 * time.Minute
+	DefaultMultiKueueScoringTimeout                      = 5 * time.Minute
+	DefaultMultiKueueManagedByPath                       = ".spec.managedBy"
+	DefaultMultiKueueStatusPath                          = ".status"
+	DefaultRequeuingBackoffBaseSeconds                   = 60
+	DefaultRequeuingBackoffMaxSeconds                    = 3600
+	DefaultResourceTransformationStrategy                = Retain
+	DefaultTASDefragmentationInterval                    = 5 * time.Minute
+	DefaultTASDefragmentationMinFreeDomains      int32   = 1
+	DefaultTASDefragmentationMaxEvictions        int32   = 1
+	DefaultTASDefragmentationMaxPriority         int32   = 0
+	DefaultTASDomainResourcesMaxDomains          int32   = 100
 )
 
 func getOperatorNamespace() string {
@@ -77,6 +96,9 @@ func SetDefaults_Configuration(cfg *Configuration) {
 	if len(cfg.Metrics.BindAddress) == 0 {
 		cfg.Metrics.BindAddress = DefaultMetricsBindAddress
 	}
+	if cfg.Metrics.EnableTASDomainResources && cfg.Metrics.TASDomainResourcesMaxDomains == nil {
+		cfg.Metrics.TASDomainResourcesMaxDomains = ptr.To(DefaultTASDomainResourcesMaxDomains)
+	}
 	if len(cfg.Health.HealthProbeBindAddress) == 0 {
 		cfg.Health.HealthProbeBindAddress = DefaultHealthProbeBindAddress
 	}
@@ -207,10 +229,88 @@ func SetDefaults_Configuration(cfg *Configuration) {
 	if cfg.MultiKueue.WorkerLostTimeout == nil {
 		cfg.MultiKueue.WorkerLostTimeout = &metav1.Duration{Duration: DefaultMultiKueueWorkerLostTimeout}
 	}
+	if cfg.MultiKueue.WorkerLostPolicy == nil {
+		cfg.MultiKueue.WorkerLostPolicy = ptr.To(MultiKueueWorkerLostPolicyRequeue)
+	}
+	if m := cfg.MultiKueue.StatusMirroring; m != nil {
+		if m.Interval == nil {
+			m.Interval = &metav1.Duration{Duration: DefaultMultiKueueStatusMirroringInterval}
+		}
+		if m.MaxEventsPerInterval == nil {
+			m.MaxEventsPerInterval = ptr.To(DefaultMultiKueueStatusMirroringMaxEvents)
+		}
+	}
+	if h := cfg.MultiKueue.HealthCheck; h != nil {
+		if h.Interval == nil {
+			h.Interval = &metav1.Duration{Duration: DefaultMultiKueueHealthCheckInterval}
+		}
+		if h.Timeout == nil {
+			h.Timeout = &metav1.Duration{Duration: DefaultMultiKueueHealthCheckTimeout}
+		}
+		if h.FailureThreshold == nil {
+			h.FailureThreshold = ptr.To(DefaultMultiKueueHealthCheckFailureThreshold)
+		}
+		if h.InitialBackoff == nil {
+			h.InitialBackoff = &metav1.Duration{Duration: DefaultMultiKueueHealthCheckInitialBackoff}
+		}
+		if h.MaxBackoff == nil {
+			h.MaxBackoff = &metav1.Duration{Duration: DefaultMultiKueueHealthCheckMaxBackoff}
+		}
+		if h.CanaryNamespace == nil {
+			h.CanaryNamespace = ptr.To(DefaultMultiKueueHealthCheckCanaryNamespace)
+		}
+	}
+	if d := cfg.MultiKueue.Dispatcher; d != nil {
+		if d.Name == "" {
+			d.Name = MultiKueueDispatcherAllAtOnce
+		}
+		if d.Name == MultiKueueDispatcherIncremental {
+			if d.IncrementalClusters == nil {
+				d.IncrementalClusters = ptr.To(DefaultMultiKueueIncrementalClusters)
+			}
+			if d.IncrementalTimeout == nil {
+				d.IncrementalTimeout = &metav1.Duration{Duration: DefaultMultiKueueIncrementalTimeout}
+			}
+		}
+		if (d.Name == MultiKueueDispatcherScoring || d.Name == MultiKueueDispatcherCapacityAware) && d.ScoringTimeout == nil {
+			d.ScoringTimeout = &metav1.Duration{Duration: DefaultMultiKueueScoringTimeout}
+		}
+	}
+	for i := range cfg.MultiKueue.ExternalFrameworks {
+		framework := &cfg.MultiKueue.ExternalFrameworks[i]
+		if framework.ManagedByPath == nil {
+			framework.ManagedByPath = ptr.To(DefaultMultiKueueManagedByPath)
+		}
+		if len(framework.StatusPaths) == 0 {
+			framework.StatusPaths = []string{DefaultMultiKueueStatusPath}
+		}
+	}
+	if cp := cfg.MultiKueue.ClusterProfile; cp != nil {
+		for i := range cp.CredentialsProviders {
+			if exec := &cp.CredentialsProviders[i].ExecConfig; exec.InteractiveMode == "" {
+				exec.InteractiveMode = clientcmdv1.NeverExecInteractiveMode
+			}
+		}
+	}
 	if fs := cfg.FairSharing; fs != nil && fs.Enable && len(fs.PreemptionStrategies) == 0 {
 		fs.PreemptionStrategies = []PreemptionStrategy{LessThanOrEqualToFinalShare, LessThanInitialShare}
 	}
 
+	if d := cfg.TASDefragmentation; d != nil && d.Enable {
+		if d.Interval == nil {
+			d.Interval = &metav1.Duration{Duration: DefaultTASDefragmentationInterval}
+		}
+		if d.MinFreeDomains == nil {
+			d.MinFreeDomains = ptr.To(DefaultTASDefragmentationMinFreeDomains)
+		}
+		if d.MaxEvictionsPerInterval == nil {
+			d.MaxEvictionsPerInterval = ptr.To(DefaultTASDefragmentationMaxEvictions)
+		}
+		if d.MaxPriority == nil {
+			d.MaxPriority = ptr.To(DefaultTASDefragmentationMaxPriority)
+		}
+	}
+
 	if cfg.Resources != nil {
 		for idx := range cfg.Resources.Transformations {
 			if ptr.Deref(cfg.Resources.Transformations[idx].Strategy, "") == "" {
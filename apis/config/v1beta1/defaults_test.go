@@ -96,6 +96,7 @@ func TestSetDefaults_Configuration(t *testing.T) {
 		GCInterval:        &metav1.Duration{Duration: DefaultMultiKueueGCInterval},
 		Origin:            ptr.To(DefaultMultiKueueOrigin),
 		WorkerLostTimeout: &metav1.Duration{Duration: DefaultMultiKueueWorkerLostTimeout},
+		WorkerLostPolicy:  ptr.To(MultiKueueWorkerLostPolicyRequeue),
 	}
 
 	podsReadyTimeout := metav1.Duration{Duration: defaultPodsReadyTimeout}
@@ -536,6 +537,193 @@ func TestSetDefaults_Configuration(t *testing.T) {
 					GCInterval:        &metav1.Duration{Duration: time.Second},
 					Origin:            ptr.To("multikueue-manager1"),
 					WorkerLostTimeout: &metav1.Duration{Duration: time.Minute},
+					WorkerLostPolicy:  ptr.To(MultiKueueWorkerLostPolicyRequeue),
+				},
+				ManagedJobsNamespaceSelector: defaultManagedJobsNamespaceSelector,
+			},
+		},
+		"multiKueue incremental dispatcher": {
+			original: &Configuration{
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				MultiKueue: &MultiKueue{
+					Dispatcher: &MultiKueueDispatcher{
+						Name: MultiKueueDispatcherIncremental,
+					},
+				},
+			},
+			want: &Configuration{
+				Namespace:         ptr.To(DefaultNamespace),
+				ControllerManager: defaultCtrlManagerConfigurationSpec,
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				ClientConnection: defaultClientConnection,
+				Integrations:     defaultIntegrations,
+				QueueVisibility:  defaultQueueVisibility,
+				MultiKueue: &MultiKueue{
+					GCInterval:        &metav1.Duration{Duration: DefaultMultiKueueGCInterval},
+					Origin:            ptr.To(DefaultMultiKueueOrigin),
+					WorkerLostTimeout: &metav1.Duration{Duration: DefaultMultiKueueWorkerLostTimeout},
+					WorkerLostPolicy:  ptr.To(MultiKueueWorkerLostPolicyRequeue),
+					Dispatcher: &MultiKueueDispatcher{
+						Name:                MultiKueueDispatcherIncremental,
+						IncrementalClusters: ptr.To(DefaultMultiKueueIncrementalClusters),
+						IncrementalTimeout:  &metav1.Duration{Duration: DefaultMultiKueueIncrementalTimeout},
+					},
+				},
+				ManagedJobsNamespaceSelector: defaultManagedJobsNamespaceSelector,
+			},
+		},
+		"multiKueue scoring dispatcher": {
+			original: &Configuration{
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				MultiKueue: &MultiKueue{
+					Dispatcher: &MultiKueueDispatcher{
+						Name: MultiKueueDispatcherScoring,
+					},
+				},
+			},
+			want: &Configuration{
+				Namespace:         ptr.To(DefaultNamespace),
+				ControllerManager: defaultCtrlManagerConfigurationSpec,
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				ClientConnection: defaultClientConnection,
+				Integrations:     defaultIntegrations,
+				QueueVisibility:  defaultQueueVisibility,
+				MultiKueue: &MultiKueue{
+					GCInterval:        &metav1.Duration{Duration: DefaultMultiKueueGCInterval},
+					Origin:            ptr.To(DefaultMultiKueueOrigin),
+					WorkerLostTimeout: &metav1.Duration{Duration: DefaultMultiKueueWorkerLostTimeout},
+					WorkerLostPolicy:  ptr.To(MultiKueueWorkerLostPolicyRequeue),
+					Dispatcher: &MultiKueueDispatcher{
+						Name:           MultiKueueDispatcherScoring,
+						ScoringTimeout: &metav1.Duration{Duration: DefaultMultiKueueScoringTimeout},
+					},
+				},
+				ManagedJobsNamespaceSelector: defaultManagedJobsNamespaceSelector,
+			},
+		},
+		"multiKueue status mirroring": {
+			original: &Configuration{
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				MultiKueue: &MultiKueue{
+					StatusMirroring: &MultiKueueStatusMirroring{
+						Events: true,
+						Pods:   true,
+					},
+				},
+			},
+			want: &Configuration{
+				Namespace:         ptr.To(DefaultNamespace),
+				ControllerManager: defaultCtrlManagerConfigurationSpec,
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				ClientConnection: defaultClientConnection,
+				Integrations:     defaultIntegrations,
+				QueueVisibility:  defaultQueueVisibility,
+				MultiKueue: &MultiKueue{
+					GCInterval:        &metav1.Duration{Duration: DefaultMultiKueueGCInterval},
+					Origin:            ptr.To(DefaultMultiKueueOrigin),
+					WorkerLostTimeout: &metav1.Duration{Duration: DefaultMultiKueueWorkerLostTimeout},
+					WorkerLostPolicy:  ptr.To(MultiKueueWorkerLostPolicyRequeue),
+					StatusMirroring: &MultiKueueStatusMirroring{
+						Events:               true,
+						Pods:                 true,
+						Interval:             &metav1.Duration{Duration: DefaultMultiKueueStatusMirroringInterval},
+						MaxEventsPerInterval: ptr.To(DefaultMultiKueueStatusMirroringMaxEvents),
+					},
+				},
+				ManagedJobsNamespaceSelector: defaultManagedJobsNamespaceSelector,
+			},
+		},
+		"multiKueue health check": {
+			original: &Configuration{
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				MultiKueue: &MultiKueue{
+					HealthCheck: &MultiKueueHealthCheck{
+						FailureThreshold: ptr.To[int32](5),
+					},
+				},
+			},
+			want: &Configuration{
+				Namespace:         ptr.To(DefaultNamespace),
+				ControllerManager: defaultCtrlManagerConfigurationSpec,
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				ClientConnection: defaultClientConnection,
+				Integrations:     defaultIntegrations,
+				QueueVisibility:  defaultQueueVisibility,
+				MultiKueue: &MultiKueue{
+					GCInterval:        &metav1.Duration{Duration: DefaultMultiKueueGCInterval},
+					Origin:            ptr.To(DefaultMultiKueueOrigin),
+					WorkerLostTimeout: &metav1.Duration{Duration: DefaultMultiKueueWorkerLostTimeout},
+					WorkerLostPolicy:  ptr.To(MultiKueueWorkerLostPolicyRequeue),
+					HealthCheck: &MultiKueueHealthCheck{
+						Interval:         &metav1.Duration{Duration: DefaultMultiKueueHealthCheckInterval},
+						Timeout:          &metav1.Duration{Duration: DefaultMultiKueueHealthCheckTimeout},
+						FailureThreshold: ptr.To[int32](5),
+						InitialBackoff:   &metav1.Duration{Duration: DefaultMultiKueueHealthCheckInitialBackoff},
+						MaxBackoff:       &metav1.Duration{Duration: DefaultMultiKueueHealthCheckMaxBackoff},
+						CanaryNamespace:  ptr.To(DefaultMultiKueueHealthCheckCanaryNamespace),
+					},
+				},
+				ManagedJobsNamespaceSelector: defaultManagedJobsNamespaceSelector,
+			},
+		},
+		"multiKueue external frameworks": {
+			original: &Configuration{
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				MultiKueue: &MultiKueue{
+					ExternalFrameworks: []MultiKueueExternalFramework{
+						{Name: "MyJob.v1.example.com"},
+						{
+							Name:          "OtherJob.v1.example.com",
+							ManagedByPath: ptr.To(".spec.controllerName"),
+							StatusPaths:   []string{".status.conditions"},
+						},
+					},
+				},
+			},
+			want: &Configuration{
+				Namespace:         ptr.To(DefaultNamespace),
+				ControllerManager: defaultCtrlManagerConfigurationSpec,
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				ClientConnection: defaultClientConnection,
+				Integrations:     defaultIntegrations,
+				QueueVisibility:  defaultQueueVisibility,
+				MultiKueue: &MultiKueue{
+					GCInterval:        &metav1.Duration{Duration: DefaultMultiKueueGCInterval},
+					Origin:            ptr.To(DefaultMultiKueueOrigin),
+					WorkerLostTimeout: &metav1.Duration{Duration: DefaultMultiKueueWorkerLostTimeout},
+					WorkerLostPolicy:  ptr.To(MultiKueueWorkerLostPolicyRequeue),
+					ExternalFrameworks: []MultiKueueExternalFramework{
+						{
+							Name:          "MyJob.v1.example.com",
+							ManagedByPath: ptr.To(DefaultMultiKueueManagedByPath),
+							StatusPaths:   []string{DefaultMultiKueueStatusPath},
+						},
+						{
+							Name:          "OtherJob.v1.example.com",
+							ManagedByPath: ptr.To(".spec.controllerName"),
+							StatusPaths:   []string{".status.conditions"},
+						},
+					},
 				},
 				ManagedJobsNamespaceSelector: defaultManagedJobsNamespaceSelector,
 			},
@@ -563,6 +751,7 @@ func TestSetDefaults_Configuration(t *testing.T) {
 					GCInterval:        &metav1.Duration{},
 					Origin:            ptr.To("multikueue-manager1"),
 					WorkerLostTimeout: &metav1.Duration{Duration: 15 * time.Minute},
+					WorkerLostPolicy:  ptr.To(MultiKueueWorkerLostPolicyRequeue),
 				},
 				ManagedJobsNamespaceSelector: defaultManagedJobsNamespaceSelector,
 			},
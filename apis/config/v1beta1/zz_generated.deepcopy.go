@@ -123,6 +123,11 @@ func (in *Configuration) DeepCopyInto(out *Configuration) {
 		*out = new(Resources)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TASDefragmentation != nil {
+		in, out := &in.TASDefragmentation, &out.TASDefragmentation
+		*out = new(TASDefragmentation)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.FeatureGates != nil {
 		in, out := &in.FeatureGates, &out.FeatureGates
 		*out = make(map[string]bool, len(*in))
@@ -201,7 +206,7 @@ func (in *ControllerManager) DeepCopyInto(out *ControllerManager) {
 		*out = new(v1alpha1.LeaderElectionConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
-	out.Metrics = in.Metrics
+	in.Metrics.DeepCopyInto(&out.Metrics)
 	out.Health = in.Health
 	if in.Controller != nil {
 		in, out := &in.Controller, &out.Controller
@@ -223,6 +228,11 @@ func (in *ControllerManager) DeepCopy() *ControllerManager {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControllerMetrics) DeepCopyInto(out *ControllerMetrics) {
 	*out = *in
+	if in.TASDomainResourcesMaxDomains != nil {
+		in, out := &in.TASDomainResourcesMaxDomains, &out.TASDomainResourcesMaxDomains
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerMetrics.
@@ -358,6 +368,38 @@ func (in *MultiKueue) DeepCopyInto(out *MultiKueue) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.WorkerLostPolicy != nil {
+		in, out := &in.WorkerLostPolicy, &out.WorkerLostPolicy
+		*out = new(MultiKueueWorkerLostPolicy)
+		**out = **in
+	}
+	if in.Dispatcher != nil {
+		in, out := &in.Dispatcher, &out.Dispatcher
+		*out = new(MultiKueueDispatcher)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExternalFrameworks != nil {
+		in, out := &in.ExternalFrameworks, &out.ExternalFrameworks
+		*out = make([]MultiKueueExternalFramework, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StatusMirroring != nil {
+		in, out := &in.StatusMirroring, &out.StatusMirroring
+		*out = new(MultiKueueStatusMirroring)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(MultiKueueHealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterProfile != nil {
+		in, out := &in.ClusterProfile, &out.ClusterProfile
+		*out = new(MultiKueueClusterProfile)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiKueue.
@@ -370,6 +412,169 @@ func (in *MultiKueue) DeepCopy() *MultiKueue {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiKueueClusterProfile) DeepCopyInto(out *MultiKueueClusterProfile) {
+	*out = *in
+	if in.CredentialsProviders != nil {
+		in, out := &in.CredentialsProviders, &out.CredentialsProviders
+		*out = make([]MultiKueueCredentialsProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiKueueClusterProfile.
+func (in *MultiKueueClusterProfile) DeepCopy() *MultiKueueClusterProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiKueueClusterProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiKueueCredentialsProvider) DeepCopyInto(out *MultiKueueCredentialsProvider) {
+	*out = *in
+	in.ExecConfig.DeepCopyInto(&out.ExecConfig)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiKueueCredentialsProvider.
+func (in *MultiKueueCredentialsProvider) DeepCopy() *MultiKueueCredentialsProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiKueueCredentialsProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiKueueDispatcher) DeepCopyInto(out *MultiKueueDispatcher) {
+	*out = *in
+	if in.IncrementalClusters != nil {
+		in, out := &in.IncrementalClusters, &out.IncrementalClusters
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IncrementalTimeout != nil {
+		in, out := &in.IncrementalTimeout, &out.IncrementalTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ScoringTimeout != nil {
+		in, out := &in.ScoringTimeout, &out.ScoringTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiKueueDispatcher.
+func (in *MultiKueueDispatcher) DeepCopy() *MultiKueueDispatcher {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiKueueDispatcher)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiKueueExternalFramework) DeepCopyInto(out *MultiKueueExternalFramework) {
+	*out = *in
+	if in.ManagedByPath != nil {
+		in, out := &in.ManagedByPath, &out.ManagedByPath
+		*out = new(string)
+		**out = **in
+	}
+	if in.StatusPaths != nil {
+		in, out := &in.StatusPaths, &out.StatusPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiKueueExternalFramework.
+func (in *MultiKueueExternalFramework) DeepCopy() *MultiKueueExternalFramework {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiKueueExternalFramework)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiKueueHealthCheck) DeepCopyInto(out *MultiKueueHealthCheck) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.InitialBackoff != nil {
+		in, out := &in.InitialBackoff, &out.InitialBackoff
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxBackoff != nil {
+		in, out := &in.MaxBackoff, &out.MaxBackoff
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.CanaryNamespace != nil {
+		in, out := &in.CanaryNamespace, &out.CanaryNamespace
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiKueueHealthCheck.
+func (in *MultiKueueHealthCheck) DeepCopy() *MultiKueueHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiKueueHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiKueueStatusMirroring) DeepCopyInto(out *MultiKueueStatusMirroring) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxEventsPerInterval != nil {
+		in, out := &in.MaxEventsPerInterval, &out.MaxEventsPerInterval
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiKueueStatusMirroring.
+func (in *MultiKueueStatusMirroring) DeepCopy() *MultiKueueStatusMirroring {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiKueueStatusMirroring)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodIntegrationOptions) DeepCopyInto(out *PodIntegrationOptions) {
 	*out = *in
@@ -504,6 +709,46 @@ func (in *Resources) DeepCopy() *Resources {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TASDefragmentation) DeepCopyInto(out *TASDefragmentation) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Level != nil {
+		in, out := &in.Level, &out.Level
+		*out = new(string)
+		**out = **in
+	}
+	if in.MinFreeDomains != nil {
+		in, out := &in.MinFreeDomains, &out.MinFreeDomains
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxEvictionsPerInterval != nil {
+		in, out := &in.MaxEvictionsPerInterval, &out.MaxEvictionsPerInterval
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxPriority != nil {
+		in, out := &in.MaxPriority, &out.MaxPriority
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TASDefragmentation.
+func (in *TASDefragmentation) DeepCopy() *TASDefragmentation {
+	if in == nil {
+		return nil
+	}
+	out := new(TASDefragmentation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WaitForPodsReady) DeepCopyInto(out *WaitForPodsReady) {
 	*out = *in
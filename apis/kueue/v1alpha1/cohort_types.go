@@ -62,6 +62,21 @@ type CohortSpec struct {
 	// if FairSharing is enabled in the Kueue configuration.
 	// +optional
 	FairSharing *kueuebeta.FairSharing `json:"fairSharing,omitempty"`
+
+	// maxAdmittedWorkloads is the number of workloads that the Cohort
+	// contributes to the pool shared by the members of its subtree, on top
+	// of the maxAdmittedWorkloads of its ClusterQueues and child Cohorts.
+	// ClusterQueues in the subtree of a Cohort that sets
+	// maxAdmittedWorkloads are limited by it, even when they don't set
+	// maxAdmittedWorkloads themselves. The counts of the Cohort are
+	// never lent outside of its subtree.
+	//
+	// This is an alpha field and requires enabling the MaxAdmittedWorkloads
+	// feature gate.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxAdmittedWorkloads *int32 `json:"maxAdmittedWorkloads,omitempty"`
 }
 
 // CohortStatus defines the observed state of Cohort.
@@ -46,6 +46,30 @@ const (
 	// +kubebuilder:validation:Type=boolean
 	PodSetUnconstrainedTopologyAnnotation = "kueue.x-k8s.io/podset-unconstrained-topology"
 
+	// PodSetBalancedTopologyAnnotation indicates that a PodSet requires
+	// Topology Aware Scheduling, and requires spreading the pods evenly across
+	// the topology domains at the level indicated by the annotation value
+	// (e.g. the same number of pods in each of the racks), all within a single
+	// topology domain of the level above (e.g. within a block).
+	PodSetBalancedTopologyAnnotation = "kueue.x-k8s.io/podset-balanced-topology"
+
+	// PodSetGroupNameAnnotation indicates the name of the group of PodSets,
+	// within the workload, which are placed together within a single topology
+	// domain, at the level indicated by the required or preferred topology
+	// annotation of the PodSets (e.g. a leader and its workers within a block).
+	PodSetGroupNameAnnotation = "kueue.x-k8s.io/podset-group-name"
+
+	// PodSetSliceRequiredTopologyAnnotation indicates the topology level
+	// required by each slice of the PodSet (e.g. a rack), while the required
+	// or preferred topology annotation indicates the level for all the slices
+	// of the PodSet (e.g. a block).
+	PodSetSliceRequiredTopologyAnnotation = "kueue.x-k8s.io/podset-slice-required-topology"
+
+	// PodSetSliceSizeAnnotation indicates the number of pods in each slice of
+	// the PodSet. It is required along with the slice required topology
+	// annotation.
+	PodSetSliceSizeAnnotation = "kueue.x-k8s.io/podset-slice-size"
+
 	// TopologySchedulingGate is used to delay scheduling of a Pod until the
 	// nodeSelectors corresponding to the assigned topology domain are injected
 	// into the Pod. For the Pod-based integrations the gate is added in webhook
@@ -79,6 +103,8 @@ const (
 )
 
 // TopologySpec defines the desired state of Topology
+// +kubebuilder:validation:XValidation:rule="!has(self.nodeDomainsSource) || self.levels[size(self.levels) - 1].nodeLabel == 'kubernetes.io/hostname'",message="the lowest level must be kubernetes.io/hostname when nodeDomainsSource is set"
+// +kubebuilder:validation:XValidation:rule="has(self.nodeDomainsSource) == has(oldSelf.nodeDomainsSource) && (!has(self.nodeDomainsSource) || self.nodeDomainsSource == oldSelf.nodeDomainsSource)",message="nodeDomainsSource is immutable"
 type TopologySpec struct {
 	// levels define the levels of topology.
 	//
@@ -90,6 +116,34 @@ type TopologySpec struct {
 	// +kubebuilder:validation:XValidation:rule="size(self.filter(i, size(self.filter(j, j == i)) > 1)) == 0",message="must be unique"
 	// +kubebuilder:validation:XValidation:rule="size(self.filter(i, i.nodeLabel == 'kubernetes.io/hostname')) == 0 || self[size(self) - 1].nodeLabel == 'kubernetes.io/hostname'",message="the kubernetes.io/hostname label can only be used at the lowest level of topology"
 	Levels []TopologyLevel `json:"levels,omitempty"`
+
+	// nodeDomainsSource indicates the source of the topology domains of the
+	// nodes, used instead of the node labels for all the levels but the
+	// lowest one, which must be kubernetes.io/hostname. This allows using a
+	// topology which is not set as node labels, like a network topology
+	// maintained in an external inventory.
+	//
+	// This field requires the TASNodeDomainsSource feature gate.
+	//
+	// +optional
+	NodeDomainsSource *TopologyNodeDomainsSource `json:"nodeDomainsSource,omitempty"`
+}
+
+// TopologyNodeDomainsSource defines the source of the topology domains of the
+// nodes.
+type TopologyNodeDomainsSource struct {
+	// configMapName is the name of the ConfigMap, in the namespace of Kueue,
+	// which maps the nodes to their topology domains. Each key is the name of
+	// a node, and each value is the path of the topology domain of the node,
+	// with the values of all the levels but the lowest one, from the highest
+	// level, separated by "/" (e.g. "spine-1/leaf-2"). The nodes missing from
+	// the ConfigMap are not used by the topology.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern="^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$"
+	ConfigMapName string `json:"configMapName"`
 }
 
 // TopologyLevel defines the desired state of TopologyLevel
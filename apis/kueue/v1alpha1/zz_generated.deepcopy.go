@@ -99,6 +99,11 @@ func (in *CohortSpec) DeepCopyInto(out *CohortSpec) {
 		*out = new(v1beta1.FairSharing)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MaxAdmittedWorkloads != nil {
+		in, out := &in.MaxAdmittedWorkloads, &out.MaxAdmittedWorkloads
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CohortSpec.
@@ -204,6 +209,21 @@ func (in *TopologyList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologyNodeDomainsSource) DeepCopyInto(out *TopologyNodeDomainsSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologyNodeDomainsSource.
+func (in *TopologyNodeDomainsSource) DeepCopy() *TopologyNodeDomainsSource {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologyNodeDomainsSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TopologySpec) DeepCopyInto(out *TopologySpec) {
 	*out = *in
@@ -212,6 +232,11 @@ func (in *TopologySpec) DeepCopyInto(out *TopologySpec) {
 		*out = make([]TopologyLevel, len(*in))
 		copy(*out, *in)
 	}
+	if in.NodeDomainsSource != nil {
+		in, out := &in.NodeDomainsSource, &out.NodeDomainsSource
+		*out = new(TopologyNodeDomainsSource)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologySpec.
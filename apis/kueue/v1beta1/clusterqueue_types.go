@@ -130,6 +130,40 @@ type ClusterQueueSpec struct {
 	// if FairSharing is enabled in the Kueue configuration.
 	// +optional
 	FairSharing *FairSharing `json:"fairSharing,omitempty"`
+
+	// maxAdmittedWorkloads is the maximum number of workloads that can
+	// reserve quota in this ClusterQueue at the same time. It behaves like
+	// the nominal quota of a resource which every workload requests one
+	// unit of: when the ClusterQueue is in a cohort, workloads above
+	// maxAdmittedWorkloads can be admitted by borrowing unused counts from
+	// the maxAdmittedWorkloads of the Cohorts. The counts of the
+	// ClusterQueue are never lent to the other members of the cohort, as
+	// preemption doesn't reclaim them. A workload above the limit can
+	// preempt workloads of the ClusterQueue to free one of the counts,
+	// following the withinClusterQueue preemption policy.
+	//
+	// When neither the ClusterQueue nor any of its Cohorts set
+	// maxAdmittedWorkloads, the number of workloads is not limited.
+	//
+	// This is an alpha field and requires enabling the MaxAdmittedWorkloads
+	// feature gate.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxAdmittedWorkloads *int32 `json:"maxAdmittedWorkloads,omitempty"`
+
+	// perUserLimits limits the usage of the workloads submitted by each
+	// user across all the LocalQueues of this ClusterQueue. A workload which
+	// would exceed the limits of its user remains pending and doesn't
+	// trigger preemptions. Unless the queueingStrategy is StrictFIFO, the
+	// pending workloads of the same priority are ordered round-robin across
+	// their users.
+	//
+	// This is an alpha field and requires enabling the PerUserLimits
+	// feature gate.
+	//
+	// +optional
+	PerUserLimits *PerUserLimits `json:"perUserLimits,omitempty"`
 }
 
 // AdmissionChecksStrategy defines a strategy for a AdmissionCheck.
@@ -243,6 +277,24 @@ type ResourceQuota struct {
 	// This field is in beta stage and is enabled by default.
 	// +optional
 	LendingLimit *resource.Quantity `json:"lendingLimit,omitempty"`
+
+	// nodeCapacityPercentage derives the nominalQuota of this resource from
+	// the capacity of the nodes of the flavor. When set, the nominalQuota is
+	// the given percentage of the sum of the allocatable quantities of the
+	// resource in the ready and schedulable nodes which match the nodeLabels
+	// of the ResourceFlavor, and whose NoSchedule and NoExecute taints are
+	// listed in the nodeTaints, or tolerated by the tolerations, of the
+	// ResourceFlavor. The quota is recomputed when the nodes change.
+	// The value of the nominalQuota field is used until the capacity of the
+	// nodes is known. This field is ignored in Cohorts.
+	//
+	// This is an alpha field and requires enabling the NodeCapacityQuotas
+	// feature gate.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	NodeCapacityPercentage *int32 `json:"nodeCapacityPercentage,omitempty"`
 }
 
 // ResourceFlavorReference is the name of the ResourceFlavor.
@@ -283,6 +335,56 @@ type ClusterQueueStatus struct {
 	// +optional
 	AdmittedWorkloads int32 `json:"admittedWorkloads"`
 
+	// borrowedWorkloads is the number of workloads reserving quota in this
+	// ClusterQueue above its maxAdmittedWorkloads, which are counted
+	// against the maxAdmittedWorkloads of the cohort.
+	// It is only set when the ClusterQueue, or one of its Cohorts, limits
+	// the number of admitted workloads.
+	//
+	// This is an alpha field and requires enabling the MaxAdmittedWorkloads
+	// feature gate.
+	//
+	// +optional
+	BorrowedWorkloads *int32 `json:"borrowedWorkloads,omitempty"`
+
+	// nodeCapacityQuotas reports, by flavor, the capacity of the nodes and
+	// the nominal quotas derived from it, for the resources which set
+	// nodeCapacityPercentage.
+	//
+	// This is an alpha field and requires enabling the NodeCapacityQuotas
+	// feature gate.
+	//
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	NodeCapacityQuotas []FlavorNodeCapacityQuota `json:"nodeCapacityQuotas,omitempty"`
+
+	// overcommittedQuotas reports, by flavor, the nominal quotas in physical
+	// units and the effective nominal quotas which the workloads are
+	// admitted against, for the resources with an overcommit ratio in their
+	// ResourceFlavor.
+	//
+	// This is an alpha field and requires enabling the FlavorOvercommit
+	// feature gate.
+	//
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	OvercommittedQuotas []FlavorOvercommittedQuota `json:"overcommittedQuotas,omitempty"`
+
+	// federatedQuota reports the nominal quotas computed from the quotas of
+	// the ClusterQueues with the same name in the MultiKueue worker
+	// clusters, when the MultiKueueConfig of the ClusterQueue's MultiKueue
+	// admission check sets a quotaPolicy.
+	//
+	// This is an alpha field and requires enabling the MultiKueueFederatedQuota
+	// feature gate.
+	//
+	// +optional
+	FederatedQuota *FederatedQuota `json:"federatedQuota,omitempty"`
+
 	// conditions hold the latest available observations of the ClusterQueue
 	// current state.
 	// +optional
@@ -351,6 +453,101 @@ type ResourceUsage struct {
 	Borrowed resource.Quantity `json:"borrowed,omitempty"`
 }
 
+type FlavorNodeCapacityQuota struct {
+	// name of the flavor.
+	Name ResourceFlavorReference `json:"name"`
+
+	// nodes is the number of nodes of the flavor counted in the capacity.
+	Nodes int32 `json:"nodes"`
+
+	// resources lists the capacity and the derived nominal quota of the
+	// resources in this flavor.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	Resources []ResourceNodeCapacityQuota `json:"resources"`
+}
+
+type ResourceNodeCapacityQuota struct {
+	// name of the resource
+	Name corev1.ResourceName `json:"name"`
+
+	// allocatable is the sum of the allocatable quantities of the resource
+	// in the nodes of the flavor.
+	Allocatable resource.Quantity `json:"allocatable"`
+
+	// nominalQuota is the nominal quota derived from the allocatable
+	// quantity, used by the ClusterQueue.
+	NominalQuota resource.Quantity `json:"nominalQuota"`
+}
+
+type FlavorOvercommittedQuota struct {
+	// name of the flavor.
+	Name ResourceFlavorReference `json:"name"`
+
+	// resources lists the physical and effective nominal quotas of the
+	// overcommitted resources in this flavor.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	Resources []ResourceOvercommittedQuota `json:"resources"`
+}
+
+type ResourceOvercommittedQuota struct {
+	// name of the resource
+	Name corev1.ResourceName `json:"name"`
+
+	// ratio is the overcommit ratio of the resource in the flavor.
+	Ratio resource.Quantity `json:"ratio"`
+
+	// nominalQuota is the nominal quota, in physical units.
+	NominalQuota resource.Quantity `json:"nominalQuota"`
+
+	// effectiveNominalQuota is the nominal quota multiplied by the
+	// overcommit ratio.
+	EffectiveNominalQuota resource.Quantity `json:"effectiveNominalQuota"`
+}
+
+type FederatedQuota struct {
+	// policy is the quotaPolicy used to compute the nominal quotas.
+	Policy MultiKueueQuotaPolicy `json:"policy"`
+
+	// clusters lists the connected and healthy worker clusters the nominal
+	// quotas are computed from.
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	Clusters []string `json:"clusters,omitempty"`
+
+	// flavors lists, by flavor, the nominal quotas computed from the
+	// quotas of the worker clusters.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	Flavors []FlavorFederatedQuota `json:"flavors,omitempty"`
+}
+
+type FlavorFederatedQuota struct {
+	// name of the flavor.
+	Name ResourceFlavorReference `json:"name"`
+
+	// resources lists the nominal quotas of the resources in this flavor.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	Resources []ResourceFederatedQuota `json:"resources"`
+}
+
+type ResourceFederatedQuota struct {
+	// name of the resource
+	Name corev1.ResourceName `json:"name"`
+
+	// nominalQuota is the nominal quota computed from the quotas of the
+	// worker clusters, used by the ClusterQueue.
+	NominalQuota resource.Quantity `json:"nominalQuota"`
+}
+
 const (
 	// ClusterQueueActive indicates that the ClusterQueue can admit new workloads and its quota
 	// can be borrowed by other ClusterQueues in the same cohort.
@@ -41,6 +41,109 @@ type LocalQueueSpec struct {
 	// +kubebuilder:validation:Enum=None;Hold;HoldAndDrain
 	// +kubebuilder:default="None"
 	StopPolicy *StopPolicy `json:"stopPolicy,omitempty"`
+
+	// limits caps the quota, by flavor and resource, that the workloads
+	// submitted to this LocalQueue can reserve in its ClusterQueue.
+	// The limits are enforced in addition to the ClusterQueue quotas, so a
+	// LocalQueue can never reserve more than its ClusterQueue (and Cohort)
+	// could provide. A workload which would exceed the limits of its
+	// LocalQueue remains pending and doesn't trigger preemptions.
+	//
+	// Flavors and resources not listed are not limited.
+	//
+	// This is an alpha field and requires enabling the LocalQueueLimits
+	// feature gate.
+	//
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	Limits []LocalQueueFlavorLimit `json:"limits,omitempty"`
+
+	// maxAdmittedWorkloads is the maximum number of workloads submitted to
+	// this LocalQueue that can reserve quota in its ClusterQueue at the
+	// same time. A workload which would exceed it remains pending and
+	// doesn't trigger preemptions.
+	//
+	// This is an alpha field and requires enabling the MaxAdmittedWorkloads
+	// feature gate.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxAdmittedWorkloads *int32 `json:"maxAdmittedWorkloads,omitempty"`
+
+	// perUserLimits limits the usage of the workloads submitted to this
+	// LocalQueue by each user. A workload which would exceed the limits of
+	// its user remains pending and doesn't trigger preemptions.
+	//
+	// This is an alpha field and requires enabling the PerUserLimits
+	// feature gate.
+	//
+	// +optional
+	PerUserLimits *PerUserLimits `json:"perUserLimits,omitempty"`
+}
+
+// PerUserLimits limits the usage of the workloads submitted by each user.
+// The user submitting a workload is the user or service account which
+// created its job, as recorded by Kueue in the
+// kueue.x-k8s.io/submitted-by annotation.
+type PerUserLimits struct {
+	// maxAdmittedWorkloads is the maximum number of workloads submitted by
+	// a single user that can reserve quota at the same time.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxAdmittedWorkloads *int32 `json:"maxAdmittedWorkloads,omitempty"`
+
+	// resources lists the maximum quantities of resources, summed across
+	// all the flavors, that the workloads submitted by a single user can
+	// reserve at the same time.
+	//
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	Resources []PerUserResourceLimit `json:"resources,omitempty"`
+}
+
+type PerUserResourceLimit struct {
+	// name of the resource.
+	// +required
+	// +kubebuilder:validation:Required
+	Name corev1.ResourceName `json:"name"`
+
+	// max is the maximum quantity of the resource that the workloads
+	// submitted by a single user can reserve.
+	// +required
+	// +kubebuilder:validation:Required
+	Max resource.Quantity `json:"max"`
+}
+
+type LocalQueueFlavorLimit struct {
+	// name of the flavor.
+	// +required
+	// +kubebuilder:validation:Required
+	Name ResourceFlavorReference `json:"name"`
+
+	// resources lists the limits for the resources in this flavor.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	Resources []LocalQueueResourceLimit `json:"resources"`
+}
+
+type LocalQueueResourceLimit struct {
+	// name of the resource.
+	// +required
+	// +kubebuilder:validation:Required
+	Name corev1.ResourceName `json:"name"`
+
+	// max is the maximum quantity of the resource that the workloads
+	// in the LocalQueue can reserve in the flavor.
+	// +required
+	// +kubebuilder:validation:Required
+	Max resource.Quantity `json:"max"`
 }
 
 // ClusterQueueReference is the name of the ClusterQueue.
@@ -172,6 +275,11 @@ type LocalQueueResourceUsage struct {
 
 	// total is the total quantity of used quota.
 	Total resource.Quantity `json:"total,omitempty"`
+
+	// limit is the maximum quantity of the resource that the LocalQueue
+	// can reserve, as configured in .spec.limits.
+	// +optional
+	Limit *resource.Quantity `json:"limit,omitempty"`
 }
 
 // +genclient
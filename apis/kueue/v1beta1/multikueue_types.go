@@ -17,6 +17,8 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -24,10 +26,44 @@ const (
 	MultiKueueConfigSecretKey = "kubeconfig"
 	MultiKueueClusterActive   = "Active"
 
+	// MultiKueueClusterCredentialsValid is the condition reporting if the
+	// credentials used to connect to the cluster are valid, and when they expire,
+	// if known.
+	MultiKueueClusterCredentialsValid = "CredentialsValid"
+
+	// MultiKueueClusterHealthy is the condition reporting if the cluster
+	// passes the health probes, when they are enabled in the MultiKueue
+	// configuration. The workloads are not dispatched to the unhealthy clusters.
+	MultiKueueClusterHealthy = "Healthy"
+
 	// MultiKueueOriginLabel is a label used to track the creator
 	// of multikueue remote objects.
 	MultiKueueOriginLabel = "kueue.x-k8s.io/multikueue-origin"
 
+	// MultiKueueReservingClusterAnnotation is the annotation of the local
+	// workloads holding the name of the worker cluster which reserved quota for
+	// the workload. It's kept until the workload is requeued in the manager
+	// cluster, also when the worker cluster is lost.
+	MultiKueueReservingClusterAnnotation = "kueue.x-k8s.io/multikueue-reserving-cluster"
+
+	// MultiKueueLostClustersAnnotation is the annotation of the local workloads
+	// migrated to another worker cluster, listing the lost worker clusters in
+	// which stale remote objects can be left. The remote objects are deleted,
+	// and the cluster removed from the list, when the worker cluster reconnects.
+	MultiKueueLostClustersAnnotation = "kueue.x-k8s.io/multikueue-lost-clusters"
+
+	// MultiKueueMigratedFromAnnotation is the annotation of the jobs migrated
+	// to another worker cluster after the loss of their reserving worker cluster,
+	// listing the lost worker clusters. It's copied to the remote job, which
+	// should resume from its last checkpoint.
+	MultiKueueMigratedFromAnnotation = "kueue.x-k8s.io/multikueue-migrated-from"
+
+	// MultiKueueMirroredEventsAnnotation is the annotation of the local workloads
+	// whose remote events are mirrored. It holds the time of the most recent
+	// mirrored event, followed by the names of the events mirrored at that time,
+	// comma separated, so that the events aren't mirrored twice.
+	MultiKueueMirroredEventsAnnotation = "kueue.x-k8s.io/multikueue-mirrored-events"
+
 	// MultiKueueControllerName is the name used by the MultiKueue
 	// admission check controller.
 	MultiKueueControllerName = "kueue.x-k8s.io/multikueue"
@@ -58,9 +94,33 @@ type KubeConfig struct {
 	LocationType LocationType `json:"locationType"`
 }
 
+// +kubebuilder:validation:XValidation:rule="has(self.kubeConfig) != has(self.clusterProfileRef)", message="exactly one of kubeConfig and clusterProfileRef must be set"
 type MultiKueueClusterSpec struct {
 	// Information how to connect to the cluster.
-	KubeConfig KubeConfig `json:"kubeConfig"`
+	//
+	// The kubeconfig can use token files and exec plugins, their credentials
+	// are refreshed automatically.
+	// +optional
+	KubeConfig *KubeConfig `json:"kubeConfig,omitempty"`
+
+	// clusterProfileRef is the reference to the ClusterProfile, of the Cluster
+	// Inventory API, describing the cluster. The credentials used to connect
+	// to the cluster are provided by the ClusterProfile credentials provider
+	// configured in Kueue.
+	// Requires the MultiKueueClusterProfile feature gate.
+	// +optional
+	ClusterProfileRef *ClusterProfileReference `json:"clusterProfileRef,omitempty"`
+}
+
+// ClusterProfileReference is the reference to a ClusterProfile.
+type ClusterProfileReference struct {
+	// name is the name of the ClusterProfile.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// namespace is the namespace of the ClusterProfile.
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
 }
 
 type MultiKueueClusterStatus struct {
@@ -70,6 +130,64 @@ type MultiKueueClusterStatus struct {
 	// +patchStrategy=merge
 	// +patchMergeKey=type
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// capacity is a summary of the quota and the usage of the ClusterQueues
+	// in the worker cluster. It is only reported when the Scoring or the
+	// CapacityAware MultiKueue dispatcher is configured.
+	//
+	// +optional
+	Capacity *MultiKueueClusterCapacity `json:"capacity,omitempty"`
+}
+
+// MultiKueueClusterCapacity is a summary of the quota and the usage of the
+// ClusterQueues in a worker cluster.
+type MultiKueueClusterCapacity struct {
+	// clusterQueues is the list of the ClusterQueues in the worker cluster.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=1000
+	ClusterQueues []MultiKueueClusterQueueCapacity `json:"clusterQueues,omitempty"`
+}
+
+// MultiKueueClusterQueueCapacity is a summary of the quota and the usage of
+// a ClusterQueue in a worker cluster.
+type MultiKueueClusterQueueCapacity struct {
+	// name is the name of the ClusterQueue.
+	Name ClusterQueueReference `json:"name"`
+
+	// cohort is the name of the cohort the ClusterQueue belongs to.
+	//
+	// +optional
+	Cohort CohortReference `json:"cohort,omitempty"`
+
+	// pendingWorkloads is the number of pending workloads in the ClusterQueue.
+	PendingWorkloads int32 `json:"pendingWorkloads"`
+
+	// resources is the list of the resources of the ClusterQueue, with the
+	// quota and the reservation summed over the resource flavors.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=64
+	Resources []MultiKueueResourceCapacity `json:"resources,omitempty"`
+}
+
+// MultiKueueResourceCapacity is the quota and the reservation of a resource
+// in a ClusterQueue of a worker cluster.
+type MultiKueueResourceCapacity struct {
+	// name of the resource.
+	Name corev1.ResourceName `json:"name"`
+
+	// nominalQuota is the nominal quota of the resource, summed over the
+	// resource flavors.
+	NominalQuota resource.Quantity `json:"nominalQuota"`
+
+	// reserved is the quantity of the resource reserved by the workloads,
+	// summed over the resource flavors.
+	Reserved resource.Quantity `json:"reserved"`
 }
 
 // +genclient
@@ -107,8 +225,37 @@ type MultiKueueConfigSpec struct {
 	// +kubebuilder:validation:MinItems=1
 	// +kubebuilder:validation:MaxItems=10
 	Clusters []string `json:"clusters"`
+
+	// quotaPolicy enables computing the nominal quotas of the ClusterQueues
+	// using this configuration from the nominal quotas of the ClusterQueues
+	// with the same name in the connected and healthy worker clusters, for
+	// the matching flavor names. The nominal quotas are updated when worker
+	// clusters join, leave or change their quotas. The possible values are:
+	//
+	// - `Sum`: the sum of the nominal quotas of the worker clusters.
+	// - `Min`: the smallest nominal quota of the worker clusters.
+	// - `Max`: the largest nominal quota of the worker clusters.
+	//
+	// Only the worker clusters defining the flavor and resource are taken
+	// into account. The flavors and resources no worker cluster defines,
+	// including when no worker cluster is reachable, have no nominal quota.
+	//
+	// This is an alpha field and requires enabling the MultiKueueFederatedQuota
+	// feature gate.
+	//
+	// +optional
+	// +kubebuilder:validation:Enum=Sum;Min;Max
+	QuotaPolicy *MultiKueueQuotaPolicy `json:"quotaPolicy,omitempty"`
 }
 
+type MultiKueueQuotaPolicy string
+
+const (
+	MultiKueueQuotaPolicySum MultiKueueQuotaPolicy = "Sum"
+	MultiKueueQuotaPolicyMin MultiKueueQuotaPolicy = "Min"
+	MultiKueueQuotaPolicyMax MultiKueueQuotaPolicy = "Max"
+)
+
 // +genclient
 // +genclient:nonNamespaced
 // +kubebuilder:object:root=true
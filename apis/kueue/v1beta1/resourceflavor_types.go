@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -101,6 +102,33 @@ type ResourceFlavorSpec struct {
 	//
 	// +optional
 	TopologyName *TopologyReference `json:"topologyName,omitempty"`
+
+	// overcommit lists the ratios by which the quotas of the resources in
+	// this flavor are multiplied, so that the ClusterQueues and Cohorts can
+	// admit more requests than the physical capacity of the nodes, while
+	// their quotas stay in physical units. A resource not listed has a ratio
+	// of 1.
+	//
+	// An example is a ratio of 2.5 for cpu in a flavor whose nodes are
+	// lightly utilized.
+	//
+	// This is an alpha field and requires enabling the FlavorOvercommit
+	// feature gate.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	Overcommit []ResourceOvercommit `json:"overcommit,omitempty"`
+}
+
+type ResourceOvercommit struct {
+	// name of the resource.
+	Name corev1.ResourceName `json:"name"`
+
+	// ratio by which the quotas of the resource are multiplied.
+	// It must be positive.
+	Ratio resource.Quantity `json:"ratio"`
 }
 
 // +kubebuilder:object:root=true
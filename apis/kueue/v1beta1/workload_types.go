@@ -112,6 +112,41 @@ type PodSetTopologyRequest struct {
 	// +kubebuilder:validation:Type=boolean
 	Unconstrained *bool `json:"unconstrained,omitempty"`
 
+	// balanced indicates the topology level at which the pods of the PodSet
+	// are spread evenly, as indicated by the `kueue.x-k8s.io/podset-balanced-topology`
+	// PodSet annotation. The pods are assigned in equal numbers to domains
+	// at the level, all within a single domain of the level above.
+	//
+	// +optional
+	Balanced *string `json:"balanced,omitempty"`
+
+	// podSetGroupName indicates the name of the group of PodSets, within the
+	// workload, which are placed together within a single topology domain, as
+	// indicated by the `kueue.x-k8s.io/podset-group-name` PodSet annotation.
+	// The domain is at the topology level required, or preferred, by the
+	// PodSets in the group.
+	//
+	// +optional
+	PodSetGroupName *string `json:"podSetGroupName,omitempty"`
+
+	// podSetSliceRequiredTopology indicates the topology level required by
+	// each slice of the PodSet, as indicated by the
+	// `kueue.x-k8s.io/podset-slice-required-topology` PodSet annotation.
+	// The pods are split into slices of podSetSliceSize pods, and every slice
+	// is placed within a single topology domain at the level, while all the
+	// slices are placed according to the required or preferred topology level.
+	//
+	// +optional
+	PodSetSliceRequiredTopology *string `json:"podSetSliceRequiredTopology,omitempty"`
+
+	// podSetSliceSize indicates the number of pods in each slice of the
+	// PodSet, as indicated by the `kueue.x-k8s.io/podset-slice-size` PodSet
+	// annotation. The count of the PodSet must be divisible by the slice size.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	PodSetSliceSize *int32 `json:"podSetSliceSize,omitempty"`
+
 	// PodIndexLabel indicates the name of the label indexing the pods.
 	// For example, in the context of
 	// - kubernetes job this is: kubernetes.io/job-completion-index
@@ -237,6 +272,16 @@ type TopologyAssignment struct {
 	//
 	// +required
 	Domains []TopologyDomainAssignment `json:"domains"`
+
+	// sliceDomains is a list of topology domains at the slice topology level,
+	// along with the number of slices assigned to each of them, when the
+	// PodSet requests slices. The values correspond to the consecutive
+	// topology levels, from the highest to the slice level. The slices are
+	// formed by the consecutive pods in the order of the domains.
+	//
+	// +optional
+	// +listType=atomic
+	SliceDomains []TopologyDomainAssignment `json:"sliceDomains,omitempty"`
 }
 
 type TopologyDomainAssignment struct {
@@ -367,6 +412,71 @@ type WorkloadStatus struct {
 	//
 	// +optional
 	AccumulatedPastExexcutionTimeSeconds *int32 `json:"accumulatedPastExexcutionTimeSeconds,omitempty"`
+
+	// remotePods is the summary of the status of the pods of the workload
+	// running in a MultiKueue worker cluster. It's mirrored by the manager
+	// cluster when enabled in the MultiKueue configuration.
+	//
+	// +optional
+	RemotePods *RemotePodsStatus `json:"remotePods,omitempty"`
+}
+
+// RemotePodsStatus is the summary of the status of the pods running in a
+// MultiKueue worker cluster.
+type RemotePodsStatus struct {
+	// clusterName is the name of the MultiKueueCluster running the pods.
+	ClusterName string `json:"clusterName"`
+
+	// pending is the number of pending pods.
+	// +kubebuilder:validation:Minimum=0
+	Pending int32 `json:"pending"`
+
+	// running is the number of running pods.
+	// +kubebuilder:validation:Minimum=0
+	Running int32 `json:"running"`
+
+	// succeeded is the number of succeeded pods.
+	// +kubebuilder:validation:Minimum=0
+	Succeeded int32 `json:"succeeded"`
+
+	// failed is the number of failed pods.
+	// +kubebuilder:validation:Minimum=0
+	Failed int32 `json:"failed"`
+
+	// pods lists the status of a subset of the pods, the failed and the
+	// pending ones first.
+	//
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=16
+	Pods []RemotePodStatus `json:"pods,omitempty"`
+
+	// lastUpdateTime is the last time the mirrored status changed.
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
+}
+
+// RemotePodStatus is the status of a pod running in a MultiKueue worker cluster.
+type RemotePodStatus struct {
+	// name is the name of the pod.
+	Name string `json:"name"`
+
+	// phase is the phase of the pod.
+	Phase corev1.PodPhase `json:"phase"`
+
+	// reason is the reason of the pod status, or of the status of its
+	// first container which is not running.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// message is the message of the pod status, or of the status of its
+	// first container which is not running.
+	// +optional
+	// +kubebuilder:validation:MaxLength=256
+	Message string `json:"message,omitempty"`
+
+	// restarts is the number of restarts of the containers of the pod.
+	// +kubebuilder:validation:Minimum=0
+	Restarts int32 `json:"restarts"`
 }
 
 type RequeueState struct {
@@ -560,6 +670,16 @@ const (
 	// because the LocalQueue is Stopped.
 	WorkloadEvictedByLocalQueueStopped = "LocalQueueStopped"
 
+	// WorkloadEvictedDueToNodeFailures indicates that the workload was evicted
+	// because a node of its topology assignment failed, and no replacement
+	// could be found.
+	WorkloadEvictedDueToNodeFailures = "NodeFailures"
+
+	// WorkloadEvictedDueToDefragmentation indicates that the workload was evicted
+	// by the TAS defragmentation, to consolidate the free capacity of a topology
+	// domain.
+	WorkloadEvictedDueToDefragmentation = "Defragmentation"
+
 	// WorkloadEvictedByDeactivation indicates that the workload was evicted
 	// because spec.active is set to false.
 	// Deprecated: The reason is not set any longer, it is only kept temporarily to ensure
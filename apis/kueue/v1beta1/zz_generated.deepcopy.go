@@ -254,6 +254,21 @@ func (in *BorrowWithinCohort) DeepCopy() *BorrowWithinCohort {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterProfileReference) DeepCopyInto(out *ClusterProfileReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProfileReference.
+func (in *ClusterProfileReference) DeepCopy() *ClusterProfileReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterProfileReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterQueue) DeepCopyInto(out *ClusterQueue) {
 	*out = *in
@@ -414,6 +429,16 @@ func (in *ClusterQueueSpec) DeepCopyInto(out *ClusterQueueSpec) {
 		*out = new(FairSharing)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MaxAdmittedWorkloads != nil {
+		in, out := &in.MaxAdmittedWorkloads, &out.MaxAdmittedWorkloads
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PerUserLimits != nil {
+		in, out := &in.PerUserLimits, &out.PerUserLimits
+		*out = new(PerUserLimits)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQueueSpec.
@@ -443,6 +468,30 @@ func (in *ClusterQueueStatus) DeepCopyInto(out *ClusterQueueStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.BorrowedWorkloads != nil {
+		in, out := &in.BorrowedWorkloads, &out.BorrowedWorkloads
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NodeCapacityQuotas != nil {
+		in, out := &in.NodeCapacityQuotas, &out.NodeCapacityQuotas
+		*out = make([]FlavorNodeCapacityQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OvercommittedQuotas != nil {
+		in, out := &in.OvercommittedQuotas, &out.OvercommittedQuotas
+		*out = make([]FlavorOvercommittedQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FederatedQuota != nil {
+		in, out := &in.FederatedQuota, &out.FederatedQuota
+		*out = new(FederatedQuota)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -507,6 +556,55 @@ func (in *FairSharingStatus) DeepCopy() *FairSharingStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FederatedQuota) DeepCopyInto(out *FederatedQuota) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Flavors != nil {
+		in, out := &in.Flavors, &out.Flavors
+		*out = make([]FlavorFederatedQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FederatedQuota.
+func (in *FederatedQuota) DeepCopy() *FederatedQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(FederatedQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlavorFederatedQuota) DeepCopyInto(out *FlavorFederatedQuota) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ResourceFederatedQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlavorFederatedQuota.
+func (in *FlavorFederatedQuota) DeepCopy() *FlavorFederatedQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(FlavorFederatedQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FlavorFungibility) DeepCopyInto(out *FlavorFungibility) {
 	*out = *in
@@ -522,6 +620,50 @@ func (in *FlavorFungibility) DeepCopy() *FlavorFungibility {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlavorNodeCapacityQuota) DeepCopyInto(out *FlavorNodeCapacityQuota) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ResourceNodeCapacityQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlavorNodeCapacityQuota.
+func (in *FlavorNodeCapacityQuota) DeepCopy() *FlavorNodeCapacityQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(FlavorNodeCapacityQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlavorOvercommittedQuota) DeepCopyInto(out *FlavorOvercommittedQuota) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ResourceOvercommittedQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlavorOvercommittedQuota.
+func (in *FlavorOvercommittedQuota) DeepCopy() *FlavorOvercommittedQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(FlavorOvercommittedQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FlavorQuotas) DeepCopyInto(out *FlavorQuotas) {
 	*out = *in
@@ -608,6 +750,28 @@ func (in *LocalQueue) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalQueueFlavorLimit) DeepCopyInto(out *LocalQueueFlavorLimit) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]LocalQueueResourceLimit, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalQueueFlavorLimit.
+func (in *LocalQueueFlavorLimit) DeepCopy() *LocalQueueFlavorLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalQueueFlavorLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LocalQueueFlavorStatus) DeepCopyInto(out *LocalQueueFlavorStatus) {
 	*out = *in
@@ -701,10 +865,31 @@ func (in *LocalQueueList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalQueueResourceLimit) DeepCopyInto(out *LocalQueueResourceLimit) {
+	*out = *in
+	out.Max = in.Max.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalQueueResourceLimit.
+func (in *LocalQueueResourceLimit) DeepCopy() *LocalQueueResourceLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalQueueResourceLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LocalQueueResourceUsage) DeepCopyInto(out *LocalQueueResourceUsage) {
 	*out = *in
 	out.Total = in.Total.DeepCopy()
+	if in.Limit != nil {
+		in, out := &in.Limit, &out.Limit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalQueueResourceUsage.
@@ -725,6 +910,23 @@ func (in *LocalQueueSpec) DeepCopyInto(out *LocalQueueSpec) {
 		*out = new(StopPolicy)
 		**out = **in
 	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = make([]LocalQueueFlavorLimit, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MaxAdmittedWorkloads != nil {
+		in, out := &in.MaxAdmittedWorkloads, &out.MaxAdmittedWorkloads
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PerUserLimits != nil {
+		in, out := &in.PerUserLimits, &out.PerUserLimits
+		*out = new(PerUserLimits)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalQueueSpec.
@@ -785,7 +987,7 @@ func (in *MultiKueueCluster) DeepCopyInto(out *MultiKueueCluster) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -807,6 +1009,28 @@ func (in *MultiKueueCluster) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiKueueClusterCapacity) DeepCopyInto(out *MultiKueueClusterCapacity) {
+	*out = *in
+	if in.ClusterQueues != nil {
+		in, out := &in.ClusterQueues, &out.ClusterQueues
+		*out = make([]MultiKueueClusterQueueCapacity, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiKueueClusterCapacity.
+func (in *MultiKueueClusterCapacity) DeepCopy() *MultiKueueClusterCapacity {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiKueueClusterCapacity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MultiKueueClusterList) DeepCopyInto(out *MultiKueueClusterList) {
 	*out = *in
@@ -839,10 +1063,41 @@ func (in *MultiKueueClusterList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiKueueClusterQueueCapacity) DeepCopyInto(out *MultiKueueClusterQueueCapacity) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]MultiKueueResourceCapacity, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiKueueClusterQueueCapacity.
+func (in *MultiKueueClusterQueueCapacity) DeepCopy() *MultiKueueClusterQueueCapacity {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiKueueClusterQueueCapacity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MultiKueueClusterSpec) DeepCopyInto(out *MultiKueueClusterSpec) {
 	*out = *in
-	out.KubeConfig = in.KubeConfig
+	if in.KubeConfig != nil {
+		in, out := &in.KubeConfig, &out.KubeConfig
+		*out = new(KubeConfig)
+		**out = **in
+	}
+	if in.ClusterProfileRef != nil {
+		in, out := &in.ClusterProfileRef, &out.ClusterProfileRef
+		*out = new(ClusterProfileReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiKueueClusterSpec.
@@ -865,6 +1120,11 @@ func (in *MultiKueueClusterStatus) DeepCopyInto(out *MultiKueueClusterStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = new(MultiKueueClusterCapacity)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiKueueClusterStatus.
@@ -943,6 +1203,11 @@ func (in *MultiKueueConfigSpec) DeepCopyInto(out *MultiKueueConfigSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.QuotaPolicy != nil {
+		in, out := &in.QuotaPolicy, &out.QuotaPolicy
+		*out = new(MultiKueueQuotaPolicy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiKueueConfigSpec.
@@ -955,6 +1220,66 @@ func (in *MultiKueueConfigSpec) DeepCopy() *MultiKueueConfigSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiKueueResourceCapacity) DeepCopyInto(out *MultiKueueResourceCapacity) {
+	*out = *in
+	out.NominalQuota = in.NominalQuota.DeepCopy()
+	out.Reserved = in.Reserved.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiKueueResourceCapacity.
+func (in *MultiKueueResourceCapacity) DeepCopy() *MultiKueueResourceCapacity {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiKueueResourceCapacity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerUserLimits) DeepCopyInto(out *PerUserLimits) {
+	*out = *in
+	if in.MaxAdmittedWorkloads != nil {
+		in, out := &in.MaxAdmittedWorkloads, &out.MaxAdmittedWorkloads
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]PerUserResourceLimit, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerUserLimits.
+func (in *PerUserLimits) DeepCopy() *PerUserLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(PerUserLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerUserResourceLimit) DeepCopyInto(out *PerUserResourceLimit) {
+	*out = *in
+	out.Max = in.Max.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerUserResourceLimit.
+func (in *PerUserResourceLimit) DeepCopy() *PerUserResourceLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(PerUserResourceLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodSet) DeepCopyInto(out *PodSet) {
 	*out = *in
@@ -1060,6 +1385,26 @@ func (in *PodSetTopologyRequest) DeepCopyInto(out *PodSetTopologyRequest) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Balanced != nil {
+		in, out := &in.Balanced, &out.Balanced
+		*out = new(string)
+		**out = **in
+	}
+	if in.PodSetGroupName != nil {
+		in, out := &in.PodSetGroupName, &out.PodSetGroupName
+		*out = new(string)
+		**out = **in
+	}
+	if in.PodSetSliceRequiredTopology != nil {
+		in, out := &in.PodSetSliceRequiredTopology, &out.PodSetSliceRequiredTopology
+		*out = new(string)
+		**out = **in
+	}
+	if in.PodSetSliceSize != nil {
+		in, out := &in.PodSetSliceSize, &out.PodSetSliceSize
+		*out = new(int32)
+		**out = **in
+	}
 	if in.PodIndexLabel != nil {
 		in, out := &in.PodIndexLabel, &out.PodIndexLabel
 		*out = new(string)
@@ -1265,6 +1610,42 @@ func (in *ReclaimablePod) DeepCopy() *ReclaimablePod {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemotePodStatus) DeepCopyInto(out *RemotePodStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemotePodStatus.
+func (in *RemotePodStatus) DeepCopy() *RemotePodStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RemotePodStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemotePodsStatus) DeepCopyInto(out *RemotePodsStatus) {
+	*out = *in
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = make([]RemotePodStatus, len(*in))
+		copy(*out, *in)
+	}
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemotePodsStatus.
+func (in *RemotePodsStatus) DeepCopy() *RemotePodsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RemotePodsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RequeueState) DeepCopyInto(out *RequeueState) {
 	*out = *in
@@ -1289,6 +1670,22 @@ func (in *RequeueState) DeepCopy() *RequeueState {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceFederatedQuota) DeepCopyInto(out *ResourceFederatedQuota) {
+	*out = *in
+	out.NominalQuota = in.NominalQuota.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceFederatedQuota.
+func (in *ResourceFederatedQuota) DeepCopy() *ResourceFederatedQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceFederatedQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceFlavor) DeepCopyInto(out *ResourceFlavor) {
 	*out = *in
@@ -1376,6 +1773,13 @@ func (in *ResourceFlavorSpec) DeepCopyInto(out *ResourceFlavorSpec) {
 		*out = new(TopologyReference)
 		**out = **in
 	}
+	if in.Overcommit != nil {
+		in, out := &in.Overcommit, &out.Overcommit
+		*out = make([]ResourceOvercommit, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceFlavorSpec.
@@ -1415,6 +1819,57 @@ func (in *ResourceGroup) DeepCopy() *ResourceGroup {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceNodeCapacityQuota) DeepCopyInto(out *ResourceNodeCapacityQuota) {
+	*out = *in
+	out.Allocatable = in.Allocatable.DeepCopy()
+	out.NominalQuota = in.NominalQuota.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceNodeCapacityQuota.
+func (in *ResourceNodeCapacityQuota) DeepCopy() *ResourceNodeCapacityQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceNodeCapacityQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceOvercommit) DeepCopyInto(out *ResourceOvercommit) {
+	*out = *in
+	out.Ratio = in.Ratio.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceOvercommit.
+func (in *ResourceOvercommit) DeepCopy() *ResourceOvercommit {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceOvercommit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceOvercommittedQuota) DeepCopyInto(out *ResourceOvercommittedQuota) {
+	*out = *in
+	out.Ratio = in.Ratio.DeepCopy()
+	out.NominalQuota = in.NominalQuota.DeepCopy()
+	out.EffectiveNominalQuota = in.EffectiveNominalQuota.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceOvercommittedQuota.
+func (in *ResourceOvercommittedQuota) DeepCopy() *ResourceOvercommittedQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceOvercommittedQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceQuota) DeepCopyInto(out *ResourceQuota) {
 	*out = *in
@@ -1429,6 +1884,11 @@ func (in *ResourceQuota) DeepCopyInto(out *ResourceQuota) {
 		x := (*in).DeepCopy()
 		*out = &x
 	}
+	if in.NodeCapacityPercentage != nil {
+		in, out := &in.NodeCapacityPercentage, &out.NodeCapacityPercentage
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceQuota.
@@ -1473,6 +1933,13 @@ func (in *TopologyAssignment) DeepCopyInto(out *TopologyAssignment) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SliceDomains != nil {
+		in, out := &in.SliceDomains, &out.SliceDomains
+		*out = make([]TopologyDomainAssignment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologyAssignment.
@@ -1722,6 +2189,11 @@ func (in *WorkloadStatus) DeepCopyInto(out *WorkloadStatus) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.RemotePods != nil {
+		in, out := &in.RemotePods, &out.RemotePods
+		*out = new(RemotePodsStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadStatus.
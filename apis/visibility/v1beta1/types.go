@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -75,6 +76,25 @@ type PendingWorkload struct {
 
 	// PositionInLocalQueue indicates the workload's position in the LocalQueue, starting from 0
 	PositionInLocalQueue int32 `json:"positionInLocalQueue"`
+
+	// SubmittedBy indicates the user or service account which submitted the workload.
+	// It is only set when the PerUserLimits feature gate is enabled.
+	// +optional
+	SubmittedBy string `json:"submittedBy,omitempty"`
+}
+
+// UserUsage summarizes the quota reserved by the workloads submitted by a user.
+type UserUsage struct {
+	// User is the user or service account which submitted the workloads
+	User string `json:"user"`
+
+	// ReservingWorkloads indicates the number of workloads submitted by the user that reserve quota
+	ReservingWorkloads int32 `json:"reservingWorkloads"`
+
+	// Resources indicates the quantities of resources, summed across flavors, reserved by the workloads
+	// submitted by the user
+	// +optional
+	Resources corev1.ResourceList `json:"resources,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -87,6 +107,11 @@ type PendingWorkloadsSummary struct {
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
 	Items []PendingWorkload `json:"items"`
+
+	// UserUsage indicates the quota reserved in the context of the query by the workloads of each user.
+	// It is only set when the PerUserLimits feature gate is enabled.
+	// +optional
+	UserUsage []UserUsage `json:"userUsage,omitempty"`
 }
 
 // +kubebuilder:object:root=true
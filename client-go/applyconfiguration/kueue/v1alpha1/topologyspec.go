@@ -20,7 +20,8 @@ package v1alpha1
 // TopologySpecApplyConfiguration represents a declarative configuration of the TopologySpec type for use
 // with apply.
 type TopologySpecApplyConfiguration struct {
-	Levels []TopologyLevelApplyConfiguration `json:"levels,omitempty"`
+	Levels            []TopologyLevelApplyConfiguration            `json:"levels,omitempty"`
+	NodeDomainsSource *TopologyNodeDomainsSourceApplyConfiguration `json:"nodeDomainsSource,omitempty"`
 }
 
 // TopologySpecApplyConfiguration constructs a declarative configuration of the TopologySpec type for use with
@@ -41,3 +42,11 @@ func (b *TopologySpecApplyConfiguration) WithLevels(values ...*TopologyLevelAppl
 	}
 	return b
 }
+
+// WithNodeDomainsSource sets the NodeDomainsSource field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NodeDomainsSource field is set to the value of the last call.
+func (b *TopologySpecApplyConfiguration) WithNodeDomainsSource(value *TopologyNodeDomainsSourceApplyConfiguration) *TopologySpecApplyConfiguration {
+	b.NodeDomainsSource = value
+	return b
+}
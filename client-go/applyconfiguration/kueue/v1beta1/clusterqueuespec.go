@@ -35,6 +35,8 @@ type ClusterQueueSpecApplyConfiguration struct {
 	AdmissionChecksStrategy *AdmissionChecksStrategyApplyConfiguration `json:"admissionChecksStrategy,omitempty"`
 	StopPolicy              *kueuev1beta1.StopPolicy                   `json:"stopPolicy,omitempty"`
 	FairSharing             *FairSharingApplyConfiguration             `json:"fairSharing,omitempty"`
+	MaxAdmittedWorkloads    *int32                                     `json:"maxAdmittedWorkloads,omitempty"`
+	PerUserLimits           *PerUserLimitsApplyConfiguration           `json:"perUserLimits,omitempty"`
 }
 
 // ClusterQueueSpecApplyConfiguration constructs a declarative configuration of the ClusterQueueSpec type for use with
@@ -129,3 +131,19 @@ func (b *ClusterQueueSpecApplyConfiguration) WithFairSharing(value *FairSharingA
 	b.FairSharing = value
 	return b
 }
+
+// WithMaxAdmittedWorkloads sets the MaxAdmittedWorkloads field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxAdmittedWorkloads field is set to the value of the last call.
+func (b *ClusterQueueSpecApplyConfiguration) WithMaxAdmittedWorkloads(value int32) *ClusterQueueSpecApplyConfiguration {
+	b.MaxAdmittedWorkloads = &value
+	return b
+}
+
+// WithPerUserLimits sets the PerUserLimits field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PerUserLimits field is set to the value of the last call.
+func (b *ClusterQueueSpecApplyConfiguration) WithPerUserLimits(value *PerUserLimitsApplyConfiguration) *ClusterQueueSpecApplyConfiguration {
+	b.PerUserLimits = value
+	return b
+}
@@ -29,6 +29,10 @@ type ClusterQueueStatusApplyConfiguration struct {
 	PendingWorkloads       *int32                                                `json:"pendingWorkloads,omitempty"`
 	ReservingWorkloads     *int32                                                `json:"reservingWorkloads,omitempty"`
 	AdmittedWorkloads      *int32                                                `json:"admittedWorkloads,omitempty"`
+	BorrowedWorkloads      *int32                                                `json:"borrowedWorkloads,omitempty"`
+	NodeCapacityQuotas     []FlavorNodeCapacityQuotaApplyConfiguration           `json:"nodeCapacityQuotas,omitempty"`
+	OvercommittedQuotas    []FlavorOvercommittedQuotaApplyConfiguration          `json:"overcommittedQuotas,omitempty"`
+	FederatedQuota         *FederatedQuotaApplyConfiguration                     `json:"federatedQuota,omitempty"`
 	Conditions             []v1.ConditionApplyConfiguration                      `json:"conditions,omitempty"`
 	PendingWorkloadsStatus *ClusterQueuePendingWorkloadsStatusApplyConfiguration `json:"pendingWorkloadsStatus,omitempty"`
 	FairSharing            *FairSharingStatusApplyConfiguration                  `json:"fairSharing,omitempty"`
@@ -90,6 +94,48 @@ func (b *ClusterQueueStatusApplyConfiguration) WithAdmittedWorkloads(value int32
 	return b
 }
 
+// WithBorrowedWorkloads sets the BorrowedWorkloads field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BorrowedWorkloads field is set to the value of the last call.
+func (b *ClusterQueueStatusApplyConfiguration) WithBorrowedWorkloads(value int32) *ClusterQueueStatusApplyConfiguration {
+	b.BorrowedWorkloads = &value
+	return b
+}
+
+// WithNodeCapacityQuotas adds the given value to the NodeCapacityQuotas field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the NodeCapacityQuotas field.
+func (b *ClusterQueueStatusApplyConfiguration) WithNodeCapacityQuotas(values ...*FlavorNodeCapacityQuotaApplyConfiguration) *ClusterQueueStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithNodeCapacityQuotas")
+		}
+		b.NodeCapacityQuotas = append(b.NodeCapacityQuotas, *values[i])
+	}
+	return b
+}
+
+// WithOvercommittedQuotas adds the given value to the OvercommittedQuotas field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the OvercommittedQuotas field.
+func (b *ClusterQueueStatusApplyConfiguration) WithOvercommittedQuotas(values ...*FlavorOvercommittedQuotaApplyConfiguration) *ClusterQueueStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithOvercommittedQuotas")
+		}
+		b.OvercommittedQuotas = append(b.OvercommittedQuotas, *values[i])
+	}
+	return b
+}
+
+// WithFederatedQuota sets the FederatedQuota field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FederatedQuota field is set to the value of the last call.
+func (b *ClusterQueueStatusApplyConfiguration) WithFederatedQuota(value *FederatedQuotaApplyConfiguration) *ClusterQueueStatusApplyConfiguration {
+	b.FederatedQuota = value
+	return b
+}
+
 // WithConditions adds the given value to the Conditions field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the Conditions field.
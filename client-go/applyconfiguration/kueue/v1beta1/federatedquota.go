@@ -0,0 +1,67 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	kueuev1beta1 "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+// FederatedQuotaApplyConfiguration represents a declarative configuration of the FederatedQuota type for use
+// with apply.
+type FederatedQuotaApplyConfiguration struct {
+	Policy   *kueuev1beta1.MultiKueueQuotaPolicy      `json:"policy,omitempty"`
+	Clusters []string                                 `json:"clusters,omitempty"`
+	Flavors  []FlavorFederatedQuotaApplyConfiguration `json:"flavors,omitempty"`
+}
+
+// FederatedQuotaApplyConfiguration constructs a declarative configuration of the FederatedQuota type for use with
+// apply.
+func FederatedQuota() *FederatedQuotaApplyConfiguration {
+	return &FederatedQuotaApplyConfiguration{}
+}
+
+// WithPolicy sets the Policy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Policy field is set to the value of the last call.
+func (b *FederatedQuotaApplyConfiguration) WithPolicy(value kueuev1beta1.MultiKueueQuotaPolicy) *FederatedQuotaApplyConfiguration {
+	b.Policy = &value
+	return b
+}
+
+// WithClusters adds the given value to the Clusters field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Clusters field.
+func (b *FederatedQuotaApplyConfiguration) WithClusters(values ...string) *FederatedQuotaApplyConfiguration {
+	for i := range values {
+		b.Clusters = append(b.Clusters, values[i])
+	}
+	return b
+}
+
+// WithFlavors adds the given value to the Flavors field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Flavors field.
+func (b *FederatedQuotaApplyConfiguration) WithFlavors(values ...*FlavorFederatedQuotaApplyConfiguration) *FederatedQuotaApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithFlavors")
+		}
+		b.Flavors = append(b.Flavors, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,56 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	kueuev1beta1 "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+// FlavorFederatedQuotaApplyConfiguration represents a declarative configuration of the FlavorFederatedQuota type for use
+// with apply.
+type FlavorFederatedQuotaApplyConfiguration struct {
+	Name      *kueuev1beta1.ResourceFlavorReference      `json:"name,omitempty"`
+	Resources []ResourceFederatedQuotaApplyConfiguration `json:"resources,omitempty"`
+}
+
+// FlavorFederatedQuotaApplyConfiguration constructs a declarative configuration of the FlavorFederatedQuota type for use with
+// apply.
+func FlavorFederatedQuota() *FlavorFederatedQuotaApplyConfiguration {
+	return &FlavorFederatedQuotaApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *FlavorFederatedQuotaApplyConfiguration) WithName(value kueuev1beta1.ResourceFlavorReference) *FlavorFederatedQuotaApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithResources adds the given value to the Resources field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Resources field.
+func (b *FlavorFederatedQuotaApplyConfiguration) WithResources(values ...*ResourceFederatedQuotaApplyConfiguration) *FlavorFederatedQuotaApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithResources")
+		}
+		b.Resources = append(b.Resources, *values[i])
+	}
+	return b
+}
@@ -27,6 +27,7 @@ import (
 type LocalQueueResourceUsageApplyConfiguration struct {
 	Name  *v1.ResourceName   `json:"name,omitempty"`
 	Total *resource.Quantity `json:"total,omitempty"`
+	Limit *resource.Quantity `json:"limit,omitempty"`
 }
 
 // LocalQueueResourceUsageApplyConfiguration constructs a declarative configuration of the LocalQueueResourceUsage type for use with
@@ -50,3 +51,11 @@ func (b *LocalQueueResourceUsageApplyConfiguration) WithTotal(value resource.Qua
 	b.Total = &value
 	return b
 }
+
+// WithLimit sets the Limit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Limit field is set to the value of the last call.
+func (b *LocalQueueResourceUsageApplyConfiguration) WithLimit(value resource.Quantity) *LocalQueueResourceUsageApplyConfiguration {
+	b.Limit = &value
+	return b
+}
@@ -24,7 +24,8 @@ import (
 // MultiKueueClusterStatusApplyConfiguration represents a declarative configuration of the MultiKueueClusterStatus type for use
 // with apply.
 type MultiKueueClusterStatusApplyConfiguration struct {
-	Conditions []v1.ConditionApplyConfiguration `json:"conditions,omitempty"`
+	Conditions []v1.ConditionApplyConfiguration             `json:"conditions,omitempty"`
+	Capacity   *MultiKueueClusterCapacityApplyConfiguration `json:"capacity,omitempty"`
 }
 
 // MultiKueueClusterStatusApplyConfiguration constructs a declarative configuration of the MultiKueueClusterStatus type for use with
@@ -45,3 +46,11 @@ func (b *MultiKueueClusterStatusApplyConfiguration) WithConditions(values ...*v1
 	}
 	return b
 }
+
+// WithCapacity sets the Capacity field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Capacity field is set to the value of the last call.
+func (b *MultiKueueClusterStatusApplyConfiguration) WithCapacity(value *MultiKueueClusterCapacityApplyConfiguration) *MultiKueueClusterStatusApplyConfiguration {
+	b.Capacity = value
+	return b
+}
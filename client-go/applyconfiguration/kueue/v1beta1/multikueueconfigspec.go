@@ -17,10 +17,15 @@ limitations under the License.
 
 package v1beta1
 
+import (
+	kueuev1beta1 "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
 // MultiKueueConfigSpecApplyConfiguration represents a declarative configuration of the MultiKueueConfigSpec type for use
 // with apply.
 type MultiKueueConfigSpecApplyConfiguration struct {
-	Clusters []string `json:"clusters,omitempty"`
+	Clusters    []string                            `json:"clusters,omitempty"`
+	QuotaPolicy *kueuev1beta1.MultiKueueQuotaPolicy `json:"quotaPolicy,omitempty"`
 }
 
 // MultiKueueConfigSpecApplyConfiguration constructs a declarative configuration of the MultiKueueConfigSpec type for use with
@@ -38,3 +43,11 @@ func (b *MultiKueueConfigSpecApplyConfiguration) WithClusters(values ...string)
 	}
 	return b
 }
+
+// WithQuotaPolicy sets the QuotaPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the QuotaPolicy field is set to the value of the last call.
+func (b *MultiKueueConfigSpecApplyConfiguration) WithQuotaPolicy(value kueuev1beta1.MultiKueueQuotaPolicy) *MultiKueueConfigSpecApplyConfiguration {
+	b.QuotaPolicy = &value
+	return b
+}
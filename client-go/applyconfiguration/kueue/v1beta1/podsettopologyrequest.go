@@ -20,12 +20,16 @@ package v1beta1
 // PodSetTopologyRequestApplyConfiguration represents a declarative configuration of the PodSetTopologyRequest type for use
 // with apply.
 type PodSetTopologyRequestApplyConfiguration struct {
-	Required           *string `json:"required,omitempty"`
-	Preferred          *string `json:"preferred,omitempty"`
-	Unconstrained      *bool   `json:"unconstrained,omitempty"`
-	PodIndexLabel      *string `json:"podIndexLabel,omitempty"`
-	SubGroupIndexLabel *string `json:"subGroupIndexLabel,omitempty"`
-	SubGroupCount      *int32  `json:"subGroupCount,omitempty"`
+	Required                    *string `json:"required,omitempty"`
+	Preferred                   *string `json:"preferred,omitempty"`
+	Unconstrained               *bool   `json:"unconstrained,omitempty"`
+	Balanced                    *string `json:"balanced,omitempty"`
+	PodSetGroupName             *string `json:"podSetGroupName,omitempty"`
+	PodSetSliceRequiredTopology *string `json:"podSetSliceRequiredTopology,omitempty"`
+	PodSetSliceSize             *int32  `json:"podSetSliceSize,omitempty"`
+	PodIndexLabel               *string `json:"podIndexLabel,omitempty"`
+	SubGroupIndexLabel          *string `json:"subGroupIndexLabel,omitempty"`
+	SubGroupCount               *int32  `json:"subGroupCount,omitempty"`
 }
 
 // PodSetTopologyRequestApplyConfiguration constructs a declarative configuration of the PodSetTopologyRequest type for use with
@@ -58,6 +62,38 @@ func (b *PodSetTopologyRequestApplyConfiguration) WithUnconstrained(value bool)
 	return b
 }
 
+// WithBalanced sets the Balanced field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Balanced field is set to the value of the last call.
+func (b *PodSetTopologyRequestApplyConfiguration) WithBalanced(value string) *PodSetTopologyRequestApplyConfiguration {
+	b.Balanced = &value
+	return b
+}
+
+// WithPodSetGroupName sets the PodSetGroupName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PodSetGroupName field is set to the value of the last call.
+func (b *PodSetTopologyRequestApplyConfiguration) WithPodSetGroupName(value string) *PodSetTopologyRequestApplyConfiguration {
+	b.PodSetGroupName = &value
+	return b
+}
+
+// WithPodSetSliceRequiredTopology sets the PodSetSliceRequiredTopology field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PodSetSliceRequiredTopology field is set to the value of the last call.
+func (b *PodSetTopologyRequestApplyConfiguration) WithPodSetSliceRequiredTopology(value string) *PodSetTopologyRequestApplyConfiguration {
+	b.PodSetSliceRequiredTopology = &value
+	return b
+}
+
+// WithPodSetSliceSize sets the PodSetSliceSize field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PodSetSliceSize field is set to the value of the last call.
+func (b *PodSetTopologyRequestApplyConfiguration) WithPodSetSliceSize(value int32) *PodSetTopologyRequestApplyConfiguration {
+	b.PodSetSliceSize = &value
+	return b
+}
+
 // WithPodIndexLabel sets the PodIndexLabel field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the PodIndexLabel field is set to the value of the last call.
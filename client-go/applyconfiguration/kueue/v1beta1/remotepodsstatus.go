@@ -0,0 +1,101 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RemotePodsStatusApplyConfiguration represents a declarative configuration of the RemotePodsStatus type for use
+// with apply.
+type RemotePodsStatusApplyConfiguration struct {
+	ClusterName    *string                             `json:"clusterName,omitempty"`
+	Pending        *int32                              `json:"pending,omitempty"`
+	Running        *int32                              `json:"running,omitempty"`
+	Succeeded      *int32                              `json:"succeeded,omitempty"`
+	Failed         *int32                              `json:"failed,omitempty"`
+	Pods           []RemotePodStatusApplyConfiguration `json:"pods,omitempty"`
+	LastUpdateTime *v1.Time                            `json:"lastUpdateTime,omitempty"`
+}
+
+// RemotePodsStatusApplyConfiguration constructs a declarative configuration of the RemotePodsStatus type for use with
+// apply.
+func RemotePodsStatus() *RemotePodsStatusApplyConfiguration {
+	return &RemotePodsStatusApplyConfiguration{}
+}
+
+// WithClusterName sets the ClusterName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ClusterName field is set to the value of the last call.
+func (b *RemotePodsStatusApplyConfiguration) WithClusterName(value string) *RemotePodsStatusApplyConfiguration {
+	b.ClusterName = &value
+	return b
+}
+
+// WithPending sets the Pending field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Pending field is set to the value of the last call.
+func (b *RemotePodsStatusApplyConfiguration) WithPending(value int32) *RemotePodsStatusApplyConfiguration {
+	b.Pending = &value
+	return b
+}
+
+// WithRunning sets the Running field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Running field is set to the value of the last call.
+func (b *RemotePodsStatusApplyConfiguration) WithRunning(value int32) *RemotePodsStatusApplyConfiguration {
+	b.Running = &value
+	return b
+}
+
+// WithSucceeded sets the Succeeded field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Succeeded field is set to the value of the last call.
+func (b *RemotePodsStatusApplyConfiguration) WithSucceeded(value int32) *RemotePodsStatusApplyConfiguration {
+	b.Succeeded = &value
+	return b
+}
+
+// WithFailed sets the Failed field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Failed field is set to the value of the last call.
+func (b *RemotePodsStatusApplyConfiguration) WithFailed(value int32) *RemotePodsStatusApplyConfiguration {
+	b.Failed = &value
+	return b
+}
+
+// WithPods adds the given value to the Pods field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Pods field.
+func (b *RemotePodsStatusApplyConfiguration) WithPods(values ...*RemotePodStatusApplyConfiguration) *RemotePodsStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithPods")
+		}
+		b.Pods = append(b.Pods, *values[i])
+	}
+	return b
+}
+
+// WithLastUpdateTime sets the LastUpdateTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastUpdateTime field is set to the value of the last call.
+func (b *RemotePodsStatusApplyConfiguration) WithLastUpdateTime(value v1.Time) *RemotePodsStatusApplyConfiguration {
+	b.LastUpdateTime = &value
+	return b
+}
@@ -0,0 +1,78 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// RemotePodStatusApplyConfiguration represents a declarative configuration of the RemotePodStatus type for use
+// with apply.
+type RemotePodStatusApplyConfiguration struct {
+	Name     *string      `json:"name,omitempty"`
+	Phase    *v1.PodPhase `json:"phase,omitempty"`
+	Reason   *string      `json:"reason,omitempty"`
+	Message  *string      `json:"message,omitempty"`
+	Restarts *int32       `json:"restarts,omitempty"`
+}
+
+// RemotePodStatusApplyConfiguration constructs a declarative configuration of the RemotePodStatus type for use with
+// apply.
+func RemotePodStatus() *RemotePodStatusApplyConfiguration {
+	return &RemotePodStatusApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *RemotePodStatusApplyConfiguration) WithName(value string) *RemotePodStatusApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithPhase sets the Phase field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Phase field is set to the value of the last call.
+func (b *RemotePodStatusApplyConfiguration) WithPhase(value v1.PodPhase) *RemotePodStatusApplyConfiguration {
+	b.Phase = &value
+	return b
+}
+
+// WithReason sets the Reason field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Reason field is set to the value of the last call.
+func (b *RemotePodStatusApplyConfiguration) WithReason(value string) *RemotePodStatusApplyConfiguration {
+	b.Reason = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *RemotePodStatusApplyConfiguration) WithMessage(value string) *RemotePodStatusApplyConfiguration {
+	b.Message = &value
+	return b
+}
+
+// WithRestarts sets the Restarts field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Restarts field is set to the value of the last call.
+func (b *RemotePodStatusApplyConfiguration) WithRestarts(value int32) *RemotePodStatusApplyConfiguration {
+	b.Restarts = &value
+	return b
+}
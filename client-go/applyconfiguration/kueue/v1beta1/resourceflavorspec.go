@@ -25,10 +25,11 @@ import (
 // ResourceFlavorSpecApplyConfiguration represents a declarative configuration of the ResourceFlavorSpec type for use
 // with apply.
 type ResourceFlavorSpecApplyConfiguration struct {
-	NodeLabels   map[string]string                 `json:"nodeLabels,omitempty"`
-	NodeTaints   []v1.TaintApplyConfiguration      `json:"nodeTaints,omitempty"`
-	Tolerations  []v1.TolerationApplyConfiguration `json:"tolerations,omitempty"`
-	TopologyName *kueuev1beta1.TopologyReference   `json:"topologyName,omitempty"`
+	NodeLabels   map[string]string                      `json:"nodeLabels,omitempty"`
+	NodeTaints   []v1.TaintApplyConfiguration           `json:"nodeTaints,omitempty"`
+	Tolerations  []v1.TolerationApplyConfiguration      `json:"tolerations,omitempty"`
+	TopologyName *kueuev1beta1.TopologyReference        `json:"topologyName,omitempty"`
+	Overcommit   []ResourceOvercommitApplyConfiguration `json:"overcommit,omitempty"`
 }
 
 // ResourceFlavorSpecApplyConfiguration constructs a declarative configuration of the ResourceFlavorSpec type for use with
@@ -84,3 +85,16 @@ func (b *ResourceFlavorSpecApplyConfiguration) WithTopologyName(value kueuev1bet
 	b.TopologyName = &value
 	return b
 }
+
+// WithOvercommit adds the given value to the Overcommit field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Overcommit field.
+func (b *ResourceFlavorSpecApplyConfiguration) WithOvercommit(values ...*ResourceOvercommitApplyConfiguration) *ResourceFlavorSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithOvercommit")
+		}
+		b.Overcommit = append(b.Overcommit, *values[i])
+	}
+	return b
+}
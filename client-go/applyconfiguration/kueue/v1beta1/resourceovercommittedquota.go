@@ -0,0 +1,70 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	resource "k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ResourceOvercommittedQuotaApplyConfiguration represents a declarative configuration of the ResourceOvercommittedQuota type for use
+// with apply.
+type ResourceOvercommittedQuotaApplyConfiguration struct {
+	Name                  *v1.ResourceName   `json:"name,omitempty"`
+	Ratio                 *resource.Quantity `json:"ratio,omitempty"`
+	NominalQuota          *resource.Quantity `json:"nominalQuota,omitempty"`
+	EffectiveNominalQuota *resource.Quantity `json:"effectiveNominalQuota,omitempty"`
+}
+
+// ResourceOvercommittedQuotaApplyConfiguration constructs a declarative configuration of the ResourceOvercommittedQuota type for use with
+// apply.
+func ResourceOvercommittedQuota() *ResourceOvercommittedQuotaApplyConfiguration {
+	return &ResourceOvercommittedQuotaApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *ResourceOvercommittedQuotaApplyConfiguration) WithName(value v1.ResourceName) *ResourceOvercommittedQuotaApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithRatio sets the Ratio field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Ratio field is set to the value of the last call.
+func (b *ResourceOvercommittedQuotaApplyConfiguration) WithRatio(value resource.Quantity) *ResourceOvercommittedQuotaApplyConfiguration {
+	b.Ratio = &value
+	return b
+}
+
+// WithNominalQuota sets the NominalQuota field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NominalQuota field is set to the value of the last call.
+func (b *ResourceOvercommittedQuotaApplyConfiguration) WithNominalQuota(value resource.Quantity) *ResourceOvercommittedQuotaApplyConfiguration {
+	b.NominalQuota = &value
+	return b
+}
+
+// WithEffectiveNominalQuota sets the EffectiveNominalQuota field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EffectiveNominalQuota field is set to the value of the last call.
+func (b *ResourceOvercommittedQuotaApplyConfiguration) WithEffectiveNominalQuota(value resource.Quantity) *ResourceOvercommittedQuotaApplyConfiguration {
+	b.EffectiveNominalQuota = &value
+	return b
+}
@@ -25,10 +25,11 @@ import (
 // ResourceQuotaApplyConfiguration represents a declarative configuration of the ResourceQuota type for use
 // with apply.
 type ResourceQuotaApplyConfiguration struct {
-	Name           *v1.ResourceName   `json:"name,omitempty"`
-	NominalQuota   *resource.Quantity `json:"nominalQuota,omitempty"`
-	BorrowingLimit *resource.Quantity `json:"borrowingLimit,omitempty"`
-	LendingLimit   *resource.Quantity `json:"lendingLimit,omitempty"`
+	Name                   *v1.ResourceName   `json:"name,omitempty"`
+	NominalQuota           *resource.Quantity `json:"nominalQuota,omitempty"`
+	BorrowingLimit         *resource.Quantity `json:"borrowingLimit,omitempty"`
+	LendingLimit           *resource.Quantity `json:"lendingLimit,omitempty"`
+	NodeCapacityPercentage *int32             `json:"nodeCapacityPercentage,omitempty"`
 }
 
 // ResourceQuotaApplyConfiguration constructs a declarative configuration of the ResourceQuota type for use with
@@ -68,3 +69,11 @@ func (b *ResourceQuotaApplyConfiguration) WithLendingLimit(value resource.Quanti
 	b.LendingLimit = &value
 	return b
 }
+
+// WithNodeCapacityPercentage sets the NodeCapacityPercentage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NodeCapacityPercentage field is set to the value of the last call.
+func (b *ResourceQuotaApplyConfiguration) WithNodeCapacityPercentage(value int32) *ResourceQuotaApplyConfiguration {
+	b.NodeCapacityPercentage = &value
+	return b
+}
@@ -20,8 +20,9 @@ package v1beta1
 // TopologyAssignmentApplyConfiguration represents a declarative configuration of the TopologyAssignment type for use
 // with apply.
 type TopologyAssignmentApplyConfiguration struct {
-	Levels  []string                                     `json:"levels,omitempty"`
-	Domains []TopologyDomainAssignmentApplyConfiguration `json:"domains,omitempty"`
+	Levels       []string                                     `json:"levels,omitempty"`
+	Domains      []TopologyDomainAssignmentApplyConfiguration `json:"domains,omitempty"`
+	SliceDomains []TopologyDomainAssignmentApplyConfiguration `json:"sliceDomains,omitempty"`
 }
 
 // TopologyAssignmentApplyConfiguration constructs a declarative configuration of the TopologyAssignment type for use with
@@ -52,3 +53,16 @@ func (b *TopologyAssignmentApplyConfiguration) WithDomains(values ...*TopologyDo
 	}
 	return b
 }
+
+// WithSliceDomains adds the given value to the SliceDomains field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the SliceDomains field.
+func (b *TopologyAssignmentApplyConfiguration) WithSliceDomains(values ...*TopologyDomainAssignmentApplyConfiguration) *TopologyAssignmentApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithSliceDomains")
+		}
+		b.SliceDomains = append(b.SliceDomains, *values[i])
+	}
+	return b
+}
@@ -31,6 +31,7 @@ type WorkloadStatusApplyConfiguration struct {
 	AdmissionChecks                      []AdmissionCheckStateApplyConfiguration `json:"admissionChecks,omitempty"`
 	ResourceRequests                     []PodSetRequestApplyConfiguration       `json:"resourceRequests,omitempty"`
 	AccumulatedPastExexcutionTimeSeconds *int32                                  `json:"accumulatedPastExexcutionTimeSeconds,omitempty"`
+	RemotePods                           *RemotePodsStatusApplyConfiguration     `json:"remotePods,omitempty"`
 }
 
 // WorkloadStatusApplyConfiguration constructs a declarative configuration of the WorkloadStatus type for use with
@@ -114,3 +115,11 @@ func (b *WorkloadStatusApplyConfiguration) WithAccumulatedPastExexcutionTimeSeco
 	b.AccumulatedPastExexcutionTimeSeconds = &value
 	return b
 }
+
+// WithRemotePods sets the RemotePods field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RemotePods field is set to the value of the last call.
+func (b *WorkloadStatusApplyConfiguration) WithRemotePods(value *RemotePodsStatusApplyConfiguration) *WorkloadStatusApplyConfiguration {
+	b.RemotePods = value
+	return b
+}
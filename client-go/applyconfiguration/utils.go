@@ -39,6 +39,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1alpha1.TopologyApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("TopologyLevel"):
 		return &kueuev1alpha1.TopologyLevelApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("TopologyNodeDomainsSource"):
+		return &kueuev1alpha1.TopologyNodeDomainsSourceApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("TopologySpec"):
 		return &kueuev1alpha1.TopologySpecApplyConfiguration{}
 
@@ -61,6 +63,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta1.AdmissionCheckStrategyRuleApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("BorrowWithinCohort"):
 		return &kueuev1beta1.BorrowWithinCohortApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ClusterProfileReference"):
+		return &kueuev1beta1.ClusterProfileReferenceApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ClusterQueue"):
 		return &kueuev1beta1.ClusterQueueApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ClusterQueuePendingWorkload"):
@@ -77,8 +81,16 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta1.FairSharingApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("FairSharingStatus"):
 		return &kueuev1beta1.FairSharingStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("FederatedQuota"):
+		return &kueuev1beta1.FederatedQuotaApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("FlavorFederatedQuota"):
+		return &kueuev1beta1.FlavorFederatedQuotaApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("FlavorFungibility"):
 		return &kueuev1beta1.FlavorFungibilityApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("FlavorNodeCapacityQuota"):
+		return &kueuev1beta1.FlavorNodeCapacityQuotaApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("FlavorOvercommittedQuota"):
+		return &kueuev1beta1.FlavorOvercommittedQuotaApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("FlavorQuotas"):
 		return &kueuev1beta1.FlavorQuotasApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("FlavorUsage"):
@@ -87,10 +99,14 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta1.KubeConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("LocalQueue"):
 		return &kueuev1beta1.LocalQueueApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("LocalQueueFlavorLimit"):
+		return &kueuev1beta1.LocalQueueFlavorLimitApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("LocalQueueFlavorStatus"):
 		return &kueuev1beta1.LocalQueueFlavorStatusApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("LocalQueueFlavorUsage"):
 		return &kueuev1beta1.LocalQueueFlavorUsageApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("LocalQueueResourceLimit"):
+		return &kueuev1beta1.LocalQueueResourceLimitApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("LocalQueueResourceUsage"):
 		return &kueuev1beta1.LocalQueueResourceUsageApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("LocalQueueSpec"):
@@ -99,6 +115,10 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta1.LocalQueueStatusApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("MultiKueueCluster"):
 		return &kueuev1beta1.MultiKueueClusterApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("MultiKueueClusterCapacity"):
+		return &kueuev1beta1.MultiKueueClusterCapacityApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("MultiKueueClusterQueueCapacity"):
+		return &kueuev1beta1.MultiKueueClusterQueueCapacityApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("MultiKueueClusterSpec"):
 		return &kueuev1beta1.MultiKueueClusterSpecApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("MultiKueueClusterStatus"):
@@ -107,6 +127,12 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta1.MultiKueueConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("MultiKueueConfigSpec"):
 		return &kueuev1beta1.MultiKueueConfigSpecApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("MultiKueueResourceCapacity"):
+		return &kueuev1beta1.MultiKueueResourceCapacityApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("PerUserLimits"):
+		return &kueuev1beta1.PerUserLimitsApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("PerUserResourceLimit"):
+		return &kueuev1beta1.PerUserResourceLimitApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("PodSet"):
 		return &kueuev1beta1.PodSetApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("PodSetAssignment"):
@@ -125,14 +151,26 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta1.ProvisioningRequestRetryStrategyApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ReclaimablePod"):
 		return &kueuev1beta1.ReclaimablePodApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("RemotePodsStatus"):
+		return &kueuev1beta1.RemotePodsStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("RemotePodStatus"):
+		return &kueuev1beta1.RemotePodStatusApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("RequeueState"):
 		return &kueuev1beta1.RequeueStateApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ResourceFederatedQuota"):
+		return &kueuev1beta1.ResourceFederatedQuotaApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ResourceFlavor"):
 		return &kueuev1beta1.ResourceFlavorApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ResourceFlavorSpec"):
 		return &kueuev1beta1.ResourceFlavorSpecApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ResourceGroup"):
 		return &kueuev1beta1.ResourceGroupApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ResourceNodeCapacityQuota"):
+		return &kueuev1beta1.ResourceNodeCapacityQuotaApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ResourceOvercommit"):
+		return &kueuev1beta1.ResourceOvercommitApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ResourceOvercommittedQuota"):
+		return &kueuev1beta1.ResourceOvercommittedQuotaApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ResourceQuota"):
 		return &kueuev1beta1.ResourceQuotaApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ResourceUsage"):
@@ -161,6 +199,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &applyconfigurationvisibilityv1beta1.PendingWorkloadApplyConfiguration{}
 	case visibilityv1beta1.SchemeGroupVersion.WithKind("PendingWorkloadsSummary"):
 		return &applyconfigurationvisibilityv1beta1.PendingWorkloadsSummaryApplyConfiguration{}
+	case visibilityv1beta1.SchemeGroupVersion.WithKind("UserUsage"):
+		return &applyconfigurationvisibilityv1beta1.UserUsageApplyConfiguration{}
 
 	}
 	return nil
@@ -31,6 +31,7 @@ type PendingWorkloadApplyConfiguration struct {
 	LocalQueueName                   *string `json:"localQueueName,omitempty"`
 	PositionInClusterQueue           *int32  `json:"positionInClusterQueue,omitempty"`
 	PositionInLocalQueue             *int32  `json:"positionInLocalQueue,omitempty"`
+	SubmittedBy                      *string `json:"submittedBy,omitempty"`
 }
 
 // PendingWorkloadApplyConfiguration constructs a declarative configuration of the PendingWorkload type for use with
@@ -213,6 +214,14 @@ func (b *PendingWorkloadApplyConfiguration) WithPositionInLocalQueue(value int32
 	return b
 }
 
+// WithSubmittedBy sets the SubmittedBy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SubmittedBy field is set to the value of the last call.
+func (b *PendingWorkloadApplyConfiguration) WithSubmittedBy(value string) *PendingWorkloadApplyConfiguration {
+	b.SubmittedBy = &value
+	return b
+}
+
 // GetName retrieves the value of the Name field in the declarative configuration.
 func (b *PendingWorkloadApplyConfiguration) GetName() *string {
 	b.ensureObjectMetaApplyConfigurationExists()
@@ -29,6 +29,7 @@ type PendingWorkloadsSummaryApplyConfiguration struct {
 	v1.TypeMetaApplyConfiguration    `json:",inline"`
 	*v1.ObjectMetaApplyConfiguration `json:"metadata,omitempty"`
 	Items                            []PendingWorkloadApplyConfiguration `json:"items,omitempty"`
+	UserUsage                        []UserUsageApplyConfiguration       `json:"userUsage,omitempty"`
 }
 
 // PendingWorkloadsSummaryApplyConfiguration constructs a declarative configuration of the PendingWorkloadsSummary type for use with
@@ -211,6 +212,19 @@ func (b *PendingWorkloadsSummaryApplyConfiguration) WithItems(values ...*Pending
 	return b
 }
 
+// WithUserUsage adds the given value to the UserUsage field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the UserUsage field.
+func (b *PendingWorkloadsSummaryApplyConfiguration) WithUserUsage(values ...*UserUsageApplyConfiguration) *PendingWorkloadsSummaryApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithUserUsage")
+		}
+		b.UserUsage = append(b.UserUsage, *values[i])
+	}
+	return b
+}
+
 // GetName retrieves the value of the Name field in the declarative configuration.
 func (b *PendingWorkloadsSummaryApplyConfiguration) GetName() *string {
 	b.ensureObjectMetaApplyConfigurationExists()
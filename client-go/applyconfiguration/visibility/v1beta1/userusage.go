@@ -0,0 +1,60 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// UserUsageApplyConfiguration represents a declarative configuration of the UserUsage type for use
+// with apply.
+type UserUsageApplyConfiguration struct {
+	User               *string          `json:"user,omitempty"`
+	ReservingWorkloads *int32           `json:"reservingWorkloads,omitempty"`
+	Resources          *v1.ResourceList `json:"resources,omitempty"`
+}
+
+// UserUsageApplyConfiguration constructs a declarative configuration of the UserUsage type for use with
+// apply.
+func UserUsage() *UserUsageApplyConfiguration {
+	return &UserUsageApplyConfiguration{}
+}
+
+// WithUser sets the User field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the User field is set to the value of the last call.
+func (b *UserUsageApplyConfiguration) WithUser(value string) *UserUsageApplyConfiguration {
+	b.User = &value
+	return b
+}
+
+// WithReservingWorkloads sets the ReservingWorkloads field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReservingWorkloads field is set to the value of the last call.
+func (b *UserUsageApplyConfiguration) WithReservingWorkloads(value int32) *UserUsageApplyConfiguration {
+	b.ReservingWorkloads = &value
+	return b
+}
+
+// WithResources sets the Resources field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Resources field is set to the value of the last call.
+func (b *UserUsageApplyConfiguration) WithResources(value v1.ResourceList) *UserUsageApplyConfiguration {
+	b.Resources = &value
+	return b
+}
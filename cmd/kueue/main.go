@@ -41,7 +41,9 @@ import (
 	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlcache "sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -178,6 +180,16 @@ func main() {
 	}
 	options.Metrics = metricsServerOptions
 
+	// The ConfigMaps are only read in the namespace of Kueue, where the
+	// manager is granted access to them by a namespaced Role.
+	options.Cache.ByObject = map[client.Object]ctrlcache.ByObject{
+		&corev1.ConfigMap{}: {
+			Namespaces: map[string]ctrlcache.Config{
+				ptr.Deref(cfg.Namespace, configapi.DefaultNamespace): {},
+			},
+		},
+	}
+
 	metrics.Register()
 
 	kubeConfig := ctrl.GetConfigOrDie()
@@ -240,7 +252,7 @@ func main() {
 	go cCache.CleanUpOnContext(ctx)
 
 	if features.Enabled(features.VisibilityOnDemand) {
-		go visibility.CreateAndStartVisibilityServer(ctx, queues)
+		go visibility.CreateAndStartVisibilityServer(ctx, queues, cCache)
 	}
 
 	setupScheduler(mgr, cCache, queues, &cfg)
@@ -322,12 +334,25 @@ func setupControllers(ctx context.Context, mgr ctrl.Manager, cCache *cache.Cache
 			setupLog.Error(err, "Could not get the enabled multikueue adapters")
 			os.Exit(1)
 		}
-		if err := multikueue.SetupControllers(mgr, *cfg.Namespace,
+		if err := multikueue.AddExternalFrameworkAdapters(adapters, cfg.MultiKueue.ExternalFrameworks); err != nil {
+			setupLog.Error(err, "Could not get the multikueue adapters of the external frameworks")
+			os.Exit(1)
+		}
+		opts := []multikueue.SetupOption{
 			multikueue.WithGCInterval(cfg.MultiKueue.GCInterval.Duration),
 			multikueue.WithOrigin(ptr.Deref(cfg.MultiKueue.Origin, configapi.DefaultMultiKueueOrigin)),
 			multikueue.WithWorkerLostTimeout(cfg.MultiKueue.WorkerLostTimeout.Duration),
+			multikueue.WithWorkerLostPolicy(ptr.Deref(cfg.MultiKueue.WorkerLostPolicy, configapi.MultiKueueWorkerLostPolicyRequeue)),
+			multikueue.WithStatusMirroring(cfg.MultiKueue.StatusMirroring),
+			multikueue.WithHealthCheck(cfg.MultiKueue.HealthCheck),
 			multikueue.WithAdapters(adapters),
-		); err != nil {
+			multikueue.WithDispatcher(multikueue.NewDispatcher(cfg.MultiKueue.Dispatcher)),
+			multikueue.WithClusterProfileCredentialsProvider(multikueue.NewExecCredentialsProvider(cfg.MultiKueue.ClusterProfile)),
+		}
+		if features.Enabled(features.MultiKueueFederatedQuota) {
+			opts = append(opts, multikueue.WithFederatedQuota(cCache, queues))
+		}
+		if err := multikueue.SetupControllers(mgr, *cfg.Namespace, opts...); err != nil {
 			setupLog.Error(err, "Could not setup MultiKueue controller")
 			os.Exit(1)
 		}
@@ -340,7 +365,7 @@ func setupControllers(ctx context.Context, mgr ctrl.Manager, cCache *cache.Cache
 		}
 	}
 
-	if failedWebhook, err := webhooks.Setup(mgr); err != nil {
+	if failedWebhook, err := webhooks.Setup(mgr, webhooks.WithKueueNamespace(*cfg.Namespace)); err != nil {
 		setupLog.Error(err, "Unable to create webhook", "webhook", failedWebhook)
 		os.Exit(1)
 	}
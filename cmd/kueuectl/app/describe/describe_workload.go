@@ -0,0 +1,372 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package describe
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/kubectl/pkg/util/templates"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	clientset "sigs.k8s.io/kueue/client-go/clientset/versioned"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/completion"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
+	"sigs.k8s.io/kueue/pkg/controller/jobframework"
+	"sigs.k8s.io/kueue/pkg/workload"
+
+	// Ensure linking of the job controllers.
+	_ "sigs.k8s.io/kueue/pkg/controller/jobs"
+)
+
+var (
+	wlLong = templates.LongDesc(`
+		Pass-through "describe workload" to kubectl.
+
+		With --multikueue, shows instead the status of a Workload dispatched
+		by MultiKueue: the worker cluster selected to run the Workload, with
+		the admission of the Workload and the status of the pods in the
+		worker cluster, fetched using the kubeconfig stored by the MultiKueue
+		manager. When the worker cluster can't be reached, the status of the
+		pods mirrored by the manager is shown, if any.
+	`)
+	wlExample = templates.Examples(`
+		# Describe the Workload
+  		kueuectl describe workload my-workload
+
+  		# Describe the status of the Workload in the MultiKueue worker cluster
+  		kueuectl describe workload my-workload --multikueue
+	`)
+)
+
+type WorkloadOptions struct {
+	Name           string
+	Namespace      string
+	MultiKueue     bool
+	KueueNamespace string
+
+	ClientSet        clientset.Interface
+	DynamicClient    dynamic.Interface
+	RestMapper       meta.RESTMapper
+	WorkerClientSets util.WorkerClientSetsFunc
+
+	genericiooptions.IOStreams
+}
+
+func NewWorkloadOptions(streams genericiooptions.IOStreams) *WorkloadOptions {
+	return &WorkloadOptions{
+		IOStreams: streams,
+	}
+}
+
+// NewWorkloadCmd returns the "describe workload" command, running passThrough
+// unless the MultiKueue status of the Workload is requested.
+func NewWorkloadCmd(clientGetter util.ClientGetter, streams genericiooptions.IOStreams, passThrough func(*cobra.Command, []string) error) *cobra.Command {
+	o := NewWorkloadOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:                   "workload NAME [--multikueue] [--kueue-namespace NAMESPACE]",
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"wl"},
+		Short:                 "Pass-through \"describe workload\" to kubectl, or show the MultiKueue status of the given Workload",
+		Long:                  wlLong,
+		Example:               wlExample,
+		FParseErrWhitelist:    cobra.FParseErrWhitelist{UnknownFlags: true},
+		ValidArgsFunction:     completion.WorkloadNameFunc(clientGetter, nil),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !o.MultiKueue {
+				if cmd.Flags().Changed("kueue-namespace") {
+					return errors.New("--kueue-namespace can only be used with --multikueue")
+				}
+				return passThrough(cmd, args)
+			}
+
+			if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+
+			err := o.Complete(clientGetter, args)
+			if err != nil {
+				return err
+			}
+
+			return o.Run(cmd.Context())
+		},
+	}
+
+	cmd.Flags().BoolVar(&o.MultiKueue, "multikueue", false,
+		"Show the status of the Workload in the MultiKueue worker cluster, instead of passing through to kubectl.")
+	util.AddKueueNamespaceFlagVar(cmd, &o.KueueNamespace)
+
+	return cmd
+}
+
+// Complete completes all the required options
+func (o *WorkloadOptions) Complete(clientGetter util.ClientGetter, args []string) error {
+	o.Name = args[0]
+
+	var err error
+
+	o.Namespace, _, err = clientGetter.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+
+	o.ClientSet, err = clientGetter.KueueClientSet()
+	if err != nil {
+		return err
+	}
+
+	o.DynamicClient, err = clientGetter.DynamicClient()
+	if err != nil {
+		return err
+	}
+
+	o.RestMapper, err = clientGetter.ToRESTMapper()
+	if err != nil {
+		return err
+	}
+
+	o.WorkerClientSets, err = clientGetter.WorkerClientSets(o.KueueNamespace)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Run describes the status of the Workload in the MultiKueue worker cluster
+func (o *WorkloadOptions) Run(ctx context.Context) error {
+	wl, err := o.ClientSet.KueueV1beta1().Workloads(o.Namespace).Get(ctx, o.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	w := printers.GetNewTabWriter(o.Out)
+
+	write(w, 0, "Name:\t%s\n", wl.Name)
+	write(w, 0, "Namespace:\t%s\n", wl.Namespace)
+	if workerCluster := workload.MultiKueueWorkerCluster(wl); len(workerCluster) > 0 {
+		o.describeMultiKueue(ctx, w, wl, workerCluster)
+	} else {
+		write(w, 0, "MultiKueue:\t<none>\n")
+	}
+
+	return w.Flush()
+}
+
+func (o *WorkloadOptions) describeMultiKueue(ctx context.Context, w io.Writer, wl *v1beta1.Workload, workerCluster string) {
+	write(w, 0, "MultiKueue:\n")
+	write(w, 1, "Worker Cluster:\t%s\n", workerCluster)
+
+	var remotePods []corev1.Pod
+	clientSets, err := o.WorkerClientSets(ctx, workerCluster)
+	if err == nil {
+		var remoteWl *v1beta1.Workload
+		remoteWl, err = clientSets.KueueClientSet.KueueV1beta1().Workloads(wl.Namespace).Get(ctx, wl.Name, metav1.GetOptions{})
+		if err == nil {
+			write(w, 1, "Remote Workload:\n")
+			write(w, 2, "ClusterQueue:\t%s\n", clusterQueue(remoteWl))
+			write(w, 2, "Status:\t%s\n", strings.ToUpper(workload.Status(remoteWl)))
+			describeAdmission(w, 2, remoteWl.Status.Admission)
+			describeConditions(w, 2, remoteWl.Status.Conditions)
+			remotePods, err = o.remotePods(ctx, clientSets, wl)
+		} else if client.IgnoreNotFound(err) == nil {
+			write(w, 1, "Remote Workload:\t<none>\n")
+			err = nil
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(o.ErrOut, "Unable to get the status of the workload in the worker cluster %q: %v\n", workerCluster, err)
+	}
+
+	switch {
+	case remotePods != nil:
+		describeRemotePods(w, remotePods)
+	case wl.Status.RemotePods != nil:
+		describeMirroredRemotePods(w, wl.Status.RemotePods)
+	}
+}
+
+// remotePods returns the pods of the job of the workload in the worker cluster,
+// selected by the pod label selector of the local job. Returns nil if the job
+// doesn't provide a pod label selector.
+func (o *WorkloadOptions) remotePods(ctx context.Context, clientSets *util.WorkerClientSets, wl *v1beta1.Workload) ([]corev1.Pod, error) {
+	selector, err := o.podLabelSelector(ctx, wl)
+	if err != nil || len(selector) == 0 {
+		return nil, err
+	}
+
+	list, err := clientSets.K8sClientSet.CoreV1().Pods(wl.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+
+	pods := make([]corev1.Pod, 0, len(list.Items))
+	pods = append(pods, list.Items...)
+	slices.SortFunc(pods, func(a, b corev1.Pod) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return pods, nil
+}
+
+func (o *WorkloadOptions) podLabelSelector(ctx context.Context, wl *v1beta1.Workload) (string, error) {
+	ref := metav1.GetControllerOf(wl)
+	if ref == nil {
+		return "", nil
+	}
+
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return "", err
+	}
+	gvk := gv.WithKind(ref.Kind)
+
+	cbs, ok := jobframework.GetIntegrationByGVK(gvk)
+	if !ok || cbs.NewJob == nil {
+		return "", nil
+	}
+	genericJob := cbs.NewJob()
+
+	jobWithPodLabelSelector, ok := genericJob.(jobframework.JobWithPodLabelSelector)
+	if !ok {
+		return "", nil
+	}
+
+	mapping, err := o.RestMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return "", err
+	}
+
+	job, err := o.DynamicClient.Resource(mapping.Resource).Namespace(wl.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(job.UnstructuredContent(), genericJob.Object()); err != nil {
+		return "", fmt.Errorf("failed to convert unstructured object: %w", err)
+	}
+
+	return jobWithPodLabelSelector.PodLabelSelector(), nil
+}
+
+func describeAdmission(w io.Writer, level int, admission *v1beta1.Admission) {
+	if admission == nil {
+		return
+	}
+	write(w, level, "Admission:\n")
+	write(w, level+1, "PodSet\tCount\tFlavors\n")
+	write(w, level+1, "------\t-----\t-------\n")
+	for _, psa := range admission.PodSetAssignments {
+		flavors := make([]string, 0, len(psa.Flavors))
+		for resourceName, flavor := range psa.Flavors {
+			flavors = append(flavors, fmt.Sprintf("%s=%s", resourceName, flavor))
+		}
+		slices.Sort(flavors)
+		write(w, level+1, "%s\t%d\t%s\n", psa.Name, ptr.Deref(psa.Count, 0), strings.Join(flavors, ", "))
+	}
+}
+
+func describeConditions(w io.Writer, level int, conditions []metav1.Condition) {
+	if len(conditions) == 0 {
+		write(w, level, "Conditions:\t<none>\n")
+		return
+	}
+	write(w, level, "Conditions:\n")
+	write(w, level+1, "Type\tStatus\tReason\tMessage\n")
+	write(w, level+1, "----\t------\t------\t-------\n")
+	for _, cond := range conditions {
+		write(w, level+1, "%s\t%s\t%s\t%s\n", cond.Type, cond.Status, cond.Reason, cond.Message)
+	}
+}
+
+func describeRemotePods(w io.Writer, pods []corev1.Pod) {
+	if len(pods) == 0 {
+		write(w, 1, "Remote Pods:\t<none>\n")
+		return
+	}
+	write(w, 1, "Remote Pods:\n")
+	write(w, 2, "Name\tStatus\tRestarts\tReason\n")
+	write(w, 2, "----\t------\t--------\t------\n")
+	for _, pod := range pods {
+		restarts, reason := podRestartsAndReason(&pod)
+		write(w, 2, "%s\t%s\t%d\t%s\n", pod.Name, pod.Status.Phase, restarts, reason)
+	}
+}
+
+func describeMirroredRemotePods(w io.Writer, status *v1beta1.RemotePodsStatus) {
+	write(w, 1, "Remote Pods (mirrored %s):\n", status.LastUpdateTime.Time.Format(time.RFC1123Z))
+	write(w, 2, "Running:\t%d\n", status.Running)
+	write(w, 2, "Pending:\t%d\n", status.Pending)
+	write(w, 2, "Succeeded:\t%d\n", status.Succeeded)
+	write(w, 2, "Failed:\t%d\n", status.Failed)
+	if len(status.Pods) == 0 {
+		return
+	}
+	write(w, 2, "Name\tStatus\tRestarts\tReason\n")
+	write(w, 2, "----\t------\t--------\t------\n")
+	for _, pod := range status.Pods {
+		write(w, 2, "%s\t%s\t%d\t%s\n", pod.Name, pod.Phase, pod.Restarts, pod.Reason)
+	}
+}
+
+func podRestartsAndReason(pod *corev1.Pod) (int32, string) {
+	var restarts int32
+	reason := pod.Status.Reason
+	for _, cs := range slices.Concat(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses) {
+		restarts += cs.RestartCount
+		if len(reason) > 0 {
+			continue
+		}
+		if cs.State.Waiting != nil {
+			reason = cs.State.Waiting.Reason
+		} else if cs.State.Terminated != nil && cs.State.Terminated.ExitCode != 0 {
+			reason = cs.State.Terminated.Reason
+		}
+	}
+	return restarts, reason
+}
+
+func clusterQueue(wl *v1beta1.Workload) string {
+	if wl.Status.Admission == nil {
+		return ""
+	}
+	return string(wl.Status.Admission.ClusterQueue)
+}
+
+func write(w io.Writer, level int, format string, a ...any) {
+	fmt.Fprintf(w, strings.Repeat("  ", level)+format, a...)
+}
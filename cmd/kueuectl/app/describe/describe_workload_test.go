@@ -0,0 +1,248 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package describe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/cobra"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	k8sscheme "k8s.io/client-go/kubernetes/scheme"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/fake"
+	cmdtesting "sigs.k8s.io/kueue/cmd/kueuectl/app/testing"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	testingpod "sigs.k8s.io/kueue/pkg/util/testingjobs/pod"
+)
+
+func TestWorkloadCmd(t *testing.T) {
+	jobGVK := batchv1.SchemeGroupVersion.WithKind("Job")
+	created := time.Date(2024, time.May, 1, 10, 0, 0, 0, time.UTC)
+
+	baseWorkload := utiltesting.MakeWorkload("wl1", metav1.NamespaceDefault).
+		ControllerReference(jobGVK, "j1", "test-uid").
+		Queue("lq1").
+		Creation(created)
+	admission := utiltesting.MakeAdmission("cq1").
+		Assignment(corev1.ResourceCPU, "default", "1").
+		AssignmentPodCount(2).
+		Obj()
+	multiKueueCheck := kueue.AdmissionCheckState{
+		Name:    "ac1",
+		State:   kueue.CheckStateReady,
+		Message: `The workload got reservation on "worker1"`,
+	}
+
+	testCases := map[string]struct {
+		args                []string
+		objs                []runtime.Object
+		jobs                []runtime.Object
+		workerObjs          []runtime.Object
+		workerPods          []runtime.Object
+		wantPassThroughArgs []string
+		wantOut             string
+		wantOutErr          string
+		wantErr             string
+	}{
+		"should pass through to kubectl": {
+			args:                []string{"wl1", "--show-events=false"},
+			wantPassThroughArgs: []string{"wl1"},
+		},
+		"kueue namespace without multikueue": {
+			args:    []string{"wl1", "--kueue-namespace", "kueue"},
+			wantErr: "--kueue-namespace can only be used with --multikueue",
+		},
+		"no arguments": {
+			args:    []string{"--multikueue"},
+			wantErr: "accepts 1 arg(s), received 0",
+		},
+		"workload not found": {
+			args:    []string{"wl1", "--multikueue"},
+			wantErr: `workloads.kueue.x-k8s.io "wl1" not found`,
+		},
+		"should describe a workload not dispatched by MultiKueue": {
+			args: []string{"wl1", "--multikueue"},
+			objs: []runtime.Object{
+				baseWorkload.Clone().
+					ReserveQuotaAt(admission, created).
+					AdmissionCheck(kueue.AdmissionCheckState{
+						Name:    "ac1",
+						State:   kueue.CheckStateReady,
+						Message: `The workload got reservation on "worker1"`,
+					}).
+					Obj(),
+			},
+			wantOut: `Name:         wl1
+Namespace:    default
+MultiKueue:   <none>
+`,
+		},
+		"should describe a workload running in a worker cluster": {
+			args: []string{"wl1", "--multikueue"},
+			objs: []runtime.Object{
+				baseWorkload.Clone().
+					ReserveQuotaAt(admission, created).
+					Annotation(kueue.MultiKueueReservingClusterAnnotation, "worker1").
+					AdmissionCheck(multiKueueCheck).
+					Obj(),
+			},
+			jobs: []runtime.Object{
+				&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "j1", Namespace: metav1.NamespaceDefault}},
+			},
+			workerObjs: []runtime.Object{
+				baseWorkload.Clone().
+					ReserveQuotaAt(admission, created).
+					AdmittedAt(true, created).
+					Obj(),
+			},
+			workerPods: []runtime.Object{
+				testingpod.MakePod("j1-b", metav1.NamespaceDefault).
+					Label(batchv1.JobNameLabel, "j1").
+					StatusPhase(corev1.PodRunning).
+					Obj(),
+				testingpod.MakePod("j1-a", metav1.NamespaceDefault).
+					Label(batchv1.JobNameLabel, "j1").
+					StatusPhase(corev1.PodFailed).
+					Obj(),
+				testingpod.MakePod("other", metav1.NamespaceDefault).
+					StatusPhase(corev1.PodRunning).
+					Obj(),
+			},
+			wantOut: `Name:        wl1
+Namespace:   default
+MultiKueue:
+  Worker Cluster:   worker1
+  Remote Workload:
+    ClusterQueue:   cq1
+    Status:         ADMITTED
+    Admission:
+      PodSet        Count   Flavors
+      ------        -----   -------
+      main          2       cpu=default
+    Conditions:
+      Type            Status   Reason           Message
+      ----            ------   ------           -------
+      QuotaReserved   True     AdmittedByTest   Admitted by ClusterQueue cq1
+      Admitted        True     ByTest           Admitted by ClusterQueue cq1
+  Remote Pods:
+    Name              Status    Restarts         Reason
+    ----              ------    --------         ------
+    j1-a              Failed    0                
+    j1-b              Running   0                
+`,
+		},
+		"should describe the mirrored pods when the worker cluster can't be reached": {
+			args: []string{"wl1", "--multikueue"},
+			objs: []runtime.Object{
+				baseWorkload.Clone().
+					ReserveQuotaAt(admission, created).
+					Annotation(kueue.MultiKueueReservingClusterAnnotation, "worker1").
+					AdmissionCheck(multiKueueCheck).
+					RemotePods(&kueue.RemotePodsStatus{
+						ClusterName:    "worker1",
+						LastUpdateTime: metav1.NewTime(created),
+						Running:        1,
+						Failed:         1,
+						Pods: []kueue.RemotePodStatus{
+							{Name: "j1-a", Phase: corev1.PodFailed, Reason: "Error", Restarts: 2},
+							{Name: "j1-b", Phase: corev1.PodRunning},
+						},
+					}).
+					Obj(),
+			},
+			wantOut: `Name:        wl1
+Namespace:   default
+MultiKueue:
+  Worker Cluster:   worker1
+  Remote Pods (mirrored Wed, 01 May 2024 10:00:00 +0000):
+    Running:        1
+    Pending:        0
+    Succeeded:      0
+    Failed:         1
+    Name            Status    Restarts   Reason
+    ----            ------    --------   ------
+    j1-a            Failed    2          Error
+    j1-b            Running   0          
+`,
+			wantOutErr: "Unable to get the status of the workload in the worker cluster \"worker1\": no clients for the worker cluster \"worker1\"\n",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, outErr := genericiooptions.NewTestIOStreams()
+
+			restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{})
+			restMapper.Add(jobGVK, meta.RESTScopeNamespace)
+
+			tcg := cmdtesting.NewTestClientGetter().
+				WithKueueClientset(fake.NewSimpleClientset(tc.objs...)).
+				WithDynamicClient(dynamicfake.NewSimpleDynamicClient(k8sscheme.Scheme, tc.jobs...)).
+				WithRESTMapper(restMapper)
+			if tc.workerObjs != nil {
+				tcg.WithWorkerClientSets("worker1", fake.NewSimpleClientset(tc.workerObjs...), k8sfake.NewSimpleClientset(tc.workerPods...))
+			}
+
+			var gotPassThroughArgs []string
+			passThrough := func(_ *cobra.Command, args []string) error {
+				gotPassThroughArgs = args
+				return nil
+			}
+
+			cmd := NewWorkloadCmd(tcg, streams, passThrough)
+			cmd.SetOut(out)
+			cmd.SetErr(outErr)
+			cmd.SetArgs(tc.args)
+
+			gotErr := cmd.Execute()
+
+			var gotErrStr string
+			if gotErr != nil {
+				gotErrStr = gotErr.Error()
+			}
+			if diff := cmp.Diff(tc.wantErr, gotErrStr); diff != "" {
+				t.Errorf("Unexpected error (-want/+got)\n%s", diff)
+			}
+
+			if gotErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.wantPassThroughArgs, gotPassThroughArgs); diff != "" {
+				t.Errorf("Unexpected pass-through arguments (-want/+got)\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.wantOut, out.String()); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.wantOutErr, outErr.String()); diff != "" {
+				t.Errorf("Unexpected error output (-want/+got)\n%s", diff)
+			}
+		})
+	}
+}
@@ -45,6 +45,7 @@ func NewListCmd(clientGetter util.ClientGetter, streams genericiooptions.IOStrea
 	cmd.AddCommand(NewWorkloadCmd(clientGetter, streams, clock))
 	cmd.AddCommand(NewResourceFlavorCmd(clientGetter, streams, clock))
 	cmd.AddCommand(NewPodCmd(clientGetter, streams))
+	cmd.AddCommand(NewMultiKueueClusterCmd(clientGetter, streams, clock))
 
 	return cmd
 }
@@ -0,0 +1,181 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package list
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/kubectl/pkg/util/templates"
+	"k8s.io/utils/clock"
+
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/scheme"
+	kueuev1beta1 "sigs.k8s.io/kueue/client-go/clientset/versioned/typed/kueue/v1beta1"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
+)
+
+var (
+	mkcLong = templates.LongDesc(`
+		Lists MultiKueueClusters, with their connectivity and health, and
+		a summary of the capacity of the worker clusters.
+
+		The capacity is the quota and the reservation of the resources,
+		summed over the ClusterQueues of the worker cluster. It is only
+		reported when the Scoring or the CapacityAware MultiKueue dispatcher
+		is configured.
+	`)
+	mkcExample = templates.Examples(`
+		# List MultiKueueCluster
+		kueuectl list multikueuecluster
+	`)
+)
+
+type MultiKueueClusterOptions struct {
+	Clock      clock.Clock
+	PrintFlags *genericclioptions.PrintFlags
+
+	Limit         int64
+	FieldSelector string
+	LabelSelector string
+
+	Client kueuev1beta1.KueueV1beta1Interface
+
+	genericiooptions.IOStreams
+}
+
+func NewMultiKueueClusterOptions(streams genericiooptions.IOStreams, clock clock.Clock) *MultiKueueClusterOptions {
+	return &MultiKueueClusterOptions{
+		PrintFlags: genericclioptions.NewPrintFlags("").WithTypeSetter(scheme.Scheme),
+		IOStreams:  streams,
+		Clock:      clock,
+	}
+}
+
+func NewMultiKueueClusterCmd(clientGetter util.ClientGetter, streams genericiooptions.IOStreams, clock clock.Clock) *cobra.Command {
+	o := NewMultiKueueClusterOptions(streams, clock)
+
+	cmd := &cobra.Command{
+		Use:                   "multikueuecluster [--selector KEY=VALUE] [--field-selector FIELD_NAME=VALUE]",
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"mkc"},
+		Short:                 "List MultiKueueCluster",
+		Long:                  mkcLong,
+		Example:               mkcExample,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cmd.SilenceUsage = true
+			err := o.Complete(clientGetter)
+			if err != nil {
+				return err
+			}
+			return o.Run(cmd.Context())
+		},
+	}
+
+	o.PrintFlags.AddFlags(cmd)
+
+	addFieldSelectorFlagVar(cmd, &o.FieldSelector)
+	addLabelSelectorFlagVar(cmd, &o.LabelSelector)
+
+	return cmd
+}
+
+// Complete completes all the required options
+func (o *MultiKueueClusterOptions) Complete(clientGetter util.ClientGetter) error {
+	var err error
+
+	o.Limit, err = listRequestLimit()
+	if err != nil {
+		return err
+	}
+
+	clientset, err := clientGetter.KueueClientSet()
+	if err != nil {
+		return err
+	}
+
+	o.Client = clientset.KueueV1beta1()
+
+	return nil
+}
+
+func (o *MultiKueueClusterOptions) ToPrinter(headers bool) (printers.ResourcePrinterFunc, error) {
+	if !o.PrintFlags.OutputFlagSpecified() {
+		printer := newMultiKueueClusterTablePrinter().WithHeaders(headers).WithClock(o.Clock)
+		return printer.PrintObj, nil
+	}
+
+	printer, err := o.PrintFlags.ToPrinter()
+	if err != nil {
+		return nil, err
+	}
+
+	return printer.PrintObj, nil
+}
+
+// Run performs the list operation.
+func (o *MultiKueueClusterOptions) Run(ctx context.Context) error {
+	var totalCount int
+
+	opts := metav1.ListOptions{
+		LabelSelector: o.LabelSelector,
+		FieldSelector: o.FieldSelector,
+		Limit:         o.Limit,
+	}
+
+	tabWriter := printers.GetNewTabWriter(o.Out)
+
+	for {
+		headers := totalCount == 0
+
+		list, err := o.Client.MultiKueueClusters().List(ctx, opts)
+		if err != nil {
+			return err
+		}
+
+		totalCount += len(list.Items)
+
+		printer, err := o.ToPrinter(headers)
+		if err != nil {
+			return err
+		}
+
+		if err := printer.PrintObj(list, tabWriter); err != nil {
+			return err
+		}
+
+		if list.Continue != "" {
+			opts.Continue = list.Continue
+			continue
+		}
+
+		if totalCount == 0 {
+			fmt.Fprintln(o.ErrOut, "No resources found")
+			return nil
+		}
+
+		if err := tabWriter.Flush(); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
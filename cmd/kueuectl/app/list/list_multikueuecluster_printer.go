@@ -0,0 +1,153 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package list
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/utils/clock"
+
+	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+type listMultiKueueClusterPrinter struct {
+	clock        clock.Clock
+	printOptions printers.PrintOptions
+}
+
+var _ printers.ResourcePrinter = (*listMultiKueueClusterPrinter)(nil)
+
+func (p *listMultiKueueClusterPrinter) PrintObj(obj runtime.Object, out io.Writer) error {
+	printer := printers.NewTablePrinter(p.printOptions)
+
+	list, ok := obj.(*v1beta1.MultiKueueClusterList)
+	if !ok {
+		return errors.New("invalid object type")
+	}
+
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Name", Type: "string", Format: "name"},
+			{Name: "Connected", Type: "string"},
+			{Name: "Healthy", Type: "string"},
+			{Name: "ClusterQueues", Type: "string"},
+			{Name: "Pending Workloads", Type: "string"},
+			{Name: "Reserved/Quota", Type: "string"},
+			{Name: "Age", Type: "string"},
+		},
+		Rows: p.printMultiKueueClusterList(list),
+	}
+
+	return printer.PrintObj(table, out)
+}
+
+func (p *listMultiKueueClusterPrinter) WithHeaders(f bool) *listMultiKueueClusterPrinter {
+	p.printOptions.NoHeaders = !f
+	return p
+}
+
+func (p *listMultiKueueClusterPrinter) WithClock(c clock.Clock) *listMultiKueueClusterPrinter {
+	p.clock = c
+	return p
+}
+
+func newMultiKueueClusterTablePrinter() *listMultiKueueClusterPrinter {
+	return &listMultiKueueClusterPrinter{
+		clock: clock.RealClock{},
+	}
+}
+
+func (p *listMultiKueueClusterPrinter) printMultiKueueClusterList(list *v1beta1.MultiKueueClusterList) []metav1.TableRow {
+	rows := make([]metav1.TableRow, len(list.Items))
+	for index := range list.Items {
+		rows[index] = p.printMultiKueueCluster(&list.Items[index])
+	}
+	return rows
+}
+
+func (p *listMultiKueueClusterPrinter) printMultiKueueCluster(cluster *v1beta1.MultiKueueCluster) metav1.TableRow {
+	row := metav1.TableRow{Object: runtime.RawExtension{Object: cluster}}
+
+	var clusterQueues, pendingWorkloads, capacity string
+	if cluster.Status.Capacity != nil {
+		clusterQueues = fmt.Sprintf("%d", len(cluster.Status.Capacity.ClusterQueues))
+		var pending int32
+		for _, cq := range cluster.Status.Capacity.ClusterQueues {
+			pending += cq.PendingWorkloads
+		}
+		pendingWorkloads = fmt.Sprintf("%d", pending)
+		capacity = capacitySummary(cluster.Status.Capacity)
+	}
+
+	row.Cells = []any{
+		cluster.Name,
+		conditionStatus(cluster.Status.Conditions, v1beta1.MultiKueueClusterActive),
+		conditionStatus(cluster.Status.Conditions, v1beta1.MultiKueueClusterHealthy),
+		clusterQueues,
+		pendingWorkloads,
+		capacity,
+		duration.HumanDuration(p.clock.Since(cluster.CreationTimestamp.Time)),
+	}
+	return row
+}
+
+func conditionStatus(conditions []metav1.Condition, conditionType string) string {
+	if cond := apimeta.FindStatusCondition(conditions, conditionType); cond != nil {
+		return string(cond.Status)
+	}
+	return ""
+}
+
+// capacitySummary returns the reservation and the quota of the resources,
+// summed over the ClusterQueues of the worker cluster.
+func capacitySummary(capacity *v1beta1.MultiKueueClusterCapacity) string {
+	reserved := make(map[corev1.ResourceName]*resource.Quantity)
+	nominal := make(map[corev1.ResourceName]*resource.Quantity)
+	for _, cq := range capacity.ClusterQueues {
+		for _, r := range cq.Resources {
+			if _, ok := nominal[r.Name]; !ok {
+				reserved[r.Name] = &resource.Quantity{}
+				nominal[r.Name] = &resource.Quantity{}
+			}
+			reserved[r.Name].Add(r.Reserved)
+			nominal[r.Name].Add(r.NominalQuota)
+		}
+	}
+
+	names := make([]corev1.ResourceName, 0, len(nominal))
+	for name := range nominal {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	resources := make([]string, 0, len(names))
+	for _, name := range names {
+		resources = append(resources, fmt.Sprintf("%s: %s/%s", name, reserved[name], nominal[name]))
+	}
+	return strings.Join(resources, ", ")
+}
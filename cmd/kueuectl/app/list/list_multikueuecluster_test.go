@@ -0,0 +1,138 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package list
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	testingclock "k8s.io/utils/clock/testing"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/fake"
+	cmdtesting "sigs.k8s.io/kueue/cmd/kueuectl/app/testing"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestMultiKueueClusterCmd(t *testing.T) {
+	testStartTime := time.Now()
+
+	testCases := map[string]struct {
+		objs       []runtime.Object
+		args       []string
+		wantOut    string
+		wantOutErr string
+		wantErr    error
+	}{
+		"should print multikueue cluster list": {
+			objs: []runtime.Object{
+				utiltesting.MakeMultiKueueCluster("worker1").
+					Creation(testStartTime.Add(-1*time.Hour).Truncate(time.Second)).
+					Active(metav1.ConditionTrue, "Active", "Connected", 1).
+					Healthy(metav1.ConditionTrue, "Healthy", "", 1).
+					Obj(),
+				utiltesting.MakeMultiKueueCluster("worker2").
+					Creation(testStartTime.Add(-2*time.Hour).Truncate(time.Second)).
+					Active(metav1.ConditionFalse, "ClientConnectionFailed", "", 1).
+					Obj(),
+			},
+			wantOut: `NAME      CONNECTED   HEALTHY   CLUSTERQUEUES   PENDING WORKLOADS   RESERVED/QUOTA   AGE
+worker1   True        True                                                           60m
+worker2   False                                                                      120m
+`,
+		},
+		"should print multikueue cluster list with capacity": {
+			objs: []runtime.Object{
+				utiltesting.MakeMultiKueueCluster("worker1").
+					Creation(testStartTime.Add(-1*time.Hour).Truncate(time.Second)).
+					Active(metav1.ConditionTrue, "Active", "Connected", 1).
+					ClusterQueueCapacity(kueue.MultiKueueClusterQueueCapacity{
+						Name:             "cq1",
+						PendingWorkloads: 2,
+						Resources: []kueue.MultiKueueResourceCapacity{
+							{Name: corev1.ResourceCPU, NominalQuota: resource.MustParse("10"), Reserved: resource.MustParse("4")},
+							{Name: corev1.ResourceMemory, NominalQuota: resource.MustParse("8Gi"), Reserved: resource.MustParse("1Gi")},
+						},
+					}).
+					ClusterQueueCapacity(kueue.MultiKueueClusterQueueCapacity{
+						Name:             "cq2",
+						PendingWorkloads: 1,
+						Resources: []kueue.MultiKueueResourceCapacity{
+							{Name: corev1.ResourceCPU, NominalQuota: resource.MustParse("6"), Reserved: resource.MustParse("6")},
+						},
+					}).
+					Obj(),
+			},
+			wantOut: `NAME      CONNECTED   HEALTHY   CLUSTERQUEUES   PENDING WORKLOADS   RESERVED/QUOTA                AGE
+worker1   True                  2               3                   cpu: 10/16, memory: 1Gi/8Gi   60m
+`,
+		},
+		"should print multikueue cluster list with label selector filter": {
+			args: []string{"--selector", "key=value1"},
+			objs: []runtime.Object{
+				utiltesting.MakeMultiKueueCluster("worker1").
+					Label("key", "value1").
+					Creation(testStartTime.Add(-1 * time.Hour).Truncate(time.Second)).
+					Obj(),
+				utiltesting.MakeMultiKueueCluster("worker2").
+					Label("key", "value2").
+					Creation(testStartTime.Add(-2 * time.Hour).Truncate(time.Second)).
+					Obj(),
+			},
+			wantOut: `NAME      CONNECTED   HEALTHY   CLUSTERQUEUES   PENDING WORKLOADS   RESERVED/QUOTA   AGE
+worker1                                                                              60m
+`,
+		},
+		"should print not found error": {
+			wantOutErr: "No resources found\n",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, outErr := genericiooptions.NewTestIOStreams()
+
+			tcg := cmdtesting.NewTestClientGetter().WithKueueClientset(fake.NewSimpleClientset(tc.objs...))
+
+			cmd := NewMultiKueueClusterCmd(tcg, streams, testingclock.NewFakeClock(testStartTime))
+			cmd.SetOut(out)
+			cmd.SetErr(outErr)
+			cmd.SetArgs(tc.args)
+
+			gotErr := cmd.Execute()
+			if diff := cmp.Diff(tc.wantErr, gotErr, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("Unexpected error (-want/+got)\n%s", diff)
+			}
+
+			gotOut := out.String()
+			if diff := cmp.Diff(tc.wantOut, gotOut); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+
+			gotOutErr := outErr.String()
+			if diff := cmp.Diff(tc.wantOutErr, gotOutErr); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+		})
+	}
+}
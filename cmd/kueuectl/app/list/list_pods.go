@@ -37,6 +37,7 @@ import (
 	"k8s.io/kubectl/pkg/util/templates"
 	"k8s.io/utils/ptr"
 
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
 
@@ -52,6 +53,9 @@ var (
 
 		The --for=pod/pod-name option allows to find pods from the same 
 		pod group as the specified pod, including that pod itself. 
+
+		The --for=workload/workload-name option lists the status of the pods
+		mirrored from the MultiKueue worker cluster running the workload.
 	`)
 	podExample = templates.Examples(`
 		# List Pods for the Job
@@ -59,6 +63,9 @@ var (
 
   		# List Pods for the Pod group
   		kueuectl list pods --for pod/pod-name
+
+  		# List the Pods running in a MultiKueue worker cluster for the Workload
+  		kueuectl list pods --for workload/workload-name
 	`)
 )
 
@@ -108,6 +115,9 @@ func NewPodCmd(clientGetter util.ClientGetter, streams genericiooptions.IOStream
 			if o.ForObject == nil {
 				return nil
 			}
+			if o.ForGVK.GroupKind() == kueue.GroupVersion.WithKind("Workload").GroupKind() {
+				return o.RunRemote()
+			}
 			if len(o.PodLabelSelector) == 0 {
 				return fmt.Errorf("unsupported kind: %s", o.ForObject.GetKind())
 			}
@@ -292,6 +302,42 @@ func (o *PodOptions) Run(clientGetter util.ClientGetter) error {
 	return nil
 }
 
+// RunRemote prints the status of the pods mirrored from the MultiKueue
+// worker cluster running the workload.
+func (o *PodOptions) RunRemote() error {
+	wl := &kueue.Workload{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(o.ForObject.UnstructuredContent(), wl); err != nil {
+		return fmt.Errorf("failed to convert unstructured object: %w", err)
+	}
+
+	remotePods := wl.Status.RemotePods
+	if remotePods == nil || len(remotePods.Pods) == 0 {
+		o.printNoResourcesFound()
+		return nil
+	}
+
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Cluster", Type: "string"},
+			{Name: "Name", Type: "string", Format: "name"},
+			{Name: "Status", Type: "string"},
+			{Name: "Restarts", Type: "integer"},
+			{Name: "Reason", Type: "string"},
+			{Name: "Message", Type: "string", Priority: 1},
+		},
+	}
+	for _, pod := range remotePods.Pods {
+		table.Rows = append(table.Rows, metav1.TableRow{
+			Cells: []any{remotePods.ClusterName, pod.Name, string(pod.Phase), int64(pod.Restarts), pod.Reason, pod.Message},
+		})
+	}
+
+	printer := printers.NewTablePrinter(printers.PrintOptions{
+		Wide: ptr.Deref(o.PrintFlags.OutputFormat, "") == "wide",
+	})
+	return printer.PrintObj(table, o.Out)
+}
+
 func (o *PodOptions) ToPrinter() (printers.ResourcePrinterFunc, error) {
 	if o.ServerPrint {
 		tablePrinter := printers.NewTablePrinter(printers.PrintOptions{
@@ -44,6 +44,7 @@ import (
 	"k8s.io/utils/strings/slices"
 	jobsetapi "sigs.k8s.io/jobset/api/jobset/v1alpha2"
 
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	kueuecmdtesting "sigs.k8s.io/kueue/cmd/kueuectl/app/testing"
 	testingpod "sigs.k8s.io/kueue/pkg/util/testingjobs/pod"
 )
@@ -573,6 +574,82 @@ valid-pod-1   1/1     Running   0          <unknown>
 			wantOut: `NAME          READY   STATUS    RESTARTS   AGE
 valid-pod-1   1/1     Running   0          <unknown>
 `,
+		}, {
+			name: "list remote pods for workload",
+			job: &kueue.Workload{
+				TypeMeta: metav1.TypeMeta{
+					Kind: "Workload",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-wl",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Status: kueue.WorkloadStatus{
+					RemotePods: &kueue.RemotePodsStatus{
+						ClusterName: "worker1",
+						Failed:      1,
+						Running:     1,
+						Pods: []kueue.RemotePodStatus{
+							{Name: "pod-1", Phase: corev1.PodFailed, Reason: "Error", Message: "exit code 1", Restarts: 2},
+							{Name: "pod-2", Phase: corev1.PodRunning},
+						},
+					},
+				},
+			},
+			mapperGVKs: []schema.GroupVersionKind{
+				kueue.GroupVersion.WithKind("Workload"),
+				corev1.SchemeGroupVersion.WithKind("Pod"),
+			},
+			args: []string{"--for", "workload/test-wl"},
+			wantOut: `CLUSTER   NAME    STATUS    RESTARTS   REASON
+worker1   pod-1   Failed    2          Error
+worker1   pod-2   Running   0          
+`,
+		}, {
+			name: "list remote pods for workload with wide output",
+			job: &kueue.Workload{
+				TypeMeta: metav1.TypeMeta{
+					Kind: "Workload",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-wl",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Status: kueue.WorkloadStatus{
+					RemotePods: &kueue.RemotePodsStatus{
+						ClusterName: "worker1",
+						Failed:      1,
+						Pods: []kueue.RemotePodStatus{
+							{Name: "pod-1", Phase: corev1.PodFailed, Reason: "Error", Message: "exit code 1", Restarts: 2},
+						},
+					},
+				},
+			},
+			mapperGVKs: []schema.GroupVersionKind{
+				kueue.GroupVersion.WithKind("Workload"),
+				corev1.SchemeGroupVersion.WithKind("Pod"),
+			},
+			args: []string{"--for", "workload/test-wl", "-o", "wide"},
+			wantOut: `CLUSTER   NAME    STATUS   RESTARTS   REASON   MESSAGE
+worker1   pod-1   Failed   2          Error    exit code 1
+`,
+		}, {
+			name: "list remote pods for workload without mirrored status",
+			job: &kueue.Workload{
+				TypeMeta: metav1.TypeMeta{
+					Kind: "Workload",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-wl",
+					Namespace: metav1.NamespaceDefault,
+				},
+			},
+			mapperGVKs: []schema.GroupVersionKind{
+				kueue.GroupVersion.WithKind("Workload"),
+				corev1.SchemeGroupVersion.WithKind("Pod"),
+			},
+			args:       []string{"--for", "workload/test-wl"},
+			wantOutErr: "No resources found in default namespace.\n",
 		},
 	}
 
@@ -646,6 +723,9 @@ func buildTestRuntimeScheme() (*runtime.Scheme, error) {
 	if err := jobsetapi.AddToScheme(scheme); err != nil {
 		return nil, err
 	}
+	if err := kueue.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
 
 	return scheme, nil
 }
@@ -33,6 +33,7 @@ import (
 	"k8s.io/cli-runtime/pkg/printers"
 	"k8s.io/kubectl/pkg/util/templates"
 	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
@@ -46,10 +47,19 @@ import (
 )
 
 var (
-	wlLong    = templates.LongDesc(`Lists Workloads that match the provided criteria.`)
+	wlLong = templates.LongDesc(`
+		Lists Workloads that match the provided criteria.
+
+		The wide output adds the MultiKueue worker cluster selected to run
+		the Workload, and the status of the Workload in the worker cluster,
+		fetched using the kubeconfig stored by the MultiKueue manager.
+	`)
 	wlExample = templates.Examples(`
 		# List Workload 
   		kueuectl list workload
+
+  		# List Workload with the MultiKueue worker cluster running them
+  		kueuectl list workload -o wide
 	`)
 )
 
@@ -78,8 +88,10 @@ type WorkloadOptions struct {
 	forObject          *unstructured.Unstructured
 
 	UserSpecifiedForObject string
+	KueueNamespace         string
 
-	ClientSet clientset.Interface
+	ClientSet        clientset.Interface
+	WorkerClientSets util.WorkerClientSetsFunc
 
 	genericiooptions.IOStreams
 }
@@ -121,6 +133,7 @@ func NewWorkloadCmd(clientGetter util.ClientGetter, streams genericiooptions.IOS
 	addClusterQueueFilterFlagVar(cmd, &o.ClusterQueueFilter)
 	addLocalQueueFilterFlagVar(cmd, &o.LocalQueueFilter)
 	addForObjectFlagVar(cmd, &o.UserSpecifiedForObject)
+	util.AddKueueNamespaceFlagVar(cmd, &o.KueueNamespace)
 
 	cobra.CheckErr(cmd.RegisterFlagCompletionFunc("clusterqueue", completion.ClusterQueueNameFunc(clientGetter, nil)))
 	cobra.CheckErr(cmd.RegisterFlagCompletionFunc("localqueue", completion.LocalQueueNameFunc(clientGetter, nil)))
@@ -182,6 +195,13 @@ func (o *WorkloadOptions) Complete(clientGetter util.ClientGetter, cmd *cobra.Co
 		return err
 	}
 
+	if o.wide() {
+		o.WorkerClientSets, err = clientGetter.WorkerClientSets(o.KueueNamespace)
+		if err != nil {
+			return err
+		}
+	}
+
 	if o.UserSpecifiedForObject != "" {
 		mapper, err := clientGetter.ToRESTMapper()
 		if err != nil {
@@ -235,12 +255,17 @@ func (o *WorkloadOptions) Complete(clientGetter util.ClientGetter, cmd *cobra.Co
 	return nil
 }
 
+func (o *WorkloadOptions) wide() bool {
+	return ptr.Deref(o.PrintFlags.OutputFormat, "") == "wide"
+}
+
 func (o *WorkloadOptions) ToPrinter(r *listWorkloadResources, headers bool) (printers.ResourcePrinterFunc, error) {
-	if !o.PrintFlags.OutputFlagSpecified() {
+	if !o.PrintFlags.OutputFlagSpecified() || o.wide() {
 		printer := newWorkloadTablePrinter().
 			WithResources(r).
 			WithNamespace(o.AllNamespaces).
 			WithHeaders(headers).
+			WithWide(o.wide()).
 			WithClock(o.Clock)
 		return printer.PrintObj, nil
 	}
@@ -280,6 +305,8 @@ func (o *WorkloadOptions) Run(ctx context.Context) error {
 	tabWriter := printers.GetNewTabWriter(o.Out)
 
 	var enableOwnerReferenceFilter bool
+	listedRemoteWorkloads := make(map[workerNamespace]map[string]*v1beta1.Workload)
+	warnedClusters := sets.New[string]()
 	for {
 		headers := totalCount == 0
 
@@ -315,6 +342,10 @@ func (o *WorkloadOptions) Run(ctx context.Context) error {
 			return err
 		}
 
+		if o.wide() {
+			r.remoteWorkloads = o.remoteWorkloads(ctx, list, listedRemoteWorkloads, warnedClusters)
+		}
+
 		printer, err := o.ToPrinter(r, headers)
 		if err != nil {
 			return err
@@ -493,6 +524,63 @@ func (o *WorkloadOptions) apiResources(list *v1beta1.WorkloadList) (map[string]*
 	return apiResourceLists, nil
 }
 
+// workerNamespace is a namespace of a MultiKueue worker cluster.
+type workerNamespace struct {
+	cluster   string
+	namespace string
+}
+
+// remoteWorkloads returns the workloads in the MultiKueue worker clusters
+// running the listed workloads. The workloads of each worker cluster are listed
+// once per namespace, and kept in listedWorkloads for the next pages. The worker
+// clusters which can't be reached are reported once, on the error output.
+func (o *WorkloadOptions) remoteWorkloads(
+	ctx context.Context,
+	list *v1beta1.WorkloadList,
+	listedWorkloads map[workerNamespace]map[string]*v1beta1.Workload,
+	warnedClusters sets.Set[string],
+) map[string]*v1beta1.Workload {
+	remoteWorkloads := make(map[string]*v1beta1.Workload)
+	for _, wl := range list.Items {
+		workerCluster := workload.MultiKueueWorkerCluster(&wl)
+		if len(workerCluster) == 0 || warnedClusters.Has(workerCluster) {
+			continue
+		}
+		key := workerNamespace{cluster: workerCluster, namespace: wl.Namespace}
+		workloads, ok := listedWorkloads[key]
+		if !ok {
+			var err error
+			workloads, err = o.listRemoteWorkloads(ctx, workerCluster, wl.Namespace)
+			if err != nil {
+				fmt.Fprintf(o.ErrOut, "Unable to get the workloads from the worker cluster %q: %v\n", workerCluster, err)
+				warnedClusters.Insert(workerCluster)
+				continue
+			}
+			listedWorkloads[key] = workloads
+		}
+		if remoteWl, ok := workloads[wl.Name]; ok {
+			remoteWorkloads[workload.Key(&wl)] = remoteWl
+		}
+	}
+	return remoteWorkloads
+}
+
+func (o *WorkloadOptions) listRemoteWorkloads(ctx context.Context, workerCluster, namespace string) (map[string]*v1beta1.Workload, error) {
+	clientSets, err := o.WorkerClientSets(ctx, workerCluster)
+	if err != nil {
+		return nil, err
+	}
+	list, err := clientSets.KueueClientSet.KueueV1beta1().Workloads(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	workloads := make(map[string]*v1beta1.Workload, len(list.Items))
+	for i := range list.Items {
+		workloads[list.Items[i].Name] = &list.Items[i]
+	}
+	return workloads, nil
+}
+
 func workloadPending(wl *v1beta1.Workload) bool {
 	return workload.Status(wl) == workload.StatusPending
 }
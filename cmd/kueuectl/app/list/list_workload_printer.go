@@ -41,6 +41,7 @@ type listWorkloadResources struct {
 	localQueues      map[string]*v1beta1.LocalQueue
 	pendingWorkloads map[string]*visibility.PendingWorkload
 	apiResourceLists map[string]*metav1.APIResourceList
+	remoteWorkloads  map[string]*v1beta1.Workload
 }
 
 func newListWorkloadResources() *listWorkloadResources {
@@ -48,6 +49,7 @@ func newListWorkloadResources() *listWorkloadResources {
 		localQueues:      make(map[string]*v1beta1.LocalQueue),
 		pendingWorkloads: make(map[string]*visibility.PendingWorkload),
 		apiResourceLists: make(map[string]*metav1.APIResourceList),
+		remoteWorkloads:  make(map[string]*v1beta1.Workload),
 	}
 }
 
@@ -78,6 +80,8 @@ func (p *listWorkloadPrinter) PrintObj(obj runtime.Object, out io.Writer) error
 			{Name: "Position in Queue", Type: "string"},
 			{Name: "Exec Time", Type: "string"},
 			{Name: "Age", Type: "string"},
+			{Name: "Worker Cluster", Type: "string", Priority: 1},
+			{Name: "Remote Status", Type: "string", Priority: 1},
 		},
 		Rows: p.printWorkloadList(list),
 	}
@@ -95,6 +99,11 @@ func (p *listWorkloadPrinter) WithHeaders(f bool) *listWorkloadPrinter {
 	return p
 }
 
+func (p *listWorkloadPrinter) WithWide(f bool) *listWorkloadPrinter {
+	p.printOptions.Wide = f
+	return p
+}
+
 func (p *listWorkloadPrinter) WithResources(r *listWorkloadResources) *listWorkloadPrinter {
 	if r == nil {
 		r = newListWorkloadResources()
@@ -153,6 +162,11 @@ func (p *listWorkloadPrinter) printWorkload(wl *v1beta1.Workload) metav1.TableRo
 		execTime = duration.HumanDuration(finishedTime.Sub(admittedCond.LastTransitionTime.Time))
 	}
 
+	var remoteStatus string
+	if remoteWl, ok := p.resources.remoteWorkloads[workload.Key(wl)]; ok {
+		remoteStatus = strings.ToUpper(workload.Status(remoteWl))
+	}
+
 	row.Cells = []any{
 		wl.Name,
 		strings.Join(p.crdTypes(wl), ", "),
@@ -163,6 +177,8 @@ func (p *listWorkloadPrinter) printWorkload(wl *v1beta1.Workload) metav1.TableRo
 		positionInQueue,
 		execTime,
 		duration.HumanDuration(p.clock.Since(wl.CreationTimestamp.Time)),
+		workload.MultiKueueWorkerCluster(wl),
+		remoteStatus,
 	}
 
 	return row
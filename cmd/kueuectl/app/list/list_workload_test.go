@@ -39,6 +39,7 @@ import (
 	"k8s.io/cli-runtime/pkg/genericiooptions"
 	"k8s.io/cli-runtime/pkg/resource"
 	fakediscovery "k8s.io/client-go/discovery/fake"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
 	restfake "k8s.io/client-go/rest/fake"
 	kubetesting "k8s.io/client-go/testing"
 	testingclock "k8s.io/utils/clock/testing"
@@ -63,6 +64,8 @@ func TestWorkloadCmd(t *testing.T) {
 		args             []string
 		mapperKinds      []schema.GroupVersionKind
 		job              []runtime.Object
+		workerObjs       map[string][]runtime.Object
+		wantWorkerLists  map[string]int
 		wantOut          string
 		wantOutErr       string
 		wantErr          error
@@ -863,6 +866,73 @@ wl1               j1         lq1          cq1            PENDING   12
 wl2               j2         lq2          cq2            PENDING   22                              120m
 `,
 		},
+		"should print workload list with worker clusters": {
+			args: []string{"-o", "wide"},
+			objs: []runtime.Object{
+				utiltesting.MakeWorkload("wl1", metav1.NamespaceDefault).
+					Queue("lq1").
+					Active(true).
+					ReserveQuotaAt(utiltesting.MakeAdmission("cq1").Obj(), testStartTime).
+					Annotation(kueue.MultiKueueReservingClusterAnnotation, "worker1").
+					AdmissionCheck(kueue.AdmissionCheckState{
+						Name:    "ac1",
+						State:   kueue.CheckStateReady,
+						Message: `The workload got reservation on "worker1"`,
+					}).
+					Creation(testStartTime.Add(-1 * time.Hour).Truncate(time.Second)).
+					Obj(),
+				utiltesting.MakeWorkload("wl2", metav1.NamespaceDefault).
+					Queue("lq1").
+					Active(true).
+					ReserveQuotaAt(utiltesting.MakeAdmission("cq1").Obj(), testStartTime).
+					Annotation(kueue.MultiKueueReservingClusterAnnotation, "worker2").
+					AdmissionCheck(kueue.AdmissionCheckState{
+						Name:    "ac1",
+						State:   kueue.CheckStatePending,
+						Message: `The workload got reservation on "worker2"`,
+					}).
+					Creation(testStartTime.Add(-2 * time.Hour).Truncate(time.Second)).
+					Obj(),
+				utiltesting.MakeWorkload("wl3", metav1.NamespaceDefault).
+					Queue("lq1").
+					Active(true).
+					Creation(testStartTime.Add(-3 * time.Hour).Truncate(time.Second)).
+					Obj(),
+				utiltesting.MakeWorkload("wl4", metav1.NamespaceDefault).
+					Queue("lq1").
+					Active(true).
+					ReserveQuotaAt(utiltesting.MakeAdmission("cq1").Obj(), testStartTime).
+					Annotation(kueue.MultiKueueReservingClusterAnnotation, "worker1").
+					AdmissionCheck(kueue.AdmissionCheckState{
+						Name:    "ac1",
+						State:   kueue.CheckStateReady,
+						Message: `The workload got reservation on "worker1"`,
+					}).
+					Creation(testStartTime.Add(-4 * time.Hour).Truncate(time.Second)).
+					Obj(),
+			},
+			workerObjs: map[string][]runtime.Object{
+				"worker1": {
+					utiltesting.MakeWorkload("wl1", metav1.NamespaceDefault).
+						Queue("lq1").
+						ReserveQuotaAt(utiltesting.MakeAdmission("cq1").Obj(), testStartTime).
+						AdmittedAt(true, testStartTime).
+						Obj(),
+					utiltesting.MakeWorkload("wl4", metav1.NamespaceDefault).
+						Queue("lq1").
+						ReserveQuotaAt(utiltesting.MakeAdmission("cq1").Obj(), testStartTime).
+						Obj(),
+				},
+			},
+			wantWorkerLists: map[string]int{"worker1": 1},
+			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS          POSITION IN QUEUE   EXEC TIME   AGE    WORKER CLUSTER   REMOTE STATUS
+wl1                          lq1          cq1            QUOTARESERVED                                   60m    worker1          ADMITTED
+wl2                          lq1          cq1            QUOTARESERVED                                   120m   worker2          
+wl3                          lq1                         PENDING                                         3h                      
+wl4                          lq1          cq1            QUOTARESERVED                                   4h     worker1          QUOTARESERVED
+`,
+			wantOutErr: "Unable to get the workloads from the worker cluster \"worker2\": no clients for the worker cluster \"worker2\"\n",
+		},
 		"should print not found error": {
 			wantOutErr: fmt.Sprintf("No resources found in %s namespace.\n", metav1.NamespaceDefault),
 		},
@@ -878,6 +948,11 @@ wl2               j2         lq2          cq2            PENDING   22
 			clientset := fake.NewSimpleClientset(tc.objs...)
 
 			tcg := cmdtesting.NewTestClientGetter().WithKueueClientset(clientset)
+			workerClientSets := make(map[string]*fake.Clientset, len(tc.workerObjs))
+			for cluster, objs := range tc.workerObjs {
+				workerClientSets[cluster] = fake.NewSimpleClientset(objs...)
+				tcg.WithWorkerClientSets(cluster, workerClientSets[cluster], k8sfake.NewSimpleClientset())
+			}
 			if len(tc.ns) > 0 {
 				tcg.WithNamespace(tc.ns)
 			}
@@ -941,6 +1016,21 @@ wl2               j2         lq2          cq2            PENDING   22
 			if diff := cmp.Diff(tc.wantOutErr, gotOutErr); diff != "" {
 				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
 			}
+
+			var gotWorkerLists map[string]int
+			for cluster, workerClientSet := range workerClientSets {
+				for _, action := range workerClientSet.Actions() {
+					if action.GetVerb() == "list" && action.GetResource().Resource == "workloads" {
+						if gotWorkerLists == nil {
+							gotWorkerLists = make(map[string]int)
+						}
+						gotWorkerLists[cluster]++
+					}
+				}
+			}
+			if diff := cmp.Diff(tc.wantWorkerLists, gotWorkerLists); diff != "" {
+				t.Errorf("Unexpected lists of the workloads in the worker clusters (-want/+got)\n%s", diff)
+			}
 		})
 	}
 }
@@ -26,6 +26,7 @@ import (
 	"k8s.io/cli-runtime/pkg/genericiooptions"
 
 	"sigs.k8s.io/kueue/cmd/kueuectl/app/delete"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/describe"
 	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
 )
 
@@ -75,9 +76,12 @@ func newCommand(
 		Short: command.short,
 	}
 	for _, ptType := range ptTypes {
-		if command.name == "delete" && ptType.name == "workload" {
+		switch {
+		case command.name == "delete" && ptType.name == "workload":
 			cmd.AddCommand(delete.NewWorkloadCmd(clientGetter, streams))
-		} else {
+		case command.name == "describe" && ptType.name == "workload":
+			cmd.AddCommand(describe.NewWorkloadCmd(clientGetter, streams, runKubectl))
+		default:
 			cmd.AddCommand(newSubcommand(command, ptType))
 		}
 	}
@@ -91,21 +95,25 @@ func newSubcommand(command passThroughCommand, ptType passThroughType) *cobra.Co
 		Aliases:            ptType.aliases,
 		Short:              fmt.Sprintf("Pass-through \"%s %s\" to kubectl", command.name, ptType.name),
 		FParseErrWhitelist: cobra.FParseErrWhitelist{UnknownFlags: true},
-		RunE: func(cmd *cobra.Command, _ []string) error {
-			cmd.SilenceUsage = true
-
-			kubectlPath, err := exec.LookPath("kubectl")
-			if err != nil {
-				return fmt.Errorf("pass-through command are not available: %w, PATH=%q", err, os.Getenv("PATH"))
-			}
+		RunE:               runKubectl,
+	}
+	return cmd
+}
 
-			// prepare the args
-			args := os.Args
-			args[0] = kubectlPath
+// runKubectl replaces the current process with kubectl, run with the
+// arguments of the current process.
+func runKubectl(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
 
-			// go in kubectl
-			return syscall.Exec(kubectlPath, args, os.Environ())
-		},
+	kubectlPath, err := exec.LookPath("kubectl")
+	if err != nil {
+		return fmt.Errorf("pass-through command are not available: %w, PATH=%q", err, os.Getenv("PATH"))
 	}
-	return cmd
+
+	// prepare the args
+	args := os.Args
+	args[0] = kubectlPath
+
+	// go in kubectl
+	return syscall.Exec(kubectlPath, args, os.Environ())
 }
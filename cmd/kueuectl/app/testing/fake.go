@@ -17,6 +17,9 @@ limitations under the License.
 package testing
 
 import (
+	"context"
+	"fmt"
+
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -41,6 +44,8 @@ type TestClientGetter struct {
 	restClient     resource.RESTClient
 	dynamicClient  dynamic.Interface
 
+	workerClientSets map[string]*util.WorkerClientSets
+
 	configFlags *genericclioptions.TestConfigFlags
 }
 
@@ -101,6 +106,27 @@ func (cg *TestClientGetter) DynamicClient() (dynamic.Interface, error) {
 	return cg.dynamicClient, nil
 }
 
+func (cg *TestClientGetter) WithWorkerClientSets(clusterName string, kueueClientset versioned.Interface, k8sClientset k8s.Interface) *TestClientGetter {
+	if cg.workerClientSets == nil {
+		cg.workerClientSets = make(map[string]*util.WorkerClientSets)
+	}
+	cg.workerClientSets[clusterName] = &util.WorkerClientSets{
+		KueueClientSet: kueueClientset,
+		K8sClientSet:   k8sClientset,
+	}
+	return cg
+}
+
+func (cg *TestClientGetter) WorkerClientSets(string) (util.WorkerClientSetsFunc, error) {
+	return func(_ context.Context, clusterName string) (*util.WorkerClientSets, error) {
+		clientSets, ok := cg.workerClientSets[clusterName]
+		if !ok {
+			return nil, fmt.Errorf("no clients for the worker cluster %q", clusterName)
+		}
+		return clientSets, nil
+	}, nil
+}
+
 func (cg *TestClientGetter) NewResourceBuilder() *resource.Builder {
 	return resource.NewFakeBuilder(
 		func(version schema.GroupVersion) (resource.RESTClient, error) {
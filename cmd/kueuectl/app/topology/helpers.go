@@ -0,0 +1,133 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topology
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	k8s "k8s.io/client-go/kubernetes"
+	"k8s.io/component-base/featuregate"
+
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/resources"
+	utiltas "sigs.k8s.io/kueue/pkg/util/tas"
+)
+
+const noneValue = "<none>"
+
+// tasFeatures are the feature gates used to compute the topology
+// assignments. The fields they guard are only set on the objects when the
+// feature gates are enabled in the Kueue controller manager, so they are
+// enabled to interpret the objects as the controller does.
+var tasFeatures = []featuregate.Feature{
+	features.TopologyAwareScheduling,
+	features.TASBalancedPlacement,
+	features.TASPodSetGroups,
+	features.TASPodSetSlices,
+}
+
+func enableTASFeatures() error {
+	for _, f := range tasFeatures {
+		if err := features.SetEnable(f, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// topologyObjects holds the objects used to build the snapshots of the TAS
+// flavors.
+type topologyObjects struct {
+	nodes     []corev1.Node
+	pods      []corev1.Pod
+	workloads []kueue.Workload
+}
+
+func listTopologyObjects(ctx context.Context, k8sClient k8s.Interface, kueueClient versioned.Interface) (*topologyObjects, error) {
+	nodes, err := k8sClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	r, err := labels.NewRequirement(kueuealpha.TASLabel, selection.DoesNotExist, nil)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := k8sClient.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.NewSelector().Add(*r).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	workloads, err := kueueClient.KueueV1beta1().Workloads(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return &topologyObjects{nodes: nodes.Items, pods: pods.Items, workloads: workloads.Items}, nil
+}
+
+// flavorTopology returns the levels of the topology referenced by the
+// flavor, and the topology domains of the nodes when the topology reads them
+// from a ConfigMap in the kueueNamespace, as the controller does.
+func flavorTopology(ctx context.Context, kueueClient versioned.Interface, k8sClient k8s.Interface,
+	flavor *kueue.ResourceFlavor, kueueNamespace string) ([]string, cache.NodeDomains, error) {
+	if flavor.Spec.TopologyName == nil {
+		return nil, nil, fmt.Errorf("resource flavor %q doesn't reference a topology", flavor.Name)
+	}
+	topology, err := kueueClient.KueueV1alpha1().Topologies().Get(ctx, string(*flavor.Spec.TopologyName), metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	levels := utiltas.Levels(topology)
+	if topology.Spec.NodeDomainsSource == nil {
+		return levels, nil, nil
+	}
+	configMap, err := k8sClient.CoreV1().ConfigMaps(kueueNamespace).Get(ctx, topology.Spec.NodeDomainsSource.ConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return levels, cache.NodeDomains{}, nil
+		}
+		return nil, nil, err
+	}
+	return levels, cache.ParseNodeDomains(logr.Discard(), configMap, len(levels)-1), nil
+}
+
+func formatRequests(requests resources.Requests) string {
+	var parts []string
+	for _, name := range slices.Sorted(maps.Keys(requests)) {
+		if requests[name] != 0 {
+			parts = append(parts, fmt.Sprintf("%s=%s", name, resources.ResourceQuantityString(name, requests[name])))
+		}
+	}
+	if len(parts) == 0 {
+		return noneValue
+	}
+	return strings.Join(parts, ",")
+}
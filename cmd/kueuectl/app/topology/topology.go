@@ -0,0 +1,48 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topology
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
+)
+
+var (
+	topologyExample = templates.Examples(`
+		# Show the topology domains of the ResourceFlavor
+		kueuectl topology show my-flavor
+
+		# Explain why the Workload doesn't fit in the topology
+		kueuectl topology explain my-workload
+	`)
+)
+
+func NewTopologyCmd(clientGetter util.ClientGetter, streams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "topology",
+		Short:   "Inspect the Topology Aware Scheduling domains and placements",
+		Example: topologyExample,
+	}
+
+	cmd.AddCommand(NewShowCmd(clientGetter, streams))
+	cmd.AddCommand(NewExplainCmd(clientGetter, streams))
+
+	return cmd
+}
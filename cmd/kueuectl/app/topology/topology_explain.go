@@ -0,0 +1,250 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topology
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	k8s "k8s.io/client-go/kubernetes"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/completion"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+var (
+	explainLong = templates.LongDesc(`
+Explains whether the PodSets of the given pending Workload fit in the
+topology of each ResourceFlavor using Topology Aware Scheduling (TAS) in the
+ClusterQueue of the Workload. For the PodSets which don't fit at the
+requested topology level, it shows the reason, and whether they would fit
+if the workloads admitted by TAS were preempted. The quota of the
+ClusterQueue is not taken into account.
+`)
+	explainExample = templates.Examples(`
+		# Explain why the Workload doesn't fit in the topology
+		kueuectl topology explain my-workload
+	`)
+)
+
+type ExplainOptions struct {
+	Name      string
+	Namespace string
+
+	KueueNamespace string
+
+	KueueClient versioned.Interface
+	K8sClient   k8s.Interface
+
+	genericiooptions.IOStreams
+}
+
+func NewExplainOptions(streams genericiooptions.IOStreams) *ExplainOptions {
+	return &ExplainOptions{
+		IOStreams: streams,
+	}
+}
+
+func NewExplainCmd(clientGetter util.ClientGetter, streams genericiooptions.IOStreams) *cobra.Command {
+	o := NewExplainOptions(streams)
+
+	cmd := &cobra.Command{
+		Use: "explain WORKLOAD [--namespace NAMESPACE]",
+		// To do not add "[flags]" suffix on the end of usage line
+		DisableFlagsInUseLine: true,
+		Short:                 "Explain why the Workload doesn't fit in the topology",
+		Long:                  explainLong,
+		Example:               explainExample,
+		Args:                  cobra.ExactArgs(1),
+		ValidArgsFunction:     completion.WorkloadNameFunc(clientGetter, nil),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			err := o.Complete(clientGetter, args)
+			if err != nil {
+				return err
+			}
+			return o.Run(cmd.Context())
+		},
+	}
+
+	util.AddKueueNamespaceFlagVar(cmd, &o.KueueNamespace)
+
+	return cmd
+}
+
+// Complete completes all the required options
+func (o *ExplainOptions) Complete(clientGetter util.ClientGetter, args []string) error {
+	o.Name = args[0]
+
+	var err error
+	o.Namespace, _, err = clientGetter.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+
+	o.KueueClient, err = clientGetter.KueueClientSet()
+	if err != nil {
+		return err
+	}
+
+	o.K8sClient, err = clientGetter.K8sClientSet()
+	if err != nil {
+		return err
+	}
+
+	return enableTASFeatures()
+}
+
+// Run performs the explain operation.
+func (o *ExplainOptions) Run(ctx context.Context) error {
+	wl, err := o.KueueClient.KueueV1beta1().Workloads(o.Namespace).Get(ctx, o.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if workload.HasQuotaReservation(wl) {
+		fmt.Fprintf(o.Out, "Workload %q has quota reserved in the ClusterQueue %q\n", workload.Key(wl), wl.Status.Admission.ClusterQueue)
+		for _, psa := range wl.Status.Admission.PodSetAssignments {
+			if psa.TopologyAssignment != nil {
+				fmt.Fprintf(o.Out, "  PodSet %q: assigned to %d domain(s) at the levels %v\n",
+					psa.Name, len(psa.TopologyAssignment.Domains), psa.TopologyAssignment.Levels)
+			}
+		}
+		return nil
+	}
+	if cond := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadQuotaReserved); cond != nil && cond.Message != "" {
+		fmt.Fprintf(o.Out, "Workload %q is pending: %s\n", workload.Key(wl), cond.Message)
+	} else {
+		fmt.Fprintf(o.Out, "Workload %q is pending\n", workload.Key(wl))
+	}
+
+	lq, err := o.KueueClient.KueueV1beta1().LocalQueues(wl.Namespace).Get(ctx, string(wl.Spec.QueueName), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	cq, err := o.KueueClient.KueueV1beta1().ClusterQueues().Get(ctx, string(lq.Spec.ClusterQueue), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	flavors, tasOnly, err := o.tasFlavors(ctx, cq)
+	if err != nil {
+		return err
+	}
+	if len(flavors) == 0 {
+		return fmt.Errorf("cluster queue %q doesn't use any flavor with a topology", cq.Name)
+	}
+	if !tasOnly && !slices.ContainsFunc(wl.Spec.PodSets, func(ps kueue.PodSet) bool { return ps.TopologyRequest != nil }) {
+		return fmt.Errorf("workload %q doesn't request topology aware scheduling", workload.Key(wl))
+	}
+
+	objects, err := listTopologyObjects(ctx, o.K8sClient, o.KueueClient)
+	if err != nil {
+		return err
+	}
+	info := workload.NewInfo(wl)
+	for _, flavor := range flavors {
+		levels, nodeDomains, err := flavorTopology(ctx, o.KueueClient, o.K8sClient, flavor, o.KueueNamespace)
+		if err != nil {
+			return err
+		}
+		snapshot := cache.NewTASFlavorSnapshot(logr.Discard(), flavor, levels, nodeDomains, objects.nodes, objects.pods, objects.workloads)
+		requests := podSetRequests(info, kueue.ResourceFlavorReference(flavor.Name), tasOnly)
+		result := snapshot.FindTopologyAssignmentsForFlavor(requests, false)
+		var emptyResult cache.TASAssignmentsResult
+		if result.Failure() != nil {
+			emptyResult = snapshot.FindTopologyAssignmentsForFlavor(requests, true)
+		}
+		fmt.Fprintf(o.Out, "Flavor %q (topology %q):\n", flavor.Name, *flavor.Spec.TopologyName)
+		for _, tr := range requests {
+			psResult, found := result[tr.PodSet.Name]
+			switch {
+			case !found:
+				fmt.Fprintf(o.Out, "  PodSet %q: not evaluated, as a previous PodSet doesn't fit\n", tr.PodSet.Name)
+			case psResult.FailureReason == "":
+				fmt.Fprintf(o.Out, "  PodSet %q: fits in %d domain(s) at the levels %v\n",
+					tr.PodSet.Name, len(psResult.TopologyAssignment.Domains), psResult.TopologyAssignment.Levels)
+			default:
+				fmt.Fprintf(o.Out, "  PodSet %q: doesn't fit: %s\n", tr.PodSet.Name, psResult.FailureReason)
+				if emptyResult.Failure() == nil {
+					fmt.Fprintln(o.Out, "    It would fit if the workloads admitted by TAS were preempted")
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// tasFlavors returns the ResourceFlavors of the ClusterQueue which reference
+// a topology, and whether the ClusterQueue only uses such flavors, in which
+// case TAS is implied for the PodSets without a topology request.
+func (o *ExplainOptions) tasFlavors(ctx context.Context, cq *kueue.ClusterQueue) ([]*kueue.ResourceFlavor, bool, error) {
+	var result []*kueue.ResourceFlavor
+	tasOnly := true
+	for _, rg := range cq.Spec.ResourceGroups {
+		for _, fq := range rg.Flavors {
+			if slices.ContainsFunc(result, func(f *kueue.ResourceFlavor) bool { return f.Name == string(fq.Name) }) {
+				continue
+			}
+			flavor, err := o.KueueClient.KueueV1beta1().ResourceFlavors().Get(ctx, string(fq.Name), metav1.GetOptions{})
+			if err != nil {
+				return nil, false, err
+			}
+			if flavor.Spec.TopologyName == nil {
+				tasOnly = false
+				continue
+			}
+			result = append(result, flavor)
+		}
+	}
+	return result, tasOnly, nil
+}
+
+// podSetRequests returns the TAS requests of the PodSets of the workload, as
+// if they were all assigned the flavor. When TAS is implied, the PodSets
+// without a topology request are included.
+func podSetRequests(info *workload.Info, flavor kueue.ResourceFlavorReference, tasImplied bool) cache.FlavorTASRequests {
+	var result cache.FlavorTASRequests
+	for i := range info.Obj.Spec.PodSets {
+		ps := &info.Obj.Spec.PodSets[i]
+		if ps.TopologyRequest == nil && !tasImplied {
+			continue
+		}
+		psrIdx := slices.IndexFunc(info.TotalRequests, func(psr workload.PodSetResources) bool { return psr.Name == ps.Name })
+		if psrIdx < 0 {
+			continue
+		}
+		psr := &info.TotalRequests[psrIdx]
+		result = append(result, cache.TASPodSetRequests{
+			PodSet:            ps,
+			SinglePodRequests: psr.SinglePodRequests(),
+			Count:             psr.Count,
+			Flavor:            flavor,
+			Implied:           ps.TopologyRequest == nil,
+		})
+	}
+	return result
+}
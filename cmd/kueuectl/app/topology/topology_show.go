@@ -0,0 +1,198 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topology
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	k8s "k8s.io/client-go/kubernetes"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
+	"sigs.k8s.io/kueue/pkg/cache"
+	utiltas "sigs.k8s.io/kueue/pkg/util/tas"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+var (
+	showLong = templates.LongDesc(`
+Shows the topology domains of the given ResourceFlavor as a tree, following
+the levels of the Topology referenced by the flavor. For each domain it shows
+the capacity of the ready and schedulable nodes, the usage of the workloads
+admitted by Topology Aware Scheduling (TAS), the usage of the other pods, the
+free capacity, and the workloads with pods assigned to the domain.
+`)
+	showExample = templates.Examples(`
+		# Show the topology domains of the ResourceFlavor
+		kueuectl topology show my-flavor
+	`)
+)
+
+type ShowOptions struct {
+	Name string
+
+	KueueNamespace string
+
+	KueueClient versioned.Interface
+	K8sClient   k8s.Interface
+
+	genericiooptions.IOStreams
+}
+
+func NewShowOptions(streams genericiooptions.IOStreams) *ShowOptions {
+	return &ShowOptions{
+		IOStreams: streams,
+	}
+}
+
+func NewShowCmd(clientGetter util.ClientGetter, streams genericiooptions.IOStreams) *cobra.Command {
+	o := NewShowOptions(streams)
+
+	cmd := &cobra.Command{
+		Use: "show FLAVOR",
+		// To do not add "[flags]" suffix on the end of usage line
+		DisableFlagsInUseLine: true,
+		Short:                 "Show the topology domains of the ResourceFlavor",
+		Long:                  showLong,
+		Example:               showExample,
+		Args:                  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			err := o.Complete(clientGetter, args)
+			if err != nil {
+				return err
+			}
+			return o.Run(cmd.Context())
+		},
+	}
+
+	util.AddKueueNamespaceFlagVar(cmd, &o.KueueNamespace)
+
+	return cmd
+}
+
+// Complete completes all the required options
+func (o *ShowOptions) Complete(clientGetter util.ClientGetter, args []string) error {
+	o.Name = args[0]
+
+	var err error
+	o.KueueClient, err = clientGetter.KueueClientSet()
+	if err != nil {
+		return err
+	}
+
+	o.K8sClient, err = clientGetter.K8sClientSet()
+	if err != nil {
+		return err
+	}
+
+	return enableTASFeatures()
+}
+
+// Run performs the show operation.
+func (o *ShowOptions) Run(ctx context.Context) error {
+	flavor, err := o.KueueClient.KueueV1beta1().ResourceFlavors().Get(ctx, o.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	levels, nodeDomains, err := flavorTopology(ctx, o.KueueClient, o.K8sClient, flavor, o.KueueNamespace)
+	if err != nil {
+		return err
+	}
+	objects, err := listTopologyObjects(ctx, o.K8sClient, o.KueueClient)
+	if err != nil {
+		return err
+	}
+	snapshot := cache.NewTASFlavorSnapshot(logr.Discard(), flavor, levels, nodeDomains, objects.nodes, objects.pods, objects.workloads)
+	domains := snapshot.DomainResources()
+	if len(domains[levels[0]]) == 0 {
+		fmt.Fprintln(o.ErrOut, "No topology domains found")
+		return nil
+	}
+	placements := domainWorkloads(snapshot, kueue.ResourceFlavorReference(flavor.Name), objects.workloads)
+
+	tabWriter := printers.GetNewTabWriter(o.Out)
+	fmt.Fprintln(tabWriter, "DOMAIN\tCAPACITY\tTAS USAGE\tNON-TAS USAGE\tFREE\tWORKLOADS")
+	printDomains(tabWriter, levels, domains, placements, 0, "")
+	return tabWriter.Flush()
+}
+
+// domainWorkloads returns the names of the workloads with pods assigned to
+// each topology domain of the flavor, keyed by the level.
+func domainWorkloads(snapshot *cache.TASFlavorSnapshot, flavor kueue.ResourceFlavorReference,
+	workloads []kueue.Workload) map[string]map[utiltas.TopologyDomainID]sets.Set[string] {
+	result := make(map[string]map[utiltas.TopologyDomainID]sets.Set[string])
+	for _, wl := range workloads {
+		if !workload.HasQuotaReservation(&wl) {
+			continue
+		}
+		for _, psa := range wl.Status.Admission.PodSetAssignments {
+			if psa.TopologyAssignment == nil || !slices.Contains(slices.Collect(maps.Values(psa.Flavors)), flavor) {
+				continue
+			}
+			for level, ids := range snapshot.AssignmentDomains(psa.TopologyAssignment) {
+				if result[level] == nil {
+					result[level] = make(map[utiltas.TopologyDomainID]sets.Set[string])
+				}
+				for id := range ids {
+					if result[level][id] == nil {
+						result[level][id] = sets.New[string]()
+					}
+					result[level][id].Insert(workload.Key(&wl))
+				}
+			}
+		}
+	}
+	return result
+}
+
+// printDomains prints the domains at the level, within the parent domain
+// identified by the prefix, each followed by its child domains.
+func printDomains(w io.Writer, levels []string, domains map[string][]cache.TASDomainResources,
+	placements map[string]map[utiltas.TopologyDomainID]sets.Set[string], levelIdx int, prefix string) {
+	level := levels[levelIdx]
+	for _, domain := range domains[level] {
+		id := string(domain.Domain)
+		if !strings.HasPrefix(id, prefix) {
+			continue
+		}
+		workloads := noneValue
+		if names := placements[level][domain.Domain]; names.Len() > 0 {
+			workloads = strings.Join(sets.List(names), ",")
+		}
+		fmt.Fprintf(w, "%s%s\t%s\t%s\t%s\t%s\t%s\n",
+			strings.Repeat("  ", levelIdx), id[len(prefix):],
+			formatRequests(domain.Capacity), formatRequests(domain.TASUsage),
+			formatRequests(domain.NonTASUsage), formatRequests(domain.Free()), workloads)
+		if levelIdx+1 < len(levels) {
+			printDomains(w, levels, domains, placements, levelIdx+1, id+",")
+		}
+	}
+}
@@ -0,0 +1,276 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topology
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/fake"
+	cmdtesting "sigs.k8s.io/kueue/cmd/kueuectl/app/testing"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	testingnode "sigs.k8s.io/kueue/pkg/util/testingjobs/node"
+	testingpod "sigs.k8s.io/kueue/pkg/util/testingjobs/pod"
+)
+
+const tasRackLabel = "cloud.com/topology-rack"
+
+func testNode(name, rack string) *corev1.Node {
+	return testingnode.MakeNode(name).
+		Label(tasRackLabel, rack).
+		Label(corev1.LabelHostname, name).
+		StatusAllocatable(corev1.ResourceList{
+			corev1.ResourceCPU:  resource.MustParse("2"),
+			corev1.ResourcePods: resource.MustParse("10"),
+		}).
+		Ready().
+		Obj()
+}
+
+func admittedWorkload(name string, cpu string, nodes ...string) *kueue.Workload {
+	assignment := &kueue.TopologyAssignment{Levels: []string{corev1.LabelHostname}}
+	for _, node := range nodes {
+		assignment.Domains = append(assignment.Domains, kueue.TopologyDomainAssignment{Values: []string{node}, Count: 1})
+	}
+	count := int32(len(nodes))
+	return utiltesting.MakeWorkload(name, metav1.NamespaceDefault).
+		PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, int(count)).
+			RequiredTopologyRequest(tasRackLabel).
+			Request(corev1.ResourceCPU, cpu).
+			Obj()).
+		ReserveQuota(utiltesting.MakeAdmission("cq").
+			PodSets(kueue.PodSetAssignment{
+				Name:               kueue.DefaultPodSetName,
+				Flavors:            map[corev1.ResourceName]kueue.ResourceFlavorReference{corev1.ResourceCPU: "tas"},
+				ResourceUsage:      corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+				Count:              ptr.To(count),
+				TopologyAssignment: assignment,
+			}).Obj()).
+		Obj()
+}
+
+func TestShowCmd(t *testing.T) {
+	testCases := map[string]struct {
+		args       []string
+		kueueObjs  []runtime.Object
+		k8sObjs    []runtime.Object
+		wantOut    string
+		wantOutErr string
+		wantErr    string
+	}{
+		"should print the topology domains": {
+			args: []string{"tas"},
+			kueueObjs: []runtime.Object{
+				utiltesting.MakeResourceFlavor("tas").TopologyName("default").Obj(),
+				utiltesting.MakeTopology("default").Levels(tasRackLabel, corev1.LabelHostname).Obj(),
+				admittedWorkload("wl", "1", "x1"),
+			},
+			k8sObjs: []runtime.Object{
+				testNode("x1", "r1"),
+				testNode("x2", "r1"),
+				testNode("x3", "r2"),
+				testingpod.MakePod("daemon", metav1.NamespaceDefault).NodeName("x2").Request(corev1.ResourceCPU, "500m").Obj(),
+				testingpod.MakePod("wl-pod", metav1.NamespaceDefault).NodeName("x1").Request(corev1.ResourceCPU, "1").
+					Label(kueuealpha.TASLabel, "true").Obj(),
+			},
+			wantOut: `DOMAIN   CAPACITY        TAS USAGE      NON-TAS USAGE     FREE                WORKLOADS
+r1       cpu=4,pods=20   cpu=1,pods=1   cpu=500m,pods=1   cpu=2500m,pods=18   default/wl
+  x1     cpu=2,pods=10   cpu=1,pods=1   <none>            cpu=1,pods=9        default/wl
+  x2     cpu=2,pods=10   <none>         cpu=500m,pods=1   cpu=1500m,pods=9    <none>
+r2       cpu=2,pods=10   <none>         <none>            cpu=2,pods=10       <none>
+  x3     cpu=2,pods=10   <none>         <none>            cpu=2,pods=10       <none>
+`,
+		},
+		"should print the topology domains read from the ConfigMap": {
+			args: []string{"tas"},
+			kueueObjs: []runtime.Object{
+				utiltesting.MakeResourceFlavor("tas").TopologyName("default").Obj(),
+				func() *kueuealpha.Topology {
+					topology := utiltesting.MakeTopology("default").Levels(tasRackLabel, corev1.LabelHostname).Obj()
+					topology.Spec.NodeDomainsSource = &kueuealpha.TopologyNodeDomainsSource{ConfigMapName: "node-domains"}
+					return topology
+				}(),
+			},
+			k8sObjs: []runtime.Object{
+				testingnode.MakeNode("x1").
+					Label(corev1.LabelHostname, "x1").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("2"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "node-domains", Namespace: "kueue-system"},
+					Data:       map[string]string{"x1": "r1"},
+				},
+			},
+			wantOut: `DOMAIN   CAPACITY        TAS USAGE   NON-TAS USAGE   FREE            WORKLOADS
+r1       cpu=2,pods=10   <none>      <none>          cpu=2,pods=10   <none>
+  x1     cpu=2,pods=10   <none>      <none>          cpu=2,pods=10   <none>
+`,
+		},
+		"should print no domains found": {
+			args: []string{"tas"},
+			kueueObjs: []runtime.Object{
+				utiltesting.MakeResourceFlavor("tas").TopologyName("default").Obj(),
+				utiltesting.MakeTopology("default").Levels(tasRackLabel, corev1.LabelHostname).Obj(),
+			},
+			wantOutErr: "No topology domains found\n",
+		},
+		"should fail for a flavor without topology": {
+			args: []string{"rf"},
+			kueueObjs: []runtime.Object{
+				utiltesting.MakeResourceFlavor("rf").Obj(),
+			},
+			wantOutErr: "Error: resource flavor \"rf\" doesn't reference a topology\n",
+			wantErr:    `resource flavor "rf" doesn't reference a topology`,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, outErr := genericiooptions.NewTestIOStreams()
+
+			tcg := cmdtesting.NewTestClientGetter().
+				WithKueueClientset(fake.NewSimpleClientset(tc.kueueObjs...)).
+				WithK8sClientset(k8sfake.NewSimpleClientset(tc.k8sObjs...))
+
+			cmd := NewShowCmd(tcg, streams)
+			cmd.SetOut(out)
+			cmd.SetErr(outErr)
+			cmd.SetArgs(tc.args)
+
+			var gotErr string
+			if err := cmd.Execute(); err != nil {
+				gotErr = err.Error()
+			}
+			if diff := cmp.Diff(tc.wantErr, gotErr); diff != "" {
+				t.Errorf("Unexpected error (-want/+got)\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.wantOut, out.String()); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.wantOutErr, outErr.String()); diff != "" {
+				t.Errorf("Unexpected error output (-want/+got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestExplainCmd(t *testing.T) {
+	pendingWorkload := func(count int, level string) *kueue.Workload {
+		return utiltesting.MakeWorkload("pending", metav1.NamespaceDefault).
+			Queue("lq").
+			PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, count).
+				RequiredTopologyRequest(level).
+				Request(corev1.ResourceCPU, "1").
+				Obj()).
+			Obj()
+	}
+	baseKueueObjs := []runtime.Object{
+		utiltesting.MakeResourceFlavor("tas").TopologyName("default").Obj(),
+		utiltesting.MakeTopology("default").Levels(tasRackLabel, corev1.LabelHostname).Obj(),
+		utiltesting.MakeLocalQueue("lq", metav1.NamespaceDefault).ClusterQueue("cq").Obj(),
+		utiltesting.MakeClusterQueue("cq").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("tas").Resource(corev1.ResourceCPU, "10").Obj()).
+			Obj(),
+		admittedWorkload("wl", "2", "x1", "x2"),
+	}
+	k8sObjs := []runtime.Object{
+		testNode("x1", "r1"),
+		testNode("x2", "r1"),
+		testNode("x3", "r2"),
+	}
+
+	testCases := map[string]struct {
+		args      []string
+		kueueObjs []runtime.Object
+		wantOut   string
+		wantErr   string
+	}{
+		"should explain the workload fits": {
+			args:      []string{"pending"},
+			kueueObjs: append(baseKueueObjs, pendingWorkload(2, tasRackLabel)),
+			wantOut: `Workload "default/pending" is pending
+Flavor "tas" (topology "default"):
+  PodSet "main": fits in 2 domain(s) at the levels [kubernetes.io/hostname]
+`,
+		},
+		"should explain the workload would fit after preemption": {
+			args:      []string{"pending"},
+			kueueObjs: append(baseKueueObjs, pendingWorkload(4, tasRackLabel)),
+			wantOut: `Workload "default/pending" is pending
+Flavor "tas" (topology "default"):
+  PodSet "main": doesn't fit: topology "default" allows to fit only 2 out of 4 pod(s)
+    It would fit if the workloads admitted by TAS were preempted
+`,
+		},
+		"should explain the workload doesn't fit": {
+			args:      []string{"pending"},
+			kueueObjs: append(baseKueueObjs, pendingWorkload(6, tasRackLabel)),
+			wantOut: `Workload "default/pending" is pending
+Flavor "tas" (topology "default"):
+  PodSet "main": doesn't fit: topology "default" allows to fit only 2 out of 6 pod(s)
+`,
+		},
+		"should show the admitted workload": {
+			args:      []string{"wl"},
+			kueueObjs: baseKueueObjs,
+			wantOut: `Workload "default/wl" has quota reserved in the ClusterQueue "cq"
+  PodSet "main": assigned to 2 domain(s) at the levels [kubernetes.io/hostname]
+`,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, outErr := genericiooptions.NewTestIOStreams()
+
+			tcg := cmdtesting.NewTestClientGetter().
+				WithKueueClientset(fake.NewSimpleClientset(tc.kueueObjs...)).
+				WithK8sClientset(k8sfake.NewSimpleClientset(k8sObjs...))
+
+			cmd := NewExplainCmd(tcg, streams)
+			cmd.SetOut(out)
+			cmd.SetErr(outErr)
+			cmd.SetArgs(tc.args)
+
+			var gotErr string
+			if err := cmd.Execute(); err != nil {
+				gotErr = err.Error()
+			}
+			if diff := cmp.Diff(tc.wantErr, gotErr); diff != "" {
+				t.Errorf("Unexpected error (-want/+got)\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.wantOut, out.String()); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+		})
+	}
+}
@@ -35,6 +35,7 @@ type ClientGetter interface {
 	K8sClientSet() (k8s.Interface, error)
 	DynamicClient() (dynamic.Interface, error)
 	NewResourceBuilder() *resource.Builder
+	WorkerClientSets(kueueNamespace string) (WorkerClientSetsFunc, error)
 }
 
 type clientGetterImpl struct {
@@ -95,3 +96,17 @@ func (cg *clientGetterImpl) DynamicClient() (dynamic.Interface, error) {
 func (cg *clientGetterImpl) NewResourceBuilder() *resource.Builder {
 	return resource.NewBuilder(cg.RESTClientGetter)
 }
+
+func (cg *clientGetterImpl) WorkerClientSets(kueueNamespace string) (WorkerClientSetsFunc, error) {
+	kueueClientSet, err := cg.KueueClientSet()
+	if err != nil {
+		return nil, err
+	}
+
+	k8sClientSet, err := cg.K8sClientSet()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWorkerClientSetsFunc(kueueClientSet, k8sClientSet, kueueNamespace), nil
+}
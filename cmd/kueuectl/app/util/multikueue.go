@@ -0,0 +1,112 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned"
+)
+
+// DefaultKueueNamespace is the namespace in which the kueue controller manager
+// is installed by default.
+const DefaultKueueNamespace = "kueue-system"
+
+func AddKueueNamespaceFlagVar(cmd *cobra.Command, p *string) {
+	cmd.Flags().StringVar(p, "kueue-namespace", DefaultKueueNamespace,
+		"The namespace in which the kueue controller manager is running, storing the kubeconfigs of the MultiKueue clusters and the topology domains of the nodes.")
+}
+
+// WorkerClientSets are the clients of a MultiKueue worker cluster.
+type WorkerClientSets struct {
+	KueueClientSet versioned.Interface
+	K8sClientSet   k8s.Interface
+}
+
+// WorkerClientSetsFunc returns the clients of the MultiKueue worker cluster
+// described by the MultiKueueCluster with the given name.
+type WorkerClientSetsFunc func(ctx context.Context, clusterName string) (*WorkerClientSets, error)
+
+type workerClientSetsResult struct {
+	clientSets *WorkerClientSets
+	err        error
+}
+
+// NewWorkerClientSetsFunc returns a WorkerClientSetsFunc building the clients
+// from the kubeconfigs stored by the MultiKueue manager in Secrets of the
+// kueueNamespace. The clients, or the error building them, are cached by
+// cluster name.
+func NewWorkerClientSetsFunc(kueueClientSet versioned.Interface, k8sClientSet k8s.Interface, kueueNamespace string) WorkerClientSetsFunc {
+	cache := make(map[string]workerClientSetsResult)
+	return func(ctx context.Context, clusterName string) (*WorkerClientSets, error) {
+		if result, ok := cache[clusterName]; ok {
+			return result.clientSets, result.err
+		}
+		clientSets, err := newWorkerClientSets(ctx, kueueClientSet, k8sClientSet, kueueNamespace, clusterName)
+		cache[clusterName] = workerClientSetsResult{clientSets: clientSets, err: err}
+		return clientSets, err
+	}
+}
+
+func newWorkerClientSets(ctx context.Context, kueueClientSet versioned.Interface, k8sClientSet k8s.Interface, kueueNamespace, clusterName string) (*WorkerClientSets, error) {
+	cluster, err := kueueClientSet.KueueV1beta1().MultiKueueClusters().Get(ctx, clusterName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	kubeConfig := cluster.Spec.KubeConfig
+	if kubeConfig == nil || kubeConfig.LocationType != kueue.SecretLocationType {
+		return nil, fmt.Errorf("the kubeconfig of the MultiKueueCluster %q is not stored in a Secret", clusterName)
+	}
+
+	secret, err := k8sClientSet.CoreV1().Secrets(kueueNamespace).Get(ctx, kubeConfig.Location, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	kubeConfigBytes, found := secret.Data[kueue.MultiKueueConfigSecretKey]
+	if !found {
+		return nil, fmt.Errorf("key %q not found in secret %q", kueue.MultiKueueConfigSecretKey, kubeConfig.Location)
+	}
+
+	config, err := clientcmd.RESTConfigFromKubeConfig(kubeConfigBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	workerKueueClientSet, err := versioned.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	workerK8sClientSet, err := k8s.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WorkerClientSets{
+		KueueClientSet: workerKueueClientSet,
+		K8sClientSet:   workerK8sClientSet,
+	}, nil
+}
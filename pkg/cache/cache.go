@@ -26,10 +26,12 @@ import (
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -114,6 +116,10 @@ type Cache struct {
 	hm hierarchy.Manager[*clusterQueue, *cohort]
 
 	tasCache TASCache
+
+	// federatedQuotas are the nominal quotas of the ClusterQueues computed
+	// from the quotas of the MultiKueue worker clusters.
+	federatedQuotas map[kueue.ClusterQueueReference]*FederatedQuota
 }
 
 func New(client client.Client, opts ...Option) *Cache {
@@ -145,8 +151,10 @@ func (c *Cache) newClusterQueue(cq *kueue.ClusterQueue) (*clusterQueue, error) {
 		podsReadyTracking:   c.podsReadyTracking,
 		workloadInfoOptions: c.workloadInfoOptions,
 		AdmittedUsage:       make(resources.FlavorResourceQuantities),
+		usersUsage:          make(usersUsage),
 		resourceNode:        NewResourceNode(),
 		tasCache:            &c.tasCache,
+		federatedQuota:      c.federatedQuotas[kueue.ClusterQueueReference(cq.Name)],
 	}
 	c.hm.AddClusterQueue(cqImpl)
 	c.hm.UpdateClusterQueueEdge(kueue.ClusterQueueReference(cq.Name), cq.Spec.Cohort)
@@ -252,14 +260,43 @@ func (c *Cache) AddOrUpdateResourceFlavor(rf *kueue.ResourceFlavor) sets.Set[kue
 	c.Lock()
 	defer c.Unlock()
 	c.resourceFlavors[kueue.ResourceFlavorReference(rf.Name)] = rf
-	return c.updateClusterQueues()
+	return c.updateClusterQueues().Union(c.updateOvercommit())
 }
 
 func (c *Cache) DeleteResourceFlavor(rf *kueue.ResourceFlavor) sets.Set[kueue.ClusterQueueReference] {
 	c.Lock()
 	defer c.Unlock()
 	delete(c.resourceFlavors, kueue.ResourceFlavorReference(rf.Name))
-	return c.updateClusterQueues()
+	return c.updateClusterQueues().Union(c.updateOvercommit())
+}
+
+// updateOvercommit applies the overcommit ratios of the flavors to the
+// quotas of the ClusterQueues and Cohorts. It returns the ClusterQueues
+// whose effective quotas changed.
+func (c *Cache) updateOvercommit() sets.Set[kueue.ClusterQueueReference] {
+	ratios := newOvercommitRatios(c.resourceFlavors)
+	cqs := sets.New[kueue.ClusterQueueReference]()
+	roots := sets.New[*cohort]()
+	for _, cohort := range c.hm.Cohorts() {
+		if cohort.resourceNode.setQuotas(cohort.resourceNode.physicalQuotas(), ratios) && !hierarchy.HasCycle(cohort) {
+			roots.Insert(cohort.getRootUnsafe())
+		}
+	}
+	for _, cq := range c.hm.ClusterQueues() {
+		if !cq.resourceNode.setQuotas(cq.resourceNode.physicalQuotas(), ratios) {
+			continue
+		}
+		cqs.Insert(cq.Name)
+		if !cq.HasParent() {
+			updateClusterQueueResourceNode(cq)
+		} else if !hierarchy.HasCycle(cq.Parent()) {
+			roots.Insert(cq.Parent().getRootUnsafe())
+		}
+	}
+	for root := range roots {
+		updateCohortResourceNode(root)
+	}
+	return cqs
 }
 
 func (c *Cache) AddOrUpdateTopologyForFlavor(topology *kueuealpha.Topology, flv *kueue.ResourceFlavor) sets.Set[kueue.ClusterQueueReference] {
@@ -267,6 +304,11 @@ func (c *Cache) AddOrUpdateTopologyForFlavor(topology *kueuealpha.Topology, flv
 	defer c.Unlock()
 	levels := utiltas.Levels(topology)
 	tasInfo := c.tasCache.NewTASFlavorCache(kueue.TopologyReference(topology.Name), levels, flv.Spec.NodeLabels, flv.Spec.Tolerations)
+	if features.Enabled(features.TASNodeDomainsSource) && topology.Spec.NodeDomainsSource != nil {
+		// The domains are set when the flavor is added to the TAS cache, if
+		// they were already read from the source.
+		tasInfo.setNodeDomains(NodeDomains{})
+	}
 	c.tasCache.Set(kueue.ResourceFlavorReference(flv.Name), tasInfo)
 	return c.updateClusterQueues()
 }
@@ -278,6 +320,46 @@ func (c *Cache) DeleteTopologyForFlavor(flv kueue.ResourceFlavorReference) sets.
 	return c.updateClusterQueues()
 }
 
+// UpdateNodeCapacity sets, or deletes when nil, the capacity of the nodes of
+// the flavor, and updates the nominal quotas derived from it. It returns the
+// ClusterQueues whose quotas changed.
+func (c *Cache) UpdateNodeCapacity(flavor kueue.ResourceFlavorReference, capacity *NodeCapacity) sets.Set[kueue.ClusterQueueReference] {
+	c.Lock()
+	defer c.Unlock()
+	cqs := sets.New[kueue.ClusterQueueReference]()
+	if !c.tasCache.setNodeCapacity(flavor, capacity) {
+		return cqs
+	}
+	ratios := newOvercommitRatios(c.resourceFlavors)
+	for _, cq := range c.hm.ClusterQueues() {
+		if !cq.usesNodeCapacity(flavor) || !cq.resourceNode.setQuotas(cq.withNodeCapacityQuotas(cq.resourceNode.physicalQuotas()), ratios) {
+			continue
+		}
+		cqs.Insert(cq.Name)
+		if cq.HasParent() {
+			// ignore error when the Cohort has a cycle.
+			_ = updateCohortTreeResources(cq.Parent())
+		} else {
+			updateClusterQueueResourceNode(cq)
+		}
+	}
+	return cqs
+}
+
+// ClusterQueuesUsingNodeCapacity returns the ClusterQueues deriving nominal
+// quotas from the capacity of the nodes of the flavor.
+func (c *Cache) ClusterQueuesUsingNodeCapacity(flavor kueue.ResourceFlavorReference) []kueue.ClusterQueueReference {
+	c.RLock()
+	defer c.RUnlock()
+	var cqs []kueue.ClusterQueueReference
+	for _, cq := range c.hm.ClusterQueues() {
+		if cq.usesNodeCapacity(flavor) {
+			cqs = append(cqs, cq.Name)
+		}
+	}
+	return cqs
+}
+
 func (c *Cache) AddOrUpdateAdmissionCheck(ac *kueue.AdmissionCheck) sets.Set[kueue.ClusterQueueReference] {
 	c.Lock()
 	defer c.Unlock()
@@ -397,6 +479,7 @@ func (c *Cache) AddClusterQueue(ctx context.Context, cq *kueue.ClusterQueue) err
 			totalReserved:      make(resources.FlavorResourceQuantities),
 			admittedUsage:      make(resources.FlavorResourceQuantities),
 		}
+		qImpl.updateLimits(&q)
 		qImpl.resetFlavorsAndResources(cqImpl.resourceNode.Usage, cqImpl.AdmittedUsage)
 		cqImpl.localQueues[qKey] = qImpl
 	}
@@ -460,7 +543,7 @@ func (c *Cache) AddOrUpdateCohort(apiCohort *kueuealpha.Cohort) error {
 	cohort := c.hm.Cohort(cohortName)
 	oldParent := cohort.Parent()
 	c.hm.UpdateCohortEdge(cohortName, apiCohort.Spec.Parent)
-	return cohort.updateCohort(apiCohort, oldParent)
+	return cohort.updateCohort(apiCohort, oldParent, newOvercommitRatios(c.resourceFlavors))
 }
 
 func (c *Cache) DeleteCohort(cohortName kueue.CohortReference) {
@@ -497,11 +580,16 @@ func (c *Cache) DeleteLocalQueue(q *kueue.LocalQueue) {
 }
 
 func (c *Cache) UpdateLocalQueue(oldQ, newQ *kueue.LocalQueue) error {
+	c.Lock()
+	defer c.Unlock()
 	if oldQ.Spec.ClusterQueue == newQ.Spec.ClusterQueue {
+		if cq := c.hm.ClusterQueue(newQ.Spec.ClusterQueue); cq != nil {
+			if qImpl, ok := cq.localQueues[queueKey(newQ)]; ok {
+				qImpl.updateLimits(newQ)
+			}
+		}
 		return nil
 	}
-	c.Lock()
-	defer c.Unlock()
 	cq := c.hm.ClusterQueue(oldQ.Spec.ClusterQueue)
 	if cq != nil {
 		cq.deleteLocalQueue(oldQ)
@@ -655,7 +743,20 @@ type ClusterQueueUsageStats struct {
 	ReservingWorkloads int
 	AdmittedResources  []kueue.FlavorUsage
 	AdmittedWorkloads  int
-	WeightedShare      int64
+	// BorrowedWorkloads is the number of workloads above the
+	// maxAdmittedWorkloads of the ClusterQueue. Nil when the number of
+	// workloads is not limited.
+	BorrowedWorkloads *int
+	WeightedShare     int64
+	// NodeCapacityQuotas are the nominal quotas derived from the capacity
+	// of the nodes of the flavors.
+	NodeCapacityQuotas []kueue.FlavorNodeCapacityQuota
+	// OvercommittedQuotas are the physical and effective nominal quotas
+	// of the resources with an overcommit ratio.
+	OvercommittedQuotas []kueue.FlavorOvercommittedQuota
+	// FederatedQuota are the nominal quotas computed from the quotas of
+	// the MultiKueue worker clusters.
+	FederatedQuota *kueue.FederatedQuota
 }
 
 // Usage reports the reserved and admitted resources and number of workloads holding them in the ClusterQueue.
@@ -675,14 +776,83 @@ func (c *Cache) Usage(cqObj *kueue.ClusterQueue) (*ClusterQueueUsageStats, error
 		AdmittedWorkloads:  cq.admittedWorkloadsCount,
 	}
 
+	if cq.limitsWorkloadCount() {
+		var borrowed int64
+		// Enforce `borrowed=0` if the clusterQueue doesn't belong to a cohort.
+		if cq.HasParent() {
+			borrowed = max(0, cq.resourceNode.Usage[WorkloadsFlavorResource]-cq.resourceNode.Quotas[WorkloadsFlavorResource].Nominal)
+		}
+		stats.BorrowedWorkloads = ptr.To(int(borrowed))
+	}
+
 	if c.fairSharingEnabled {
 		weightedShare, _ := dominantResourceShare(cq, nil)
 		stats.WeightedShare = int64(weightedShare)
 	}
 
+	stats.NodeCapacityQuotas = getNodeCapacityQuotas(cq)
+	stats.OvercommittedQuotas = getOvercommittedQuotas(cq, newOvercommitRatios(c.resourceFlavors))
+	stats.FederatedQuota = getFederatedQuota(cq)
+
 	return stats, nil
 }
 
+func getNodeCapacityQuotas(cq *clusterQueue) []kueue.FlavorNodeCapacityQuota {
+	var out []kueue.FlavorNodeCapacityQuota
+	for _, rg := range cq.ResourceGroups {
+		for _, fName := range rg.Flavors {
+			capacity, found := cq.tasCache.NodeCapacity(fName)
+			if !found || !cq.usesNodeCapacity(fName) {
+				continue
+			}
+			flvQuota := kueue.FlavorNodeCapacityQuota{
+				Name:  fName,
+				Nodes: int32(capacity.Nodes),
+			}
+			for _, rName := range sets.List(rg.CoveredResources) {
+				fr := resources.FlavorResource{Flavor: fName, Resource: rName}
+				if _, found := cq.nodeCapacityQuotas[fr]; !found {
+					continue
+				}
+				flvQuota.Resources = append(flvQuota.Resources, kueue.ResourceNodeCapacityQuota{
+					Name:         rName,
+					Allocatable:  resources.ResourceQuantity(rName, capacity.Allocatable[rName]),
+					NominalQuota: resources.ResourceQuantity(rName, cq.resourceNode.Quotas[fr].Nominal),
+				})
+			}
+			out = append(out, flvQuota)
+		}
+	}
+	return out
+}
+
+func getOvercommittedQuotas(cq *clusterQueue, ratios overcommitRatios) []kueue.FlavorOvercommittedQuota {
+	var out []kueue.FlavorOvercommittedQuota
+	for _, rg := range cq.ResourceGroups {
+		for _, fName := range rg.Flavors {
+			var flvQuota *kueue.FlavorOvercommittedQuota
+			for _, rName := range sets.List(rg.CoveredResources) {
+				fr := resources.FlavorResource{Flavor: fName, Resource: rName}
+				ratio, found := ratios[fr]
+				if !found {
+					continue
+				}
+				if flvQuota == nil {
+					out = append(out, kueue.FlavorOvercommittedQuota{Name: fName})
+					flvQuota = &out[len(out)-1]
+				}
+				flvQuota.Resources = append(flvQuota.Resources, kueue.ResourceOvercommittedQuota{
+					Name:                  rName,
+					Ratio:                 *resource.NewMilliQuantity(ratio, resource.DecimalSI),
+					NominalQuota:          resources.ResourceQuantity(rName, cq.resourceNode.physicalQuotas()[fr].Nominal),
+					EffectiveNominalQuota: resources.ResourceQuantity(rName, cq.resourceNode.Quotas[fr].Nominal),
+				})
+			}
+		}
+	}
+	return out
+}
+
 type CohortUsageStats struct {
 	WeightedShare int64
 }
@@ -768,6 +938,37 @@ func getUsage(frq resources.FlavorResourceQuantities, cq *clusterQueue) []kueue.
 	return usage
 }
 
+// ClusterQueueUsersUsage returns the usage of each user which submitted
+// workloads reserving quota in the ClusterQueue.
+func (c *Cache) ClusterQueueUsersUsage(name kueue.ClusterQueueReference) map[string]UserUsage {
+	c.RLock()
+	defer c.RUnlock()
+
+	cq := c.hm.ClusterQueue(name)
+	if cq == nil {
+		return nil
+	}
+	return cq.usersUsage.clone()
+}
+
+// LocalQueueUsersUsage returns the usage of each user which submitted
+// workloads reserving quota through the LocalQueue, identified by its
+// (namespace/name) key.
+func (c *Cache) LocalQueueUsersUsage(cqName kueue.ClusterQueueReference, lqKey string) map[string]UserUsage {
+	c.RLock()
+	defer c.RUnlock()
+
+	cq := c.hm.ClusterQueue(cqName)
+	if cq == nil {
+		return nil
+	}
+	lq, found := cq.localQueues[lqKey]
+	if !found {
+		return nil
+	}
+	return lq.usersUsage.clone()
+}
+
 type LocalQueueUsageStats struct {
 	ReservedResources  []kueue.LocalQueueFlavorUsage
 	ReservingWorkloads int
@@ -826,15 +1027,15 @@ func (c *Cache) LocalQueueUsage(qObj *kueue.LocalQueue) (*LocalQueueUsageStats,
 	}
 
 	return &LocalQueueUsageStats{
-		ReservedResources:  filterLocalQueueUsage(qImpl.totalReserved, cqImpl.ResourceGroups),
+		ReservedResources:  filterLocalQueueUsage(qImpl.totalReserved, qImpl.limits, cqImpl.ResourceGroups),
 		ReservingWorkloads: qImpl.reservingWorkloads,
-		AdmittedResources:  filterLocalQueueUsage(qImpl.admittedUsage, cqImpl.ResourceGroups),
+		AdmittedResources:  filterLocalQueueUsage(qImpl.admittedUsage, nil, cqImpl.ResourceGroups),
 		AdmittedWorkloads:  qImpl.admittedWorkloads,
 		Flavors:            flavors,
 	}, nil
 }
 
-func filterLocalQueueUsage(orig resources.FlavorResourceQuantities, resourceGroups []ResourceGroup) []kueue.LocalQueueFlavorUsage {
+func filterLocalQueueUsage(orig, limits resources.FlavorResourceQuantities, resourceGroups []ResourceGroup) []kueue.LocalQueueFlavorUsage {
 	qFlvUsages := make([]kueue.LocalQueueFlavorUsage, 0, len(orig))
 	for _, rg := range resourceGroups {
 		for _, fName := range rg.Flavors {
@@ -844,10 +1045,14 @@ func filterLocalQueueUsage(orig resources.FlavorResourceQuantities, resourceGrou
 			}
 			for rName := range rg.CoveredResources {
 				fr := resources.FlavorResource{Flavor: fName, Resource: rName}
-				outFlvUsage.Resources = append(outFlvUsage.Resources, kueue.LocalQueueResourceUsage{
+				resUsage := kueue.LocalQueueResourceUsage{
 					Name:  rName,
 					Total: resources.ResourceQuantity(rName, orig[fr]),
-				})
+				}
+				if limit, found := limits[fr]; found {
+					resUsage.Limit = ptr.To(resources.ResourceQuantity(rName, limit))
+				}
+				outFlvUsage.Resources = append(outFlvUsage.Resources, resUsage)
 			}
 			// The resourceUsages should be in a stable order to avoid endless creation of update events.
 			sort.Slice(outFlvUsage.Resources, func(i, j int) bool {
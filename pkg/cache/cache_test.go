@@ -2088,6 +2088,7 @@ func TestLocalQueueUsage(t *testing.T) {
 	cases := map[string]struct {
 		cq             *kueue.ClusterQueue
 		wls            []kueue.Workload
+		localQueue     *kueue.LocalQueue
 		wantUsage      []kueue.LocalQueueFlavorUsage
 		inAdmissibleWl sets.Set[string]
 	}{
@@ -2257,9 +2258,67 @@ func TestLocalQueueUsage(t *testing.T) {
 				},
 			},
 		},
+		"limits are reported": {
+			cq: &cq,
+			localQueue: utiltesting.MakeLocalQueue("test", "ns1").
+				ClusterQueue("foo").
+				Limit("default", corev1.ResourceCPU, "6").
+				Limit("model-a", "example.com/gpu", "2").
+				Obj(),
+			wls: []kueue.Workload{
+				*utiltesting.MakeWorkload("one", "ns1").
+					Queue("test").
+					Request(corev1.ResourceCPU, "5").
+					ReserveQuota(
+						utiltesting.MakeAdmission("foo").
+							Assignment(corev1.ResourceCPU, "default", "5000m").Obj(),
+					).
+					Obj(),
+			},
+			wantUsage: []kueue.LocalQueueFlavorUsage{
+				{
+					Name: "default",
+					Resources: []kueue.LocalQueueResourceUsage{
+						{
+							Name:  corev1.ResourceCPU,
+							Total: resource.MustParse("5"),
+							Limit: ptr.To(resource.MustParse("6")),
+						},
+					},
+				},
+				{
+					Name: "model-a",
+					Resources: []kueue.LocalQueueResourceUsage{
+						{
+							Name:  "example.com/gpu",
+							Total: resource.MustParse("0"),
+							Limit: ptr.To(resource.MustParse("2")),
+						},
+					},
+				},
+				{
+					Name: "model-b",
+					Resources: []kueue.LocalQueueResourceUsage{
+						{
+							Name:  "example.com/gpu",
+							Total: resource.MustParse("0"),
+						},
+					},
+				},
+				{
+					Name: "interconnect-a",
+					Resources: []kueue.LocalQueueResourceUsage{
+						{Name: "example.com/vf-0"},
+						{Name: "example.com/vf-1"},
+						{Name: "example.com/vf-2"},
+					},
+				},
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.LocalQueueLimits, true)
 			cache := New(utiltesting.NewFakeClient())
 			ctx := t.Context()
 			if tc.cq != nil {
@@ -2267,7 +2326,11 @@ func TestLocalQueueUsage(t *testing.T) {
 					t.Fatalf("Adding ClusterQueue: %v", err)
 				}
 			}
-			if err := cache.AddLocalQueue(&localQueue); err != nil {
+			lq := &localQueue
+			if tc.localQueue != nil {
+				lq = tc.localQueue
+			}
+			if err := cache.AddLocalQueue(lq); err != nil {
 				t.Fatalf("Adding LocalQueue: %v", err)
 			}
 			for _, w := range tc.wls {
@@ -2275,7 +2338,7 @@ func TestLocalQueueUsage(t *testing.T) {
 					t.Fatalf("Workload %s was not added", workload.Key(&w))
 				}
 			}
-			gotUsage, err := cache.LocalQueueUsage(&localQueue)
+			gotUsage, err := cache.LocalQueueUsage(lq)
 			if err != nil {
 				t.Fatalf("Couldn't get usage for the queue: %v", err)
 			}
@@ -3900,3 +3963,302 @@ func TestClusterQueueAncestors(t *testing.T) {
 		})
 	}
 }
+
+func TestUsersUsage(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.PerUserLimits, true)
+	ctx, _ := utiltesting.ContextWithLog(t)
+	cache := New(utiltesting.NewFakeClient())
+	cache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+		PerUserLimits(kueue.PerUserLimits{MaxAdmittedWorkloads: ptr.To[int32](2)}).
+		Obj()
+	if err := cache.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Adding ClusterQueue: %v", err)
+	}
+	lqA := utiltesting.MakeLocalQueue("lq-a", "ns").ClusterQueue("cq").
+		PerUserLimits(kueue.PerUserLimits{Resources: []kueue.PerUserResourceLimit{
+			{Name: corev1.ResourceCPU, Max: resource.MustParse("4")},
+		}}).
+		Obj()
+	lqB := utiltesting.MakeLocalQueue("lq-b", "ns").ClusterQueue("cq").Obj()
+	for _, lq := range []*kueue.LocalQueue{lqA, lqB} {
+		if err := cache.AddLocalQueue(lq); err != nil {
+			t.Fatalf("Adding LocalQueue: %v", err)
+		}
+	}
+	admission := utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "2").Obj()
+	workloads := []*kueue.Workload{
+		utiltesting.MakeWorkload("alice-1", "ns").Queue("lq-a").SubmittedBy("alice").
+			Request(corev1.ResourceCPU, "2").ReserveQuota(admission).Obj(),
+		utiltesting.MakeWorkload("alice-2", "ns").Queue("lq-b").SubmittedBy("alice").
+			Request(corev1.ResourceCPU, "2").ReserveQuota(admission).Obj(),
+		utiltesting.MakeWorkload("bob-1", "ns").Queue("lq-a").SubmittedBy("bob").
+			Request(corev1.ResourceCPU, "2").ReserveQuota(admission).Obj(),
+	}
+	for _, wl := range workloads {
+		cache.AddOrUpdateWorkload(wl)
+	}
+	if err := cache.DeleteWorkload(workloads[2]); err != nil {
+		t.Fatalf("Deleting workload: %v", err)
+	}
+
+	wantCQUsage := map[string]UserUsage{
+		"alice": {Workloads: 2, Resources: resources.Requests{corev1.ResourceCPU: 4_000, corev1.ResourcePods: 2}},
+	}
+	if diff := cmp.Diff(wantCQUsage, cache.ClusterQueueUsersUsage("cq")); diff != "" {
+		t.Errorf("Unexpected ClusterQueue users usage (-want,+got):\n%s", diff)
+	}
+	wantLQUsage := map[string]UserUsage{
+		"alice": {Workloads: 1, Resources: resources.Requests{corev1.ResourceCPU: 2_000, corev1.ResourcePods: 1}},
+	}
+	if diff := cmp.Diff(wantLQUsage, cache.LocalQueueUsersUsage("cq", "ns/lq-a")); diff != "" {
+		t.Errorf("Unexpected LocalQueue users usage (-want,+got):\n%s", diff)
+	}
+
+	snapshot, err := cache.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("Building snapshot: %v", err)
+	}
+	cqSnapshot := snapshot.ClusterQueue("cq")
+	if limits, usage := cqSnapshot.ClusterQueueUserLimits("alice"); limits == nil || usage.Workloads != 2 {
+		t.Errorf("Unexpected ClusterQueue limits %v and usage %v for alice", limits, usage)
+	}
+	if limits, usage := cqSnapshot.LocalQueueUserLimits("ns/lq-a", "alice"); limits == nil || usage.Resources[corev1.ResourceCPU] != 2_000 {
+		t.Errorf("Unexpected LocalQueue limits %v and usage %v for alice", limits, usage)
+	}
+	if limits, _ := cqSnapshot.LocalQueueUserLimits("ns/lq-b", "alice"); limits != nil {
+		t.Errorf("Unexpected limits for LocalQueue without perUserLimits: %v", limits)
+	}
+}
+
+func TestNodeCapacityQuotas(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.NodeCapacityQuotas, true)
+	cache := New(utiltesting.NewFakeClient())
+	cache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+			ResourceQuotaWrapper(corev1.ResourceCPU).NominalQuota("10").NodeCapacityPercentage(50).Append().
+			Resource(corev1.ResourceMemory, "1Gi").
+			Obj()).
+		Obj()
+	if err := cache.AddClusterQueue(context.Background(), cq); err != nil {
+		t.Fatalf("Adding ClusterQueue: %v", err)
+	}
+	cpu := resources.FlavorResource{Flavor: "default", Resource: corev1.ResourceCPU}
+	memory := resources.FlavorResource{Flavor: "default", Resource: corev1.ResourceMemory}
+	nominalQuota := func(fr resources.FlavorResource) int64 {
+		cache.RLock()
+		defer cache.RUnlock()
+		return cache.hm.ClusterQueue("cq").resourceNode.Quotas[fr].Nominal
+	}
+	if got := nominalQuota(cpu); got != 10_000 {
+		t.Errorf("Unexpected nominal quota before the capacity is known: %d", got)
+	}
+
+	capacity := &NodeCapacity{
+		Nodes:       3,
+		Allocatable: resources.Requests{corev1.ResourceCPU: 24_000, corev1.ResourceMemory: 96 * 1024 * 1024 * 1024},
+	}
+	if got := cache.UpdateNodeCapacity("default", capacity); !got.Has("cq") {
+		t.Errorf("Expected the ClusterQueue to be updated, got %v", got)
+	}
+	if got := nominalQuota(cpu); got != 12_000 {
+		t.Errorf("Unexpected nominal quota derived from the capacity: %d", got)
+	}
+	if got := nominalQuota(memory); got != 1024*1024*1024 {
+		t.Errorf("Unexpected nominal quota for a resource not derived from the capacity: %d", got)
+	}
+	if got := cache.UpdateNodeCapacity("default", capacity); got.Len() != 0 {
+		t.Errorf("Unexpected updated ClusterQueues when the capacity didn't change: %v", got)
+	}
+	stats, err := cache.Usage(cq)
+	if err != nil {
+		t.Fatalf("Getting usage: %v", err)
+	}
+	wantNodeCapacityQuotas := []kueue.FlavorNodeCapacityQuota{{
+		Name:  "default",
+		Nodes: 3,
+		Resources: []kueue.ResourceNodeCapacityQuota{{
+			Name:         corev1.ResourceCPU,
+			Allocatable:  resource.MustParse("24"),
+			NominalQuota: resource.MustParse("12"),
+		}},
+	}}
+	if diff := cmp.Diff(wantNodeCapacityQuotas, stats.NodeCapacityQuotas); diff != "" {
+		t.Errorf("Unexpected node capacity quotas (-want,+got):\n%s", diff)
+	}
+
+	if got := cache.UpdateNodeCapacity("default", nil); !got.Has("cq") {
+		t.Errorf("Expected the ClusterQueue to be updated, got %v", got)
+	}
+	if got := nominalQuota(cpu); got != 10_000 {
+		t.Errorf("Unexpected nominal quota after the capacity is removed: %d", got)
+	}
+}
+
+func TestOvercommit(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.FlavorOvercommit, true)
+	cache := New(utiltesting.NewFakeClient())
+	cache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Overcommit(corev1.ResourceCPU, "2.5").Obj())
+	if err := cache.AddOrUpdateCohort(utiltesting.MakeCohort("cohort").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4").Obj()).
+		Obj()); err != nil {
+		t.Fatalf("Adding Cohort: %v", err)
+	}
+	cq := utiltesting.MakeClusterQueue("cq").
+		Cohort("cohort").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+			Resource(corev1.ResourceCPU, "10").
+			Resource(corev1.ResourceMemory, "1Gi").
+			Obj()).
+		Obj()
+	if err := cache.AddClusterQueue(context.Background(), cq); err != nil {
+		t.Fatalf("Adding ClusterQueue: %v", err)
+	}
+	cpu := resources.FlavorResource{Flavor: "default", Resource: corev1.ResourceCPU}
+	memory := resources.FlavorResource{Flavor: "default", Resource: corev1.ResourceMemory}
+	nominalQuota := func(fr resources.FlavorResource) (int64, int64) {
+		cache.RLock()
+		defer cache.RUnlock()
+		return cache.hm.ClusterQueue("cq").resourceNode.Quotas[fr].Nominal,
+			cache.hm.Cohort("cohort").resourceNode.SubtreeQuota[fr]
+	}
+	if got, gotCohort := nominalQuota(cpu); got != 25_000 || gotCohort != 35_000 {
+		t.Errorf("Unexpected overcommitted quotas, ClusterQueue: %d, Cohort subtree: %d", got, gotCohort)
+	}
+	if got, _ := nominalQuota(memory); got != 1024*1024*1024 {
+		t.Errorf("Unexpected nominal quota for a resource without overcommit: %d", got)
+	}
+	stats, err := cache.Usage(cq)
+	if err != nil {
+		t.Fatalf("Getting usage: %v", err)
+	}
+	wantOvercommittedQuotas := []kueue.FlavorOvercommittedQuota{{
+		Name: "default",
+		Resources: []kueue.ResourceOvercommittedQuota{{
+			Name:                  corev1.ResourceCPU,
+			Ratio:                 resource.MustParse("2.5"),
+			NominalQuota:          resource.MustParse("10"),
+			EffectiveNominalQuota: resource.MustParse("25"),
+		}},
+	}}
+	if diff := cmp.Diff(wantOvercommittedQuotas, stats.OvercommittedQuotas); diff != "" {
+		t.Errorf("Unexpected overcommitted quotas (-want,+got):\n%s", diff)
+	}
+
+	if got := cache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj()); !got.Has("cq") {
+		t.Errorf("Expected the ClusterQueue to be updated, got %v", got)
+	}
+	if got, gotCohort := nominalQuota(cpu); got != 10_000 || gotCohort != 14_000 {
+		t.Errorf("Unexpected quotas after the overcommit is removed, ClusterQueue: %d, Cohort subtree: %d", got, gotCohort)
+	}
+}
+
+func TestFederatedQuota(t *testing.T) {
+	cache := New(utiltesting.NewFakeClient())
+	cache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+	cpu := resources.FlavorResource{Flavor: "default", Resource: corev1.ResourceCPU}
+	memory := resources.FlavorResource{Flavor: "default", Resource: corev1.ResourceMemory}
+	quota := &FederatedQuota{
+		Policy:   kueue.MultiKueueQuotaPolicySum,
+		Clusters: []string{"worker1", "worker2"},
+		Nominal:  resources.FlavorResourceQuantities{cpu: 24_000},
+	}
+	// The quota is known before the ClusterQueue is added.
+	if cache.UpdateFederatedQuota("cq", quota) {
+		t.Errorf("Unexpected update of a missing ClusterQueue")
+	}
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+			Resource(corev1.ResourceCPU, "10").
+			Resource(corev1.ResourceMemory, "1Gi").
+			Obj()).
+		Obj()
+	if err := cache.AddClusterQueue(context.Background(), cq); err != nil {
+		t.Fatalf("Adding ClusterQueue: %v", err)
+	}
+	nominalQuota := func(fr resources.FlavorResource) int64 {
+		cache.RLock()
+		defer cache.RUnlock()
+		return cache.hm.ClusterQueue("cq").resourceNode.Quotas[fr].Nominal
+	}
+	if got := nominalQuota(cpu); got != 24_000 {
+		t.Errorf("Unexpected federated nominal quota: %d", got)
+	}
+	if got := nominalQuota(memory); got != 0 {
+		t.Errorf("Unexpected nominal quota for a resource not defined by the worker clusters: %d", got)
+	}
+
+	quota = &FederatedQuota{
+		Policy:   kueue.MultiKueueQuotaPolicySum,
+		Clusters: []string{"worker1"},
+		Nominal:  resources.FlavorResourceQuantities{cpu: 8_000},
+	}
+	if !cache.UpdateFederatedQuota("cq", quota) {
+		t.Errorf("Expected the ClusterQueue to be updated when a worker cluster leaves")
+	}
+	if cache.UpdateFederatedQuota("cq", quota) {
+		t.Errorf("Unexpected update when the quota didn't change")
+	}
+	if got := nominalQuota(cpu); got != 8_000 {
+		t.Errorf("Unexpected federated nominal quota after a worker cluster left: %d", got)
+	}
+	stats, err := cache.Usage(cq)
+	if err != nil {
+		t.Fatalf("Getting usage: %v", err)
+	}
+	wantFederatedQuota := &kueue.FederatedQuota{
+		Policy:   kueue.MultiKueueQuotaPolicySum,
+		Clusters: []string{"worker1"},
+		Flavors: []kueue.FlavorFederatedQuota{{
+			Name: "default",
+			Resources: []kueue.ResourceFederatedQuota{
+				{
+					Name:         corev1.ResourceCPU,
+					NominalQuota: resource.MustParse("8"),
+				},
+				{
+					Name:         corev1.ResourceMemory,
+					NominalQuota: resource.MustParse("0"),
+				},
+			},
+		}},
+	}
+	if diff := cmp.Diff(wantFederatedQuota, stats.FederatedQuota); diff != "" {
+		t.Errorf("Unexpected federated quota (-want,+got):\n%s", diff)
+	}
+
+	// No worker cluster is reachable.
+	if !cache.UpdateFederatedQuota("cq", &FederatedQuota{Policy: kueue.MultiKueueQuotaPolicySum}) {
+		t.Errorf("Expected the ClusterQueue to be updated when no worker cluster is reachable")
+	}
+	if got := nominalQuota(cpu); got != 0 {
+		t.Errorf("Unexpected federated nominal quota when no worker cluster is reachable: %d", got)
+	}
+	if !cache.UpdateFederatedQuota("cq", quota) {
+		t.Errorf("Expected the ClusterQueue to be updated when a worker cluster reconnects")
+	}
+
+	// The spec changes keep the federated quota.
+	cq.Spec.ResourceGroups[0].Flavors[0].Resources[1].NominalQuota = resource.MustParse("2Gi")
+	if err := cache.UpdateClusterQueue(cq); err != nil {
+		t.Fatalf("Updating ClusterQueue: %v", err)
+	}
+	if got := nominalQuota(cpu); got != 8_000 {
+		t.Errorf("Unexpected federated nominal quota after a spec update: %d", got)
+	}
+	if got := nominalQuota(memory); got != 0 {
+		t.Errorf("Unexpected nominal quota for a resource not defined by the worker clusters after a spec update: %d", got)
+	}
+
+	if !cache.UpdateFederatedQuota("cq", nil) {
+		t.Errorf("Expected the ClusterQueue to be updated when the federated quota is removed")
+	}
+	if got := nominalQuota(cpu); got != 10_000 {
+		t.Errorf("Unexpected nominal quota after the federated quota is removed: %d", got)
+	}
+	if got := nominalQuota(memory); got != 2*1024*1024*1024 {
+		t.Errorf("Unexpected nominal quota for a resource not defined by the worker clusters after the federated quota is removed: %d", got)
+	}
+}
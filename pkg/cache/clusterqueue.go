@@ -85,6 +85,22 @@ type clusterQueue struct {
 	hierarchy.ClusterQueue[*cohort]
 
 	tasCache *TASCache
+
+	// userLimits are the limits on the usage of each user in the
+	// ClusterQueue. Nil when not limited.
+	userLimits *UserLimits
+	usersUsage usersUsage
+
+	// nodeCapacityQuotas holds the FlavorResources whose nominal quota is
+	// derived from the capacity of the nodes of the flavor.
+	nodeCapacityQuotas map[resources.FlavorResource]nodeCapacityQuota
+
+	// specQuotas are the ResourceQuotas in the spec, before deriving the
+	// nominal quotas from the node capacity or the worker clusters.
+	specQuotas map[resources.FlavorResource]ResourceQuota
+	// federatedQuota holds the nominal quotas computed from the quotas of
+	// the MultiKueue worker clusters. Nil when not federated.
+	federatedQuota *FederatedQuota
 }
 
 func (c *clusterQueue) GetName() kueue.ClusterQueueReference {
@@ -113,7 +129,7 @@ var defaultPreemption = kueue.ClusterQueuePreemption{
 var defaultFlavorFungibility = kueue.FlavorFungibility{WhenCanBorrow: kueue.Borrow, WhenCanPreempt: kueue.TryNextFlavor}
 
 func (c *clusterQueue) updateClusterQueue(in *kueue.ClusterQueue, resourceFlavors map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor, admissionChecks map[kueue.AdmissionCheckReference]AdmissionCheck, oldParent *cohort) error {
-	if c.updateQuotasAndResourceGroups(in.Spec.ResourceGroups) || oldParent != c.Parent() {
+	if c.updateQuotasAndResourceGroups(&in.Spec, newOvercommitRatios(resourceFlavors)) || oldParent != c.Parent() {
 		if oldParent != nil && oldParent != c.Parent() {
 			// ignore error when old Cohort has cycle.
 			_ = updateCohortTreeResources(oldParent)
@@ -162,6 +178,7 @@ func (c *clusterQueue) updateClusterQueue(in *kueue.ClusterQueue, resourceFlavor
 	}
 
 	c.FairWeight = parseFairWeight(in.Spec.FairSharing)
+	c.userLimits = newUserLimits(in.Spec.PerUserLimits)
 
 	return nil
 }
@@ -182,16 +199,92 @@ func createdResourceGroups(kueueRgs []kueue.ResourceGroup) []ResourceGroup {
 
 // updateQuotasAndResourceGroups updates Quotas and ResourceGroups.
 // It returns true if any changes were made.
-func (c *clusterQueue) updateQuotasAndResourceGroups(in []kueue.ResourceGroup) bool {
+func (c *clusterQueue) updateQuotasAndResourceGroups(in *kueue.ClusterQueueSpec, ratios overcommitRatios) bool {
 	oldRG := c.ResourceGroups
-	oldQuotas := c.resourceNode.Quotas
-	c.ResourceGroups = createdResourceGroups(in)
-	c.resourceNode.Quotas = createResourceQuotas(in)
+	c.ResourceGroups = createdResourceGroups(in.ResourceGroups)
+	quotas := createResourceQuotas(in.ResourceGroups)
+	addMaxAdmittedWorkloadsQuota(quotas, in.MaxAdmittedWorkloads)
+	c.nodeCapacityQuotas = createNodeCapacityQuotas(in.ResourceGroups)
+	c.specQuotas = quotas
+	quotasChanged := c.resourceNode.setQuotas(c.withFederatedQuota(c.withNodeCapacityQuotas(quotas)), ratios)
 
 	// Start at 1, for backwards compatibility.
 	return c.AllocatableResourceGeneration == 0 ||
 		!equality.Semantic.DeepEqual(oldRG, c.ResourceGroups) ||
-		!equality.Semantic.DeepEqual(oldQuotas, c.resourceNode.Quotas)
+		quotasChanged
+}
+
+// withNodeCapacityQuotas returns the quotas with the nominal quotas derived
+// from the capacity of the nodes of the flavors.
+func (c *clusterQueue) withNodeCapacityQuotas(quotas map[resources.FlavorResource]ResourceQuota) map[resources.FlavorResource]ResourceQuota {
+	var out map[resources.FlavorResource]ResourceQuota
+	for fr, ncq := range c.nodeCapacityQuotas {
+		nominal := ncq.nominal
+		if capacity, found := c.tasCache.NodeCapacity(fr.Flavor); found {
+			nominal = capacity.Allocatable[fr.Resource] * ncq.percentage / 100
+		}
+		quota := quotas[fr]
+		if quota.Nominal == nominal {
+			continue
+		}
+		// The quotas are shared with the snapshots, so they are copied
+		// before being modified.
+		if out == nil {
+			out = maps.Clone(quotas)
+		}
+		quota.Nominal = nominal
+		out[fr] = quota
+	}
+	if out == nil {
+		return quotas
+	}
+	return out
+}
+
+// withFederatedQuota returns the quotas with the nominal quotas computed
+// from the quotas of the MultiKueue worker clusters. The FlavorResources no
+// worker cluster reports, for instance when none of them is reachable, get
+// no nominal quota, so that the manager doesn't admit more than the worker
+// clusters can run.
+func (c *clusterQueue) withFederatedQuota(quotas map[resources.FlavorResource]ResourceQuota) map[resources.FlavorResource]ResourceQuota {
+	if c.federatedQuota == nil {
+		return quotas
+	}
+	var out map[resources.FlavorResource]ResourceQuota
+	for _, rg := range c.ResourceGroups {
+		for _, fName := range rg.Flavors {
+			for rName := range rg.CoveredResources {
+				fr := resources.FlavorResource{Flavor: fName, Resource: rName}
+				nominal := c.federatedQuota.Nominal[fr]
+				quota, found := quotas[fr]
+				if !found || quota.Nominal == nominal {
+					continue
+				}
+				// The quotas are shared with the snapshots, so they are copied
+				// before being modified.
+				if out == nil {
+					out = maps.Clone(quotas)
+				}
+				quota.Nominal = nominal
+				out[fr] = quota
+			}
+		}
+	}
+	if out == nil {
+		return quotas
+	}
+	return out
+}
+
+// usesNodeCapacity returns whether the ClusterQueue derives nominal quotas
+// from the capacity of the nodes of the flavor.
+func (c *clusterQueue) usesNodeCapacity(flavor kueue.ResourceFlavorReference) bool {
+	for fr := range c.nodeCapacityQuotas {
+		if fr.Flavor == flavor {
+			return true
+		}
+	}
+	return false
 }
 
 func (c *clusterQueue) updateQueueStatus() {
@@ -463,6 +556,14 @@ func (c *clusterQueue) updateWorkloadUsage(wi *workload.Info, m int64) {
 			removeUsage(c, fr, q)
 		}
 	}
+	if c.limitsWorkloadCount() {
+		if m == 1 {
+			addUsage(c, WorkloadsFlavorResource, 1)
+		}
+		if m == -1 {
+			removeUsage(c, WorkloadsFlavorResource, 1)
+		}
+	}
 	if features.Enabled(features.TopologyAwareScheduling) && wi.IsUsingTAS() {
 		for tasFlavor, tasUsage := range wi.TASUsage() {
 			if tasFlvCache := c.tasFlavorCache(tasFlavor); tasFlvCache != nil {
@@ -479,10 +580,17 @@ func (c *clusterQueue) updateWorkloadUsage(wi *workload.Info, m int64) {
 		updateFlavorUsage(frUsage, c.AdmittedUsage, m)
 		c.admittedWorkloadsCount += int(m)
 	}
+	trackUsers := features.Enabled(features.PerUserLimits)
+	if trackUsers {
+		c.usersUsage.update(wi, m)
+	}
 	qKey := workload.QueueKey(wi.Obj)
 	if lq, ok := c.localQueues[qKey]; ok {
 		updateFlavorUsage(frUsage, lq.totalReserved, m)
 		lq.reservingWorkloads += int(m)
+		if trackUsers {
+			lq.usersUsage.update(wi, m)
+		}
 		if admitted {
 			updateFlavorUsage(frUsage, lq.admittedUsage, m)
 			lq.admittedWorkloads += int(m)
@@ -493,6 +601,23 @@ func (c *clusterQueue) updateWorkloadUsage(wi *workload.Info, m int64) {
 	}
 }
 
+// limitsWorkloadCount returns whether the ClusterQueue, or one of its
+// Cohorts, sets maxAdmittedWorkloads.
+func (c *clusterQueue) limitsWorkloadCount() bool {
+	if _, found := c.resourceNode.Quotas[WorkloadsFlavorResource]; found {
+		return true
+	}
+	if !c.HasParent() || hierarchy.HasCycle(c.Parent()) {
+		return false
+	}
+	for cohort := range c.Parent().PathSelfToRoot() {
+		if _, found := cohort.resourceNode.Quotas[WorkloadsFlavorResource]; found {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *clusterQueue) tasFlavorCache(flvName kueue.ResourceFlavorReference) *TASFlavorCache {
 	if !features.Enabled(features.TopologyAwareScheduling) {
 		return nil
@@ -520,13 +645,18 @@ func (c *clusterQueue) addLocalQueue(q *kueue.LocalQueue) error {
 		key:                qKey,
 		reservingWorkloads: 0,
 		totalReserved:      make(resources.FlavorResourceQuantities),
+		usersUsage:         make(usersUsage),
 	}
+	qImpl.updateLimits(q)
 	qImpl.resetFlavorsAndResources(c.resourceNode.Usage, c.AdmittedUsage)
 	for _, wl := range c.Workloads {
 		if workloadBelongsToLocalQueue(wl.Obj, q) {
 			frq := wl.FlavorResourceUsage()
 			updateFlavorUsage(frq, qImpl.totalReserved, 1)
 			qImpl.reservingWorkloads++
+			if features.Enabled(features.PerUserLimits) {
+				qImpl.usersUsage.update(wl, 1)
+			}
 			if workload.IsAdmitted(wl.Obj) {
 				updateFlavorUsage(frq, qImpl.admittedUsage, 1)
 				qImpl.admittedWorkloads++
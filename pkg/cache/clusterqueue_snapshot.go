@@ -17,6 +17,7 @@ limitations under the License.
 package cache
 
 import (
+	"fmt"
 	"iter"
 
 	corev1 "k8s.io/api/core/v1"
@@ -56,6 +57,16 @@ type ClusterQueueSnapshot struct {
 
 	TASFlavors map[kueue.ResourceFlavorReference]*TASFlavorSnapshot
 	tasOnly    bool
+
+	// LocalQueues holds the LocalQueues with limits or maxAdmittedWorkloads,
+	// by (namespace/name).
+	LocalQueues map[string]*LocalQueueSnapshot
+
+	// UserLimits are the limits on the usage of each user in the
+	// ClusterQueue, and UsersUsage the usage by user. Both are nil when
+	// the ClusterQueue doesn't limit the usage of users.
+	UserLimits *UserLimits
+	UsersUsage map[string]UserUsage
 }
 
 // RGByResource returns the ResourceGroup which contains capacity
@@ -75,7 +86,7 @@ func (c *ClusterQueueSnapshot) RGByResource(resource corev1.ResourceName) *Resou
 func (c *ClusterQueueSnapshot) SimulateWorkloadRemoval(workloads []*workload.Info) func() {
 	usage := make([]workload.Usage, 0, len(workloads))
 	for _, w := range workloads {
-		usage = append(usage, w.Usage())
+		usage = append(usage, c.WorkloadUsage(w))
 	}
 	for _, u := range usage {
 		c.RemoveUsage(u)
@@ -149,6 +160,75 @@ func (c *ClusterQueueSnapshot) Fits(usage workload.Usage) bool {
 	return true
 }
 
+// LocalQueueAvailable returns how much more of the FlavorResource the
+// LocalQueue can reserve, and whether the LocalQueue limits the FlavorResource.
+func (c *ClusterQueueSnapshot) LocalQueueAvailable(lqKey string, fr resources.FlavorResource) (int64, bool) {
+	lq, found := c.LocalQueues[lqKey]
+	if !found {
+		return 0, false
+	}
+	return lq.Available(fr)
+}
+
+// LocalQueueWorkloadsAvailable returns how many more workloads the
+// LocalQueue can admit, and whether the LocalQueue limits the number of
+// workloads.
+func (c *ClusterQueueSnapshot) LocalQueueWorkloadsAvailable(lqKey string) (int, bool) {
+	lq, found := c.LocalQueues[lqKey]
+	if !found {
+		return 0, false
+	}
+	return lq.WorkloadsAvailable()
+}
+
+// LocalQueueUserLimits returns the limits on the usage of each user in the
+// LocalQueue, and the usage of the user in the LocalQueue. The limits are
+// nil when the LocalQueue doesn't limit the usage of users.
+func (c *ClusterQueueSnapshot) LocalQueueUserLimits(lqKey, user string) (*UserLimits, UserUsage) {
+	lq, found := c.LocalQueues[lqKey]
+	if !found || lq.UserLimits == nil {
+		return nil, UserUsage{}
+	}
+	return lq.UserLimits, lq.UsersUsage[user]
+}
+
+// ClusterQueueUserLimits returns the limits on the usage of each user in
+// the ClusterQueue, and the usage of the user in the ClusterQueue. The
+// limits are nil when the ClusterQueue doesn't limit the usage of users.
+func (c *ClusterQueueSnapshot) ClusterQueueUserLimits(user string) (*UserLimits, UserUsage) {
+	if c.UserLimits == nil {
+		return nil, UserUsage{}
+	}
+	return c.UserLimits, c.UsersUsage[user]
+}
+
+// LimitsWorkloadCount returns whether the ClusterQueue, or one of its
+// Cohorts, sets maxAdmittedWorkloads. When it does, every workload uses
+// one unit of the WorkloadsFlavorResource.
+func (c *ClusterQueueSnapshot) LimitsWorkloadCount() bool {
+	if _, found := c.ResourceNode.Quotas[WorkloadsFlavorResource]; found {
+		return true
+	}
+	for cohort := range c.PathParentToRoot() {
+		if _, found := cohort.ResourceNode.Quotas[WorkloadsFlavorResource]; found {
+			return true
+		}
+	}
+	return false
+}
+
+// WorkloadUsage returns the usage of the workload, including its unit of the
+// WorkloadsFlavorResource when the workload belongs to the ClusterQueue and
+// the ClusterQueue limits the number of admitted workloads. The counts are
+// never lent, so workloads of other ClusterQueues don't use them.
+func (c *ClusterQueueSnapshot) WorkloadUsage(wl *workload.Info) workload.Usage {
+	usage := wl.Usage()
+	if wl.ClusterQueue == c.Name && c.LimitsWorkloadCount() {
+		usage.Quota[WorkloadsFlavorResource] = 1
+	}
+	return usage
+}
+
 func (c *ClusterQueueSnapshot) QuotaFor(fr resources.FlavorResource) ResourceQuota {
 	return c.ResourceNode.Quotas[fr]
 }
@@ -207,6 +287,12 @@ func (c *ClusterQueueSnapshot) FindTopologyAssignmentsForWorkload(
 	tasRequestsByFlavor WorkloadTASRequests,
 	simulateEmpty bool) TASAssignmentsResult {
 	result := make(TASAssignmentsResult)
+	if psName, groupName, ok := podSetGroupAcrossFlavors(tasRequestsByFlavor); ok {
+		result[psName] = tasPodSetAssignmentResult{
+			FailureReason: fmt.Sprintf("podset group %q must be assigned a single flavor for all podsets", groupName),
+		}
+		return result
+	}
 	for tasFlavor, flavorTASRequests := range tasRequestsByFlavor {
 		// We assume the `tasFlavor` is already in the snapshot as this was
 		// already checked earlier during flavor assignment, and the set of
@@ -220,6 +306,51 @@ func (c *ClusterQueueSnapshot) FindTopologyAssignmentsForWorkload(
 	return result
 }
 
+// podSetGroupAcrossFlavors returns a PodSet, and the name of its PodSet group,
+// when the PodSets of the group are assigned different flavors, as they
+// cannot be placed together then.
+func podSetGroupAcrossFlavors(tasRequestsByFlavor WorkloadTASRequests) (kueue.PodSetReference, string, bool) {
+	groupFlavors := make(map[string]kueue.ResourceFlavorReference)
+	for tasFlavor, flavorTASRequests := range tasRequestsByFlavor {
+		for _, tr := range flavorTASRequests {
+			groupName := podSetGroupName(tr.PodSet.TopologyRequest)
+			if groupName == "" {
+				continue
+			}
+			if flavor, found := groupFlavors[groupName]; found && flavor != tasFlavor {
+				return tr.PodSet.Name, groupName, true
+			}
+			groupFlavors[groupName] = tasFlavor
+		}
+	}
+	return "", "", false
+}
+
+// TASUsageDomain returns the topology domain, at the highest level requested
+// by the TAS requests, which contains all the TAS usage in the flavors of
+// the requests. It returns false when the usage spans multiple domains.
+// The returned domain is empty when there is no usage in the flavors.
+func (c *ClusterQueueSnapshot) TASUsageDomain(tasRequestsByFlavor WorkloadTASRequests, usage workload.TASUsage) (string, bool) {
+	var result string
+	for tasFlavor, flavorTASRequests := range tasRequestsByFlavor {
+		flavorUsage := usage[tasFlavor]
+		if len(flavorUsage) == 0 {
+			continue
+		}
+		tasFlavorCache := c.TASFlavors[tasFlavor]
+		if tasFlavorCache == nil {
+			return "", false
+		}
+		domains, ok := tasFlavorCache.UsageDomains(flavorTASRequests, flavorUsage)
+		if !ok || domains.Len() != 1 || result != "" {
+			return "", false
+		}
+		domainID, _ := domains.PopAny()
+		result = fmt.Sprintf("%s/%s", tasFlavor, domainID)
+	}
+	return result, true
+}
+
 func (c *ClusterQueueSnapshot) IsTASOnly() bool {
 	return c.tasOnly
 }
@@ -44,10 +44,12 @@ func newCohort(name kueue.CohortReference) *cohort {
 	}
 }
 
-func (c *cohort) updateCohort(apiCohort *kueuealpha.Cohort, oldParent *cohort) error {
+func (c *cohort) updateCohort(apiCohort *kueuealpha.Cohort, oldParent *cohort, ratios overcommitRatios) error {
 	c.FairWeight = parseFairWeight(apiCohort.Spec.FairSharing)
 
-	c.resourceNode.Quotas = createResourceQuotas(apiCohort.Spec.ResourceGroups)
+	quotas := createResourceQuotas(apiCohort.Spec.ResourceGroups)
+	addMaxAdmittedWorkloadsQuota(quotas, apiCohort.Spec.MaxAdmittedWorkloads)
+	c.resourceNode.setQuotas(quotas, ratios)
 	if oldParent != nil && oldParent != c.Parent() {
 		// ignore error when old Cohort has cycle.
 		_ = updateCohortTreeResources(oldParent)
@@ -53,6 +53,9 @@ func dominantResourceShare(node dominantResourceShareNode, wlReq resources.Flavo
 
 	borrowing := make(map[corev1.ResourceName]int64, len(node.getResourceNode().SubtreeQuota))
 	for fr, quota := range node.getResourceNode().SubtreeQuota {
+		if fr == WorkloadsFlavorResource {
+			continue
+		}
 		amountBorrowed := wlReq[fr] + node.getResourceNode().Usage[fr] - quota
 		if amountBorrowed > 0 {
 			borrowing[fr.Resource] += amountBorrowed
@@ -98,6 +101,10 @@ func calculateLendable(node hierarchicalResourceNode) map[corev1.ResourceName]in
 	// The root's SubtreeQuota contains all FlavorResources,
 	// as we accumulate even 0s in accumulateFromChild.
 	for fr := range root.getResourceNode().SubtreeQuota {
+		// The workload counts are not a resource shared fairly.
+		if fr == WorkloadsFlavorResource {
+			continue
+		}
 		lendable[fr.Resource] += potentialAvailable(node, fr)
 	}
 	return lendable
@@ -0,0 +1,99 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/resources"
+)
+
+// FederatedQuota holds the nominal quotas of a MultiKueue manager
+// ClusterQueue computed from the quotas of the worker clusters.
+type FederatedQuota struct {
+	// Policy is the quotaPolicy of the MultiKueueConfig.
+	Policy kueue.MultiKueueQuotaPolicy
+	// Clusters are the worker clusters the quotas are computed from.
+	Clusters []string
+	// Nominal are the nominal quotas of the FlavorResources defined by at
+	// least one of the worker clusters. The other FlavorResources of the
+	// ClusterQueue have no nominal quota.
+	Nominal resources.FlavorResourceQuantities
+}
+
+// UpdateFederatedQuota sets, or deletes when nil, the nominal quotas of the
+// ClusterQueue computed from the quotas of the worker clusters. It returns
+// whether the quotas, or the worker clusters they are computed from, changed.
+func (c *Cache) UpdateFederatedQuota(name kueue.ClusterQueueReference, quota *FederatedQuota) bool {
+	c.Lock()
+	defer c.Unlock()
+	if equality.Semantic.DeepEqual(c.federatedQuotas[name], quota) {
+		return false
+	}
+	if quota == nil {
+		delete(c.federatedQuotas, name)
+	} else {
+		if c.federatedQuotas == nil {
+			c.federatedQuotas = make(map[kueue.ClusterQueueReference]*FederatedQuota)
+		}
+		c.federatedQuotas[name] = quota
+	}
+	cq := c.hm.ClusterQueue(name)
+	if cq == nil {
+		return false
+	}
+	cq.federatedQuota = quota
+	ratios := newOvercommitRatios(c.resourceFlavors)
+	if cq.resourceNode.setQuotas(cq.withFederatedQuota(cq.withNodeCapacityQuotas(cq.specQuotas)), ratios) {
+		if cq.HasParent() {
+			// ignore error when the Cohort has a cycle.
+			_ = updateCohortTreeResources(cq.Parent())
+		} else {
+			updateClusterQueueResourceNode(cq)
+		}
+	}
+	return true
+}
+
+func getFederatedQuota(cq *clusterQueue) *kueue.FederatedQuota {
+	if cq.federatedQuota == nil {
+		return nil
+	}
+	out := &kueue.FederatedQuota{
+		Policy:   cq.federatedQuota.Policy,
+		Clusters: cq.federatedQuota.Clusters,
+	}
+	for _, rg := range cq.ResourceGroups {
+		for _, fName := range rg.Flavors {
+			var flvQuota *kueue.FlavorFederatedQuota
+			for _, rName := range sets.List(rg.CoveredResources) {
+				fr := resources.FlavorResource{Flavor: fName, Resource: rName}
+				if flvQuota == nil {
+					out.Flavors = append(out.Flavors, kueue.FlavorFederatedQuota{Name: fName})
+					flvQuota = &out.Flavors[len(out.Flavors)-1]
+				}
+				flvQuota.Resources = append(flvQuota.Resources, kueue.ResourceFederatedQuota{
+					Name:         rName,
+					NominalQuota: resources.ResourceQuantity(rName, cq.resourceNode.physicalQuotas()[fr].Nominal),
+				})
+			}
+		}
+	}
+	return out
+}
@@ -17,6 +17,12 @@ limitations under the License.
 package cache
 
 import (
+	"maps"
+
+	"k8s.io/utils/ptr"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/resources"
 )
 
@@ -26,4 +32,90 @@ type LocalQueue struct {
 	admittedWorkloads  int
 	totalReserved      resources.FlavorResourceQuantities
 	admittedUsage      resources.FlavorResourceQuantities
+	// limits are the maximum quantities, by flavor and resource, which
+	// the workloads of the LocalQueue can reserve. Nil when the LocalQueue
+	// has no limits.
+	limits resources.FlavorResourceQuantities
+	// maxAdmittedWorkloads is the maximum number of workloads of the
+	// LocalQueue which can reserve quota. Nil when not limited.
+	maxAdmittedWorkloads *int
+	// userLimits are the limits on the usage of each user in the
+	// LocalQueue. Nil when not limited.
+	userLimits *UserLimits
+	usersUsage usersUsage
+}
+
+// LocalQueueSnapshot holds the state of a LocalQueue with limits
+// relevant for scheduling.
+type LocalQueueSnapshot struct {
+	Limits               resources.FlavorResourceQuantities
+	Usage                resources.FlavorResourceQuantities
+	MaxAdmittedWorkloads *int
+	ReservingWorkloads   int
+	UserLimits           *UserLimits
+	UsersUsage           map[string]UserUsage
+}
+
+// Available returns how much more of the FlavorResource the LocalQueue
+// can reserve, and whether the LocalQueue limits the FlavorResource.
+func (q *LocalQueueSnapshot) Available(fr resources.FlavorResource) (int64, bool) {
+	limit, found := q.Limits[fr]
+	if !found {
+		return 0, false
+	}
+	return max(0, limit-q.Usage[fr]), true
+}
+
+// WorkloadsAvailable returns how many more workloads the LocalQueue can
+// admit, and whether the LocalQueue limits the number of workloads.
+func (q *LocalQueueSnapshot) WorkloadsAvailable() (int, bool) {
+	if q.MaxAdmittedWorkloads == nil {
+		return 0, false
+	}
+	return max(0, *q.MaxAdmittedWorkloads-q.ReservingWorkloads), true
+}
+
+func (q *LocalQueue) updateLimits(in *kueue.LocalQueue) {
+	q.limits = localQueueLimits(in)
+	q.maxAdmittedWorkloads = localQueueMaxAdmittedWorkloads(in)
+	q.userLimits = newUserLimits(in.Spec.PerUserLimits)
+}
+
+func (q *LocalQueue) hasLimits() bool {
+	return q.limits != nil || q.maxAdmittedWorkloads != nil || q.userLimits != nil
+}
+
+func (q *LocalQueue) snapshot() *LocalQueueSnapshot {
+	snap := &LocalQueueSnapshot{
+		Limits:               q.limits,
+		Usage:                maps.Clone(q.totalReserved),
+		MaxAdmittedWorkloads: q.maxAdmittedWorkloads,
+		ReservingWorkloads:   q.reservingWorkloads,
+		UserLimits:           q.userLimits,
+	}
+	if q.userLimits != nil {
+		snap.UsersUsage = q.usersUsage.clone()
+	}
+	return snap
+}
+
+func localQueueLimits(q *kueue.LocalQueue) resources.FlavorResourceQuantities {
+	if !features.Enabled(features.LocalQueueLimits) || len(q.Spec.Limits) == 0 {
+		return nil
+	}
+	limits := make(resources.FlavorResourceQuantities)
+	for _, flvLimit := range q.Spec.Limits {
+		for _, resLimit := range flvLimit.Resources {
+			fr := resources.FlavorResource{Flavor: flvLimit.Name, Resource: resLimit.Name}
+			limits[fr] = resources.ResourceValue(resLimit.Name, resLimit.Max)
+		}
+	}
+	return limits
+}
+
+func localQueueMaxAdmittedWorkloads(q *kueue.LocalQueue) *int {
+	if !features.Enabled(features.MaxAdmittedWorkloads) || q.Spec.MaxAdmittedWorkloads == nil {
+		return nil
+	}
+	return ptr.To(int(*q.Spec.MaxAdmittedWorkloads))
 }
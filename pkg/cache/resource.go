@@ -43,6 +43,14 @@ func (rg *ResourceGroup) Clone() ResourceGroup {
 	}
 }
 
+// WorkloadsFlavorResource is the pseudo FlavorResource used to enforce
+// maxAdmittedWorkloads. Every workload reserving quota in a ClusterQueue
+// limited by maxAdmittedWorkloads uses one unit of it, so that the
+// counts of a Cohort are shared by the members of its subtree.
+// The counts are never lent to the rest of the Cohort tree, as
+// preemption doesn't reclaim them.
+var WorkloadsFlavorResource = resources.FlavorResource{Resource: "kueue.x-k8s.io/workloads"}
+
 type ResourceQuota struct {
 	Nominal        int64
 	BorrowingLimit *int64
@@ -73,3 +81,50 @@ func createResourceQuotas(kueueRgs []kueue.ResourceGroup) map[resources.FlavorRe
 	}
 	return quotas
 }
+
+// nodeCapacityQuota derives the nominal quota of a FlavorResource from the
+// capacity of the nodes of the flavor.
+type nodeCapacityQuota struct {
+	percentage int64
+	// nominal is the nominalQuota in the spec, used until the capacity of
+	// the nodes is known.
+	nominal int64
+}
+
+func createNodeCapacityQuotas(kueueRgs []kueue.ResourceGroup) map[resources.FlavorResource]nodeCapacityQuota {
+	if !features.Enabled(features.NodeCapacityQuotas) {
+		return nil
+	}
+	var quotas map[resources.FlavorResource]nodeCapacityQuota
+	for _, kueueRg := range kueueRgs {
+		for _, kueueFlavor := range kueueRg.Flavors {
+			for _, kueueQuota := range kueueFlavor.Resources {
+				if kueueQuota.NodeCapacityPercentage == nil {
+					continue
+				}
+				if quotas == nil {
+					quotas = make(map[resources.FlavorResource]nodeCapacityQuota)
+				}
+				quotas[resources.FlavorResource{Flavor: kueueFlavor.Name, Resource: kueueQuota.Name}] = nodeCapacityQuota{
+					percentage: int64(*kueueQuota.NodeCapacityPercentage),
+					nominal:    resources.ResourceValue(kueueQuota.Name, kueueQuota.NominalQuota),
+				}
+			}
+		}
+	}
+	return quotas
+}
+
+// addMaxAdmittedWorkloadsQuota adds the nominal quota of the
+// WorkloadsFlavorResource, when maxAdmittedWorkloads is set. The
+// counts have a lending limit of zero, so that a ClusterQueue or
+// Cohort can always use its own counts.
+func addMaxAdmittedWorkloadsQuota(quotas map[resources.FlavorResource]ResourceQuota, maxAdmittedWorkloads *int32) {
+	if !features.Enabled(features.MaxAdmittedWorkloads) || maxAdmittedWorkloads == nil {
+		return
+	}
+	quotas[WorkloadsFlavorResource] = ResourceQuota{
+		Nominal:      int64(*maxAdmittedWorkloads),
+		LendingLimit: ptr.To[int64](0),
+	}
+}
@@ -18,7 +18,13 @@ package cache
 
 import (
 	"maps"
+	"math"
 
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/utils/ptr"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/hierarchy"
 	"sigs.k8s.io/kueue/pkg/resources"
 )
@@ -26,9 +32,14 @@ import (
 // resourceNode is the shared representation of Quotas and Usage, used
 // by ClusterQueues and Cohorts.
 type resourceNode struct {
-	// Quotas are the ResourceQuotas specified for the current
-	// node.
+	// Quotas are the effective ResourceQuotas for the current node:
+	// the ResourceQuotas specified, multiplied by the overcommit
+	// ratios of their flavors.
 	Quotas map[resources.FlavorResource]ResourceQuota
+	// PhysicalQuotas are the ResourceQuotas specified for the
+	// current node, before applying the overcommit ratios. They are
+	// nil when no overcommit ratio applies, as they equal Quotas.
+	PhysicalQuotas map[resources.FlavorResource]ResourceQuota
 	// SubtreeQuota is the sum of the node's quota, as well as
 	// resources available from its children, constrained by
 	// LendingLimits.
@@ -52,10 +63,86 @@ func NewResourceNode() resourceNode {
 // Quota and SubtreeQuota (these are replaced with new maps upon update).
 func (r resourceNode) Clone() resourceNode {
 	return resourceNode{
-		Quotas:       r.Quotas,
-		SubtreeQuota: r.SubtreeQuota,
-		Usage:        maps.Clone(r.Usage),
+		Quotas:         r.Quotas,
+		PhysicalQuotas: r.PhysicalQuotas,
+		SubtreeQuota:   r.SubtreeQuota,
+		Usage:          maps.Clone(r.Usage),
+	}
+}
+
+// overcommitRatios are the overcommit ratios of the FlavorResources,
+// in thousandths.
+type overcommitRatios map[resources.FlavorResource]int64
+
+func newOvercommitRatios(flavors map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor) overcommitRatios {
+	if !features.Enabled(features.FlavorOvercommit) {
+		return nil
+	}
+	var ratios overcommitRatios
+	for name, flavor := range flavors {
+		for _, o := range flavor.Spec.Overcommit {
+			if ratios == nil {
+				ratios = make(overcommitRatios)
+			}
+			ratios[resources.FlavorResource{Flavor: name, Resource: o.Name}] = o.Ratio.MilliValue()
+		}
 	}
+	return ratios
+}
+
+// scale multiplies the quantity by the overcommit ratio of the
+// FlavorResource.
+func (o overcommitRatios) scale(fr resources.FlavorResource, v int64) int64 {
+	ratio, found := o[fr]
+	if !found || ratio == 1000 {
+		return v
+	}
+	if v > math.MaxInt64/ratio {
+		return int64(float64(v) * float64(ratio) / 1000)
+	}
+	return v * ratio / 1000
+}
+
+func (o overcommitRatios) scalePtr(fr resources.FlavorResource, v *int64) *int64 {
+	if v == nil {
+		return nil
+	}
+	return ptr.To(o.scale(fr, *v))
+}
+
+// setQuotas sets the physical quotas of the node, and the effective
+// quotas resulting from multiplying them by the overcommit ratios.
+// It returns whether the effective quotas changed.
+func (r *resourceNode) setQuotas(physical map[resources.FlavorResource]ResourceQuota, ratios overcommitRatios) bool {
+	oldQuotas := r.Quotas
+	r.PhysicalQuotas = nil
+	r.Quotas = physical
+	for fr := range physical {
+		if _, found := ratios[fr]; found {
+			r.PhysicalQuotas = physical
+			break
+		}
+	}
+	if r.PhysicalQuotas != nil {
+		r.Quotas = make(map[resources.FlavorResource]ResourceQuota, len(physical))
+		for fr, quota := range physical {
+			r.Quotas[fr] = ResourceQuota{
+				Nominal:        ratios.scale(fr, quota.Nominal),
+				BorrowingLimit: ratios.scalePtr(fr, quota.BorrowingLimit),
+				LendingLimit:   ratios.scalePtr(fr, quota.LendingLimit),
+			}
+		}
+	}
+	return !equality.Semantic.DeepEqual(oldQuotas, r.Quotas)
+}
+
+// physicalQuotas returns the ResourceQuotas specified for the node,
+// before applying the overcommit ratios.
+func (r *resourceNode) physicalQuotas() map[resources.FlavorResource]ResourceQuota {
+	if r.PhysicalQuotas != nil {
+		return r.PhysicalQuotas
+	}
+	return r.Quotas
 }
 
 // guaranteedQuota is the capacity which will not be lent the node's
@@ -149,6 +236,14 @@ func updateClusterQueueResourceNode(cq *clusterQueue) {
 	for fr, quota := range cq.resourceNode.Quotas {
 		cq.resourceNode.SubtreeQuota[fr] = quota.Nominal
 	}
+	// The workloads are only counted while the ClusterQueue, or one of
+	// its Cohorts, limits the number of workloads. Whether it does can
+	// change with any update in the Cohort tree.
+	if cq.limitsWorkloadCount() {
+		cq.resourceNode.Usage[WorkloadsFlavorResource] = int64(len(cq.Workloads))
+	} else {
+		delete(cq.resourceNode.Usage, WorkloadsFlavorResource)
+	}
 }
 
 // updateCohortTreeResources traverses the Cohort tree from the root
@@ -21,11 +21,17 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
 
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/features"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	"sigs.k8s.io/kueue/pkg/workload"
 )
 
 func TestCohortLendable(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.MaxAdmittedWorkloads, true)
 	cache := New(utiltesting.NewFakeClient())
 
 	cq1 := utiltesting.MakeClusterQueue("cq1").
@@ -35,6 +41,7 @@ func TestCohortLendable(t *testing.T) {
 				ResourceQuotaWrapper("example.com/gpu").NominalQuota("3").LendingLimit("3").Append().
 				FlavorQuotas,
 		).Cohort("test-cohort").
+		MaxAdmittedWorkloads(2).
 		ClusterQueue
 
 	cq2 := utiltesting.MakeClusterQueue("cq2").
@@ -62,3 +69,158 @@ func TestCohortLendable(t *testing.T) {
 		t.Errorf("Unexpected cohort lendable (-want,+got):\n%s", diff)
 	}
 }
+
+func TestWorkloadsFlavorResource(t *testing.T) {
+	workloads := []kueue.Workload{
+		*utiltesting.MakeWorkload("a", "").ReserveQuota(utiltesting.MakeAdmission("cq1").Obj()).Obj(),
+		*utiltesting.MakeWorkload("b", "").ReserveQuota(utiltesting.MakeAdmission("cq1").Obj()).Obj(),
+	}
+	cases := map[string]struct {
+		cohorts           []*kueuealpha.Cohort
+		clusterQueues     []*kueue.ClusterQueue
+		cohortUpdate      *kueuealpha.Cohort
+		wantLimited       bool
+		wantUsage         int64
+		wantAvailable     int64
+		wantBorrowed      *int
+		wantCohortUsage   int64
+		wantCohortSubtree int64
+	}{
+		"not limited": {
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("cq1").Cohort("cohort").Obj(),
+				utiltesting.MakeClusterQueue("cq2").Cohort("cohort").Obj(),
+			},
+		},
+		"limited by the ClusterQueues, the counts are not lent": {
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("cq1").Cohort("cohort").MaxAdmittedWorkloads(1).Obj(),
+				utiltesting.MakeClusterQueue("cq2").Cohort("cohort").MaxAdmittedWorkloads(2).Obj(),
+			},
+			wantLimited:       true,
+			wantUsage:         2,
+			wantAvailable:     -1,
+			wantBorrowed:      ptr.To(1),
+			wantCohortUsage:   1,
+			wantCohortSubtree: 0,
+		},
+		"limited by the ClusterQueue and the Cohort, borrowing": {
+			cohorts: []*kueuealpha.Cohort{
+				utiltesting.MakeCohort("cohort").MaxAdmittedWorkloads(2).Obj(),
+			},
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("cq1").Cohort("cohort").MaxAdmittedWorkloads(1).Obj(),
+				utiltesting.MakeClusterQueue("cq2").Cohort("cohort").MaxAdmittedWorkloads(5).Obj(),
+			},
+			wantLimited:       true,
+			wantUsage:         2,
+			wantAvailable:     1,
+			wantBorrowed:      ptr.To(1),
+			wantCohortUsage:   1,
+			wantCohortSubtree: 2,
+		},
+		"limited by the ClusterQueue, without cohort": {
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("cq1").MaxAdmittedWorkloads(3).Obj(),
+			},
+			wantLimited:   true,
+			wantUsage:     2,
+			wantAvailable: 1,
+			wantBorrowed:  ptr.To(0),
+		},
+		"limited by the Cohort": {
+			cohorts: []*kueuealpha.Cohort{
+				utiltesting.MakeCohort("cohort").MaxAdmittedWorkloads(2).Obj(),
+			},
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("cq1").Cohort("cohort").Obj(),
+				utiltesting.MakeClusterQueue("cq2").Cohort("cohort").Obj(),
+			},
+			wantLimited:       true,
+			wantUsage:         2,
+			wantAvailable:     0,
+			wantBorrowed:      ptr.To(2),
+			wantCohortUsage:   2,
+			wantCohortSubtree: 2,
+		},
+		"limited by the root Cohort": {
+			cohorts: []*kueuealpha.Cohort{
+				utiltesting.MakeCohort("root").MaxAdmittedWorkloads(5).Obj(),
+				utiltesting.MakeCohort("cohort").Parent("root").Obj(),
+			},
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("cq1").Cohort("cohort").Obj(),
+			},
+			wantLimited:       true,
+			wantUsage:         2,
+			wantAvailable:     3,
+			wantBorrowed:      ptr.To(2),
+			wantCohortUsage:   2,
+			wantCohortSubtree: 0,
+		},
+		"Cohort limit removed": {
+			cohorts: []*kueuealpha.Cohort{
+				utiltesting.MakeCohort("cohort").MaxAdmittedWorkloads(2).Obj(),
+			},
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("cq1").Cohort("cohort").Obj(),
+			},
+			cohortUpdate: utiltesting.MakeCohort("cohort").Obj(),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.MaxAdmittedWorkloads, true)
+			cache := New(utiltesting.NewFakeClient())
+			for _, cohort := range tc.cohorts {
+				if err := cache.AddOrUpdateCohort(cohort); err != nil {
+					t.Fatalf("Failed to add Cohort to cache: %v", err)
+				}
+			}
+			for _, cq := range tc.clusterQueues {
+				if err := cache.AddClusterQueue(t.Context(), cq); err != nil {
+					t.Fatalf("Failed to add CQ to cache: %v", err)
+				}
+			}
+			for i := range workloads {
+				if added := cache.AddOrUpdateWorkload(&workloads[i]); !added {
+					t.Fatalf("Workload %s was not added", workload.Key(&workloads[i]))
+				}
+			}
+			if tc.cohortUpdate != nil {
+				if err := cache.AddOrUpdateCohort(tc.cohortUpdate); err != nil {
+					t.Fatalf("Failed to update Cohort in cache: %v", err)
+				}
+			}
+
+			cq := cache.hm.ClusterQueue("cq1")
+			if got := cq.limitsWorkloadCount(); got != tc.wantLimited {
+				t.Errorf("Unexpected limitsWorkloadCount, want=%v, got=%v", tc.wantLimited, got)
+			}
+			if got := cq.resourceNode.Usage[WorkloadsFlavorResource]; got != tc.wantUsage {
+				t.Errorf("Unexpected usage, want=%d, got=%d", tc.wantUsage, got)
+			}
+			if tc.wantLimited {
+				if got := available(cq, WorkloadsFlavorResource); got != tc.wantAvailable {
+					t.Errorf("Unexpected available, want=%d, got=%d", tc.wantAvailable, got)
+				}
+			}
+			if cq.HasParent() {
+				cohort := cq.Parent()
+				if got := cohort.resourceNode.Usage[WorkloadsFlavorResource]; got != tc.wantCohortUsage {
+					t.Errorf("Unexpected Cohort usage, want=%d, got=%d", tc.wantCohortUsage, got)
+				}
+				if got := cohort.resourceNode.SubtreeQuota[WorkloadsFlavorResource]; got != tc.wantCohortSubtree {
+					t.Errorf("Unexpected Cohort subtree quota, want=%d, got=%d", tc.wantCohortSubtree, got)
+				}
+			}
+			stats, err := cache.Usage(tc.clusterQueues[0])
+			if err != nil {
+				t.Fatalf("Couldn't get usage: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantBorrowed, stats.BorrowedWorkloads); diff != "" {
+				t.Errorf("Unexpected borrowed workloads (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
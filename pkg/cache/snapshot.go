@@ -44,7 +44,7 @@ type Snapshot struct {
 func (s *Snapshot) RemoveWorkload(wl *workload.Info) {
 	cq := s.ClusterQueue(wl.ClusterQueue)
 	delete(cq.Workloads, workload.Key(wl.Obj))
-	cq.RemoveUsage(wl.Usage())
+	cq.RemoveUsage(cq.WorkloadUsage(wl))
 }
 
 // AddWorkload adds a workload from its corresponding ClusterQueue and
@@ -52,7 +52,7 @@ func (s *Snapshot) RemoveWorkload(wl *workload.Info) {
 func (s *Snapshot) AddWorkload(wl *workload.Info) {
 	cq := s.ClusterQueue(wl.ClusterQueue)
 	cq.Workloads[workload.Key(wl.Obj)] = wl
-	cq.AddUsage(wl.Usage())
+	cq.AddUsage(cq.WorkloadUsage(wl))
 }
 
 func (s *Snapshot) Log(log logr.Logger) {
@@ -175,9 +175,22 @@ func snapshotClusterQueue(c *clusterQueue) *ClusterQueueSnapshot {
 		TASFlavors:                    make(map[kueue.ResourceFlavorReference]*TASFlavorSnapshot),
 		tasOnly:                       c.isTASOnly(),
 	}
+	if c.userLimits != nil {
+		cc.UserLimits = c.userLimits
+		cc.UsersUsage = c.usersUsage.clone()
+	}
 	for i, rg := range c.ResourceGroups {
 		cc.ResourceGroups[i] = rg.Clone()
 	}
+	for key, lq := range c.localQueues {
+		if !lq.hasLimits() {
+			continue
+		}
+		if cc.LocalQueues == nil {
+			cc.LocalQueues = make(map[string]*LocalQueueSnapshot)
+		}
+		cc.LocalQueues[key] = lq.snapshot()
+	}
 	return cc
 }
 
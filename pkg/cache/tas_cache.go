@@ -17,24 +17,79 @@ limitations under the License.
 package cache
 
 import (
+	"context"
+	"fmt"
 	"maps"
+	"slices"
+	"strings"
 	"sync"
 
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/validation"
+	corev1helpers "k8s.io/component-helpers/scheduling/corev1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/tas/indexer"
+	"sigs.k8s.io/kueue/pkg/resources"
 )
 
 type TASCache struct {
 	sync.RWMutex
 	client  client.Client
 	flavors map[kueue.ResourceFlavorReference]*TASFlavorCache
+
+	// nodeCapacity holds the capacity of the nodes of the flavors used to
+	// derive nominal quotas.
+	nodeCapacity map[kueue.ResourceFlavorReference]NodeCapacity
+
+	// nodeDomains holds the topology domains of the nodes, read from the
+	// sources referenced by the topologies.
+	nodeDomains map[kueue.TopologyReference]NodeDomains
+}
+
+// NodeDomains maps the names of the nodes to the values of the topology
+// levels, but the lowest one, from the highest level.
+type NodeDomains map[string][]string
+
+// nodeDomainsSeparator separates the values of the topology levels in the
+// path of the topology domain of a node.
+const nodeDomainsSeparator = "/"
+
+// ParseNodeDomains returns the topology domains of the nodes from the
+// ConfigMap. The entries which don't have a value for each of the levels are
+// skipped.
+func ParseNodeDomains(log logr.Logger, configMap *corev1.ConfigMap, levels int) NodeDomains {
+	domains := make(NodeDomains, len(configMap.Data))
+	for node, path := range configMap.Data {
+		values := strings.Split(strings.TrimSpace(path), nodeDomainsSeparator)
+		if len(values) != levels {
+			log.V(2).Info("Skipping node with invalid topology domain", "node", node, "path", path, "reason", "unexpected number of levels")
+			continue
+		}
+		if slices.ContainsFunc(values, func(v string) bool { return v == "" || len(validation.IsValidLabelValue(v)) > 0 }) {
+			log.V(2).Info("Skipping node with invalid topology domain", "node", node, "path", path, "reason", "invalid level value")
+			continue
+		}
+		domains[node] = values
+	}
+	return domains
+}
+
+// NodeCapacity is the allocatable capacity of the nodes of a ResourceFlavor.
+type NodeCapacity struct {
+	Nodes       int
+	Allocatable resources.Requests
 }
 
 func NewTASCache(client client.Client) TASCache {
 	return TASCache{
-		client:  client,
-		flavors: make(map[kueue.ResourceFlavorReference]*TASFlavorCache),
+		client:       client,
+		flavors:      make(map[kueue.ResourceFlavorReference]*TASFlavorCache),
+		nodeCapacity: make(map[kueue.ResourceFlavorReference]NodeCapacity),
+		nodeDomains:  make(map[kueue.TopologyReference]NodeDomains),
 	}
 }
 
@@ -51,9 +106,22 @@ func (t *TASCache) Clone() map[kueue.ResourceFlavorReference]*TASFlavorCache {
 	return maps.Clone(t.flavors)
 }
 
+// FlavorSnapshot returns a snapshot of the TAS flavor, or nil if the flavor
+// is not a TAS flavor.
+func (t *TASCache) FlavorSnapshot(ctx context.Context, name kueue.ResourceFlavorReference) (*TASFlavorSnapshot, error) {
+	c := t.Get(name)
+	if c == nil {
+		return nil, nil
+	}
+	return c.snapshot(ctx)
+}
+
 func (t *TASCache) Set(name kueue.ResourceFlavorReference, info *TASFlavorCache) {
 	t.Lock()
 	defer t.Unlock()
+	if domains, found := t.nodeDomains[info.TopologyName]; found && info.usesNodeDomains() {
+		info.setNodeDomains(domains)
+	}
 	t.flavors[name] = info
 }
 
@@ -62,3 +130,102 @@ func (t *TASCache) Delete(name kueue.ResourceFlavorReference) {
 	defer t.Unlock()
 	delete(t.flavors, name)
 }
+
+// SetNodeDomains sets, or deletes when nil, the topology domains of the nodes
+// read from the source referenced by the topology, and updates the flavors
+// using the topology. When the domains are deleted, the flavors reading the
+// domains from the source are left without any domains, rather than with the
+// stale ones. It returns whether the domains changed.
+func (t *TASCache) SetNodeDomains(topology kueue.TopologyReference, domains NodeDomains) bool {
+	t.Lock()
+	defer t.Unlock()
+	old, found := t.nodeDomains[topology]
+	if domains == nil {
+		if !found {
+			return false
+		}
+		delete(t.nodeDomains, topology)
+		for _, flavor := range t.flavors {
+			if flavor.TopologyName == topology && flavor.usesNodeDomains() {
+				flavor.setNodeDomains(NodeDomains{})
+			}
+		}
+		return true
+	}
+	if found && equality.Semantic.DeepEqual(old, domains) {
+		return false
+	}
+	t.nodeDomains[topology] = domains
+	for _, flavor := range t.flavors {
+		if flavor.TopologyName == topology {
+			flavor.setNodeDomains(domains)
+		}
+	}
+	return true
+}
+
+// NodeCapacity returns the capacity of the nodes of the flavor, and whether
+// it was computed.
+func (t *TASCache) NodeCapacity(name kueue.ResourceFlavorReference) (NodeCapacity, bool) {
+	t.RLock()
+	defer t.RUnlock()
+	capacity, found := t.nodeCapacity[name]
+	return capacity, found
+}
+
+// NodeCapacityFlavors returns the flavors whose node capacity is tracked.
+func (t *TASCache) NodeCapacityFlavors() []kueue.ResourceFlavorReference {
+	t.RLock()
+	defer t.RUnlock()
+	return slices.Collect(maps.Keys(t.nodeCapacity))
+}
+
+// setNodeCapacity sets, or deletes when nil, the capacity of the nodes of
+// the flavor. It returns whether the capacity changed.
+func (t *TASCache) setNodeCapacity(name kueue.ResourceFlavorReference, capacity *NodeCapacity) bool {
+	t.Lock()
+	defer t.Unlock()
+	old, found := t.nodeCapacity[name]
+	if capacity == nil {
+		delete(t.nodeCapacity, name)
+		return found
+	}
+	t.nodeCapacity[name] = *capacity
+	return !found || !equality.Semantic.DeepEqual(old, *capacity)
+}
+
+// ComputeNodeCapacity sums the allocatable capacity of the ready and
+// schedulable nodes which match the nodeLabels of the flavor, and whose
+// NoSchedule and NoExecute taints are listed in the nodeTaints, or tolerated
+// by the tolerations, of the flavor.
+func (t *TASCache) ComputeNodeCapacity(ctx context.Context, flv *kueue.ResourceFlavor) (*NodeCapacity, error) {
+	nodes := &corev1.NodeList{}
+	err := t.client.List(ctx, nodes, client.MatchingLabels(flv.Spec.NodeLabels), client.MatchingFields{
+		indexer.ReadyNode:       "true",
+		indexer.SchedulableNode: "true",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes for flavor %q: %w", flv.Name, err)
+	}
+	tolerations := slices.Clone(flv.Spec.Tolerations)
+	for _, taint := range flv.Spec.NodeTaints {
+		tolerations = append(tolerations, corev1.Toleration{
+			Key:      taint.Key,
+			Operator: corev1.TolerationOpEqual,
+			Value:    taint.Value,
+			Effect:   taint.Effect,
+		})
+	}
+	capacity := &NodeCapacity{Allocatable: resources.Requests{}}
+	for _, node := range nodes.Items {
+		_, untolerated := corev1helpers.FindMatchingUntoleratedTaint(node.Spec.Taints, tolerations, func(t *corev1.Taint) bool {
+			return t.Effect == corev1.TaintEffectNoSchedule || t.Effect == corev1.TaintEffectNoExecute
+		})
+		if untolerated {
+			continue
+		}
+		capacity.Nodes++
+		capacity.Allocatable.Add(resources.NewRequests(node.Status.Allocatable))
+	}
+	return capacity, nil
+}
@@ -28,6 +28,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
 	resourcehelpers "k8s.io/component-helpers/resource"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -70,6 +71,11 @@ type TASFlavorCache struct {
 
 	// usage maintains the usage per topology domain
 	usage map[utiltas.TopologyDomainID]resources.Requests
+
+	// nodeDomains holds the topology domains of the nodes when they are read
+	// from the source referenced by the topology, rather than from the node
+	// labels. It is nil when the node labels are used.
+	nodeDomains NodeDomains
 }
 
 func (t *TASCache) NewTASFlavorCache(topologyName kueue.TopologyReference, levels []string, nodeLabels map[string]string,
@@ -84,6 +90,50 @@ func (t *TASCache) NewTASFlavorCache(topologyName kueue.TopologyReference, level
 	}
 }
 
+func (c *TASFlavorCache) setNodeDomains(domains NodeDomains) {
+	c.Lock()
+	defer c.Unlock()
+	c.nodeDomains = domains
+}
+
+func (c *TASFlavorCache) usesNodeDomains() bool {
+	c.RLock()
+	defer c.RUnlock()
+	return c.nodeDomains != nil
+}
+
+// TopologyLabels returns the labels of the node, with the values of the
+// topology levels read from the source referenced by the topology, if any,
+// and whether the node has the values of all the topology levels.
+func (c *TASFlavorCache) TopologyLabels(node *corev1.Node) (map[string]string, bool) {
+	c.RLock()
+	defer c.RUnlock()
+	return c.topologyLabels(node)
+}
+
+func (c *TASFlavorCache) topologyLabels(node *corev1.Node) (map[string]string, bool) {
+	nodeLabels := node.Labels
+	if c.nodeDomains != nil {
+		values, found := c.nodeDomains[node.Name]
+		if !found || len(values) != len(c.Levels)-1 {
+			return nil, false
+		}
+		nodeLabels = maps.Clone(node.Labels)
+		if nodeLabels == nil {
+			nodeLabels = make(map[string]string, len(c.Levels))
+		}
+		for i, value := range values {
+			nodeLabels[c.Levels[i]] = value
+		}
+	}
+	for _, level := range c.Levels {
+		if _, found := nodeLabels[level]; !found {
+			return nil, false
+		}
+	}
+	return nodeLabels, true
+}
+
 func (c *TASFlavorCache) snapshot(ctx context.Context) (*TASFlavorSnapshot, error) {
 	log := ctrl.LoggerFrom(ctx)
 	nodes := &corev1.NodeList{}
@@ -92,7 +142,7 @@ func (c *TASFlavorCache) snapshot(ctx context.Context) (*TASFlavorSnapshot, erro
 		requiredLabels[k] = v
 	}
 	requiredLabelKeys := client.HasLabels{}
-	requiredLabelKeys = append(requiredLabelKeys, c.Levels...)
+	requiredLabelKeys = append(requiredLabelKeys, c.requiredLabelKeys()...)
 	err := c.client.List(ctx, nodes, requiredLabels, requiredLabelKeys, client.MatchingFields{
 		indexer.ReadyNode:       "true",
 		indexer.SchedulableNode: "true",
@@ -115,6 +165,63 @@ func (c *TASFlavorCache) snapshot(ctx context.Context) (*TASFlavorSnapshot, erro
 	return c.snapshotForNodes(log, nodes.Items, pods.Items), nil
 }
 
+// NewTASFlavorSnapshot builds the snapshot of the TAS flavor from the given
+// objects rather than from the cache, for inspecting the topology outside of
+// the controller. The capacity comes from the ready and schedulable nodes
+// matching the node labels of the flavor, and labeled with all the topology
+// levels. When nodeDomains is not nil, the values of the topology levels, but
+// the lowest one, are read from it rather than from the node labels. The
+// usage comes from the non-TAS pods bound to these nodes, and from the
+// topology assignments of the workloads with quota reserved in the flavor.
+func NewTASFlavorSnapshot(log logr.Logger, flavor *kueue.ResourceFlavor, levels []string, nodeDomains NodeDomains,
+	nodes []corev1.Node, pods []corev1.Pod, workloads []kueue.Workload) *TASFlavorSnapshot {
+	c := &TASFlavorCache{
+		TopologyName: ptr.Deref(flavor.Spec.TopologyName, ""),
+		Levels:       slices.Clone(levels),
+		NodeLabels:   maps.Clone(flavor.Spec.NodeLabels),
+		Tolerations:  slices.Clone(flavor.Spec.Tolerations),
+		usage:        make(map[utiltas.TopologyDomainID]resources.Requests),
+		nodeDomains:  nodeDomains,
+	}
+	requiredLabelKeys := c.requiredLabelKeys()
+	selector := labels.SelectorFromSet(flavor.Spec.NodeLabels)
+	var flavorNodes []corev1.Node
+	for _, node := range nodes {
+		if !selector.Matches(labels.Set(node.Labels)) || node.Spec.Unschedulable ||
+			!utiltas.IsNodeStatusConditionTrue(node.Status.Conditions, corev1.NodeReady) {
+			continue
+		}
+		if slices.ContainsFunc(requiredLabelKeys, func(key string) bool { _, found := node.Labels[key]; return !found }) {
+			continue
+		}
+		flavorNodes = append(flavorNodes, node)
+	}
+	var nonTASPods []corev1.Pod
+	for _, pod := range pods {
+		if _, found := pod.Labels[kueuealpha.TASLabel]; !found {
+			nonTASPods = append(nonTASPods, pod)
+		}
+	}
+	for i := range workloads {
+		if workload.HasQuotaReservation(&workloads[i]) {
+			c.addUsage(workload.NewInfo(&workloads[i]).TASUsage()[kueue.ResourceFlavorReference(flavor.Name)])
+		}
+	}
+	return c.snapshotForNodes(log, flavorNodes, nonTASPods)
+}
+
+// requiredLabelKeys returns the keys of the labels which the nodes of the
+// topology need to have.
+func (c *TASFlavorCache) requiredLabelKeys() []string {
+	c.RLock()
+	defer c.RUnlock()
+	if c.nodeDomains != nil {
+		// Only the lowest level is read from the node labels.
+		return c.Levels[len(c.Levels)-1:]
+	}
+	return c.Levels
+}
+
 func (c *TASFlavorCache) snapshotForNodes(log logr.Logger, nodes []corev1.Node, pods []corev1.Pod) *TASFlavorSnapshot {
 	c.RLock()
 	defer c.RUnlock()
@@ -122,8 +229,16 @@ func (c *TASFlavorCache) snapshotForNodes(log logr.Logger, nodes []corev1.Node,
 	log.V(3).Info("Constructing TAS snapshot", "nodeLabels", c.NodeLabels,
 		"levels", c.Levels, "nodeCount", len(nodes), "podCount", len(pods))
 	snapshot := newTASFlavorSnapshot(log, c.TopologyName, c.Levels, c.Tolerations)
+	snapshot.nodeDomains = c.nodeDomains
 	nodeToDomain := make(map[string]utiltas.TopologyDomainID)
 	for _, node := range nodes {
+		if c.nodeDomains != nil {
+			nodeLabels, found := c.topologyLabels(&node)
+			if !found {
+				continue
+			}
+			node.Labels = nodeLabels
+		}
 		nodeToDomain[node.Name] = snapshot.addNode(node)
 	}
 	snapshot.initialize()
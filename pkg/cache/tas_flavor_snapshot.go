@@ -26,12 +26,16 @@ import (
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	corev1helpers "k8s.io/component-helpers/scheduling/corev1"
+	"k8s.io/component-helpers/scheduling/corev1/nodeaffinity"
 	"k8s.io/utils/ptr"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/resources"
+	utilpod "sigs.k8s.io/kueue/pkg/util/pod"
 	utiltas "sigs.k8s.io/kueue/pkg/util/tas"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
@@ -80,9 +84,22 @@ type leafDomain struct {
 	// tasUsage represents the usage associated with TAS workloads.
 	tasUsage resources.Requests
 
+	// nonTASUsage represents the usage of the Pods which are not managed by
+	// workloads admitted by TAS, already subtracted from freeCapacity.
+	nonTASUsage resources.Requests
+
 	// nodeTaints contains the list of taints for the node, only applies for
 	// lowest level of topology, if the lowest level is node
 	nodeTaints []corev1.Taint
+
+	// nodeLabels contains the labels of the node, only applies for lowest
+	// level of topology, if the lowest level is node
+	nodeLabels map[string]string
+
+	// nodeName is the name of the node, which can differ from its hostname
+	// label, only applies for lowest level of topology, if the lowest level
+	// is node
+	nodeName string
 }
 
 type domainByID map[utiltas.TopologyDomainID]*domain
@@ -113,6 +130,11 @@ type TASFlavorSnapshot struct {
 
 	// tolerations represents the list of tolerations defined for the resource flavor
 	tolerations []corev1.Toleration
+
+	// nodeDomains holds the topology domains of the nodes when they are read
+	// from the source referenced by the topology, rather than from the node
+	// labels.
+	nodeDomains NodeDomains
 }
 
 func newTASFlavorSnapshot(log logr.Logger, topologyName kueue.TopologyReference,
@@ -150,6 +172,8 @@ func (s *TASFlavorSnapshot) addNode(node corev1.Node) utiltas.TopologyDomainID {
 		}
 		if s.isLowestLevelNode() {
 			leafDomain.nodeTaints = slices.Clone(node.Spec.Taints)
+			leafDomain.nodeLabels = maps.Clone(node.Labels)
+			leafDomain.nodeName = node.Name
 		}
 		s.leaves[domainID] = &leafDomain
 	}
@@ -217,6 +241,11 @@ func (s *TASFlavorSnapshot) addNonTASUsage(domainID utiltas.TopologyDomainID, us
 	// freeCapacity is already called.
 	s.leaves[domainID].freeCapacity.Sub(usage)
 	s.leaves[domainID].freeCapacity.Sub(resources.Requests{corev1.ResourcePods: 1})
+	if s.leaves[domainID].nonTASUsage == nil {
+		s.leaves[domainID].nonTASUsage = resources.Requests{}
+	}
+	s.leaves[domainID].nonTASUsage.Add(usage)
+	s.leaves[domainID].nonTASUsage.Add(resources.Requests{corev1.ResourcePods: 1})
 }
 
 func (s *TASFlavorSnapshot) updateTASUsage(domainID utiltas.TopologyDomainID, usage resources.Requests, op usageOp, count int32) {
@@ -270,6 +299,85 @@ func (s *TASFlavorSnapshot) tasUsagePerDomain() map[utiltas.TopologyDomainID]res
 	return tasUsagePerDomain
 }
 
+// TASDomainResources holds the capacity and the usage of a topology domain.
+type TASDomainResources struct {
+	Domain      utiltas.TopologyDomainID
+	Capacity    resources.Requests
+	TASUsage    resources.Requests
+	NonTASUsage resources.Requests
+}
+
+// Free returns the capacity of the domain which is not used by any Pods.
+func (r *TASDomainResources) Free() resources.Requests {
+	free := r.Capacity.Clone()
+	free.Sub(r.TASUsage)
+	free.Sub(r.NonTASUsage)
+	return free
+}
+
+// DomainResources returns the capacity and the usage of the topology domains
+// at each of the topology levels, keyed by the level. The domains are sorted
+// by their IDs.
+func (s *TASFlavorSnapshot) DomainResources() map[string][]TASDomainResources {
+	perLevel := make([]map[utiltas.TopologyDomainID]*TASDomainResources, len(s.levelKeys))
+	for idx := range s.levelKeys {
+		perLevel[idx] = make(map[utiltas.TopologyDomainID]*TASDomainResources)
+	}
+	for _, leaf := range s.leaves {
+		capacity := leaf.freeCapacity.Clone()
+		capacity.Add(leaf.nonTASUsage)
+		for idx := range s.levelKeys {
+			domainID := utiltas.DomainID(leaf.levelValues[:idx+1])
+			r, found := perLevel[idx][domainID]
+			if !found {
+				r = &TASDomainResources{
+					Domain:      domainID,
+					Capacity:    resources.Requests{},
+					TASUsage:    resources.Requests{},
+					NonTASUsage: resources.Requests{},
+				}
+				perLevel[idx][domainID] = r
+			}
+			r.Capacity.Add(capacity)
+			r.TASUsage.Add(leaf.tasUsage)
+			r.NonTASUsage.Add(leaf.nonTASUsage)
+		}
+	}
+	result := make(map[string][]TASDomainResources, len(s.levelKeys))
+	for idx, level := range s.levelKeys {
+		domains := make([]TASDomainResources, 0, len(perLevel[idx]))
+		for _, r := range perLevel[idx] {
+			domains = append(domains, *r)
+		}
+		slices.SortFunc(domains, func(a, b TASDomainResources) int {
+			return cmp.Compare(a.Domain, b.Domain)
+		})
+		result[level] = domains
+	}
+	return result
+}
+
+// AssignmentDomains returns the IDs of the topology domains, keyed by the
+// level, which hold the pods of the topology assignment. The IDs are built
+// from the values of all the levels down to the level, like the IDs returned
+// by DomainResources.
+func (s *TASFlavorSnapshot) AssignmentDomains(assignment *kueue.TopologyAssignment) map[string]sets.Set[utiltas.TopologyDomainID] {
+	result := make(map[string]sets.Set[utiltas.TopologyDomainID], len(s.levelKeys))
+	for _, domain := range assignment.Domains {
+		leaf, found := s.leaves[utiltas.DomainID(domain.Values)]
+		if !found {
+			continue
+		}
+		for idx, level := range s.levelKeys {
+			if result[level] == nil {
+				result[level] = sets.New[utiltas.TopologyDomainID]()
+			}
+			result[level].Insert(utiltas.DomainID(leaf.levelValues[:idx+1]))
+		}
+	}
+	return result
+}
+
 type domainCapacityDetails struct {
 	FreeCapacity map[corev1.ResourceName]string `json:"freeCapacity"`
 	TasUsage     map[corev1.ResourceName]string `json:"tasUsage"`
@@ -371,24 +479,459 @@ func (s *TASFlavorSnapshot) Fits(flavorUsage workload.TASFlavorUsage) bool {
 // the TAS requests in the flavor handled by the snapshot.
 // The simulateEmpty parameter allows to look for the assignment under the
 // assumption that all TAS workloads are preempted.
+// The PodSets which belong to the same PodSet group are placed together within
+// a single topology domain.
 func (s *TASFlavorSnapshot) FindTopologyAssignmentsForFlavor(flavorTASRequests FlavorTASRequests, simulateEmpty bool) TASAssignmentsResult {
 	result := make(map[kueue.PodSetReference]tasPodSetAssignmentResult)
 	assumedUsage := make(map[utiltas.TopologyDomainID]resources.Requests)
+	groups := podSetGroups(flavorTASRequests)
 	for _, tr := range flavorTASRequests {
-		assignment, reason := s.findTopologyAssignment(tr, assumedUsage, simulateEmpty)
+		if _, done := result[tr.PodSet.Name]; done {
+			continue
+		}
+		group := groups[podSetGroupName(tr.PodSet.TopologyRequest)]
+		if len(group) > 1 {
+			assignments, reason := s.findGroupTopologyAssignments(group, assumedUsage, simulateEmpty)
+			if reason != "" {
+				result[tr.PodSet.Name] = tasPodSetAssignmentResult{FailureReason: reason}
+				return result
+			}
+			for i := range group {
+				result[group[i].PodSet.Name] = tasPodSetAssignmentResult{TopologyAssignment: assignments[i]}
+				addAssumedUsage(assumedUsage, assignments[i], &group[i])
+			}
+			continue
+		}
+		assignment, reason := s.findTopologyAssignment(tr, assumedUsage, simulateEmpty, nil)
 		result[tr.PodSet.Name] = tasPodSetAssignmentResult{TopologyAssignment: assignment, FailureReason: reason}
 		if reason != "" {
 			return result
 		}
-		for _, domain := range assignment.Domains {
-			domainID := utiltas.DomainID(domain.Values)
-			if assumedUsage[domainID] == nil {
-				assumedUsage[domainID] = resources.Requests{}
+		addAssumedUsage(assumedUsage, assignment, &tr)
+	}
+	return result
+}
+
+func addAssumedUsage(assumedUsage map[utiltas.TopologyDomainID]resources.Requests, assignment *kueue.TopologyAssignment, tr *TASPodSetRequests) {
+	for _, domain := range assignment.Domains {
+		domainID := utiltas.DomainID(domain.Values)
+		if assumedUsage[domainID] == nil {
+			assumedUsage[domainID] = resources.Requests{}
+		}
+		assumedUsage[domainID].Add(tr.TotalRequests())
+	}
+}
+
+func podSetGroupName(tr *kueue.PodSetTopologyRequest) string {
+	if tr == nil || !features.Enabled(features.TASPodSetGroups) {
+		return ""
+	}
+	return ptr.Deref(tr.PodSetGroupName, "")
+}
+
+// podSetGroups returns the TAS requests grouped by the PodSet group name.
+func podSetGroups(flavorTASRequests FlavorTASRequests) map[string][]TASPodSetRequests {
+	groups := make(map[string][]TASPodSetRequests)
+	for _, tr := range flavorTASRequests {
+		if name := podSetGroupName(tr.PodSet.TopologyRequest); name != "" {
+			groups[name] = append(groups[name], tr)
+		}
+	}
+	return groups
+}
+
+// findGroupTopologyAssignments returns the assignments for the PodSets of a
+// PodSet group, such that all of them are placed within a single domain at
+// the level requested by the PodSets. The domains are evaluated in the order
+// of the free capacity for the first PodSet, and the PodSets are assigned
+// one after another within the domain, so that their combined requests are
+// accounted for. When no domain fits the group, the next level up is
+// considered, unless the level is required. At the highest level, the
+// PodSets are assigned independently.
+func (s *TASFlavorSnapshot) findGroupTopologyAssignments(
+	group []TASPodSetRequests,
+	assumedUsage map[utiltas.TopologyDomainID]resources.Requests,
+	simulateEmpty bool) ([]*kueue.TopologyAssignment, string) {
+	groupName := podSetGroupName(group[0].PodSet.TopologyRequest)
+	key := s.levelKey(group[0].PodSet.TopologyRequest)
+	required := false
+	for _, tr := range group {
+		if otherKey := s.levelKey(tr.PodSet.TopologyRequest); key == nil || otherKey == nil || *otherKey != *key {
+			return nil, fmt.Sprintf("podset group %q must request the same topology level for all podsets", groupName)
+		}
+		required = required || isRequired(tr.PodSet.TopologyRequest)
+	}
+	levelIdx, found := s.resolveLevelIdx(*key)
+	if !found {
+		return nil, fmt.Sprintf("no requested topology level: %s", *key)
+	}
+	first := group[0]
+	requests := first.SinglePodRequests.Clone()
+	requests.Add(resources.Requests{corev1.ResourcePods: 1})
+	for ; levelIdx >= 0; levelIdx-- {
+		s.fillInCounts(requests, assumedUsage, simulateEmpty,
+			append(slices.Clone(first.PodSet.Template.Spec.Tolerations), s.tolerations...),
+			s.nodeSelector(&first.PodSet.Template.Spec), nil)
+		// The scopes are recorded before trying any of them, as looking for
+		// an assignment within a domain recomputes the counts.
+		var scopes [][]string
+		for _, candidate := range s.sortedDomains(slices.Collect(maps.Values(s.domainsPerLevel[levelIdx])), false) {
+			if candidate.state >= first.Count {
+				scopes = append(scopes, candidate.levelValues)
 			}
-			assumedUsage[domainID].Add(tr.TotalRequests())
+		}
+		for _, scope := range scopes {
+			if assignments, fits := s.findGroupTopologyAssignmentsInDomain(group, assumedUsage, simulateEmpty, scope); fits {
+				return assignments, ""
+			}
+		}
+		if required {
+			return nil, fmt.Sprintf("topology %q doesn't allow to fit the podset group %q within a single domain at level: %s",
+				s.topologyName, groupName, s.levelKeys[levelIdx])
 		}
 	}
-	return result
+	assignments, fits := s.findGroupTopologyAssignmentsInDomain(group, assumedUsage, simulateEmpty, nil)
+	if !fits {
+		return nil, fmt.Sprintf("topology %q doesn't allow to fit the podset group %q", s.topologyName, groupName)
+	}
+	return assignments, ""
+}
+
+// findGroupTopologyAssignmentsInDomain returns the assignments for the PodSets
+// of the group within the domain identified by the scope level values, and
+// whether all the PodSets fit.
+func (s *TASFlavorSnapshot) findGroupTopologyAssignmentsInDomain(
+	group []TASPodSetRequests,
+	assumedUsage map[utiltas.TopologyDomainID]resources.Requests,
+	simulateEmpty bool,
+	scope []string) ([]*kueue.TopologyAssignment, bool) {
+	groupUsage := make(map[utiltas.TopologyDomainID]resources.Requests, len(assumedUsage))
+	for domainID, usage := range assumedUsage {
+		groupUsage[domainID] = usage.Clone()
+	}
+	assignments := make([]*kueue.TopologyAssignment, len(group))
+	for i := range group {
+		assignment, reason := s.findTopologyAssignment(group[i], groupUsage, simulateEmpty, scope)
+		if reason != "" {
+			return nil, false
+		}
+		assignments[i] = assignment
+		addAssumedUsage(groupUsage, assignment, &group[i])
+	}
+	return assignments, true
+}
+
+// UsageDomains returns the IDs of the domains, at the highest topology level
+// requested by the TAS requests, which contain the usage. It returns false
+// when the level cannot be determined, or when some of the usage is outside
+// of the snapshot.
+func (s *TASFlavorSnapshot) UsageDomains(requests FlavorTASRequests, usage workload.TASFlavorUsage) (sets.Set[utiltas.TopologyDomainID], bool) {
+	levelIdx := -1
+	for i := range requests {
+		key := s.levelKeyWithImpliedFallback(&requests[i])
+		if key == nil {
+			return nil, false
+		}
+		idx, found := s.resolveLevelIdx(*key)
+		if !found {
+			return nil, false
+		}
+		if levelIdx == -1 || idx < levelIdx {
+			levelIdx = idx
+		}
+	}
+	if levelIdx == -1 {
+		return nil, false
+	}
+	result := sets.New[utiltas.TopologyDomainID]()
+	for _, domainUsage := range usage {
+		leaf, found := s.leaves[utiltas.DomainID(domainUsage.Values)]
+		if !found {
+			return nil, false
+		}
+		result.Insert(utiltas.DomainID(leaf.levelValues[:levelIdx+1]))
+	}
+	return result, true
+}
+
+// FindReplacementAssignment returns the topology assignment of the PodSet in
+// which the pods assigned to the failed node are moved to other nodes, as
+// close as possible to the failed node in the topology. The nodes already in
+// the assignment are not used as replacements, so that the assignment of the
+// remaining pods is kept. The failed node is identified by its hostname label
+// value in the assignment, and failedNodeObj is the failed node, or nil when
+// it was deleted. The location of the failed node is determined from the node
+// or, when it was deleted, from the other nodes in the assignment.
+// The domain at the required topology level, if any, is kept. The usage of
+// the replacement pods is added to the snapshot.
+func (s *TASFlavorSnapshot) FindReplacementAssignment(
+	tasPodSetRequests TASPodSetRequests,
+	assignment *kueue.TopologyAssignment,
+	failedNode string,
+	failedNodeObj *corev1.Node) (*kueue.TopologyAssignment, string) {
+	if !s.isLowestLevelNode() {
+		return nil, "replacing nodes requires the lowest topology level to be the node"
+	}
+	failedIdx := slices.IndexFunc(assignment.Domains, func(d kueue.TopologyDomainAssignment) bool {
+		return d.Values[len(d.Values)-1] == failedNode
+	})
+	if failedIdx < 0 {
+		return assignment, ""
+	}
+	count := assignment.Domains[failedIdx].Count
+	anchor := s.replacementAnchor(assignment, failedNodeObj)
+	minSharedLevels := 0
+	if tr := tasPodSetRequests.PodSet.TopologyRequest; isRequired(tr) {
+		levelIdx, found := s.resolveLevelIdx(*tr.Required)
+		if !found {
+			return nil, fmt.Sprintf("no requested topology level: %s", *tr.Required)
+		}
+		if anchor == nil {
+			return nil, fmt.Sprintf("unknown topology domain of the failed node %q", failedNode)
+		}
+		minSharedLevels = levelIdx + 1
+	}
+	maxSharedLevels := 0
+	if anchor != nil {
+		maxSharedLevels = len(s.levelKeys) - 1
+	}
+
+	requests := tasPodSetRequests.SinglePodRequests.Clone()
+	requests.Add(resources.Requests{corev1.ResourcePods: 1})
+	spec := &tasPodSetRequests.PodSet.Template.Spec
+	s.fillInCounts(requests, nil, false, append(slices.Clone(spec.Tolerations), s.tolerations...), s.nodeSelector(spec), nil)
+
+	assigned := sets.New(utiltas.AssignedHostnames(assignment)...)
+	// look for replacements in the domains sharing the most levels with the
+	// failed node first.
+	for shared := maxSharedLevels; shared >= minSharedLevels; shared-- {
+		var candidates []*leafDomain
+		var fitCount int32
+		for _, leaf := range s.leaves {
+			if leaf.state == 0 || assigned.Has(string(leaf.id)) || !slices.Equal(leaf.levelValues[:shared], anchor[:shared]) {
+				continue
+			}
+			candidates = append(candidates, leaf)
+			fitCount += leaf.state
+		}
+		if fitCount < count {
+			continue
+		}
+		// prefer the nodes which fit the most pods, to minimize the number of
+		// replacement nodes.
+		slices.SortFunc(candidates, func(a, b *leafDomain) int {
+			if a.state != b.state {
+				return cmp.Compare(b.state, a.state)
+			}
+			return cmp.Compare(a.id, b.id)
+		})
+		var replacements []kueue.TopologyDomainAssignment
+		remaining := count
+		for _, leaf := range candidates {
+			if remaining == 0 {
+				break
+			}
+			leafCount := min(leaf.state, remaining)
+			replacements = append(replacements, kueue.TopologyDomainAssignment{
+				Values: leaf.levelValues[len(leaf.levelValues)-1:],
+				Count:  leafCount,
+			})
+			// account for the usage, in case the snapshot is used to
+			// replace the node for other PodSets.
+			s.addTASUsage(leaf.id, requests.ScaledUp(int64(leafCount)))
+			remaining -= leafCount
+		}
+		result := assignment.DeepCopy()
+		result.Domains = slices.Concat(result.Domains[:failedIdx], replacements, result.Domains[failedIdx+1:])
+		return result, ""
+	}
+	return nil, fmt.Sprintf("topology %q doesn't allow to fit the %v pod(s) assigned to the failed node %q", s.topologyName, count, failedNode)
+}
+
+// replacementAnchor returns the level values of the failed node, from the
+// source of the topology domains of the nodes, keyed by the node name, or
+// from its labels, or from another node of the assignment present in the
+// snapshot.
+func (s *TASFlavorSnapshot) replacementAnchor(assignment *kueue.TopologyAssignment, failedNode *corev1.Node) []string {
+	if failedNode != nil {
+		if s.nodeDomains == nil {
+			return utiltas.LevelValues(s.levelKeys, failedNode.Labels)
+		}
+		hostname, hasHostname := failedNode.Labels[corev1.LabelHostname]
+		if values, found := s.nodeDomains[failedNode.Name]; found && hasHostname && len(values) == len(s.levelKeys)-1 {
+			return append(slices.Clone(values), hostname)
+		}
+	}
+	for _, node := range utiltas.AssignedHostnames(assignment) {
+		if leaf, found := s.leaves[utiltas.TopologyDomainID(node)]; found {
+			return leaf.levelValues
+		}
+	}
+	return nil
+}
+
+// TASDefragmentationCandidate is a workload which can be evicted by the
+// defragmentation of the flavor, along with its TAS requests and usage in
+// the flavor.
+type TASDefragmentationCandidate struct {
+	Workload *workload.Info
+	Requests FlavorTASRequests
+	Usage    workload.TASFlavorUsage
+}
+
+// TASDefragmentationPlan is the set of workloads to evict in order to free
+// a topology domain from the TAS workloads.
+type TASDefragmentationPlan struct {
+	Domain    utiltas.TopologyDomainID
+	Workloads []*workload.Info
+}
+
+// PlanDefragmentation returns the plan to free a topology domain at the
+// level, when less than minFreeDomains domains at the level are free from
+// the TAS workloads. The domain is chosen such that all the workloads using
+// it are candidates, at most maxEvictions of them, and they fit again in the
+// other domains of the topology. The domain with the fewest workloads to
+// evict is preferred. It returns nil when no defragmentation is needed, or
+// when it is not possible.
+func (s *TASFlavorSnapshot) PlanDefragmentation(levelKey string, minFreeDomains, maxEvictions int, candidates []TASDefragmentationCandidate) *TASDefragmentationPlan {
+	if levelKey == "" {
+		levelKey = s.levelKeys[0]
+	}
+	levelIdx, found := s.resolveLevelIdx(levelKey)
+	if !found {
+		return nil
+	}
+	leavesPerDomain := make(map[utiltas.TopologyDomainID][]*leafDomain)
+	for _, leaf := range s.leaves {
+		domainID := utiltas.DomainID(leaf.levelValues[:levelIdx+1])
+		leavesPerDomain[domainID] = append(leavesPerDomain[domainID], leaf)
+	}
+	freeDomains := 0
+	for _, leaves := range leavesPerDomain {
+		if !slices.ContainsFunc(leaves, func(leaf *leafDomain) bool { return !isEmptyUsage(leaf.tasUsage) }) {
+			freeDomains++
+		}
+	}
+	if freeDomains >= minFreeDomains {
+		return nil
+	}
+
+	// candidatesPerDomain maps the domains to the indexes of the candidates
+	// using them, and candidateUsage maps the leaves to the usage of the
+	// candidates.
+	candidatesPerDomain := make(map[utiltas.TopologyDomainID]sets.Set[int])
+	candidateUsage := make(map[utiltas.TopologyDomainID]resources.Requests)
+	for i, candidate := range candidates {
+		for _, domainUsage := range candidate.Usage {
+			leafID := utiltas.DomainID(domainUsage.Values)
+			leaf, found := s.leaves[leafID]
+			if !found {
+				continue
+			}
+			domainID := utiltas.DomainID(leaf.levelValues[:levelIdx+1])
+			if candidatesPerDomain[domainID] == nil {
+				candidatesPerDomain[domainID] = sets.New[int]()
+			}
+			candidatesPerDomain[domainID].Insert(i)
+			if candidateUsage[leafID] == nil {
+				candidateUsage[leafID] = resources.Requests{}
+			}
+			candidateUsage[leafID].Add(domainUsage.TotalRequests())
+			candidateUsage[leafID].Add(resources.Requests{corev1.ResourcePods: int64(domainUsage.Count)})
+		}
+	}
+
+	domainIDs := make([]utiltas.TopologyDomainID, 0, len(candidatesPerDomain))
+	for domainID, indexes := range candidatesPerDomain {
+		if indexes.Len() > maxEvictions || !s.onlyCandidatesUse(leavesPerDomain[domainID], candidateUsage) {
+			continue
+		}
+		domainIDs = append(domainIDs, domainID)
+	}
+	slices.SortFunc(domainIDs, func(a, b utiltas.TopologyDomainID) int {
+		if diff := candidatesPerDomain[a].Len() - candidatesPerDomain[b].Len(); diff != 0 {
+			return diff
+		}
+		return cmp.Compare(a, b)
+	})
+	for _, domainID := range domainIDs {
+		evicted := make([]*TASDefragmentationCandidate, 0, candidatesPerDomain[domainID].Len())
+		for _, i := range sets.List(candidatesPerDomain[domainID]) {
+			evicted = append(evicted, &candidates[i])
+		}
+		if !s.fitsOutsideDomain(leavesPerDomain[domainID], evicted) {
+			s.log.V(3).Info("evicted workloads don't fit outside of the domain", "domain", domainID)
+			continue
+		}
+		plan := &TASDefragmentationPlan{Domain: domainID}
+		for _, candidate := range evicted {
+			plan.Workloads = append(plan.Workloads, candidate.Workload)
+		}
+		return plan
+	}
+	return nil
+}
+
+// onlyCandidatesUse checks if the TAS usage of the leaves comes only from
+// the candidates.
+func (s *TASFlavorSnapshot) onlyCandidatesUse(leaves []*leafDomain, candidateUsage map[utiltas.TopologyDomainID]resources.Requests) bool {
+	for _, leaf := range leaves {
+		remaining := leaf.tasUsage.Clone()
+		remaining.Sub(candidateUsage[leaf.id])
+		if !isEmptyUsage(remaining) {
+			return false
+		}
+	}
+	return true
+}
+
+// fitsOutsideDomain checks if the evicted workloads can be placed again
+// without using the leaves of the freed domain. The snapshot is restored
+// before returning.
+func (s *TASFlavorSnapshot) fitsOutsideDomain(leaves []*leafDomain, evicted []*TASDefragmentationCandidate) bool {
+	for _, candidate := range evicted {
+		for _, domainUsage := range candidate.Usage {
+			s.updateTASUsage(utiltas.DomainID(domainUsage.Values), domainUsage.TotalRequests(), subtract, domainUsage.Count)
+		}
+	}
+	defer func() {
+		for _, candidate := range evicted {
+			for _, domainUsage := range candidate.Usage {
+				s.updateTASUsage(utiltas.DomainID(domainUsage.Values), domainUsage.TotalRequests(), add, domainUsage.Count)
+			}
+		}
+	}()
+	assumedUsage := make(map[utiltas.TopologyDomainID]resources.Requests)
+	for _, leaf := range leaves {
+		assumedUsage[leaf.id] = leaf.freeCapacity.Clone()
+	}
+	for _, candidate := range evicted {
+		for i := range candidate.Requests {
+			tr := &candidate.Requests[i]
+			assignment, reason := s.findTopologyAssignment(*tr, assumedUsage, false, nil)
+			if reason != "" {
+				return false
+			}
+			for _, domain := range assignment.Domains {
+				domainID := utiltas.DomainID(domain.Values)
+				if assumedUsage[domainID] == nil {
+					assumedUsage[domainID] = resources.Requests{}
+				}
+				assumedUsage[domainID].Add(tr.SinglePodRequests.ScaledUp(int64(domain.Count)))
+				assumedUsage[domainID].Add(resources.Requests{corev1.ResourcePods: int64(domain.Count)})
+			}
+		}
+	}
+	return true
+}
+
+func isEmptyUsage(usage resources.Requests) bool {
+	for _, v := range usage {
+		if v > 0 {
+			return false
+		}
+	}
+	return true
 }
 
 // Algorithm overview:
@@ -403,13 +946,18 @@ func (s *TASFlavorSnapshot) FindTopologyAssignmentsForFlavor(flavorTASRequests F
 //	b) traverse the structure down level-by-level optimizing the number of used
 //	  domains at each level
 //	c) build the assignment for the lowest level in the hierarchy
+//
+// When scope is set, only the domains within the domain identified by the
+// scope level values are considered.
 func (s *TASFlavorSnapshot) findTopologyAssignment(
 	tasPodSetRequests TASPodSetRequests,
 	assumedUsage map[utiltas.TopologyDomainID]resources.Requests,
-	simulateEmpty bool) (*kueue.TopologyAssignment, string) {
+	simulateEmpty bool,
+	scope []string) (*kueue.TopologyAssignment, string) {
 	requests := tasPodSetRequests.SinglePodRequests.Clone()
 	requests.Add(resources.Requests{corev1.ResourcePods: 1})
 	podSetTolerations := tasPodSetRequests.PodSet.Template.Spec.Tolerations
+	selector := s.nodeSelector(&tasPodSetRequests.PodSet.Template.Spec)
 	count := tasPodSetRequests.Count
 	required := isRequired(tasPodSetRequests.PodSet.TopologyRequest)
 	key := s.levelKeyWithImpliedFallback(&tasPodSetRequests)
@@ -422,7 +970,14 @@ func (s *TASFlavorSnapshot) findTopologyAssignment(
 		return nil, fmt.Sprintf("no requested topology level: %s", *key)
 	}
 	// phase 1 - determine the number of pods which can fit in each topology domain
-	s.fillInCounts(requests, assumedUsage, simulateEmpty, append(podSetTolerations, s.tolerations...))
+	s.fillInCounts(requests, assumedUsage, simulateEmpty, append(podSetTolerations, s.tolerations...), selector, scope)
+
+	if isBalanced(tasPodSetRequests.PodSet.TopologyRequest) {
+		return s.findBalancedAssignment(levelIdx, count)
+	}
+	if isSliced(tasPodSetRequests.PodSet.TopologyRequest) {
+		return s.findSliceAssignment(tasPodSetRequests.PodSet.TopologyRequest, levelIdx, required, count)
+	}
 
 	// phase 2a: determine the level at which the assignment is done along with
 	// the domains which can accommodate all pods
@@ -483,6 +1038,8 @@ func (s *TASFlavorSnapshot) levelKey(topologyRequest *kueue.PodSetTopologyReques
 		return topologyRequest.Required
 	case topologyRequest.Preferred != nil:
 		return topologyRequest.Preferred
+	case topologyRequest.Balanced != nil:
+		return topologyRequest.Balanced
 	case ptr.Deref(topologyRequest.Unconstrained, false):
 		return ptr.To(s.lowestLevel())
 	default:
@@ -494,6 +1051,178 @@ func isUnconstrained(tr *kueue.PodSetTopologyRequest, tasRequests *TASPodSetRequ
 	return (tr != nil && tr.Unconstrained != nil && *tr.Unconstrained) || tasRequests.Implied
 }
 
+func isBalanced(tr *kueue.PodSetTopologyRequest) bool {
+	return tr != nil && tr.Balanced != nil && features.Enabled(features.TASBalancedPlacement)
+}
+
+func isSliced(tr *kueue.PodSetTopologyRequest) bool {
+	return tr != nil && tr.PodSetSliceRequiredTopology != nil && tr.PodSetSliceSize != nil &&
+		features.Enabled(features.TASPodSetSlices)
+}
+
+// findSliceAssignment splits the pods into slices of the requested size, and
+// places each of the slices within a single domain at the slice level, while
+// all the slices are placed within a domain at the given level, or at the
+// levels above if not required. The domains at the slice level are recorded
+// in the assignment, with the number of slices in each of them.
+func (s *TASFlavorSnapshot) findSliceAssignment(tr *kueue.PodSetTopologyRequest, levelIdx int, required bool, count int32) (*kueue.TopologyAssignment, string) {
+	sliceLevelIdx, found := s.resolveLevelIdx(*tr.PodSetSliceRequiredTopology)
+	if !found {
+		return nil, fmt.Sprintf("no requested topology level for slices: %s", *tr.PodSetSliceRequiredTopology)
+	}
+	if sliceLevelIdx < levelIdx {
+		return nil, fmt.Sprintf("slice topology level %s must not be above the topology level %s", s.levelKeys[sliceLevelIdx], s.levelKeys[levelIdx])
+	}
+	sliceSize := *tr.PodSetSliceSize
+	if count%sliceSize != 0 {
+		return nil, fmt.Sprintf("pod count %v is not divisible by the slice size %v", count, sliceSize)
+	}
+	sliceCount := count / sliceSize
+
+	// convert the counts of the domains at the slice level, and above, to
+	// the number of slices which fit in the domains.
+	for _, d := range s.domainsPerLevel[sliceLevelIdx] {
+		d.state /= sliceSize
+	}
+	for idx := sliceLevelIdx - 1; idx >= 0; idx-- {
+		for _, d := range s.domainsPerLevel[idx] {
+			d.state = 0
+			for _, child := range d.children {
+				d.state += child.state
+			}
+		}
+	}
+
+	fitLevelIdx, sliceDomains, reason := s.findLevelWithFitDomains(levelIdx, required, sliceCount, false)
+	if len(reason) > 0 {
+		return nil, fmt.Sprintf("topology %q doesn't allow to fit %v slice(s) of %v pod(s)", s.topologyName, sliceCount, sliceSize)
+	}
+	sliceDomains = s.updateCountsToMinimum(sliceDomains, sliceCount, false)
+	for idx := fitLevelIdx; idx < sliceLevelIdx; idx++ {
+		sliceDomains = s.updateCountsToMinimum(s.sortedDomains(s.lowerLevelDomains(sliceDomains), false), sliceCount, false)
+	}
+
+	// place the pods of the slices within each of the domains at the slice level
+	var leaves []*domain
+	sliceAssignments := make([]kueue.TopologyDomainAssignment, 0, len(sliceDomains))
+	for _, sliceDomain := range sliceDomains {
+		sliceAssignments = append(sliceAssignments, kueue.TopologyDomainAssignment{
+			Values: slices.Clone(sliceDomain.levelValues),
+			Count:  sliceDomain.state,
+		})
+		podCount := sliceDomain.state * sliceSize
+		sliceDomain.state = podCount
+		domains := []*domain{sliceDomain}
+		for idx := sliceLevelIdx; idx+1 < len(s.domainsPerLevel); idx++ {
+			domains = s.updateCountsToMinimum(s.sortedDomains(s.lowerLevelDomains(domains), false), podCount, false)
+		}
+		leaves = append(leaves, domains...)
+	}
+	slices.SortFunc(sliceAssignments, func(a, b kueue.TopologyDomainAssignment) int {
+		return slices.Compare(a.Values, b.Values)
+	})
+	assignment := s.buildAssignment(leaves)
+	assignment.SliceDomains = sliceAssignments
+	return assignment, ""
+}
+
+// findBalancedAssignment spreads the pods evenly across the domains at the
+// given level, all within a single domain of the level above. It chooses the
+// highest number of domains which divides the number of pods, such that each
+// of the domains fits its share of the pods. Within each of the chosen domains
+// the pods are assigned to as few lower-level domains as possible.
+func (s *TASFlavorSnapshot) findBalancedAssignment(levelIdx int, count int32) (*kueue.TopologyAssignment, string) {
+	var groups [][]*domain
+	if levelIdx == 0 {
+		groups = append(groups, slices.Collect(maps.Values(s.roots)))
+	} else {
+		parents := s.sortedDomains(slices.Collect(maps.Values(s.domainsPerLevel[levelIdx-1])), false)
+		for _, parent := range parents {
+			groups = append(groups, parent.children)
+		}
+	}
+	var chosen []*domain
+	maxGroupSize := 0
+	for _, group := range groups {
+		maxGroupSize = max(maxGroupSize, len(group))
+		if candidate := balancedDomains(group, count); len(candidate) > len(chosen) {
+			chosen = candidate
+		}
+	}
+	if len(chosen) == 0 {
+		if minBalancedDomains(count, int32(maxGroupSize)) == 0 {
+			return nil, fmt.Sprintf("topology %q doesn't allow to spread %v pod(s) evenly across domains at level: %s, as they can't be divided between %v or fewer domains",
+				s.topologyName, count, s.levelKeys[levelIdx], maxGroupSize)
+		}
+		return nil, fmt.Sprintf("topology %q doesn't allow to spread %v pod(s) evenly across domains at level: %s",
+			s.topologyName, count, s.levelKeys[levelIdx])
+	}
+	perDomain := count / int32(len(chosen))
+	var result []*domain
+	for _, dom := range chosen {
+		dom.state = perDomain
+		currFitDomain := []*domain{dom}
+		for lowerIdx := levelIdx; lowerIdx+1 < len(s.domainsPerLevel); lowerIdx++ {
+			lowerFitDomains := s.lowerLevelDomains(currFitDomain)
+			currFitDomain = s.updateCountsToMinimum(s.sortedDomains(lowerFitDomains, false), perDomain, false)
+		}
+		result = append(result, currFitDomain...)
+	}
+	return s.buildAssignment(result), ""
+}
+
+// minBalancedDomains returns the lowest number of domains, not higher than
+// maxDomains, across which the pods can be spread evenly, or 0 if there is
+// none. Several pods are never spread across a single domain, as that
+// wouldn't be balanced.
+func minBalancedDomains(count, maxDomains int32) int32 {
+	if count == 1 {
+		return 1
+	}
+	for k := int32(2); k <= min(maxDomains, count); k++ {
+		if count%k == 0 {
+			return k
+		}
+	}
+	return 0
+}
+
+// balancedDomains returns the largest set of domains, among the given
+// sibling domains, across which the pods can be spread evenly, or nil if there
+// is no such set.
+func balancedDomains(domains []*domain, count int32) []*domain {
+	minK := minBalancedDomains(count, int32(len(domains)))
+	if minK == 0 {
+		return nil
+	}
+	sorted := slices.Clone(domains)
+	slices.SortFunc(sorted, func(a, b *domain) int {
+		if a.state == b.state {
+			return slices.Compare(a.levelValues, b.levelValues)
+		}
+		return cmp.Compare(b.state, a.state)
+	})
+	for k := min(int32(len(sorted)), count); k >= minK; k-- {
+		if count%k != 0 {
+			continue
+		}
+		perDomain := count / k
+		fitCount := int32(slices.IndexFunc(sorted, func(d *domain) bool { return d.state < perDomain }))
+		if fitCount == -1 {
+			fitCount = int32(len(sorted))
+		}
+		if fitCount < k {
+			continue
+		}
+		if useBestFitAlgorithm(false) {
+			// choose the domains with the least free capacity which fit
+			return sorted[fitCount-k : fitCount]
+		}
+		return sorted[:k]
+	}
+	return nil
+}
+
 // findBestFitDomainIdx finds an index of the first domain with the lowest
 // value of state, higher or equal than count.
 // If such a domain doesn't exist, it returns 0 as it's an index of the domain with the
@@ -647,13 +1376,18 @@ func (s *TASFlavorSnapshot) sortedDomains(domains []*domain, unconstrained bool)
 func (s *TASFlavorSnapshot) fillInCounts(requests resources.Requests,
 	assumedUsage map[utiltas.TopologyDomainID]resources.Requests,
 	simulateEmpty bool,
-	tolerations []corev1.Toleration) {
+	tolerations []corev1.Toleration,
+	selector nodeaffinity.RequiredNodeAffinity,
+	scope []string) {
 	for _, domain := range s.domains {
 		// cleanup the state in case some remaining values are present from computing
 		// assignments for previous PodSets.
 		domain.state = 0
 	}
 	for _, leaf := range s.leaves {
+		if !slices.Equal(leaf.levelValues[:len(scope)], scope) {
+			continue
+		}
 		taint, untolerated := corev1helpers.FindMatchingUntoleratedTaint(leaf.nodeTaints, tolerations, func(t *corev1.Taint) bool {
 			return t.Effect == corev1.TaintEffectNoSchedule || t.Effect == corev1.TaintEffectNoExecute
 		})
@@ -661,6 +1395,10 @@ func (s *TASFlavorSnapshot) fillInCounts(requests resources.Requests,
 			s.log.V(2).Info("excluding node with untolerated taint", "domainID", leaf.id, "taint", taint)
 			continue
 		}
+		if match, err := selector.Match(s.leafNode(leaf)); !match || err != nil {
+			s.log.V(2).Info("excluding domain not matching the node affinity", "domainID", leaf.id, "error", err)
+			continue
+		}
 		remainingCapacity := leaf.freeCapacity.Clone()
 		if !simulateEmpty {
 			remainingCapacity.Sub(leaf.tasUsage)
@@ -675,6 +1413,36 @@ func (s *TASFlavorSnapshot) fillInCounts(requests resources.Requests,
 	}
 }
 
+// nodeSelector returns the matcher for the node selector and the required
+// node affinity of the PodSpec. When the lowest level of the topology is
+// node, the leaf domains are matched against all the node labels. Otherwise,
+// only the requirements for the topology levels are kept, as the nodes in a
+// leaf domain share only the labels of the levels.
+func (s *TASFlavorSnapshot) nodeSelector(spec *corev1.PodSpec) nodeaffinity.RequiredNodeAffinity {
+	if s.isLowestLevelNode() {
+		return nodeaffinity.GetRequiredNodeAffinity(&corev1.Pod{Spec: *spec})
+	}
+	return utilpod.RequiredNodeAffinity(spec, sets.New(s.levelKeys...))
+}
+
+// leafNode returns a Node with the labels of the leaf, to match it against
+// the node affinity. When the lowest level is the hostname, the Node has the
+// name of the node of the leaf, so that the matchFields terms on the node
+// name match.
+func (s *TASFlavorSnapshot) leafNode(leaf *leafDomain) *corev1.Node {
+	return &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:   leaf.nodeName,
+		Labels: s.leafLabels(leaf),
+	}}
+}
+
+func (s *TASFlavorSnapshot) leafLabels(leaf *leafDomain) map[string]string {
+	if leaf.nodeLabels != nil {
+		return leaf.nodeLabels
+	}
+	return utiltas.NodeLabelsFromKeysAndValues(s.levelKeys, leaf.levelValues)
+}
+
 func (s *TASFlavorSnapshot) fillInCountsHelper(domain *domain) int32 {
 	// logic for a leaf
 	if len(domain.children) == 0 {
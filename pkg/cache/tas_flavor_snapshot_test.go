@@ -17,13 +17,23 @@ limitations under the License.
 package cache
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
 
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/resources"
+	utiltas "sigs.k8s.io/kueue/pkg/util/tas"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	testingnode "sigs.k8s.io/kueue/pkg/util/testingjobs/node"
+	testingpod "sigs.k8s.io/kueue/pkg/util/testingjobs/pod"
+	"sigs.k8s.io/kueue/pkg/workload"
 )
 
 func TestFreeCapacityPerDomain(t *testing.T) {
@@ -65,3 +75,960 @@ func TestFreeCapacityPerDomain(t *testing.T) {
 		t.Errorf("SerializeFreeCapacityPerDomain() mismatch (-expected +got):\n%s", diff)
 	}
 }
+
+func TestDomainResources(t *testing.T) {
+	const tasRackLabel = "cloud.com/topology-rack"
+	levels := []string{tasRackLabel, corev1.LabelHostname}
+	makeNode := func(rack, name string) corev1.Node {
+		return *testingnode.MakeNode(name).
+			Label(tasRackLabel, rack).
+			Label(corev1.LabelHostname, name).
+			StatusAllocatable(corev1.ResourceList{
+				corev1.ResourceCPU:  resource.MustParse("4"),
+				corev1.ResourcePods: resource.MustParse("10"),
+			}).
+			Ready().
+			Obj()
+	}
+	nodes := []corev1.Node{makeNode("r1", "x1"), makeNode("r1", "x2"), makeNode("r2", "x3")}
+	pods := []corev1.Pod{
+		*testingpod.MakePod("daemon", "ns").NodeName("x1").Request(corev1.ResourceCPU, "1").Obj(),
+	}
+	_, log := utiltesting.ContextWithLog(t)
+	tasCache := NewTASCache(utiltesting.NewFakeClient())
+	snapshot := tasCache.NewTASFlavorCache("default", levels, nil, nil).snapshotForNodes(log, nodes, pods)
+	snapshot.updateTASUsage("x2", resources.Requests{corev1.ResourceCPU: 2000}, add, 2)
+
+	domainResources := func(domain utiltas.TopologyDomainID, capacityCPU, tasCPU, tasPods, nonTASCPU, nonTASPods int64) TASDomainResources {
+		return TASDomainResources{
+			Domain:      domain,
+			Capacity:    resources.Requests{corev1.ResourceCPU: capacityCPU, corev1.ResourcePods: 10 * (capacityCPU / 4000)},
+			TASUsage:    resources.Requests{corev1.ResourceCPU: tasCPU, corev1.ResourcePods: tasPods},
+			NonTASUsage: resources.Requests{corev1.ResourceCPU: nonTASCPU, corev1.ResourcePods: nonTASPods},
+		}
+	}
+	want := map[string][]TASDomainResources{
+		tasRackLabel: {
+			domainResources("r1", 8000, 2000, 2, 1000, 1),
+			domainResources("r2", 4000, 0, 0, 0, 0),
+		},
+		corev1.LabelHostname: {
+			domainResources("r1,x1", 4000, 0, 0, 1000, 1),
+			domainResources("r1,x2", 4000, 2000, 2, 0, 0),
+			domainResources("r2,x3", 4000, 0, 0, 0, 0),
+		},
+	}
+	got := snapshot.DomainResources()
+	if diff := cmp.Diff(want, got, cmpopts.EquateEmpty(), cmpopts.IgnoreMapEntries(func(_ corev1.ResourceName, v int64) bool { return v == 0 })); diff != "" {
+		t.Errorf("Unexpected domain resources (-want,+got):\n%s", diff)
+	}
+	if diff := cmp.Diff(resources.Requests{corev1.ResourceCPU: 5000, corev1.ResourcePods: 17}, got[tasRackLabel][0].Free()); diff != "" {
+		t.Errorf("Unexpected free capacity (-want,+got):\n%s", diff)
+	}
+}
+
+func TestSnapshotWithNodeDomains(t *testing.T) {
+	const tasRackLabel = "cloud.com/topology-rack"
+	levels := []string{tasRackLabel, corev1.LabelHostname}
+	makeNode := func(name string) corev1.Node {
+		return *testingnode.MakeNode(name).
+			Label(tasRackLabel, "labeled-rack").
+			Label(corev1.LabelHostname, name).
+			StatusAllocatable(corev1.ResourceList{
+				corev1.ResourceCPU:  resource.MustParse("1"),
+				corev1.ResourcePods: resource.MustParse("10"),
+			}).
+			Ready().
+			Obj()
+	}
+	nodes := []corev1.Node{makeNode("x1"), makeNode("x2"), makeNode("x3"), makeNode("x4")}
+	_, log := utiltesting.ContextWithLog(t)
+	tasCache := NewTASCache(utiltesting.NewFakeClient())
+	flavorCache := tasCache.NewTASFlavorCache("default", levels, nil, nil)
+	flavorCache.setNodeDomains(NodeDomains{
+		"x1": {"r1"},
+		"x2": {"r1"},
+		"x3": {"r2"},
+		// an entry with the wrong number of levels is skipped
+		"x4": {"b1", "r2"},
+	})
+	snapshot := flavorCache.snapshotForNodes(log, nodes, nil)
+
+	got := snapshot.DomainResources()
+	want := map[string][]utiltas.TopologyDomainID{
+		tasRackLabel:         {"r1", "r2"},
+		corev1.LabelHostname: {"r1,x1", "r1,x2", "r2,x3"},
+	}
+	for level, wantDomains := range want {
+		var gotDomains []utiltas.TopologyDomainID
+		for _, domain := range got[level] {
+			gotDomains = append(gotDomains, domain.Domain)
+		}
+		if diff := cmp.Diff(wantDomains, gotDomains); diff != "" {
+			t.Errorf("Unexpected domains at the level %s (-want,+got):\n%s", level, diff)
+		}
+	}
+	if diff := cmp.Diff("labeled-rack", nodes[0].Labels[tasRackLabel]); diff != "" {
+		t.Errorf("Unexpected change of the node labels (-want,+got):\n%s", diff)
+	}
+}
+
+func TestNewTASFlavorSnapshotWithNodeDomains(t *testing.T) {
+	const tasRackLabel = "cloud.com/topology-rack"
+	levels := []string{tasRackLabel, corev1.LabelHostname}
+	makeNode := func(name string) corev1.Node {
+		return *testingnode.MakeNode(name).
+			Label(corev1.LabelHostname, name).
+			StatusAllocatable(corev1.ResourceList{
+				corev1.ResourceCPU:  resource.MustParse("1"),
+				corev1.ResourcePods: resource.MustParse("10"),
+			}).
+			Ready().
+			Obj()
+	}
+	nodes := []corev1.Node{makeNode("x1"), makeNode("x2"), makeNode("x3")}
+	_, log := utiltesting.ContextWithLog(t)
+	flavor := utiltesting.MakeResourceFlavor("tas").TopologyName("default").Obj()
+	snapshot := NewTASFlavorSnapshot(log, flavor, levels, NodeDomains{
+		"x1": {"r1"},
+		"x2": {"r2"},
+	}, nodes, nil, nil)
+
+	var gotDomains []utiltas.TopologyDomainID
+	for _, domain := range snapshot.DomainResources()[corev1.LabelHostname] {
+		gotDomains = append(gotDomains, domain.Domain)
+	}
+	if diff := cmp.Diff([]utiltas.TopologyDomainID{"r1,x1", "r2,x2"}, gotDomains); diff != "" {
+		t.Errorf("Unexpected domains (-want,+got):\n%s", diff)
+	}
+}
+
+func TestSetNodeDomains(t *testing.T) {
+	levels := []string{"cloud.com/topology-rack", corev1.LabelHostname}
+	tasCache := NewTASCache(utiltesting.NewFakeClient())
+	withSource := tasCache.NewTASFlavorCache("default", levels, nil, nil)
+	withSource.setNodeDomains(NodeDomains{})
+	tasCache.Set("with-source", withSource)
+	withLabels := tasCache.NewTASFlavorCache("other", levels, nil, nil)
+	tasCache.Set("with-labels", withLabels)
+
+	if !tasCache.SetNodeDomains("default", NodeDomains{"x1": {"r1"}}) {
+		t.Error("Expected the node domains to change")
+	}
+	if diff := cmp.Diff(NodeDomains{"x1": {"r1"}}, withSource.nodeDomains); diff != "" {
+		t.Errorf("Unexpected node domains of the flavor (-want,+got):\n%s", diff)
+	}
+	if !tasCache.SetNodeDomains("default", nil) {
+		t.Error("Expected the node domains to change after deletion")
+	}
+	if diff := cmp.Diff(NodeDomains{}, withSource.nodeDomains); diff != "" {
+		t.Errorf("Unexpected node domains of the flavor after deletion (-want,+got):\n%s", diff)
+	}
+	if withLabels.usesNodeDomains() {
+		t.Error("Unexpected node domains of the flavor using the node labels")
+	}
+	if tasCache.SetNodeDomains("default", nil) {
+		t.Error("Unexpected change of the already deleted node domains")
+	}
+}
+
+func TestFindReplacementAssignment(t *testing.T) {
+	const (
+		tasBlockLabel = "cloud.com/topology-block"
+		tasRackLabel  = "cloud.com/topology-rack"
+	)
+	levels := []string{tasBlockLabel, tasRackLabel, corev1.LabelHostname}
+	makeNode := func(block, rack, name, cpu string) corev1.Node {
+		return *testingnode.MakeNode(name).
+			Label(tasBlockLabel, block).
+			Label(tasRackLabel, rack).
+			Label(corev1.LabelHostname, name).
+			StatusAllocatable(corev1.ResourceList{
+				corev1.ResourceCPU:  resource.MustParse(cpu),
+				corev1.ResourcePods: resource.MustParse("10"),
+			}).
+			Ready().
+			Obj()
+	}
+	//        b1            b2
+	//     /      \         |
+	//    r1      r2        r1
+	//  /  |  \    |        |
+	// x0  x1  x2  x3       x4
+	//
+	// x0 failed, and it is not in the snapshot.
+	nodes := []corev1.Node{
+		makeNode("b1", "r1", "x1", "2"),
+		makeNode("b1", "r1", "x2", "2"),
+		makeNode("b1", "r2", "x3", "4"),
+		makeNode("b2", "r1", "x4", "4"),
+	}
+	failedNode := testingnode.MakeNode("x0").
+		Label(tasBlockLabel, "b1").
+		Label(tasRackLabel, "r1").
+		Label(corev1.LabelHostname, "x0").
+		NotReady().
+		Obj()
+	assignment := func(domains ...kueue.TopologyDomainAssignment) *kueue.TopologyAssignment {
+		return &kueue.TopologyAssignment{
+			Levels:  []string{corev1.LabelHostname},
+			Domains: domains,
+		}
+	}
+	domain := func(node string, count int32) kueue.TopologyDomainAssignment {
+		return kueue.TopologyDomainAssignment{Values: []string{node}, Count: count}
+	}
+
+	cases := map[string]struct {
+		topologyRequest *kueue.PodSetTopologyRequest
+		assignment      *kueue.TopologyAssignment
+		failedNode      *corev1.Node
+		nodeDomains     NodeDomains
+		wantAssignment  *kueue.TopologyAssignment
+		wantReason      string
+	}{
+		"replace with a node in the same rack": {
+			assignment:     assignment(domain("x0", 1), domain("x1", 1)),
+			failedNode:     failedNode,
+			wantAssignment: assignment(domain("x2", 1), domain("x1", 1)),
+		},
+		"replace with a node in the same block when the rack is full": {
+			assignment:     assignment(domain("x0", 3), domain("x1", 1)),
+			failedNode:     failedNode,
+			wantAssignment: assignment(domain("x3", 3), domain("x1", 1)),
+		},
+		"replace with multiple nodes in the same block": {
+			assignment:     assignment(domain("x1", 1), domain("x0", 5)),
+			failedNode:     failedNode,
+			wantAssignment: assignment(domain("x1", 1), domain("x3", 4), domain("x2", 1)),
+		},
+		"replace a node named differently from its hostname using its domain from the node domains source": {
+			assignment: assignment(domain("x0", 1), domain("x1", 1)),
+			failedNode: testingnode.MakeNode("node-0").
+				Label(corev1.LabelHostname, "x0").
+				NotReady().
+				Obj(),
+			nodeDomains: NodeDomains{
+				"x1":     {"b1", "r1"},
+				"x2":     {"b1", "r1"},
+				"x3":     {"b1", "r2"},
+				"x4":     {"b2", "r1"},
+				"node-0": {"b2", "r1"},
+			},
+			wantAssignment: assignment(domain("x4", 1), domain("x1", 1)),
+		},
+		"replace a deleted node using the location of the other assigned nodes": {
+			assignment:     assignment(domain("x0", 1), domain("x1", 1)),
+			wantAssignment: assignment(domain("x2", 1), domain("x1", 1)),
+		},
+		"replace with a node in another block for a preferred level": {
+			topologyRequest: &kueue.PodSetTopologyRequest{
+				Preferred: ptr.To(tasRackLabel),
+			},
+			assignment:     assignment(domain("x0", 7), domain("x1", 1)),
+			failedNode:     failedNode,
+			wantAssignment: assignment(domain("x3", 4), domain("x4", 3), domain("x1", 1)),
+		},
+		"keep the domain of the required level": {
+			topologyRequest: &kueue.PodSetTopologyRequest{
+				Required: ptr.To(tasRackLabel),
+			},
+			assignment: assignment(domain("x0", 3), domain("x1", 1)),
+			failedNode: failedNode,
+			wantReason: `topology "default" doesn't allow to fit the 3 pod(s) assigned to the failed node "x0"`,
+		},
+		"no replacement": {
+			assignment: assignment(domain("x0", 20)),
+			failedNode: failedNode,
+			wantReason: `topology "default" doesn't allow to fit the 20 pod(s) assigned to the failed node "x0"`,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, log := utiltesting.ContextWithLog(t)
+			tasCache := NewTASCache(utiltesting.NewFakeClient())
+			flavorCache := tasCache.NewTASFlavorCache("default", levels, nil, nil)
+			if tc.nodeDomains != nil {
+				flavorCache.setNodeDomains(tc.nodeDomains)
+			}
+			snapshot := flavorCache.snapshotForNodes(log, nodes, nil)
+			tasRequests := TASPodSetRequests{
+				PodSet: &kueue.PodSet{
+					Name:            kueue.DefaultPodSetName,
+					TopologyRequest: tc.topologyRequest,
+				},
+				SinglePodRequests: resources.Requests{corev1.ResourceCPU: 1000},
+				Flavor:            "tas",
+			}
+			got, gotReason := snapshot.FindReplacementAssignment(tasRequests, tc.assignment, "x0", tc.failedNode)
+			if diff := cmp.Diff(tc.wantReason, gotReason); diff != "" {
+				t.Errorf("Unexpected reason (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantAssignment, got); diff != "" {
+				t.Errorf("Unexpected assignment (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFindBalancedAssignment(t *testing.T) {
+	const (
+		tasBlockLabel = "cloud.com/topology-block"
+		tasRackLabel  = "cloud.com/topology-rack"
+	)
+	levels := []string{tasBlockLabel, tasRackLabel, corev1.LabelHostname}
+	makeNode := func(block, rack, name, cpu string) corev1.Node {
+		return *testingnode.MakeNode(name).
+			Label(tasBlockLabel, block).
+			Label(tasRackLabel, rack).
+			Label(corev1.LabelHostname, name).
+			StatusAllocatable(corev1.ResourceList{
+				corev1.ResourceCPU:  resource.MustParse(cpu),
+				corev1.ResourcePods: resource.MustParse("10"),
+			}).
+			Ready().
+			Obj()
+	}
+	//              b1                  b2
+	//       /      |      \          /    \
+	//      r1      r2     r3        r1     r2
+	//     /  \     |      |         |      |
+	//    x1  x2    x3     x4        x5     x6
+	//    4   4     4      1         2      2   (cpu)
+	nodes := []corev1.Node{
+		makeNode("b1", "r1", "x1", "4"),
+		makeNode("b1", "r1", "x2", "4"),
+		makeNode("b1", "r2", "x3", "4"),
+		makeNode("b1", "r3", "x4", "1"),
+		makeNode("b2", "r1", "x5", "2"),
+		makeNode("b2", "r2", "x6", "2"),
+	}
+	assignment := func(domains ...kueue.TopologyDomainAssignment) *kueue.TopologyAssignment {
+		return &kueue.TopologyAssignment{
+			Levels:  []string{corev1.LabelHostname},
+			Domains: domains,
+		}
+	}
+	domain := func(node string, count int32) kueue.TopologyDomainAssignment {
+		return kueue.TopologyDomainAssignment{Values: []string{node}, Count: count}
+	}
+
+	cases := map[string]struct {
+		level                    string
+		count                    int32
+		disableBalancedPlacement bool
+		wantAssignment           *kueue.TopologyAssignment
+		wantReason               string
+	}{
+		"spread across all racks of a block": {
+			level:          tasRackLabel,
+			count:          3,
+			wantAssignment: assignment(domain("x1", 1), domain("x3", 1), domain("x4", 1)),
+		},
+		"spread across the racks which fit their share of the pods": {
+			level:          tasRackLabel,
+			count:          6,
+			wantAssignment: assignment(domain("x1", 3), domain("x3", 3)),
+		},
+		"spread across blocks": {
+			level:          tasBlockLabel,
+			count:          4,
+			wantAssignment: assignment(domain("x3", 2), domain("x5", 2)),
+		},
+		"spread across nodes of a rack": {
+			level:          corev1.LabelHostname,
+			count:          4,
+			wantAssignment: assignment(domain("x1", 2), domain("x2", 2)),
+		},
+		"no balanced assignment when the pods can't be divided between racks": {
+			level:      tasRackLabel,
+			count:      7,
+			wantReason: `topology "default" doesn't allow to spread 7 pod(s) evenly across domains at level: cloud.com/topology-rack, as they can't be divided between 3 or fewer domains`,
+		},
+		"single pod": {
+			level:          tasRackLabel,
+			count:          1,
+			wantAssignment: assignment(domain("x4", 1)),
+		},
+		"no balanced assignment": {
+			level:      tasRackLabel,
+			count:      9,
+			wantReason: `topology "default" doesn't allow to spread 9 pod(s) evenly across domains at level: cloud.com/topology-rack`,
+		},
+		"balanced placement disabled": {
+			level:                    tasRackLabel,
+			count:                    6,
+			disableBalancedPlacement: true,
+			wantAssignment:           assignment(domain("x1", 4), domain("x2", 2)),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.TASBalancedPlacement, !tc.disableBalancedPlacement)
+			_, log := utiltesting.ContextWithLog(t)
+			tasCache := NewTASCache(utiltesting.NewFakeClient())
+			snapshot := tasCache.NewTASFlavorCache("default", levels, nil, nil).snapshotForNodes(log, nodes, nil)
+			tasRequests := TASPodSetRequests{
+				PodSet: &kueue.PodSet{
+					Name:            kueue.DefaultPodSetName,
+					TopologyRequest: &kueue.PodSetTopologyRequest{Balanced: ptr.To(tc.level)},
+				},
+				SinglePodRequests: resources.Requests{corev1.ResourceCPU: 1000},
+				Count:             tc.count,
+				Flavor:            "tas",
+			}
+			result := snapshot.FindTopologyAssignmentsForFlavor(FlavorTASRequests{tasRequests}, false)
+			got := result[kueue.DefaultPodSetName]
+			if diff := cmp.Diff(tc.wantReason, got.FailureReason); diff != "" {
+				t.Errorf("Unexpected reason (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantAssignment, got.TopologyAssignment); diff != "" {
+				t.Errorf("Unexpected assignment (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFindGroupTopologyAssignments(t *testing.T) {
+	const (
+		tasBlockLabel = "cloud.com/topology-block"
+		tasRackLabel  = "cloud.com/topology-rack"
+	)
+	levels := []string{tasBlockLabel, tasRackLabel, corev1.LabelHostname}
+	makeNode := func(block, rack, name, cpu string) corev1.Node {
+		return *testingnode.MakeNode(name).
+			Label(tasBlockLabel, block).
+			Label(tasRackLabel, rack).
+			Label(corev1.LabelHostname, name).
+			StatusAllocatable(corev1.ResourceList{
+				corev1.ResourceCPU:  resource.MustParse(cpu),
+				corev1.ResourcePods: resource.MustParse("10"),
+			}).
+			Ready().
+			Obj()
+	}
+	//           b1               b2
+	//        /      \         /      \
+	//       r1      r2       r1      r2
+	//       |       |        |       |
+	//       x1      x2       x3      x4
+	//       2       2        4       1   (cpu)
+	nodes := []corev1.Node{
+		makeNode("b1", "r1", "x1", "2"),
+		makeNode("b1", "r2", "x2", "2"),
+		makeNode("b2", "r1", "x3", "4"),
+		makeNode("b2", "r2", "x4", "1"),
+	}
+	assignment := func(domains ...kueue.TopologyDomainAssignment) *kueue.TopologyAssignment {
+		return &kueue.TopologyAssignment{
+			Levels:  []string{corev1.LabelHostname},
+			Domains: domains,
+		}
+	}
+	domain := func(node string, count int32) kueue.TopologyDomainAssignment {
+		return kueue.TopologyDomainAssignment{Values: []string{node}, Count: count}
+	}
+	required := func(level string) *kueue.PodSetTopologyRequest {
+		return &kueue.PodSetTopologyRequest{Required: ptr.To(level), PodSetGroupName: ptr.To("g")}
+	}
+	preferred := func(level string) *kueue.PodSetTopologyRequest {
+		return &kueue.PodSetTopologyRequest{Preferred: ptr.To(level), PodSetGroupName: ptr.To("g")}
+	}
+
+	cases := map[string]struct {
+		leaderRequest       *kueue.PodSetTopologyRequest
+		workersRequest      *kueue.PodSetTopologyRequest
+		workersCount        int32
+		workersCPU          int64
+		nodes               []corev1.Node
+		disablePodSetGroups bool
+		wantAssignments     map[kueue.PodSetReference]*kueue.TopologyAssignment
+		wantReason          string
+	}{
+		"podsets placed independently when the groups are disabled": {
+			leaderRequest:       required(tasBlockLabel),
+			workersRequest:      required(tasBlockLabel),
+			workersCount:        4,
+			disablePodSetGroups: true,
+			wantAssignments: map[kueue.PodSetReference]*kueue.TopologyAssignment{
+				"leader":  assignment(domain("x1", 1)),
+				"workers": assignment(domain("x3", 4)),
+			},
+		},
+		"group placed within a single required block": {
+			leaderRequest:  required(tasBlockLabel),
+			workersRequest: required(tasBlockLabel),
+			workersCount:   4,
+			wantAssignments: map[kueue.PodSetReference]*kueue.TopologyAssignment{
+				"leader":  assignment(domain("x4", 1)),
+				"workers": assignment(domain("x3", 4)),
+			},
+		},
+		"group doesn't fit within a single required block": {
+			leaderRequest:  required(tasBlockLabel),
+			workersRequest: required(tasBlockLabel),
+			workersCount:   5,
+			wantReason:     `topology "default" doesn't allow to fit the podset group "g" within a single domain at level: cloud.com/topology-block`,
+		},
+		"group placed within a single preferred rack": {
+			leaderRequest:  preferred(tasRackLabel),
+			workersRequest: preferred(tasRackLabel),
+			workersCount:   3,
+			wantAssignments: map[kueue.PodSetReference]*kueue.TopologyAssignment{
+				"leader":  assignment(domain("x3", 1)),
+				"workers": assignment(domain("x3", 3)),
+			},
+		},
+		"group placed within a single block when it doesn't fit in a rack": {
+			leaderRequest:  preferred(tasRackLabel),
+			workersRequest: preferred(tasRackLabel),
+			workersCount:   4,
+			wantAssignments: map[kueue.PodSetReference]*kueue.TopologyAssignment{
+				"leader":  assignment(domain("x4", 1)),
+				"workers": assignment(domain("x3", 4)),
+			},
+		},
+		"podsets placed independently when the preferred group doesn't fit": {
+			leaderRequest:  preferred(tasRackLabel),
+			workersRequest: preferred(tasRackLabel),
+			workersCount:   6,
+			wantAssignments: map[kueue.PodSetReference]*kueue.TopologyAssignment{
+				"leader":  assignment(domain("x4", 1)),
+				"workers": assignment(domain("x1", 2), domain("x3", 4)),
+			},
+		},
+		"group placed within the next block when it doesn't fit in the top-ranked one": {
+			//           b1                 b2
+			//        /  |  \            /  |  \
+			//       r1  r2  r3         r1  r2  r3
+			//       |   |   |          |   |   |
+			//       x1  x2  x3         x4  x5  x6
+			//       6   6   1          5   5   5   (cpu)
+			nodes: []corev1.Node{
+				makeNode("b1", "r1", "x1", "6"),
+				makeNode("b1", "r2", "x2", "6"),
+				makeNode("b1", "r3", "x3", "1"),
+				makeNode("b2", "r1", "x4", "5"),
+				makeNode("b2", "r2", "x5", "5"),
+				makeNode("b2", "r3", "x6", "5"),
+			},
+			leaderRequest:  required(tasBlockLabel),
+			workersRequest: required(tasBlockLabel),
+			workersCount:   2,
+			workersCPU:     6000,
+			wantAssignments: map[kueue.PodSetReference]*kueue.TopologyAssignment{
+				"leader":  assignment(domain("x3", 1)),
+				"workers": assignment(domain("x1", 1), domain("x2", 1)),
+			},
+		},
+		"podsets in a group requesting different levels": {
+			leaderRequest:  preferred(tasRackLabel),
+			workersRequest: preferred(tasBlockLabel),
+			workersCount:   1,
+			wantReason:     `podset group "g" must request the same topology level for all podsets`,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.TASPodSetGroups, !tc.disablePodSetGroups)
+			_, log := utiltesting.ContextWithLog(t)
+			tasCache := NewTASCache(utiltesting.NewFakeClient())
+			testNodes := nodes
+			if tc.nodes != nil {
+				testNodes = tc.nodes
+			}
+			workersCPU := int64(1000)
+			if tc.workersCPU != 0 {
+				workersCPU = tc.workersCPU
+			}
+			snapshot := tasCache.NewTASFlavorCache("default", levels, nil, nil).snapshotForNodes(log, testNodes, nil)
+			tasRequests := FlavorTASRequests{
+				{
+					PodSet:            &kueue.PodSet{Name: "leader", TopologyRequest: tc.leaderRequest},
+					SinglePodRequests: resources.Requests{corev1.ResourceCPU: 1000},
+					Count:             1,
+					Flavor:            "tas",
+				},
+				{
+					PodSet:            &kueue.PodSet{Name: "workers", TopologyRequest: tc.workersRequest},
+					SinglePodRequests: resources.Requests{corev1.ResourceCPU: workersCPU},
+					Count:             tc.workersCount,
+					Flavor:            "tas",
+				},
+			}
+			result := snapshot.FindTopologyAssignmentsForFlavor(tasRequests, false)
+			var gotReason string
+			if failure := result.Failure(); failure != nil {
+				gotReason = failure.Reason
+			}
+			if diff := cmp.Diff(tc.wantReason, gotReason); diff != "" {
+				t.Errorf("Unexpected reason (-want,+got):\n%s", diff)
+			}
+			if tc.wantReason != "" {
+				return
+			}
+			gotAssignments := make(map[kueue.PodSetReference]*kueue.TopologyAssignment, len(result))
+			for psName, psResult := range result {
+				gotAssignments[psName] = psResult.TopologyAssignment
+			}
+			if diff := cmp.Diff(tc.wantAssignments, gotAssignments); diff != "" {
+				t.Errorf("Unexpected assignments (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFindGroupTopologyAssignmentsAcrossFlavors(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.TASPodSetGroups, true)
+	_, log := utiltesting.ContextWithLog(t)
+	levels := []string{corev1.LabelHostname}
+	tasCache := NewTASCache(utiltesting.NewFakeClient())
+	nodes := []corev1.Node{
+		*testingnode.MakeNode("x1").
+			Label(corev1.LabelHostname, "x1").
+			StatusAllocatable(corev1.ResourceList{
+				corev1.ResourceCPU:  resource.MustParse("4"),
+				corev1.ResourcePods: resource.MustParse("10"),
+			}).
+			Ready().
+			Obj(),
+	}
+	cqSnapshot := &ClusterQueueSnapshot{
+		TASFlavors: map[kueue.ResourceFlavorReference]*TASFlavorSnapshot{
+			"tas-a": tasCache.NewTASFlavorCache("default", levels, nil, nil).snapshotForNodes(log, nodes, nil),
+			"tas-b": tasCache.NewTASFlavorCache("default", levels, nil, nil).snapshotForNodes(log, nodes, nil),
+		},
+	}
+	request := func(name kueue.PodSetReference, flavor kueue.ResourceFlavorReference) TASPodSetRequests {
+		return TASPodSetRequests{
+			PodSet: &kueue.PodSet{
+				Name:            name,
+				TopologyRequest: &kueue.PodSetTopologyRequest{Required: ptr.To(corev1.LabelHostname), PodSetGroupName: ptr.To("g")},
+			},
+			SinglePodRequests: resources.Requests{corev1.ResourceCPU: 1000},
+			Count:             1,
+			Flavor:            flavor,
+		}
+	}
+	result := cqSnapshot.FindTopologyAssignmentsForWorkload(WorkloadTASRequests{
+		"tas-a": {request("leader", "tas-a")},
+		"tas-b": {request("workers", "tas-b")},
+	}, false)
+	var gotReason string
+	if failure := result.Failure(); failure != nil {
+		gotReason = failure.Reason
+	}
+	wantReason := `podset group "g" must be assigned a single flavor for all podsets`
+	if diff := cmp.Diff(wantReason, gotReason); diff != "" {
+		t.Errorf("Unexpected reason (-want,+got):\n%s", diff)
+	}
+}
+
+func TestPlanDefragmentation(t *testing.T) {
+	const tasRackLabel = "cloud.com/topology-rack"
+	levels := []string{tasRackLabel, corev1.LabelHostname}
+	makeNode := func(rack, name string) corev1.Node {
+		return *testingnode.MakeNode(name).
+			Label(tasRackLabel, rack).
+			Label(corev1.LabelHostname, name).
+			StatusAllocatable(corev1.ResourceList{
+				corev1.ResourceCPU:  resource.MustParse("2"),
+				corev1.ResourcePods: resource.MustParse("10"),
+			}).
+			Ready().
+			Obj()
+	}
+	//      r1        r2        r3
+	//     /  \      /  \      /  \
+	//    x1  x2    x3  x4    x5  x6
+	nodes := []corev1.Node{
+		makeNode("r1", "x1"),
+		makeNode("r1", "x2"),
+		makeNode("r2", "x3"),
+		makeNode("r2", "x4"),
+		makeNode("r3", "x5"),
+		makeNode("r3", "x6"),
+	}
+	// wl returns a workload with a pod per node, requesting the cpu.
+	wl := func(name, cpu string, nodes ...string) TASDefragmentationCandidate {
+		podRequests := resources.NewRequests(corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)})
+		candidate := TASDefragmentationCandidate{
+			Workload: workload.NewInfo(utiltesting.MakeWorkload(name, "ns").Obj()),
+			Requests: FlavorTASRequests{{
+				PodSet: &kueue.PodSet{
+					Name:            kueue.DefaultPodSetName,
+					TopologyRequest: &kueue.PodSetTopologyRequest{Preferred: ptr.To(tasRackLabel)},
+				},
+				SinglePodRequests: podRequests,
+				Count:             int32(len(nodes)),
+				Flavor:            "tas",
+			}},
+		}
+		for _, node := range nodes {
+			candidate.Usage = append(candidate.Usage, workload.TopologyDomainRequests{
+				Values:            []string{node},
+				SinglePodRequests: podRequests,
+				Count:             1,
+			})
+		}
+		return candidate
+	}
+
+	cases := map[string]struct {
+		minFreeDomains int
+		maxEvictions   int
+		workloads      []TASDefragmentationCandidate
+		candidates     []string
+		wantDomain     utiltas.TopologyDomainID
+		wantWorkloads  []string
+	}{
+		"enough free domains": {
+			minFreeDomains: 1,
+			maxEvictions:   1,
+			workloads:      []TASDefragmentationCandidate{wl("a", "1", "x1"), wl("b", "1", "x3")},
+			candidates:     []string{"a", "b"},
+		},
+		"evict the workload from the domain with the fewest workloads": {
+			minFreeDomains: 1,
+			maxEvictions:   1,
+			workloads: []TASDefragmentationCandidate{
+				wl("a", "1", "x1", "x2"), wl("b", "1", "x2"), wl("c", "1", "x3"), wl("d", "1", "x5"),
+			},
+			candidates:    []string{"a", "b", "c", "d"},
+			wantDomain:    "r2",
+			wantWorkloads: []string{"c"},
+		},
+		"domain used by a workload which is not a candidate": {
+			minFreeDomains: 1,
+			maxEvictions:   1,
+			workloads:      []TASDefragmentationCandidate{wl("a", "1", "x1"), wl("b", "1", "x3"), wl("c", "1", "x5")},
+			candidates:     []string{"b", "c"},
+			wantDomain:     "r2",
+			wantWorkloads:  []string{"b"},
+		},
+		"evictions exceeding the budget": {
+			minFreeDomains: 1,
+			maxEvictions:   1,
+			workloads: []TASDefragmentationCandidate{
+				wl("a", "1", "x1"), wl("b", "1", "x2"), wl("c", "1", "x3"), wl("d", "1", "x5"),
+			},
+			candidates: []string{"a", "b"},
+		},
+		"evictions within the budget": {
+			minFreeDomains: 1,
+			maxEvictions:   2,
+			workloads: []TASDefragmentationCandidate{
+				wl("a", "1", "x1"), wl("b", "1", "x2"), wl("c", "1", "x3"), wl("d", "1", "x5"),
+			},
+			candidates:    []string{"a", "b"},
+			wantDomain:    "r1",
+			wantWorkloads: []string{"a", "b"},
+		},
+		"evicted workload doesn't fit in the other domains": {
+			minFreeDomains: 1,
+			maxEvictions:   1,
+			workloads: []TASDefragmentationCandidate{
+				wl("a", "2", "x1"), wl("b", "2", "x3", "x4"), wl("c", "1", "x5", "x6"),
+			},
+			candidates: []string{"a"},
+		},
+		"more free domains requested": {
+			minFreeDomains: 2,
+			maxEvictions:   1,
+			workloads:      []TASDefragmentationCandidate{wl("a", "1", "x1", "x2"), wl("b", "1", "x3")},
+			candidates:     []string{"b"},
+			wantDomain:     "r2",
+			wantWorkloads:  []string{"b"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, log := utiltesting.ContextWithLog(t)
+			tasCache := NewTASCache(utiltesting.NewFakeClient())
+			snapshot := tasCache.NewTASFlavorCache("default", levels, nil, nil).snapshotForNodes(log, nodes, nil)
+			var candidates []TASDefragmentationCandidate
+			for _, w := range tc.workloads {
+				for _, domainUsage := range w.Usage {
+					snapshot.updateTASUsage(utiltas.DomainID(domainUsage.Values), domainUsage.TotalRequests(), add, domainUsage.Count)
+				}
+				if slices.Contains(tc.candidates, w.Workload.Obj.Name) {
+					candidates = append(candidates, w)
+				}
+			}
+			wantUsage := snapshot.tasUsagePerDomain()
+
+			plan := snapshot.PlanDefragmentation(tasRackLabel, tc.minFreeDomains, tc.maxEvictions, candidates)
+			var gotDomain utiltas.TopologyDomainID
+			var gotWorkloads []string
+			if plan != nil {
+				gotDomain = plan.Domain
+				for _, wi := range plan.Workloads {
+					gotWorkloads = append(gotWorkloads, wi.Obj.Name)
+				}
+			}
+			if diff := cmp.Diff(tc.wantDomain, gotDomain); diff != "" {
+				t.Errorf("Unexpected domain (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantWorkloads, gotWorkloads); diff != "" {
+				t.Errorf("Unexpected workloads (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(wantUsage, snapshot.tasUsagePerDomain()); diff != "" {
+				t.Errorf("Unexpected TAS usage after planning (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFindSliceAssignment(t *testing.T) {
+	const (
+		tasBlockLabel = "cloud.com/topology-block"
+		tasRackLabel  = "cloud.com/topology-rack"
+	)
+	levels := []string{tasBlockLabel, tasRackLabel, corev1.LabelHostname}
+	makeNode := func(block, rack, name string) corev1.Node {
+		return *testingnode.MakeNode(name).
+			Label(tasBlockLabel, block).
+			Label(tasRackLabel, rack).
+			Label(corev1.LabelHostname, name).
+			StatusAllocatable(corev1.ResourceList{
+				corev1.ResourceCPU:  resource.MustParse("2"),
+				corev1.ResourcePods: resource.MustParse("10"),
+			}).
+			Ready().
+			Obj()
+	}
+	//           b1              b2
+	//        /      \           |
+	//       r1      r2          r3
+	//      /  \    /  \        /  \
+	//     x1  x2  x3  x4      x5  x6
+	nodes := []corev1.Node{
+		makeNode("b1", "r1", "x1"),
+		makeNode("b1", "r1", "x2"),
+		makeNode("b1", "r2", "x3"),
+		makeNode("b1", "r2", "x4"),
+		makeNode("b2", "r3", "x5"),
+		makeNode("b2", "r3", "x6"),
+	}
+	domain := func(node string, count int32) kueue.TopologyDomainAssignment {
+		return kueue.TopologyDomainAssignment{Values: []string{node}, Count: count}
+	}
+	slice := func(block, rack string, count int32) kueue.TopologyDomainAssignment {
+		return kueue.TopologyDomainAssignment{Values: []string{block, rack}, Count: count}
+	}
+
+	cases := map[string]struct {
+		topologyRequest     kueue.PodSetTopologyRequest
+		count               int32
+		disablePodSetSlices bool
+		wantAssignment      *kueue.TopologyAssignment
+		wantReason          string
+	}{
+		"slices within racks of a required block": {
+			topologyRequest: kueue.PodSetTopologyRequest{
+				Required:                    ptr.To(tasBlockLabel),
+				PodSetSliceRequiredTopology: ptr.To(tasRackLabel),
+				PodSetSliceSize:             ptr.To[int32](3),
+			},
+			count: 6,
+			wantAssignment: &kueue.TopologyAssignment{
+				Levels:       []string{corev1.LabelHostname},
+				Domains:      []kueue.TopologyDomainAssignment{domain("x1", 2), domain("x2", 1), domain("x3", 2), domain("x4", 1)},
+				SliceDomains: []kueue.TopologyDomainAssignment{slice("b1", "r1", 1), slice("b1", "r2", 1)},
+			},
+		},
+		"multiple slices within a rack": {
+			topologyRequest: kueue.PodSetTopologyRequest{
+				Required:                    ptr.To(tasBlockLabel),
+				PodSetSliceRequiredTopology: ptr.To(tasRackLabel),
+				PodSetSliceSize:             ptr.To[int32](2),
+			},
+			count: 4,
+			wantAssignment: &kueue.TopologyAssignment{
+				Levels:       []string{corev1.LabelHostname},
+				Domains:      []kueue.TopologyDomainAssignment{domain("x5", 2), domain("x6", 2)},
+				SliceDomains: []kueue.TopologyDomainAssignment{slice("b2", "r3", 2)},
+			},
+		},
+		"slices don't fit within a required block": {
+			topologyRequest: kueue.PodSetTopologyRequest{
+				Required:                    ptr.To(tasBlockLabel),
+				PodSetSliceRequiredTopology: ptr.To(tasRackLabel),
+				PodSetSliceSize:             ptr.To[int32](3),
+			},
+			count:      9,
+			wantReason: `topology "default" doesn't allow to fit 3 slice(s) of 3 pod(s)`,
+		},
+		"slices across blocks for a preferred block": {
+			topologyRequest: kueue.PodSetTopologyRequest{
+				Preferred:                   ptr.To(tasBlockLabel),
+				PodSetSliceRequiredTopology: ptr.To(tasRackLabel),
+				PodSetSliceSize:             ptr.To[int32](3),
+			},
+			count: 9,
+			wantAssignment: &kueue.TopologyAssignment{
+				Levels: []string{corev1.LabelHostname},
+				Domains: []kueue.TopologyDomainAssignment{
+					domain("x1", 2), domain("x2", 1), domain("x3", 2), domain("x4", 1), domain("x5", 2), domain("x6", 1),
+				},
+				SliceDomains: []kueue.TopologyDomainAssignment{slice("b1", "r1", 1), slice("b1", "r2", 1), slice("b2", "r3", 1)},
+			},
+		},
+		"count not divisible by the slice size": {
+			topologyRequest: kueue.PodSetTopologyRequest{
+				Required:                    ptr.To(tasBlockLabel),
+				PodSetSliceRequiredTopology: ptr.To(tasRackLabel),
+				PodSetSliceSize:             ptr.To[int32](4),
+			},
+			count:      6,
+			wantReason: "pod count 6 is not divisible by the slice size 4",
+		},
+		"slice level above the topology level": {
+			topologyRequest: kueue.PodSetTopologyRequest{
+				Required:                    ptr.To(tasRackLabel),
+				PodSetSliceRequiredTopology: ptr.To(tasBlockLabel),
+				PodSetSliceSize:             ptr.To[int32](2),
+			},
+			count:      4,
+			wantReason: "slice topology level cloud.com/topology-block must not be above the topology level cloud.com/topology-rack",
+		},
+		"slices ignored when the feature is disabled": {
+			topologyRequest: kueue.PodSetTopologyRequest{
+				Required:                    ptr.To(tasBlockLabel),
+				PodSetSliceRequiredTopology: ptr.To(tasRackLabel),
+				PodSetSliceSize:             ptr.To[int32](3),
+			},
+			count:               6,
+			disablePodSetSlices: true,
+			wantAssignment: &kueue.TopologyAssignment{
+				Levels:  []string{corev1.LabelHostname},
+				Domains: []kueue.TopologyDomainAssignment{domain("x1", 2), domain("x2", 2), domain("x3", 2)},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.TASPodSetSlices, !tc.disablePodSetSlices)
+			_, log := utiltesting.ContextWithLog(t)
+			tasCache := NewTASCache(utiltesting.NewFakeClient())
+			snapshot := tasCache.NewTASFlavorCache("default", levels, nil, nil).snapshotForNodes(log, nodes, nil)
+			tasRequests := TASPodSetRequests{
+				PodSet: &kueue.PodSet{
+					Name:            kueue.DefaultPodSetName,
+					TopologyRequest: &tc.topologyRequest,
+				},
+				SinglePodRequests: resources.Requests{corev1.ResourceCPU: 1000},
+				Count:             tc.count,
+				Flavor:            "tas",
+			}
+			result := snapshot.FindTopologyAssignmentsForFlavor(FlavorTASRequests{tasRequests}, false)
+			got := result[kueue.DefaultPodSetName]
+			if diff := cmp.Diff(tc.wantReason, got.FailureReason); diff != "" {
+				t.Errorf("Unexpected reason (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantAssignment, got.TopologyAssignment); diff != "" {
+				t.Errorf("Unexpected assignment (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"maps"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/resources"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// UserLimits are the limits on the usage of the workloads submitted by
+// each user, set by the perUserLimits of a LocalQueue or ClusterQueue.
+type UserLimits struct {
+	MaxAdmittedWorkloads *int
+	// Resources are the limits by resource, summed across flavors.
+	Resources resources.Requests
+}
+
+// UserUsage is the usage of the workloads submitted by a user which
+// reserve quota.
+type UserUsage struct {
+	Workloads int
+	// Resources are the requests by resource, summed across flavors.
+	Resources resources.Requests
+}
+
+// usersUsage holds the UserUsage by user.
+type usersUsage map[string]*UserUsage
+
+func newUserLimits(in *kueue.PerUserLimits) *UserLimits {
+	if !features.Enabled(features.PerUserLimits) || in == nil {
+		return nil
+	}
+	limits := &UserLimits{}
+	if in.MaxAdmittedWorkloads != nil {
+		limits.MaxAdmittedWorkloads = ptr.To(int(*in.MaxAdmittedWorkloads))
+	}
+	if len(in.Resources) > 0 {
+		limits.Resources = make(resources.Requests, len(in.Resources))
+		for _, r := range in.Resources {
+			limits.Resources[r.Name] = resources.ResourceValue(r.Name, r.Max)
+		}
+	}
+	return limits
+}
+
+// UserRequests returns the requests of the PodSets, by resource, summed
+// across PodSets and flavors. The number of pods is always included.
+func UserRequests(podSets []workload.PodSetResources) resources.Requests {
+	requests := make(resources.Requests)
+	for _, ps := range podSets {
+		for name, v := range ps.Requests {
+			if name != corev1.ResourcePods {
+				requests[name] += v
+			}
+		}
+		requests[corev1.ResourcePods] += int64(ps.Count)
+	}
+	return requests
+}
+
+// update adds (m=1) or removes (m=-1) the usage of the workload to the
+// usage of the user which submitted it.
+func (u usersUsage) update(wi *workload.Info, m int64) {
+	user := workload.SubmittedBy(wi.Obj)
+	usage, found := u[user]
+	if !found {
+		usage = &UserUsage{Resources: make(resources.Requests)}
+		u[user] = usage
+	}
+	for name, v := range UserRequests(wi.TotalRequests) {
+		usage.Resources[name] += v * m
+	}
+	usage.Workloads += int(m)
+	if usage.Workloads <= 0 {
+		delete(u, user)
+	}
+}
+
+func (u usersUsage) clone() map[string]UserUsage {
+	if len(u) == 0 {
+		return nil
+	}
+	out := make(map[string]UserUsage, len(u))
+	for user, usage := range u {
+		out[user] = UserUsage{
+			Workloads: usage.Workloads,
+			Resources: maps.Clone(usage.Resources),
+		}
+	}
+	return out
+}
+
+// Exceeded returns the resource for which admitting a workload with the
+// requests would exceed the limits, given the current usage of the user.
+// An empty resource name and true are returned when the limit on the
+// number of workloads would be exceeded.
+func (l *UserLimits) Exceeded(usage UserUsage, requests resources.Requests) (corev1.ResourceName, bool) {
+	if l.MaxAdmittedWorkloads != nil && usage.Workloads >= *l.MaxAdmittedWorkloads {
+		return "", true
+	}
+	for _, name := range slices.Sorted(maps.Keys(l.Resources)) {
+		if requests[name] > 0 && usage.Resources[name]+requests[name] > l.Resources[name] {
+			return name, true
+		}
+	}
+	return "", false
+}
@@ -280,6 +280,10 @@ multiKueue:
   gcInterval: 1m30s
   origin: multikueue-manager1
   workerLostTimeout: 10m
+  workerLostPolicy: Migrate
+  statusMirroring:
+    events: true
+    interval: 1m
 `), os.FileMode(0600)); err != nil {
 		t.Fatal(err)
 	}
@@ -397,6 +401,7 @@ webhook:
 		GCInterval:        &metav1.Duration{Duration: configapi.DefaultMultiKueueGCInterval},
 		Origin:            ptr.To(configapi.DefaultMultiKueueOrigin),
 		WorkerLostTimeout: &metav1.Duration{Duration: configapi.DefaultMultiKueueWorkerLostTimeout},
+		WorkerLostPolicy:  ptr.To(configapi.MultiKueueWorkerLostPolicyRequeue),
 	}
 
 	testcases := []struct {
@@ -872,6 +877,12 @@ webhook:
 					GCInterval:        &metav1.Duration{Duration: 90 * time.Second},
 					Origin:            ptr.To("multikueue-manager1"),
 					WorkerLostTimeout: &metav1.Duration{Duration: 10 * time.Minute},
+					WorkerLostPolicy:  ptr.To(configapi.MultiKueueWorkerLostPolicyMigrate),
+					StatusMirroring: &configapi.MultiKueueStatusMirroring{
+						Events:               true,
+						Interval:             &metav1.Duration{Duration: time.Minute},
+						MaxEventsPerInterval: ptr.To(configapi.DefaultMultiKueueStatusMirroringMaxEvents),
+					},
 				},
 				ManagedJobsNamespaceSelector: defaultManagedJobsNamespaceSelector,
 			},
@@ -1039,6 +1050,7 @@ func TestEncode(t *testing.T) {
 					"gcInterval":        "1m0s",
 					"origin":            "multikueue",
 					"workerLostTimeout": "15m0s",
+					"workerLostPolicy":  "Requeue",
 				},
 			},
 		},
@@ -19,6 +19,7 @@ package config
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"slices"
 	"strings"
 	"unsafe"
@@ -61,6 +62,8 @@ var (
 	internalCertManagementPath        = field.NewPath("internalCertManagement")
 	queueVisibilityPath               = field.NewPath("queueVisibility")
 	resourceTransformationPath        = field.NewPath("resources", "transformations")
+	tasDefragmentationPath            = field.NewPath("tasDefragmentation")
+	metricsPath                       = field.NewPath("metrics")
 )
 
 func validate(c *configapi.Configuration, scheme *runtime.Scheme) field.ErrorList {
@@ -70,6 +73,8 @@ func validate(c *configapi.Configuration, scheme *runtime.Scheme) field.ErrorLis
 	allErrs = append(allErrs, validateIntegrations(c, scheme)...)
 	allErrs = append(allErrs, validateMultiKueue(c)...)
 	allErrs = append(allErrs, validateFairSharing(c)...)
+	allErrs = append(allErrs, validateTASDefragmentation(c)...)
+	allErrs = append(allErrs, validateMetrics(c)...)
 	allErrs = append(allErrs, validateInternalCertManagement(c)...)
 	allErrs = append(allErrs, validateResourceTransformations(c)...)
 	allErrs = append(allErrs, validateManagedJobsNamespaceSelector(c)...)
@@ -110,6 +115,151 @@ func validateMultiKueue(c *configapi.Configuration) field.ErrorList {
 				allErrs = append(allErrs, field.Invalid(multiKueuePath.Child("origin"), *c.MultiKueue.Origin, strings.Join(errs, ",")))
 			}
 		}
+		if p := c.MultiKueue.WorkerLostPolicy; p != nil {
+			supported := []configapi.MultiKueueWorkerLostPolicy{configapi.MultiKueueWorkerLostPolicyRequeue, configapi.MultiKueueWorkerLostPolicyMigrate}
+			if !slices.Contains(supported, *p) {
+				allErrs = append(allErrs, field.NotSupported(multiKueuePath.Child("workerLostPolicy"), *p, supported))
+			}
+		}
+		if d := c.MultiKueue.Dispatcher; d != nil {
+			dispatcherPath := multiKueuePath.Child("dispatcher")
+			supported := []configapi.MultiKueueDispatcherName{configapi.MultiKueueDispatcherAllAtOnce, configapi.MultiKueueDispatcherIncremental, configapi.MultiKueueDispatcherScoring, configapi.MultiKueueDispatcherCapacityAware}
+			if d.Name != "" && !slices.Contains(supported, d.Name) {
+				allErrs = append(allErrs, field.NotSupported(dispatcherPath.Child("name"), d.Name, supported))
+			}
+			if d.IncrementalClusters != nil && *d.IncrementalClusters < 1 {
+				allErrs = append(allErrs, field.Invalid(dispatcherPath.Child("incrementalClusters"), *d.IncrementalClusters, "must be greater than 0"))
+			}
+			if d.IncrementalTimeout != nil && d.IncrementalTimeout.Duration <= 0 {
+				allErrs = append(allErrs, field.Invalid(dispatcherPath.Child("incrementalTimeout"), d.IncrementalTimeout.Duration, "must be greater than 0"))
+			}
+			if d.ScoringTimeout != nil && d.ScoringTimeout.Duration <= 0 {
+				allErrs = append(allErrs, field.Invalid(dispatcherPath.Child("scoringTimeout"), d.ScoringTimeout.Duration, "must be greater than 0"))
+			}
+		}
+		if m := c.MultiKueue.StatusMirroring; m != nil {
+			mirroringPath := multiKueuePath.Child("statusMirroring")
+			if m.Interval != nil && m.Interval.Duration <= 0 {
+				allErrs = append(allErrs, field.Invalid(mirroringPath.Child("interval"), m.Interval.Duration, "must be greater than 0"))
+			}
+			if m.MaxEventsPerInterval != nil && *m.MaxEventsPerInterval < 1 {
+				allErrs = append(allErrs, field.Invalid(mirroringPath.Child("maxEventsPerInterval"), *m.MaxEventsPerInterval, "must be greater than 0"))
+			}
+		}
+		allErrs = append(allErrs, validateMultiKueueHealthCheck(c.MultiKueue.HealthCheck)...)
+		allErrs = append(allErrs, validateMultiKueueExternalFrameworks(c.MultiKueue.ExternalFrameworks)...)
+		if cp := c.MultiKueue.ClusterProfile; cp != nil {
+			providersPath := multiKueuePath.Child("clusterProfile", "credentialsProviders")
+			names := sets.New[string]()
+			for idx, provider := range cp.CredentialsProviders {
+				switch {
+				case provider.Name == "":
+					allErrs = append(allErrs, field.Required(providersPath.Index(idx).Child("name"), ""))
+				case names.Has(provider.Name):
+					allErrs = append(allErrs, field.Duplicate(providersPath.Index(idx).Child("name"), provider.Name))
+				default:
+					names.Insert(provider.Name)
+				}
+				if provider.ExecConfig.Command == "" {
+					allErrs = append(allErrs, field.Required(providersPath.Index(idx).Child("execConfig", "command"), ""))
+				}
+			}
+		}
+	}
+	return allErrs
+}
+
+func validateMultiKueueHealthCheck(h *configapi.MultiKueueHealthCheck) field.ErrorList {
+	if h == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	healthCheckPath := multiKueuePath.Child("healthCheck")
+	durations := []struct {
+		name     string
+		duration *metav1.Duration
+	}{
+		{name: "interval", duration: h.Interval},
+		{name: "timeout", duration: h.Timeout},
+		{name: "initialBackoff", duration: h.InitialBackoff},
+		{name: "maxBackoff", duration: h.MaxBackoff},
+	}
+	for _, d := range durations {
+		if d.duration != nil && d.duration.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(healthCheckPath.Child(d.name), d.duration.Duration, "must be greater than 0"))
+		}
+	}
+	if h.FailureThreshold != nil && *h.FailureThreshold < 1 {
+		allErrs = append(allErrs, field.Invalid(healthCheckPath.Child("failureThreshold"), *h.FailureThreshold, "must be greater than 0"))
+	}
+	if h.InitialBackoff != nil && h.MaxBackoff != nil && h.MaxBackoff.Duration < h.InitialBackoff.Duration {
+		allErrs = append(allErrs, field.Invalid(healthCheckPath.Child("maxBackoff"), h.MaxBackoff.Duration, "must be greater than or equal to initialBackoff"))
+	}
+	if h.CanaryNamespace != nil {
+		for _, msg := range apimachineryvalidation.ValidateNamespaceName(*h.CanaryNamespace, false) {
+			allErrs = append(allErrs, field.Invalid(healthCheckPath.Child("canaryNamespace"), *h.CanaryNamespace, msg))
+		}
+	}
+	return allErrs
+}
+
+var multiKueueFieldPathRegex = regexp.MustCompile(`^(\.[A-Za-z0-9_-]+)+$`)
+
+func validateMultiKueueExternalFrameworks(frameworks []configapi.MultiKueueExternalFramework) field.ErrorList {
+	var allErrs field.ErrorList
+	frameworksPath := multiKueuePath.Child("externalFrameworks")
+	gvks := sets.New[string]()
+	for idx, framework := range frameworks {
+		frameworkPath := frameworksPath.Index(idx)
+		gvk, _ := schema.ParseKindArg(framework.Name)
+		switch {
+		case gvk == nil:
+			allErrs = append(allErrs, field.Invalid(frameworkPath.Child("name"), framework.Name, "must be format, 'Kind.version.group.com'"))
+		case gvks.Has(gvk.String()):
+			allErrs = append(allErrs, field.Duplicate(frameworkPath.Child("name"), framework.Name))
+		default:
+			gvks.Insert(gvk.String())
+		}
+		if framework.ManagedByPath != nil && !multiKueueFieldPathRegex.MatchString(*framework.ManagedByPath) {
+			allErrs = append(allErrs, field.Invalid(frameworkPath.Child("managedByPath"), *framework.ManagedByPath, "must be a path like '.spec.managedBy'"))
+		}
+		for i, statusPath := range framework.StatusPaths {
+			if !multiKueueFieldPathRegex.MatchString(statusPath) || (statusPath != ".status" && !strings.HasPrefix(statusPath, ".status.")) {
+				allErrs = append(allErrs, field.Invalid(frameworkPath.Child("statusPaths").Index(i), statusPath, "must be a path in the status like '.status.conditions'"))
+			}
+		}
+	}
+	return allErrs
+}
+
+func validateMetrics(c *configapi.Configuration) field.ErrorList {
+	var allErrs field.ErrorList
+	if maxDomains := c.Metrics.TASDomainResourcesMaxDomains; maxDomains != nil && *maxDomains < 1 {
+		allErrs = append(allErrs, field.Invalid(metricsPath.Child("tasDomainResourcesMaxDomains"), *maxDomains, "must be greater than 0"))
+	}
+	return allErrs
+}
+
+func validateTASDefragmentation(c *configapi.Configuration) field.ErrorList {
+	d := c.TASDefragmentation
+	if d == nil || !d.Enable {
+		return nil
+	}
+	var allErrs field.ErrorList
+	if d.Interval != nil && d.Interval.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(tasDefragmentationPath.Child("interval"),
+			d.Interval.Duration, "must be greater than 0"))
+	}
+	if d.Level != nil {
+		if errs := apimachineryutilvalidation.IsQualifiedName(*d.Level); len(errs) != 0 {
+			allErrs = append(allErrs, field.Invalid(tasDefragmentationPath.Child("level"), *d.Level, strings.Join(errs, ",")))
+		}
+	}
+	if d.MinFreeDomains != nil {
+		allErrs = append(allErrs, apimachineryvalidation.ValidateNonnegativeField(int64(*d.MinFreeDomains), tasDefragmentationPath.Child("minFreeDomains"))...)
+	}
+	if d.MaxEvictionsPerInterval != nil {
+		allErrs = append(allErrs, apimachineryvalidation.ValidateNonnegativeField(int64(*d.MaxEvictionsPerInterval), tasDefragmentationPath.Child("maxEvictionsPerInterval"))...)
 	}
 	return allErrs
 }
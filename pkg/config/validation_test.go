@@ -28,6 +28,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
 	"k8s.io/utils/ptr"
 
 	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
@@ -600,6 +601,294 @@ func TestValidate(t *testing.T) {
 				},
 			},
 		},
+		"unsupported multiKueue.workerLostPolicy": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				MultiKueue: &configapi.MultiKueue{
+					WorkerLostPolicy: ptr.To[configapi.MultiKueueWorkerLostPolicy]("Evict"),
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeNotSupported,
+					Field: "multiKueue.workerLostPolicy",
+				},
+			},
+		},
+		"unsupported multiKueue.dispatcher.name": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				MultiKueue: &configapi.MultiKueue{
+					Dispatcher: &configapi.MultiKueueDispatcher{
+						Name: "Random",
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeNotSupported,
+					Field: "multiKueue.dispatcher.name",
+				},
+			},
+		},
+		"invalid multiKueue.dispatcher incremental parameters": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				MultiKueue: &configapi.MultiKueue{
+					Dispatcher: &configapi.MultiKueueDispatcher{
+						Name:                configapi.MultiKueueDispatcherIncremental,
+						IncrementalClusters: ptr.To[int32](0),
+						IncrementalTimeout:  &metav1.Duration{},
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "multiKueue.dispatcher.incrementalClusters",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "multiKueue.dispatcher.incrementalTimeout",
+				},
+			},
+		},
+		"invalid multiKueue.dispatcher.scoringTimeout": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				MultiKueue: &configapi.MultiKueue{
+					Dispatcher: &configapi.MultiKueueDispatcher{
+						Name:           configapi.MultiKueueDispatcherScoring,
+						ScoringTimeout: &metav1.Duration{},
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "multiKueue.dispatcher.scoringTimeout",
+				},
+			},
+		},
+		"invalid multiKueue.statusMirroring": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				MultiKueue: &configapi.MultiKueue{
+					StatusMirroring: &configapi.MultiKueueStatusMirroring{
+						Events:               true,
+						Interval:             &metav1.Duration{},
+						MaxEventsPerInterval: ptr.To[int32](0),
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "multiKueue.statusMirroring.interval",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "multiKueue.statusMirroring.maxEventsPerInterval",
+				},
+			},
+		},
+		"invalid multiKueue.healthCheck": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				MultiKueue: &configapi.MultiKueue{
+					HealthCheck: &configapi.MultiKueueHealthCheck{
+						Interval:         &metav1.Duration{},
+						Timeout:          &metav1.Duration{Duration: time.Second},
+						FailureThreshold: ptr.To[int32](0),
+						InitialBackoff:   &metav1.Duration{Duration: time.Minute},
+						MaxBackoff:       &metav1.Duration{Duration: time.Second},
+						CanaryNamespace:  ptr.To("Invalid_Namespace"),
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "multiKueue.healthCheck.interval",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "multiKueue.healthCheck.failureThreshold",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "multiKueue.healthCheck.maxBackoff",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "multiKueue.healthCheck.canaryNamespace",
+				},
+			},
+		},
+		"valid multiKueue.externalFrameworks": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				MultiKueue: &configapi.MultiKueue{
+					ExternalFrameworks: []configapi.MultiKueueExternalFramework{
+						{
+							Name:          "MyJob.v1.example.com",
+							ManagedByPath: ptr.To(".spec.controllerName"),
+							StatusPaths:   []string{".status.conditions", ".status.active"},
+						},
+						{
+							Name: "OtherJob.v1.example.com",
+						},
+					},
+				},
+			},
+		},
+		"invalid multiKueue.externalFrameworks": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				MultiKueue: &configapi.MultiKueue{
+					ExternalFrameworks: []configapi.MultiKueueExternalFramework{
+						{
+							Name:          "MyJob.v1.example.com",
+							ManagedByPath: ptr.To("spec.managedBy"),
+							StatusPaths:   []string{".spec.replicas", ".statusCopy"},
+						},
+						{
+							Name: "MyJob.v1.example.com",
+						},
+						{
+							Name: "MyJob",
+						},
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "multiKueue.externalFrameworks[0].managedByPath",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "multiKueue.externalFrameworks[0].statusPaths[0]",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "multiKueue.externalFrameworks[0].statusPaths[1]",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeDuplicate,
+					Field: "multiKueue.externalFrameworks[1].name",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "multiKueue.externalFrameworks[2].name",
+				},
+			},
+		},
+		"invalid multiKueue.clusterProfile.credentialsProviders": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				MultiKueue: &configapi.MultiKueue{
+					ClusterProfile: &configapi.MultiKueueClusterProfile{
+						CredentialsProviders: []configapi.MultiKueueCredentialsProvider{
+							{
+								Name:       "gcp",
+								ExecConfig: clientcmdv1.ExecConfig{Command: "gke-gcloud-auth-plugin"},
+							},
+							{
+								Name: "gcp",
+							},
+							{
+								ExecConfig: clientcmdv1.ExecConfig{Command: "aws"},
+							},
+						},
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeDuplicate,
+					Field: "multiKueue.clusterProfile.credentialsProviders[1].name",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeRequired,
+					Field: "multiKueue.clusterProfile.credentialsProviders[1].execConfig.command",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeRequired,
+					Field: "multiKueue.clusterProfile.credentialsProviders[2].name",
+				},
+			},
+		},
+		"non-positive metrics.tasDomainResourcesMaxDomains": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				ControllerManager: configapi.ControllerManager{
+					Metrics: configapi.ControllerMetrics{
+						EnableTASDomainResources:     true,
+						TASDomainResourcesMaxDomains: ptr.To[int32](0),
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "metrics.tasDomainResourcesMaxDomains",
+				},
+			},
+		},
+		"non-positive tasDefragmentation.interval": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				TASDefragmentation: &configapi.TASDefragmentation{
+					Enable:   true,
+					Interval: &metav1.Duration{},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "tasDefragmentation.interval",
+				},
+			},
+		},
+		"invalid tasDefragmentation configuration": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				TASDefragmentation: &configapi.TASDefragmentation{
+					Enable:                  true,
+					Level:                   ptr.To("=]"),
+					MinFreeDomains:          ptr.To[int32](-1),
+					MaxEvictionsPerInterval: ptr.To[int32](-1),
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "tasDefragmentation.level",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "tasDefragmentation.minFreeDomains",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "tasDefragmentation.maxEvictionsPerInterval",
+				},
+			},
+		},
+		"valid tasDefragmentation configuration": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				TASDefragmentation: &configapi.TASDefragmentation{
+					Enable:                  true,
+					Interval:                &metav1.Duration{Duration: time.Minute},
+					Level:                   ptr.To("cloud.provider.com/topology-block"),
+					MinFreeDomains:          ptr.To[int32](2),
+					MaxEvictionsPerInterval: ptr.To[int32](3),
+					MaxPriority:             ptr.To[int32](100),
+				},
+			},
+		},
 		"unsupported preemption strategy": {
 			cfg: &configapi.Configuration{
 				Integrations: defaultIntegrations,
@@ -20,9 +20,13 @@ import (
 	"time"
 
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/constants"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
+	"sigs.k8s.io/kueue/pkg/queue"
 )
 
 const (
@@ -37,6 +41,13 @@ type SetupOptions struct {
 	workerLostTimeout time.Duration
 	eventsBatchPeriod time.Duration
 	adapters          map[string]jobframework.MultiKueueAdapter
+	dispatcher        Dispatcher
+	credentials       ClusterProfileCredentialsProvider
+	workerLostPolicy  configapi.MultiKueueWorkerLostPolicy
+	statusMirroring   *configapi.MultiKueueStatusMirroring
+	healthCheck       *configapi.MultiKueueHealthCheck
+	cache             *cache.Cache
+	queues            *queue.Manager
 }
 
 type SetupOption func(o *SetupOptions)
@@ -80,6 +91,56 @@ func WithAdapters(adapters map[string]jobframework.MultiKueueAdapter) SetupOptio
 	}
 }
 
+// WithDispatcher sets the dispatcher selecting the worker clusters
+// the workloads are dispatched to.
+func WithDispatcher(d Dispatcher) SetupOption {
+	return func(o *SetupOptions) {
+		o.dispatcher = d
+	}
+}
+
+// WithWorkerLostPolicy sets what happens to the workloads which lost
+// their reserving worker cluster.
+func WithWorkerLostPolicy(p configapi.MultiKueueWorkerLostPolicy) SetupOption {
+	return func(o *SetupOptions) {
+		o.workerLostPolicy = p
+	}
+}
+
+// WithStatusMirroring sets the mirroring of the events and of the pods
+// status of the remote jobs. If nil, they are not mirrored.
+func WithStatusMirroring(m *configapi.MultiKueueStatusMirroring) SetupOption {
+	return func(o *SetupOptions) {
+		o.statusMirroring = m
+	}
+}
+
+// WithHealthCheck sets the active probing of the worker clusters.
+// If nil, the worker clusters are not probed.
+func WithHealthCheck(h *configapi.MultiKueueHealthCheck) SetupOption {
+	return func(o *SetupOptions) {
+		o.healthCheck = h
+	}
+}
+
+// WithFederatedQuota enables computing the nominal quotas of the
+// ClusterQueues from the quotas of the worker clusters, with the
+// quotaPolicy of their MultiKueueConfig. The quotas are set in the cache.
+func WithFederatedQuota(c *cache.Cache, queues *queue.Manager) SetupOption {
+	return func(o *SetupOptions) {
+		o.cache = c
+		o.queues = queues
+	}
+}
+
+// WithClusterProfileCredentialsProvider sets the provider of the kubeconfig
+// of the clusters referencing a ClusterProfile.
+func WithClusterProfileCredentialsProvider(p ClusterProfileCredentialsProvider) SetupOption {
+	return func(o *SetupOptions) {
+		o.credentials = p
+	}
+}
+
 func SetupControllers(mgr ctrl.Manager, namespace string, opts ...SetupOption) error {
 	options := &SetupOptions{
 		gcInterval:        defaultGCInterval,
@@ -87,6 +148,8 @@ func SetupControllers(mgr ctrl.Manager, namespace string, opts ...SetupOption) e
 		workerLostTimeout: defaultWorkerLostTimeout,
 		eventsBatchPeriod: constants.UpdatesBatchPeriod,
 		adapters:          make(map[string]jobframework.MultiKueueAdapter),
+		dispatcher:        &AllAtOnceDispatcher{},
+		workerLostPolicy:  configapi.MultiKueueWorkerLostPolicyRequeue,
 	}
 
 	for _, o := range opts {
@@ -105,17 +168,34 @@ func SetupControllers(mgr ctrl.Manager, namespace string, opts ...SetupOption) e
 	}
 
 	cRec := newClustersReconciler(mgr.GetClient(), namespace, options.gcInterval, options.origin, fsWatcher, options.adapters)
+	if d, usesCapacity := options.dispatcher.(capacityDispatcher); usesCapacity {
+		d.setClusters(cRec)
+		cRec.trackCapacity = true
+	}
+	cRec.credentialsProvider = options.credentials
+	cRec.healthCheck = options.healthCheck
+	if options.cache != nil {
+		cRec.trackCapacity = true
+		cRec.quotaCh = make(chan event.GenericEvent, eventChBufferSize)
+	}
 	err = cRec.setupWithManager(mgr)
 	if err != nil {
 		return err
 	}
 
+	if options.cache != nil {
+		fqRec := newFederatedQuotaReconciler(mgr.GetClient(), helper, cRec, options.cache, options.queues)
+		if err := fqRec.setupWithManager(mgr); err != nil {
+			return err
+		}
+	}
+
 	acRec := newACReconciler(mgr.GetClient(), helper)
 	err = acRec.setupWithManager(mgr)
 	if err != nil {
 		return err
 	}
 
-	wlRec := newWlReconciler(mgr.GetClient(), helper, cRec, options.origin, mgr.GetEventRecorderFor(constants.WorkloadControllerName), options.workerLostTimeout, options.eventsBatchPeriod, options.adapters)
+	wlRec := newWlReconciler(mgr.GetClient(), helper, cRec, options.origin, mgr.GetEventRecorderFor(constants.WorkloadControllerName), options.workerLostTimeout, options.eventsBatchPeriod, options.adapters, withDispatcher(options.dispatcher), withWorkerLostPolicy(options.workerLostPolicy), withStatusMirroring(options.statusMirroring))
 	return wlRec.setupWithManager(mgr)
 }
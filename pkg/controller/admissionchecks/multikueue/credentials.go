@@ -0,0 +1,252 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multikueue
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+)
+
+var (
+	// clusterProfileGVK is the GVK of the ClusterProfile of the Cluster Inventory API.
+	clusterProfileGVK = schema.GroupVersionKind{Group: "multicluster.x-k8s.io", Version: "v1alpha1", Kind: "ClusterProfile"}
+
+	errNoCredentialsProvider = errors.New("no ClusterProfile credentials provider configured")
+)
+
+// ClusterProfileCredentialsProvider provides the kubeconfig used to connect to
+// the cluster described by a ClusterProfile.
+type ClusterProfileCredentialsProvider interface {
+	KubeConfig(ctx context.Context, clusterProfile *unstructured.Unstructured) ([]byte, error)
+}
+
+// ExecCredentialsProvider provides a kubeconfig connecting to the cluster
+// published by a credentials provider in the status of a ClusterProfile,
+// using the exec plugin configured for that provider.
+type ExecCredentialsProvider struct {
+	providers []configapi.MultiKueueCredentialsProvider
+}
+
+var _ ClusterProfileCredentialsProvider = (*ExecCredentialsProvider)(nil)
+
+func NewExecCredentialsProvider(cfg *configapi.MultiKueueClusterProfile) *ExecCredentialsProvider {
+	if cfg == nil {
+		return &ExecCredentialsProvider{}
+	}
+	return &ExecCredentialsProvider{providers: cfg.CredentialsProviders}
+}
+
+func (p *ExecCredentialsProvider) KubeConfig(_ context.Context, clusterProfile *unstructured.Unstructured) ([]byte, error) {
+	published, err := publishedClusters(clusterProfile)
+	if err != nil {
+		return nil, err
+	}
+	for i := range p.providers {
+		provider := &p.providers[i]
+		if cluster, found := published[provider.Name]; found {
+			return execKubeConfig(cluster, &provider.ExecConfig)
+		}
+	}
+	return nil, fmt.Errorf("none of the configured credentials providers is published in the status of ClusterProfile %s", klog.KObj(clusterProfile))
+}
+
+// publishedClusters returns the clusters published in the status of a
+// ClusterProfile, indexed by credentials provider name.
+func publishedClusters(clusterProfile *unstructured.Unstructured) (map[string]clientcmdv1.Cluster, error) {
+	// credentialProviders was renamed to accessProviders in the Cluster Inventory API.
+	for _, field := range []string{"accessProviders", "credentialProviders"} {
+		providers, found, err := unstructured.NestedSlice(clusterProfile.Object, "status", field)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			continue
+		}
+		data, err := json.Marshal(providers)
+		if err != nil {
+			return nil, err
+		}
+		var published []struct {
+			Name    string              `json:"name"`
+			Cluster clientcmdv1.Cluster `json:"cluster"`
+		}
+		if err := json.Unmarshal(data, &published); err != nil {
+			return nil, err
+		}
+		ret := make(map[string]clientcmdv1.Cluster, len(published))
+		for _, p := range published {
+			ret[p.Name] = p.Cluster
+		}
+		return ret, nil
+	}
+	return nil, nil
+}
+
+func execKubeConfig(cluster clientcmdv1.Cluster, exec *clientcmdv1.ExecConfig) ([]byte, error) {
+	const name = "worker"
+	config := clientcmdv1.Config{
+		APIVersion:     "v1",
+		Kind:           "Config",
+		Clusters:       []clientcmdv1.NamedCluster{{Name: name, Cluster: cluster}},
+		AuthInfos:      []clientcmdv1.NamedAuthInfo{{Name: name, AuthInfo: clientcmdv1.AuthInfo{Exec: exec.DeepCopy()}}},
+		Contexts:       []clientcmdv1.NamedContext{{Name: name, Context: clientcmdv1.Context{Cluster: name, AuthInfo: name}}},
+		CurrentContext: name,
+	}
+	return yaml.Marshal(config)
+}
+
+// kubeConfigCredentials describes the credentials used by a kubeconfig.
+type kubeConfigCredentials struct {
+	// files are the files referenced by the kubeconfig.
+	files []string
+	// expiry is the time the credentials expire, if known.
+	expiry *time.Time
+}
+
+// flattenKubeConfig inlines the certificate files referenced by the current
+// context of kubeconfig, so that the client is recreated when they change.
+// The token files are kept, they are periodically reloaded by the client.
+// The relative paths are resolved from baseDir.
+// kubeconfig is returned unchanged if it can't be parsed, the error is
+// reported when the client is created.
+func flattenKubeConfig(kubeconfig []byte, baseDir string) ([]byte, *kubeConfigCredentials, error) {
+	creds := &kubeConfigCredentials{}
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return kubeconfig, creds, nil
+	}
+	kcContext, found := config.Contexts[config.CurrentContext]
+	if !found {
+		return kubeconfig, creds, nil
+	}
+
+	changed := false
+	// readFile reads the file referenced by path, resolving it from baseDir.
+	readFile := func(path *string) ([]byte, error) {
+		if *path == "" {
+			return nil, nil
+		}
+		if !filepath.IsAbs(*path) && baseDir != "" {
+			*path = filepath.Join(baseDir, *path)
+			changed = true
+		}
+		creds.files = append(creds.files, *path)
+		return os.ReadFile(*path)
+	}
+	// inline replaces the file referenced by path with its content.
+	inline := func(path *string, data *[]byte) error {
+		content, err := readFile(path)
+		if err != nil || content == nil {
+			return err
+		}
+		*data = content
+		*path = ""
+		changed = true
+		return nil
+	}
+
+	if cluster, found := config.Clusters[kcContext.Cluster]; found {
+		if err := inline(&cluster.CertificateAuthority, &cluster.CertificateAuthorityData); err != nil {
+			return nil, nil, err
+		}
+	}
+	if authInfo, found := config.AuthInfos[kcContext.AuthInfo]; found {
+		if err := errors.Join(
+			inline(&authInfo.ClientCertificate, &authInfo.ClientCertificateData),
+			inline(&authInfo.ClientKey, &authInfo.ClientKeyData),
+		); err != nil {
+			return nil, nil, err
+		}
+		creds.expiry = certificateExpiry(authInfo.ClientCertificateData)
+
+		token := authInfo.Token
+		if authInfo.TokenFile != "" {
+			content, err := readFile(&authInfo.TokenFile)
+			if err != nil {
+				return nil, nil, err
+			}
+			token = strings.TrimSpace(string(content))
+		}
+		creds.expiry = earliest(creds.expiry, tokenExpiry(token))
+	}
+
+	if !changed {
+		return kubeconfig, creds, nil
+	}
+	flattened, err := clientcmd.Write(*config)
+	return flattened, creds, err
+}
+
+// tokenExpiry returns the expiry of a JWT token, if known.
+// The token is not verified.
+func tokenExpiry(token string) *time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil
+	}
+	var claims struct {
+		Exp *int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == nil {
+		return nil
+	}
+	expiry := time.Unix(*claims.Exp, 0)
+	return &expiry
+}
+
+// certificateExpiry returns the expiry of the first PEM encoded certificate
+// of data, if any.
+func certificateExpiry(data []byte) *time.Time {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil
+	}
+	return &cert.NotAfter
+}
+
+func earliest(a, b *time.Time) *time.Time {
+	if a == nil || (b != nil && b.Before(*a)) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,291 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multikueue
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+	"k8s.io/utils/ptr"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+)
+
+func makeTestCertificate(t *testing.T, notAfter time.Time) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "multikueue"},
+		NotBefore:    notAfter.Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestFlattenKubeConfig(t *testing.T) {
+	certExpiry := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	tokenExpiry := time.Unix(1000000000, 0)
+	cert := makeTestCertificate(t, certExpiry)
+
+	cases := map[string]struct {
+		authInfo  clientcmdapi.AuthInfo
+		files     map[string][]byte
+		invalid   bool
+		noContext bool
+
+		wantAuthInfo *clientcmdapi.AuthInfo
+		wantFiles    []string
+		wantExpiry   *time.Time
+		wantErr      bool
+	}{
+		"invalid kubeconfig is returned unchanged": {
+			invalid: true,
+		},
+		"kubeconfig without current context is returned unchanged": {
+			noContext: true,
+		},
+		"inline token": {
+			authInfo: clientcmdapi.AuthInfo{Token: "token"},
+		},
+		"client certificate files are inlined": {
+			authInfo: clientcmdapi.AuthInfo{ClientCertificate: "tls.crt", ClientKey: "tls.key"},
+			files: map[string][]byte{
+				"tls.crt": cert,
+				"tls.key": []byte("key"),
+			},
+			wantAuthInfo: &clientcmdapi.AuthInfo{
+				ClientCertificateData: cert,
+				ClientKeyData:         []byte("key"),
+			},
+			wantFiles:  []string{"tls.crt", "tls.key"},
+			wantExpiry: &certExpiry,
+		},
+		"token file is kept": {
+			authInfo: clientcmdapi.AuthInfo{TokenFile: "token"},
+			files: map[string][]byte{
+				"token": []byte("eyJhbGciOiJSUzI1NiJ9.eyJleHAiOjEwMDAwMDAwMDB9.signature\n"),
+			},
+			wantAuthInfo: &clientcmdapi.AuthInfo{TokenFile: "token"},
+			wantFiles:    []string{"token"},
+			wantExpiry:   &tokenExpiry,
+		},
+		"the earliest expiry is reported": {
+			authInfo: clientcmdapi.AuthInfo{ClientCertificate: "tls.crt", TokenFile: "token"},
+			files: map[string][]byte{
+				"tls.crt": cert,
+				"token":   []byte("eyJhbGciOiJSUzI1NiJ9.eyJleHAiOjEwMDAwMDAwMDB9.signature"),
+			},
+			wantAuthInfo: &clientcmdapi.AuthInfo{
+				ClientCertificateData: cert,
+				TokenFile:             "token",
+			},
+			wantFiles:  []string{"tls.crt", "token"},
+			wantExpiry: &tokenExpiry,
+		},
+		"missing token file": {
+			authInfo:  clientcmdapi.AuthInfo{TokenFile: "token"},
+			wantFiles: []string{"token"},
+			wantErr:   true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tc.files {
+				if err := os.WriteFile(filepath.Join(dir, name), content, 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var kubeconfig []byte
+			if tc.invalid {
+				kubeconfig = []byte("invalid")
+			} else {
+				config := clientcmdapi.NewConfig()
+				config.Clusters["worker"] = &clientcmdapi.Cluster{Server: "https://worker:6443"}
+				config.AuthInfos["worker"] = &tc.authInfo
+				config.Contexts["worker"] = &clientcmdapi.Context{Cluster: "worker", AuthInfo: "worker"}
+				if !tc.noContext {
+					config.CurrentContext = "worker"
+				}
+				var err error
+				if kubeconfig, err = clientcmd.Write(*config); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, creds, gotErr := flattenKubeConfig(kubeconfig, dir)
+			if (gotErr != nil) != tc.wantErr {
+				t.Fatalf("unexpected error: %v", gotErr)
+			}
+			if gotErr != nil {
+				return
+			}
+
+			if tc.wantAuthInfo == nil {
+				if diff := cmp.Diff(string(kubeconfig), string(got)); diff != "" {
+					t.Errorf("unexpected kubeconfig change (-want/+got):\n%s", diff)
+				}
+			} else {
+				config, err := clientcmd.Load(got)
+				if err != nil {
+					t.Fatalf("loading the flattened kubeconfig: %v", err)
+				}
+				want := tc.wantAuthInfo.DeepCopy()
+				if want.TokenFile != "" {
+					want.TokenFile = filepath.Join(dir, want.TokenFile)
+				}
+				if diff := cmp.Diff(want, config.AuthInfos["worker"], cmpopts.IgnoreFields(clientcmdapi.AuthInfo{}, "LocationOfOrigin", "Extensions")); diff != "" {
+					t.Errorf("unexpected auth info (-want/+got):\n%s", diff)
+				}
+			}
+
+			wantFiles := make([]string, 0, len(tc.wantFiles))
+			for _, f := range tc.wantFiles {
+				wantFiles = append(wantFiles, filepath.Join(dir, f))
+			}
+			if diff := cmp.Diff(wantFiles, creds.files, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("unexpected files (-want/+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantExpiry, creds.expiry, cmpopts.EquateApproxTime(0)); diff != "" {
+				t.Errorf("unexpected expiry (-want/+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestExecCredentialsProvider(t *testing.T) {
+	providers := &configapi.MultiKueueClusterProfile{
+		CredentialsProviders: []configapi.MultiKueueCredentialsProvider{
+			{
+				Name: "google",
+				ExecConfig: clientcmdv1.ExecConfig{
+					APIVersion:      "client.authentication.k8s.io/v1",
+					Command:         "gke-gcloud-auth-plugin",
+					InteractiveMode: clientcmdv1.NeverExecInteractiveMode,
+				},
+			},
+			{
+				Name: "secretreader",
+				ExecConfig: clientcmdv1.ExecConfig{
+					APIVersion:      "client.authentication.k8s.io/v1",
+					Command:         "secretreader-plugin",
+					InteractiveMode: clientcmdv1.NeverExecInteractiveMode,
+				},
+			},
+		},
+	}
+
+	cases := map[string]struct {
+		status map[string]any
+
+		wantServer  string
+		wantCommand string
+		wantErr     bool
+	}{
+		"no credentials providers published": {
+			wantErr: true,
+		},
+		"unknown credentials provider": {
+			status: map[string]any{
+				"accessProviders": []any{
+					map[string]any{"name": "other", "cluster": map[string]any{"server": "https://other"}},
+				},
+			},
+			wantErr: true,
+		},
+		"the first configured provider is used": {
+			status: map[string]any{
+				"accessProviders": []any{
+					map[string]any{"name": "secretreader", "cluster": map[string]any{"server": "https://secretreader"}},
+					map[string]any{"name": "google", "cluster": map[string]any{"server": "https://google"}},
+				},
+			},
+			wantServer:  "https://google",
+			wantCommand: "gke-gcloud-auth-plugin",
+		},
+		"legacy credentialProviders field": {
+			status: map[string]any{
+				"credentialProviders": []any{
+					map[string]any{"name": "secretreader", "cluster": map[string]any{"server": "https://secretreader"}},
+				},
+			},
+			wantServer:  "https://secretreader",
+			wantCommand: "secretreader-plugin",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			clusterProfile := makeTestClusterProfile("fleet", "worker1")
+			if tc.status != nil {
+				if err := unstructured.SetNestedField(clusterProfile.Object, tc.status, "status"); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, gotErr := NewExecCredentialsProvider(providers).KubeConfig(t.Context(), clusterProfile)
+			if (gotErr != nil) != tc.wantErr {
+				t.Fatalf("unexpected error: %v", gotErr)
+			}
+			if gotErr != nil {
+				return
+			}
+
+			config, err := clientcmd.Load(got)
+			if err != nil {
+				t.Fatalf("loading the kubeconfig: %v", err)
+			}
+			kcContext := config.Contexts[config.CurrentContext]
+			if kcContext == nil {
+				t.Fatalf("missing current context %q", config.CurrentContext)
+			}
+			if diff := cmp.Diff(tc.wantServer, ptr.Deref(config.Clusters[kcContext.Cluster], clientcmdapi.Cluster{}).Server); diff != "" {
+				t.Errorf("unexpected server (-want/+got):\n%s", diff)
+			}
+			authInfo := ptr.Deref(config.AuthInfos[kcContext.AuthInfo], clientcmdapi.AuthInfo{})
+			if authInfo.Exec == nil {
+				t.Fatal("missing exec config")
+			}
+			if diff := cmp.Diff(tc.wantCommand, authInfo.Exec.Command); diff != "" {
+				t.Errorf("unexpected exec command (-want/+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,379 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multikueue
+
+import (
+	"context"
+	"hash/fnv"
+	"slices"
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/resources"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// noClusterRetryPeriod is the delay after which the worker clusters are
+// scored again, when the workload can't be dispatched to any of them.
+const noClusterRetryPeriod = time.Minute
+
+// DispatchInput is the input of a Dispatcher.
+type DispatchInput struct {
+	// Workload is the local workload, with a quota reservation in the
+	// manager cluster.
+	Workload *kueue.Workload
+	// Clusters are the names of the active worker clusters of the
+	// MultiKueueConfig, sorted.
+	Clusters []string
+	// Dispatched are the names of the worker clusters in which the remote
+	// workload exists.
+	Dispatched sets.Set[string]
+	// DispatchTimes are the creation times of the remote workloads, by the
+	// name of the worker cluster.
+	DispatchTimes map[string]time.Time
+	// Now is the current time.
+	Now time.Time
+}
+
+// Dispatcher selects the worker clusters in which the remote workloads are
+// created. The remote workloads in the other worker clusters are deleted,
+// unless one of them has a quota reservation.
+type Dispatcher interface {
+	// Dispatch returns the names of the worker clusters, among the input
+	// clusters, in which the remote workload should exist, and the delay after
+	// which the selection should be evaluated again, 0 if not needed.
+	Dispatch(ctx context.Context, in DispatchInput) ([]string, time.Duration)
+}
+
+// AllAtOnceDispatcher dispatches the workloads to all the worker clusters,
+// the first one to reserve the quota wins.
+type AllAtOnceDispatcher struct{}
+
+var _ Dispatcher = (*AllAtOnceDispatcher)(nil)
+
+func (*AllAtOnceDispatcher) Dispatch(_ context.Context, in DispatchInput) ([]string, time.Duration) {
+	return in.Clusters, 0
+}
+
+// IncrementalDispatcher dispatches the workloads to a subset of the worker
+// clusters, and adds more worker clusters to the subset every timeout, until
+// one of them reserves the quota. The order of the worker clusters depends on
+// the workload, to spread the workloads across the worker clusters.
+type IncrementalDispatcher struct {
+	clusters int
+	timeout  time.Duration
+}
+
+var _ Dispatcher = (*IncrementalDispatcher)(nil)
+
+// NewIncrementalDispatcher returns an IncrementalDispatcher which adds the
+// given number of worker clusters every timeout.
+func NewIncrementalDispatcher(clusters int, timeout time.Duration) *IncrementalDispatcher {
+	return &IncrementalDispatcher{
+		clusters: max(clusters, 1),
+		timeout:  timeout,
+	}
+}
+
+func (d *IncrementalDispatcher) Dispatch(_ context.Context, in DispatchInput) ([]string, time.Duration) {
+	if len(in.Clusters) == 0 {
+		return nil, 0
+	}
+	var elapsed time.Duration
+	if c := apimeta.FindStatusCondition(in.Workload.Status.Conditions, kueue.WorkloadQuotaReserved); c != nil {
+		elapsed = max(in.Now.Sub(c.LastTransitionTime.Time), 0)
+	}
+	rounds := 1
+	var requeueAfter time.Duration
+	if d.timeout > 0 {
+		rounds += int(elapsed / d.timeout)
+		requeueAfter = d.timeout - elapsed%d.timeout
+	}
+	count := min(rounds*d.clusters, len(in.Clusters))
+	if count == len(in.Clusters) {
+		requeueAfter = 0
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(in.Workload.Namespace + "/" + in.Workload.Name))
+	offset := int(h.Sum32() % uint32(len(in.Clusters)))
+	selected := sets.New[string]()
+	for i := range count {
+		selected.Insert(in.Clusters[(offset+i)%len(in.Clusters)])
+	}
+	for _, cluster := range in.Clusters {
+		if in.Dispatched.Has(cluster) {
+			selected.Insert(cluster)
+		}
+	}
+	return sets.List(selected), requeueAfter
+}
+
+// ScoreFunc returns the score of a worker cluster for a workload, the higher
+// the better, and false if the workload can't be dispatched to the worker
+// cluster.
+type ScoreFunc func(ctx context.Context, wl *kueue.Workload, cluster string) (float64, bool)
+
+// ScoringDispatcher dispatches the workloads to the single worker cluster with
+// the highest score. When the workload doesn't get a quota reservation in the
+// worker cluster it was dispatched to within the timeout, the other worker
+// clusters are scored again, and the workload is dispatched to the best of
+// them. With a zero timeout the workload stays in the worker cluster as long
+// as the worker cluster is active. When the workload can't be dispatched to
+// any worker cluster, the worker clusters are scored again after
+// noClusterRetryPeriod.
+type ScoringDispatcher struct {
+	score   ScoreFunc
+	timeout time.Duration
+}
+
+var _ Dispatcher = (*ScoringDispatcher)(nil)
+
+// NewScoringDispatcher returns a ScoringDispatcher using the score function,
+// and waiting for the timeout before dispatching the workload to another
+// worker cluster.
+func NewScoringDispatcher(score ScoreFunc, timeout time.Duration) *ScoringDispatcher {
+	return &ScoringDispatcher{score: score, timeout: timeout}
+}
+
+func (d *ScoringDispatcher) Dispatch(ctx context.Context, in DispatchInput) ([]string, time.Duration) {
+	current := ""
+	if idx := slices.IndexFunc(in.Clusters, in.Dispatched.Has); idx >= 0 {
+		current = in.Clusters[idx]
+		if d.timeout <= 0 {
+			return []string{current}, 0
+		}
+		if waited := in.Now.Sub(in.DispatchTimes[current]); waited < d.timeout {
+			return []string{current}, d.timeout - waited
+		}
+	}
+	best := ""
+	var bestScore float64
+	for _, cluster := range in.Clusters {
+		if cluster == current {
+			continue
+		}
+		score, ok := d.score(ctx, in.Workload, cluster)
+		if ok && (best == "" || score > bestScore) {
+			best = cluster
+			bestScore = score
+		}
+	}
+	switch {
+	case best != "":
+		return []string{best}, d.timeout
+	case current != "":
+		// There is no other worker cluster to try, keep waiting.
+		return []string{current}, d.timeout
+	default:
+		return nil, noClusterRetryPeriod
+	}
+}
+
+// capacityDispatcher is a Dispatcher using the capacity of the ClusterQueues
+// in the worker clusters, which is tracked when the dispatcher is passed to
+// SetupControllers.
+type capacityDispatcher interface {
+	Dispatcher
+	setClusters(clusters *clustersReconciler)
+}
+
+// capacityTracker gives access to the capacity of the ClusterQueues in the
+// worker clusters.
+type capacityTracker struct {
+	clusters *clustersReconciler
+}
+
+func (t *capacityTracker) setClusters(clusters *clustersReconciler) {
+	t.clusters = clusters
+}
+
+// clusterQueue returns the worker cluster client, and the name of the
+// ClusterQueue pointed by the LocalQueue of the workload in the worker
+// cluster, as tracked by the watches of the worker cluster. It returns false
+// when the ClusterQueue is unknown.
+func (t *capacityTracker) clusterQueue(ctx context.Context, wl *kueue.Workload, cluster string) (*remoteClient, kueue.ClusterQueueReference, bool) {
+	log := ctrl.LoggerFrom(ctx).WithValues("workerCluster", cluster)
+	if t.clusters == nil {
+		return nil, "", false
+	}
+	rc, found := t.clusters.controllerFor(cluster)
+	if !found {
+		return nil, "", false
+	}
+	cqName, found := rc.localQueueClusterQueue(types.NamespacedName{Namespace: wl.Namespace, Name: string(wl.Spec.QueueName)})
+	if !found {
+		log.V(3).Info("Skip the worker cluster, the LocalQueue is not found", "localQueue", wl.Spec.QueueName)
+		return nil, "", false
+	}
+	return rc, cqName, true
+}
+
+// clusterQueueCapacity returns the capacity of the ClusterQueue pointed by
+// the LocalQueue of the workload in the worker cluster, and false if it's
+// unknown.
+func (t *capacityTracker) clusterQueueCapacity(ctx context.Context, wl *kueue.Workload, cluster string) (*kueue.MultiKueueClusterQueueCapacity, bool) {
+	rc, cqName, found := t.clusterQueue(ctx, wl, cluster)
+	if !found {
+		return nil, false
+	}
+	cq, found := rc.clusterQueueCapacity(cqName)
+	if !found {
+		ctrl.LoggerFrom(ctx).V(3).Info("Skip the worker cluster, the ClusterQueue is not found", "workerCluster", cluster, "clusterQueue", cqName)
+		return nil, false
+	}
+	return &cq, true
+}
+
+// PendingWorkloadsDispatcher dispatches the workloads to the single worker
+// cluster with the fewest pending workloads in the ClusterQueue pointed by
+// the LocalQueue of the workload.
+type PendingWorkloadsDispatcher struct {
+	ScoringDispatcher
+	capacityTracker
+}
+
+var _ capacityDispatcher = (*PendingWorkloadsDispatcher)(nil)
+
+// NewPendingWorkloadsDispatcher returns a PendingWorkloadsDispatcher waiting
+// for the timeout before dispatching the workload to another worker cluster.
+// The capacity of the worker clusters is tracked when it's passed to
+// SetupControllers.
+func NewPendingWorkloadsDispatcher(timeout time.Duration) *PendingWorkloadsDispatcher {
+	d := &PendingWorkloadsDispatcher{}
+	d.score = d.scoreCluster
+	d.timeout = timeout
+	return d
+}
+
+func (d *PendingWorkloadsDispatcher) scoreCluster(ctx context.Context, wl *kueue.Workload, cluster string) (float64, bool) {
+	cq, found := d.clusterQueueCapacity(ctx, wl, cluster)
+	if !found {
+		return 0, false
+	}
+	return 1 / float64(1+cq.PendingWorkloads), true
+}
+
+// CapacityAwareDispatcher dispatches the workloads to the single worker
+// cluster most likely to admit them right away, based on the capacity of the
+// ClusterQueues in the worker clusters. The worker clusters in which the
+// workload can never fit are skipped.
+type CapacityAwareDispatcher struct {
+	ScoringDispatcher
+	capacityTracker
+}
+
+var _ capacityDispatcher = (*CapacityAwareDispatcher)(nil)
+
+// NewCapacityAwareDispatcher returns a CapacityAwareDispatcher waiting for
+// the timeout before dispatching the workload to another worker cluster. The
+// capacity of the worker clusters is tracked when it's passed to
+// SetupControllers.
+func NewCapacityAwareDispatcher(timeout time.Duration) *CapacityAwareDispatcher {
+	d := &CapacityAwareDispatcher{}
+	d.score = d.scoreCluster
+	d.timeout = timeout
+	return d
+}
+
+func (d *CapacityAwareDispatcher) scoreCluster(ctx context.Context, wl *kueue.Workload, cluster string) (float64, bool) {
+	rc, cqName, found := d.clusterQueue(ctx, wl, cluster)
+	if !found {
+		return 0, false
+	}
+	cq, found := rc.clusterQueueCapacity(cqName)
+	if !found {
+		ctrl.LoggerFrom(ctx).V(3).Info("Skip the worker cluster, the ClusterQueue is not found", "workerCluster", cluster, "clusterQueue", cqName)
+		return 0, false
+	}
+	quotas, _ := rc.clusterQueueQuotas(cqName)
+	return capacityScore(workload.NewInfo(wl).FlavorResourceUsage(), &cq, quotas, rc.clusterQueueReserved(cqName))
+}
+
+// capacityScore returns the score of the ClusterQueue for the usage of the
+// workload, and false if the workload can never fit in the ClusterQueue. For
+// each resource, only the flavors of the ClusterQueue providing the resource
+// are considered, or only the flavor assigned to the workload in the manager
+// cluster if the ClusterQueue has it. The ClusterQueues in which the
+// workload fits right away get a higher score, and among them, the
+// ClusterQueues with less pending workloads.
+func capacityScore(usage resources.FlavorResourceQuantities, cq *kueue.MultiKueueClusterQueueCapacity,
+	quotas, reserved resources.FlavorResourceQuantities) (float64, bool) {
+	fits := true
+	for fr, value := range usage {
+		var candidates []resources.FlavorResource
+		if _, found := quotas[fr]; found {
+			candidates = []resources.FlavorResource{fr}
+		} else {
+			for candidate := range quotas {
+				if candidate.Resource == fr.Resource {
+					candidates = append(candidates, candidate)
+				}
+			}
+		}
+		if len(candidates) == 0 {
+			return 0, false
+		}
+		canFit, fitsNow := false, false
+		for _, candidate := range candidates {
+			canFit = canFit || value <= quotas[candidate]
+			fitsNow = fitsNow || value <= quotas[candidate]-reserved[candidate]
+		}
+		// Without a cohort the ClusterQueue can't borrow quota.
+		if cq.Cohort == "" && !canFit {
+			return 0, false
+		}
+		fits = fits && fitsNow
+	}
+	score := 1 / float64(1+cq.PendingWorkloads)
+	if fits {
+		score += 1
+	}
+	return score, true
+}
+
+// NewDispatcher returns the Dispatcher described by the configuration.
+func NewDispatcher(cfg *configapi.MultiKueueDispatcher) Dispatcher {
+	if cfg == nil {
+		return &AllAtOnceDispatcher{}
+	}
+	scoringTimeout := configapi.DefaultMultiKueueScoringTimeout
+	if cfg.ScoringTimeout != nil {
+		scoringTimeout = cfg.ScoringTimeout.Duration
+	}
+	switch cfg.Name {
+	case configapi.MultiKueueDispatcherScoring:
+		return NewPendingWorkloadsDispatcher(scoringTimeout)
+	case configapi.MultiKueueDispatcherCapacityAware:
+		return NewCapacityAwareDispatcher(scoringTimeout)
+	case configapi.MultiKueueDispatcherIncremental:
+		timeout := configapi.DefaultMultiKueueIncrementalTimeout
+		if cfg.IncrementalTimeout != nil {
+			timeout = cfg.IncrementalTimeout.Duration
+		}
+		return NewIncrementalDispatcher(int(ptr.Deref(cfg.IncrementalClusters, configapi.DefaultMultiKueueIncrementalClusters)), timeout)
+	default:
+		return &AllAtOnceDispatcher{}
+	}
+}
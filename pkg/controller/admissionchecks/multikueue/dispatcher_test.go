@@ -0,0 +1,299 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multikueue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/resources"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+type fakeDispatcher []string
+
+func (d fakeDispatcher) Dispatch(context.Context, DispatchInput) ([]string, time.Duration) {
+	return d, 0
+}
+
+func TestIncrementalDispatcher(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	clusters := []string{"worker1", "worker2", "worker3", "worker4", "worker5"}
+	dispatcher := NewIncrementalDispatcher(2, time.Minute)
+
+	cases := map[string]struct {
+		reservedAgo      time.Duration
+		wantCount        int
+		wantRequeueAfter time.Duration
+	}{
+		"first clusters": {
+			reservedAgo:      10 * time.Second,
+			wantCount:        2,
+			wantRequeueAfter: 50 * time.Second,
+		},
+		"more clusters after the timeout": {
+			reservedAgo:      90 * time.Second,
+			wantCount:        4,
+			wantRequeueAfter: 30 * time.Second,
+		},
+		"all clusters": {
+			reservedAgo: 5 * time.Minute,
+			wantCount:   5,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			wl := utiltesting.MakeWorkload("wl", TestNamespace).
+				ReserveQuotaAt(utiltesting.MakeAdmission("q1").Obj(), now.Add(-tc.reservedAgo)).
+				Obj()
+			got, gotRequeueAfter := dispatcher.Dispatch(ctx, DispatchInput{Workload: wl, Clusters: clusters, Now: now})
+			if len(got) != tc.wantCount {
+				t.Errorf("Unexpected clusters %v, want %d clusters", got, tc.wantCount)
+			}
+			if diff := cmp.Diff(tc.wantRequeueAfter, gotRequeueAfter); diff != "" {
+				t.Errorf("Unexpected requeue after (-want,+got):\n%s", diff)
+			}
+
+			// The clusters are only added to the selection.
+			previous, _ := dispatcher.Dispatch(ctx, DispatchInput{Workload: wl, Clusters: clusters, Now: now.Add(-time.Minute)})
+			if !sets.New(got...).IsSuperset(sets.New(previous...)) {
+				t.Errorf("Clusters %v selected before are not selected in %v", previous, got)
+			}
+		})
+	}
+}
+
+func TestScoringDispatcher(t *testing.T) {
+	scores := map[string]float64{"worker1": 1, "worker2": 3, "worker3": 2}
+	score := func(_ context.Context, _ *kueue.Workload, cluster string) (float64, bool) {
+		score, found := scores[cluster]
+		return score, found
+	}
+	clusters := []string{"worker1", "worker2", "worker3"}
+	now := time.Now()
+
+	cases := map[string]struct {
+		timeout          time.Duration
+		clusters         []string
+		dispatched       sets.Set[string]
+		dispatchedAgo    time.Duration
+		want             []string
+		wantRequeueAfter time.Duration
+	}{
+		"the cluster with the highest score": {
+			want: []string{"worker2"},
+		},
+		"the dispatched cluster is kept": {
+			dispatched: sets.New("worker3"),
+			want:       []string{"worker3"},
+		},
+		"the dispatched cluster which isn't active is ignored": {
+			dispatched: sets.New("worker4"),
+			want:       []string{"worker2"},
+		},
+		"the dispatched cluster is kept until the timeout": {
+			timeout:          time.Minute,
+			dispatched:       sets.New("worker2"),
+			dispatchedAgo:    20 * time.Second,
+			want:             []string{"worker2"},
+			wantRequeueAfter: 40 * time.Second,
+		},
+		"the workload is dispatched to the best other cluster after the timeout": {
+			timeout:          time.Minute,
+			dispatched:       sets.New("worker2"),
+			dispatchedAgo:    time.Minute,
+			want:             []string{"worker3"},
+			wantRequeueAfter: time.Minute,
+		},
+		"the dispatched cluster is kept after the timeout when there is no other cluster": {
+			timeout:          time.Minute,
+			clusters:         []string{"worker2"},
+			dispatched:       sets.New("worker2"),
+			dispatchedAgo:    time.Minute,
+			want:             []string{"worker2"},
+			wantRequeueAfter: time.Minute,
+		},
+		"no cluster can be scored": {
+			timeout:          time.Minute,
+			clusters:         []string{"worker4"},
+			wantRequeueAfter: noClusterRetryPeriod,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			dispatcher := NewScoringDispatcher(score, tc.timeout)
+			wl := utiltesting.MakeWorkload("wl", TestNamespace).Obj()
+			in := DispatchInput{
+				Workload:      wl,
+				Clusters:      clusters,
+				Dispatched:    tc.dispatched,
+				DispatchTimes: make(map[string]time.Time),
+				Now:           now,
+			}
+			if tc.clusters != nil {
+				in.Clusters = tc.clusters
+			}
+			for cluster := range tc.dispatched {
+				in.DispatchTimes[cluster] = now.Add(-tc.dispatchedAgo)
+			}
+			got, gotRequeueAfter := dispatcher.Dispatch(ctx, in)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Unexpected clusters (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantRequeueAfter, gotRequeueAfter); diff != "" {
+				t.Errorf("Unexpected requeue after (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCapacityDispatchers(t *testing.T) {
+	cqWithReservation := func(nominal, reserved string) *utiltesting.ClusterQueueWrapper {
+		cq := utiltesting.MakeClusterQueue("cq").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("f1").Resource(corev1.ResourceCPU, nominal).Obj())
+		cq.Status.FlavorsReservation = []kueue.FlavorUsage{{
+			Name:      "f1",
+			Resources: []kueue.ResourceUsage{{Name: corev1.ResourceCPU, Total: resource.MustParse(reserved)}},
+		}}
+		return cq
+	}
+	workers := map[string]*kueue.ClusterQueue{
+		// the workload fits after other workloads finish
+		"worker1": cqWithReservation("4", "3").Obj(),
+		// the workload fits right away
+		"worker2": cqWithReservation("8", "2").PendingWorkloads(2).Obj(),
+		// the workload fits right away, but behind more pending workloads
+		"worker3": cqWithReservation("8", "2").PendingWorkloads(5).Obj(),
+		// the workload can never fit
+		"worker4": cqWithReservation("1", "0").Obj(),
+		// the workload can borrow quota in the cohort
+		"worker5": cqWithReservation("1", "0").Cohort("cohort").Obj(),
+		// the workload can never fit, as the quotas of the flavors are not summed
+		"worker7": utiltesting.MakeClusterQueue("cq").
+			ResourceGroup(
+				*utiltesting.MakeFlavorQuotas("f1").Resource(corev1.ResourceCPU, "1").Obj(),
+				*utiltesting.MakeFlavorQuotas("f2").Resource(corev1.ResourceCPU, "1").Obj(),
+			).Obj(),
+		// the workload fits right away only in the flavor it's not assigned
+		"worker8": func() *kueue.ClusterQueue {
+			cq := utiltesting.MakeClusterQueue("cq").
+				ResourceGroup(
+					*utiltesting.MakeFlavorQuotas("f1").Resource(corev1.ResourceCPU, "4").Obj(),
+					*utiltesting.MakeFlavorQuotas("f2").Resource(corev1.ResourceCPU, "8").Obj(),
+				).Obj()
+			cq.Status.FlavorsReservation = []kueue.FlavorUsage{{
+				Name:      "f1",
+				Resources: []kueue.ResourceUsage{{Name: corev1.ResourceCPU, Total: resource.MustParse("3")}},
+			}}
+			return cq
+		}(),
+	}
+
+	cases := map[string]struct {
+		clusters             []string
+		pendingWorkloads     bool
+		want                 []string
+		wantPendingWorkloads []string
+		wantRequeueAfter     time.Duration
+	}{
+		"the cluster with the fewest pending workloads": {
+			clusters:             []string{"worker2", "worker3", "worker6"},
+			pendingWorkloads:     true,
+			wantPendingWorkloads: []string{"worker2"},
+		},
+		"the cluster with the fewest pending workloads, even if the workload can never fit": {
+			clusters:             []string{"worker2", "worker4"},
+			pendingWorkloads:     true,
+			wantPendingWorkloads: []string{"worker4"},
+		},
+		"the cluster where the workload fits right away": {
+			clusters: []string{"worker1", "worker2", "worker3", "worker4"},
+			want:     []string{"worker2"},
+		},
+		"the cluster where the workload fits later": {
+			clusters: []string{"worker1", "worker4"},
+			want:     []string{"worker1"},
+		},
+		"the cluster where the workload can borrow quota": {
+			clusters: []string{"worker4", "worker5"},
+			want:     []string{"worker5"},
+		},
+		"the clusters where the workload can never fit are skipped": {
+			clusters:         []string{"worker4", "worker6"},
+			wantRequeueAfter: noClusterRetryPeriod,
+		},
+		"the quotas of the flavors are not summed": {
+			clusters:         []string{"worker7"},
+			wantRequeueAfter: noClusterRetryPeriod,
+		},
+		"only the flavor assigned to the workload is considered": {
+			clusters: []string{"worker3", "worker8"},
+			want:     []string{"worker3"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			cRec := newClustersReconciler(getClientBuilder(ctx).Build(), TestNamespace, 0, defaultOrigin, nil, nil)
+			for worker, cq := range workers {
+				rc := newTrackingRemoteClient(worker)
+				rc.updateCapacity(cq, false)
+				rc.updateLocalQueue(utiltesting.MakeLocalQueue("lq", TestNamespace).ClusterQueue("cq").Obj(), false)
+				cRec.remoteClients[worker] = rc
+			}
+			// worker6 has no LocalQueue
+			cRec.remoteClients["worker6"] = newTrackingRemoteClient("worker6")
+
+			wl := utiltesting.MakeWorkload("wl", TestNamespace).Queue("lq").Request(corev1.ResourceCPU, "2").
+				ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "f1", "2").Obj()).
+				Obj()
+			var dispatcher capacityDispatcher = NewCapacityAwareDispatcher(0)
+			want := tc.want
+			if tc.pendingWorkloads {
+				dispatcher = NewPendingWorkloadsDispatcher(0)
+				want = tc.wantPendingWorkloads
+			}
+			dispatcher.setClusters(cRec)
+			got, gotRequeueAfter := dispatcher.Dispatch(ctx, DispatchInput{Workload: wl, Clusters: tc.clusters})
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("Unexpected clusters (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantRequeueAfter, gotRequeueAfter); diff != "" {
+				t.Errorf("Unexpected requeue after (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func newTrackingRemoteClient(cluster string) *remoteClient {
+	rc := newRemoteClient(nil, nil, nil, defaultOrigin, cluster, nil)
+	rc.capacity = make(map[kueue.ClusterQueueReference]kueue.MultiKueueClusterQueueCapacity)
+	rc.quotas = make(map[kueue.ClusterQueueReference]resources.FlavorResourceQuantities)
+	rc.reserved = make(map[kueue.ClusterQueueReference]resources.FlavorResourceQuantities)
+	rc.localQueues = make(map[types.NamespacedName]kueue.ClusterQueueReference)
+	return rc
+}
@@ -0,0 +1,208 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multikueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/controller/jobframework"
+	clientutil "sigs.k8s.io/kueue/pkg/util/client"
+)
+
+// externalFrameworkAdapter syncs, using unstructured objects, a job type
+// without a built-in MultiKueue adapter.
+type externalFrameworkAdapter struct {
+	gvk           schema.GroupVersionKind
+	managedByPath []string
+	statusPaths   [][]string
+}
+
+var _ jobframework.MultiKueueAdapter = (*externalFrameworkAdapter)(nil)
+var _ jobframework.MultiKueueWatcher = (*externalFrameworkAdapter)(nil)
+
+// NewExternalFrameworkAdapter returns the MultiKueue adapter of an external
+// framework configured in the MultiKueue configuration.
+func NewExternalFrameworkAdapter(framework *configapi.MultiKueueExternalFramework) (jobframework.MultiKueueAdapter, error) {
+	gvk, _ := schema.ParseKindArg(framework.Name)
+	if gvk == nil {
+		return nil, fmt.Errorf("invalid external framework %q, must be format 'Kind.version.group.com'", framework.Name)
+	}
+	a := &externalFrameworkAdapter{
+		gvk:           *gvk,
+		managedByPath: parseFieldPath(ptr.Deref(framework.ManagedByPath, configapi.DefaultMultiKueueManagedByPath)),
+	}
+	statusPaths := framework.StatusPaths
+	if len(statusPaths) == 0 {
+		statusPaths = []string{configapi.DefaultMultiKueueStatusPath}
+	}
+	for _, path := range statusPaths {
+		a.statusPaths = append(a.statusPaths, parseFieldPath(path))
+	}
+	return a, nil
+}
+
+// AddExternalFrameworkAdapters adds the adapters of the external frameworks to
+// adapters. An error is returned if a framework already has an adapter.
+func AddExternalFrameworkAdapters(adapters map[string]jobframework.MultiKueueAdapter, frameworks []configapi.MultiKueueExternalFramework) error {
+	for i := range frameworks {
+		adapter, err := NewExternalFrameworkAdapter(&frameworks[i])
+		if err != nil {
+			return err
+		}
+		gvk := adapter.GVK().String()
+		if _, found := adapters[gvk]; found {
+			return fmt.Errorf("multiple adapters for GVK: %q", gvk)
+		}
+		adapters[gvk] = adapter
+	}
+	return nil
+}
+
+// parseFieldPath splits a path like .spec.managedBy into its fields.
+func parseFieldPath(path string) []string {
+	return strings.Split(strings.TrimPrefix(path, "."), ".")
+}
+
+func (a *externalFrameworkAdapter) newObject() *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(a.gvk)
+	return obj
+}
+
+func (a *externalFrameworkAdapter) SyncJob(ctx context.Context, localClient client.Client, remoteClient client.Client, key types.NamespacedName, workloadName, origin string) error {
+	localObj := a.newObject()
+	if err := localClient.Get(ctx, key, localObj); err != nil {
+		return err
+	}
+
+	remoteObj := a.newObject()
+	err := remoteClient.Get(ctx, key, remoteObj)
+	if client.IgnoreNotFound(err) != nil {
+		return err
+	}
+
+	// if the remote exists, just copy the status
+	if err == nil {
+		return clientutil.PatchStatus(ctx, localClient, localObj, func() (bool, error) {
+			original := localObj.DeepCopy()
+			for _, path := range a.statusPaths {
+				value, found, err := unstructured.NestedFieldCopy(remoteObj.Object, path...)
+				if err != nil {
+					return false, err
+				}
+				if !found {
+					unstructured.RemoveNestedField(localObj.Object, path...)
+					continue
+				}
+				if err := unstructured.SetNestedField(localObj.Object, value, path...); err != nil {
+					return false, err
+				}
+			}
+			return !equality.Semantic.DeepEqual(original.Object, localObj.Object), nil
+		})
+	}
+
+	remoteObj = a.newObject()
+	for field, value := range localObj.Object {
+		if field == "apiVersion" || field == "kind" || field == "metadata" || field == "status" {
+			continue
+		}
+		remoteObj.Object[field] = runtime.DeepCopyJSONValue(value)
+	}
+	remoteObj.SetName(localObj.GetName())
+	remoteObj.SetNamespace(localObj.GetNamespace())
+	remoteObj.SetAnnotations(localObj.GetAnnotations())
+	labels := localObj.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string, 2)
+	}
+	labels[constants.PrebuiltWorkloadLabel] = workloadName
+	labels[kueue.MultiKueueOriginLabel] = origin
+	remoteObj.SetLabels(labels)
+
+	// clear the managedBy field, the job is managed by its own controller in the worker cluster
+	unstructured.RemoveNestedField(remoteObj.Object, a.managedByPath...)
+
+	return remoteClient.Create(ctx, remoteObj)
+}
+
+func (a *externalFrameworkAdapter) DeleteRemoteObject(ctx context.Context, remoteClient client.Client, key types.NamespacedName) error {
+	obj := a.newObject()
+	err := remoteClient.Get(ctx, key, obj)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	return client.IgnoreNotFound(remoteClient.Delete(ctx, obj))
+}
+
+func (a *externalFrameworkAdapter) KeepAdmissionCheckPending() bool {
+	return false
+}
+
+func (a *externalFrameworkAdapter) IsJobManagedByKueue(ctx context.Context, c client.Client, key types.NamespacedName) (bool, string, error) {
+	obj := a.newObject()
+	if err := c.Get(ctx, key, obj); err != nil {
+		return false, "", err
+	}
+	controllerName, _, err := unstructured.NestedString(obj.Object, a.managedByPath...)
+	if err != nil {
+		return false, "", err
+	}
+	if controllerName != kueue.MultiKueueControllerName {
+		return false, fmt.Sprintf("Expecting %s to be %q not %q", strings.Join(a.managedByPath, "."), kueue.MultiKueueControllerName, controllerName), nil
+	}
+	return true, "", nil
+}
+
+func (a *externalFrameworkAdapter) GVK() schema.GroupVersionKind {
+	return a.gvk
+}
+
+func (a *externalFrameworkAdapter) GetEmptyList() client.ObjectList {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(a.gvk.GroupVersion().WithKind(a.gvk.Kind + "List"))
+	return list
+}
+
+func (a *externalFrameworkAdapter) WorkloadKeyFor(o runtime.Object) (types.NamespacedName, error) {
+	obj, isUnstructured := o.(*unstructured.Unstructured)
+	if !isUnstructured {
+		return types.NamespacedName{}, errors.New("not an unstructured object")
+	}
+
+	prebuiltWl, hasPrebuiltWorkload := obj.GetLabels()[constants.PrebuiltWorkloadLabel]
+	if !hasPrebuiltWorkload {
+		return types.NamespacedName{}, fmt.Errorf("no prebuilt workload found for %s: %s", a.gvk.Kind, klog.KObj(obj))
+	}
+
+	return types.NamespacedName{Name: prebuiltWl, Namespace: obj.GetNamespace()}, nil
+}
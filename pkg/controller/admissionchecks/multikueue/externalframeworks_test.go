@@ -0,0 +1,258 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multikueue
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/controller/jobframework"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestExternalFrameworkAdapter(t *testing.T) {
+	objCheckOpts := cmp.Options{
+		cmpopts.IgnoreMapEntries(func(k string, _ any) bool { return k == "resourceVersion" }),
+		cmpopts.EquateEmpty(),
+	}
+
+	makeJob := func(labels map[string]any, spec map[string]any, status map[string]any) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "example.com/v1",
+			"kind":       "MyJob",
+			"metadata": map[string]any{
+				"name":      "job1",
+				"namespace": TestNamespace,
+			},
+		}}
+		if labels != nil {
+			obj.Object["metadata"].(map[string]any)["labels"] = labels
+		}
+		if spec != nil {
+			obj.Object["spec"] = spec
+		}
+		if status != nil {
+			obj.Object["status"] = status
+		}
+		return obj
+	}
+
+	managedSpec := map[string]any{"parallelism": int64(2), "controllerName": kueue.MultiKueueControllerName}
+	remoteSpec := map[string]any{"parallelism": int64(2)}
+	remoteLabels := map[string]any{constants.PrebuiltWorkloadLabel: "wl1", kueue.MultiKueueOriginLabel: "origin1"}
+	remoteStatus := map[string]any{"active": int64(2), "conditions": []any{map[string]any{"type": "Running"}}, "internal": "remote"}
+
+	framework := configapi.MultiKueueExternalFramework{
+		Name:          "MyJob.v1.example.com",
+		ManagedByPath: ptr.To(".spec.controllerName"),
+		StatusPaths:   []string{".status.active", ".status.conditions"},
+	}
+	key := types.NamespacedName{Name: "job1", Namespace: TestNamespace}
+
+	cases := map[string]struct {
+		managersJobs []*unstructured.Unstructured
+		workerJobs   []*unstructured.Unstructured
+
+		operation func(ctx context.Context, adapter jobframework.MultiKueueAdapter, managerClient, workerClient client.Client) error
+
+		wantError         error
+		wantManagersJobs  []*unstructured.Unstructured
+		wantWorkerJobs    []*unstructured.Unstructured
+		wantStatusPatches int
+	}{
+		"sync creates missing remote job": {
+			managersJobs: []*unstructured.Unstructured{
+				makeJob(nil, managedSpec, map[string]any{"active": int64(0)}),
+			},
+			operation: func(ctx context.Context, adapter jobframework.MultiKueueAdapter, managerClient, workerClient client.Client) error {
+				return adapter.SyncJob(ctx, managerClient, workerClient, key, "wl1", "origin1")
+			},
+			wantManagersJobs: []*unstructured.Unstructured{
+				makeJob(nil, managedSpec, map[string]any{"active": int64(0)}),
+			},
+			wantWorkerJobs: []*unstructured.Unstructured{
+				makeJob(remoteLabels, remoteSpec, nil),
+			},
+		},
+		"sync copies the configured status fields from the remote job": {
+			managersJobs: []*unstructured.Unstructured{
+				makeJob(nil, managedSpec, map[string]any{"active": int64(0), "startTime": "now"}),
+			},
+			workerJobs: []*unstructured.Unstructured{
+				makeJob(remoteLabels, remoteSpec, remoteStatus),
+			},
+			operation: func(ctx context.Context, adapter jobframework.MultiKueueAdapter, managerClient, workerClient client.Client) error {
+				return adapter.SyncJob(ctx, managerClient, workerClient, key, "wl1", "origin1")
+			},
+			wantManagersJobs: []*unstructured.Unstructured{
+				makeJob(nil, managedSpec, map[string]any{"active": int64(2), "startTime": "now", "conditions": []any{map[string]any{"type": "Running"}}}),
+			},
+			wantWorkerJobs: []*unstructured.Unstructured{
+				makeJob(remoteLabels, remoteSpec, remoteStatus),
+			},
+			wantStatusPatches: 1,
+		},
+		"sync doesn't patch an unchanged status": {
+			managersJobs: []*unstructured.Unstructured{
+				makeJob(nil, managedSpec, map[string]any{"active": int64(2), "conditions": []any{map[string]any{"type": "Running"}}}),
+			},
+			workerJobs: []*unstructured.Unstructured{
+				makeJob(remoteLabels, remoteSpec, remoteStatus),
+			},
+			operation: func(ctx context.Context, adapter jobframework.MultiKueueAdapter, managerClient, workerClient client.Client) error {
+				return adapter.SyncJob(ctx, managerClient, workerClient, key, "wl1", "origin1")
+			},
+			wantManagersJobs: []*unstructured.Unstructured{
+				makeJob(nil, managedSpec, map[string]any{"active": int64(2), "conditions": []any{map[string]any{"type": "Running"}}}),
+			},
+			wantWorkerJobs: []*unstructured.Unstructured{
+				makeJob(remoteLabels, remoteSpec, remoteStatus),
+			},
+		},
+		"remote job is deleted": {
+			workerJobs: []*unstructured.Unstructured{
+				makeJob(remoteLabels, remoteSpec, remoteStatus),
+			},
+			operation: func(ctx context.Context, adapter jobframework.MultiKueueAdapter, managerClient, workerClient client.Client) error {
+				return adapter.DeleteRemoteObject(ctx, workerClient, key)
+			},
+		},
+		"job with a different controller is not considered managed": {
+			managersJobs: []*unstructured.Unstructured{
+				makeJob(nil, remoteSpec, nil),
+			},
+			operation: func(ctx context.Context, adapter jobframework.MultiKueueAdapter, managerClient, workerClient client.Client) error {
+				if isManaged, _, _ := adapter.IsJobManagedByKueue(ctx, managerClient, key); isManaged {
+					return errors.New("expecting false")
+				}
+				return nil
+			},
+			wantManagersJobs: []*unstructured.Unstructured{
+				makeJob(nil, remoteSpec, nil),
+			},
+		},
+		"job managed by multikueue": {
+			managersJobs: []*unstructured.Unstructured{
+				makeJob(nil, managedSpec, nil),
+			},
+			operation: func(ctx context.Context, adapter jobframework.MultiKueueAdapter, managerClient, workerClient client.Client) error {
+				if isManaged, _, _ := adapter.IsJobManagedByKueue(ctx, managerClient, key); !isManaged {
+					return errors.New("expecting true")
+				}
+				return nil
+			},
+			wantManagersJobs: []*unstructured.Unstructured{
+				makeJob(nil, managedSpec, nil),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			statusPatches := 0
+			managerBuilder := utiltesting.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+				SubResourcePatch: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+					statusPatches++
+					return c.SubResource(subResourceName).Patch(ctx, obj, patch, opts...)
+				},
+			})
+			for _, job := range tc.managersJobs {
+				managerBuilder = managerBuilder.WithObjects(job.DeepCopy()).WithStatusSubresource(job.DeepCopy())
+			}
+			managerClient := managerBuilder.Build()
+
+			workerBuilder := utiltesting.NewClientBuilder()
+			for _, job := range tc.workerJobs {
+				workerBuilder = workerBuilder.WithObjects(job.DeepCopy())
+			}
+			workerClient := workerBuilder.Build()
+
+			ctx, _ := utiltesting.ContextWithLog(t)
+
+			adapter, err := NewExternalFrameworkAdapter(&framework)
+			if err != nil {
+				t.Fatalf("unexpected adapter error %s", err)
+			}
+
+			gotErr := tc.operation(ctx, adapter, managerClient, workerClient)
+			if diff := cmp.Diff(tc.wantError, gotErr, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("unexpected error (-want/+got):\n%s", diff)
+			}
+
+			if statusPatches != tc.wantStatusPatches {
+				t.Errorf("unexpected number of status patches, want %d, got %d", tc.wantStatusPatches, statusPatches)
+			}
+
+			watcher := adapter.(jobframework.MultiKueueWatcher)
+			gotManagersJobs := watcher.GetEmptyList().(*unstructured.UnstructuredList)
+			if err := managerClient.List(ctx, gotManagersJobs); err != nil {
+				t.Errorf("unexpected list manager's jobs error %s", err)
+			} else if diff := cmp.Diff(tc.wantManagersJobs, toPointers(gotManagersJobs.Items), objCheckOpts...); diff != "" {
+				t.Errorf("unexpected manager's jobs (-want/+got):\n%s", diff)
+			}
+
+			gotWorkerJobs := watcher.GetEmptyList().(*unstructured.UnstructuredList)
+			if err := workerClient.List(ctx, gotWorkerJobs); err != nil {
+				t.Errorf("unexpected list worker's jobs error %s", err)
+			} else if diff := cmp.Diff(tc.wantWorkerJobs, toPointers(gotWorkerJobs.Items), objCheckOpts...); diff != "" {
+				t.Errorf("unexpected worker's jobs (-want/+got):\n%s", diff)
+			}
+
+			for _, job := range gotWorkerJobs.Items {
+				wlKey, err := watcher.WorkloadKeyFor(&job)
+				if err != nil {
+					t.Errorf("unexpected workload key error %s", err)
+				} else if wlKey.Name != "wl1" {
+					t.Errorf("unexpected workload key %s", wlKey)
+				}
+			}
+		})
+	}
+}
+
+func toPointers(objs []unstructured.Unstructured) []*unstructured.Unstructured {
+	ret := make([]*unstructured.Unstructured, len(objs))
+	for i := range objs {
+		ret[i] = &objs[i]
+	}
+	return ret
+}
+
+func TestAddExternalFrameworkAdapters(t *testing.T) {
+	adapters := map[string]jobframework.MultiKueueAdapter{}
+	frameworks := []configapi.MultiKueueExternalFramework{{Name: "MyJob.v1.example.com"}}
+	if err := AddExternalFrameworkAdapters(adapters, frameworks); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if _, found := adapters["example.com/v1, Kind=MyJob"]; !found {
+		t.Errorf("missing adapter, got %v", adapters)
+	}
+	if err := AddExternalFrameworkAdapters(adapters, frameworks); err == nil {
+		t.Errorf("expecting an error for a duplicated adapter")
+	}
+}
@@ -0,0 +1,207 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multikueue
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/resources"
+	"sigs.k8s.io/kueue/pkg/util/admissioncheck"
+)
+
+// federatedQuotaReconciler computes the nominal quotas of the ClusterQueues
+// using a MultiKueue admission check whose MultiKueueConfig sets a
+// quotaPolicy, from the quotas of the ClusterQueues with the same name in
+// the worker clusters.
+type federatedQuotaReconciler struct {
+	client   client.Client
+	helper   *multiKueueStoreHelper
+	clusters *clustersReconciler
+	cache    *cache.Cache
+	queues   *queue.Manager
+}
+
+var _ reconcile.Reconciler = (*federatedQuotaReconciler)(nil)
+
+func newFederatedQuotaReconciler(c client.Client, helper *multiKueueStoreHelper, clusters *clustersReconciler, cache *cache.Cache, queues *queue.Manager) *federatedQuotaReconciler {
+	return &federatedQuotaReconciler{
+		client:   c,
+		helper:   helper,
+		clusters: clusters,
+		cache:    cache,
+		queues:   queues,
+	}
+}
+
+func (r *federatedQuotaReconciler) setupWithManager(mgr ctrl.Manager) error {
+	queueAll := handler.EnqueueRequestsFromMapFunc(r.clusterQueuesWithChecks)
+	return builder.ControllerManagedBy(mgr).
+		Named("multikueue_federated_quota").
+		For(&kueue.ClusterQueue{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&kueue.AdmissionCheck{}, queueAll).
+		Watches(&kueue.MultiKueueConfig{}, queueAll).
+		WatchesRawSource(source.Channel(r.clusters.quotaCh, queueAll)).
+		Complete(r)
+}
+
+func (r *federatedQuotaReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	log.V(2).Info("Reconcile federated quota of ClusterQueue")
+
+	cq := &kueue.ClusterQueue{}
+	if err := r.client.Get(ctx, req.NamespacedName, cq); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return reconcile.Result{}, err
+		}
+		r.updateFederatedQuota(ctx, kueue.ClusterQueueReference(req.Name), nil)
+		return reconcile.Result{}, nil
+	}
+
+	policy, clusters, err := r.quotaPolicy(ctx, cq)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	var quota *cache.FederatedQuota
+	if policy != nil {
+		quota = r.federatedQuota(cq, *policy, clusters)
+		log.V(3).Info("Computed federated quota", "clusters", quota.Clusters, "nominal", quota.Nominal)
+	}
+	r.updateFederatedQuota(ctx, kueue.ClusterQueueReference(cq.Name), quota)
+	return reconcile.Result{}, nil
+}
+
+func (r *federatedQuotaReconciler) updateFederatedQuota(ctx context.Context, cqName kueue.ClusterQueueReference, quota *cache.FederatedQuota) {
+	if !r.cache.UpdateFederatedQuota(cqName, quota) {
+		return
+	}
+	cqNames := sets.New(cqName)
+	r.queues.NotifyFederatedQuotaUpdateWatchers(cqNames)
+	// more quota can allow admitting workloads which were previously
+	// inadmissible.
+	r.queues.QueueInadmissibleWorkloads(ctx, cqNames)
+}
+
+// quotaPolicy returns the quotaPolicy and the worker clusters of the
+// MultiKueueConfig of the first MultiKueue admission check of the
+// ClusterQueue setting one.
+func (r *federatedQuotaReconciler) quotaPolicy(ctx context.Context, cq *kueue.ClusterQueue) (*kueue.MultiKueueQuotaPolicy, []string, error) {
+	for _, acName := range slices.Sorted(maps.Keys(admissioncheck.NewAdmissionChecks(cq))) {
+		ac := &kueue.AdmissionCheck{}
+		if err := r.client.Get(ctx, types.NamespacedName{Name: string(acName)}, ac); err != nil {
+			if client.IgnoreNotFound(err) != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+		if ac.Spec.ControllerName != kueue.MultiKueueControllerName {
+			continue
+		}
+		cfg, err := r.helper.ConfigFromRef(ctx, ac.Spec.Parameters)
+		if err != nil {
+			if apierrors.IsNotFound(err) || errors.Is(err, admissioncheck.ErrBadParametersRef) || errors.Is(err, admissioncheck.ErrNilParametersRef) {
+				continue
+			}
+			return nil, nil, err
+		}
+		if cfg.Spec.QuotaPolicy != nil {
+			return cfg.Spec.QuotaPolicy, cfg.Spec.Clusters, nil
+		}
+	}
+	return nil, nil, nil
+}
+
+// federatedQuota computes the nominal quotas of the ClusterQueue from the
+// quotas of the ClusterQueues with the same name in the connected and
+// healthy worker clusters.
+func (r *federatedQuotaReconciler) federatedQuota(cq *kueue.ClusterQueue, policy kueue.MultiKueueQuotaPolicy, clusters []string) *cache.FederatedQuota {
+	quota := &cache.FederatedQuota{
+		Policy:  policy,
+		Nominal: make(resources.FlavorResourceQuantities),
+	}
+	for _, clusterName := range clusters {
+		rc, found := r.clusters.controllerFor(clusterName)
+		if !found || rc.connecting.Load() || !rc.healthy() {
+			continue
+		}
+		workerQuotas, found := rc.clusterQueueQuotas(kueue.ClusterQueueReference(cq.Name))
+		if !found {
+			continue
+		}
+		quota.Clusters = append(quota.Clusters, clusterName)
+		for _, rg := range cq.Spec.ResourceGroups {
+			for _, fq := range rg.Flavors {
+				for _, rq := range fq.Resources {
+					fr := resources.FlavorResource{Flavor: fq.Name, Resource: rq.Name}
+					v, found := workerQuotas[fr]
+					if !found {
+						continue
+					}
+					current, set := quota.Nominal[fr]
+					if !set {
+						quota.Nominal[fr] = v
+						continue
+					}
+					switch policy {
+					case kueue.MultiKueueQuotaPolicySum:
+						quota.Nominal[fr] = current + v
+					case kueue.MultiKueueQuotaPolicyMin:
+						quota.Nominal[fr] = min(current, v)
+					case kueue.MultiKueueQuotaPolicyMax:
+						quota.Nominal[fr] = max(current, v)
+					}
+				}
+			}
+		}
+	}
+	return quota
+}
+
+// clusterQueuesWithChecks returns a request for every ClusterQueue using
+// admission checks, as they could use a MultiKueueConfig setting a
+// quotaPolicy.
+func (r *federatedQuotaReconciler) clusterQueuesWithChecks(ctx context.Context, _ client.Object) []reconcile.Request {
+	cqs := &kueue.ClusterQueueList{}
+	if err := r.client.List(ctx, cqs); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Listing ClusterQueues")
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range cqs.Items {
+		if len(admissioncheck.NewAdmissionChecks(&cqs.Items[i])) == 0 {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: cqs.Items[i].Name}})
+	}
+	return requests
+}
@@ -0,0 +1,264 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multikueue
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/controller/core/indexer"
+	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/resources"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestFederatedQuotaReconcile(t *testing.T) {
+	cpu := resources.FlavorResource{Flavor: "default", Resource: corev1.ResourceCPU}
+	gpu := resources.FlavorResource{Flavor: "gpu", Resource: "nvidia.com/gpu"}
+	worker1Quotas := resources.FlavorResourceQuantities{cpu: 4_000, gpu: 2}
+	worker2Quotas := resources.FlavorResourceQuantities{cpu: 6_000}
+
+	cases := map[string]struct {
+		policy           *kueue.MultiKueueQuotaPolicy
+		workersUnhealthy bool
+		worker2Unhealthy bool
+		worker2Missing   bool
+		deleteCQ         bool
+
+		wantQuota *kueue.FederatedQuota
+	}{
+		"no quota policy": {},
+		"sum of the worker quotas": {
+			policy: ptr.To(kueue.MultiKueueQuotaPolicySum),
+			wantQuota: &kueue.FederatedQuota{
+				Policy:   kueue.MultiKueueQuotaPolicySum,
+				Clusters: []string{"worker1", "worker2"},
+				Flavors: []kueue.FlavorFederatedQuota{
+					{
+						Name: "default",
+						Resources: []kueue.ResourceFederatedQuota{
+							{Name: corev1.ResourceCPU, NominalQuota: resource.MustParse("10")},
+							{Name: corev1.ResourceMemory, NominalQuota: resource.MustParse("0")},
+						},
+					},
+					{
+						Name:      "gpu",
+						Resources: []kueue.ResourceFederatedQuota{{Name: "nvidia.com/gpu", NominalQuota: resource.MustParse("2")}},
+					},
+				},
+			},
+		},
+		"smallest worker quota": {
+			policy: ptr.To(kueue.MultiKueueQuotaPolicyMin),
+			wantQuota: &kueue.FederatedQuota{
+				Policy:   kueue.MultiKueueQuotaPolicyMin,
+				Clusters: []string{"worker1", "worker2"},
+				Flavors: []kueue.FlavorFederatedQuota{
+					{
+						Name: "default",
+						Resources: []kueue.ResourceFederatedQuota{
+							{Name: corev1.ResourceCPU, NominalQuota: resource.MustParse("4")},
+							{Name: corev1.ResourceMemory, NominalQuota: resource.MustParse("0")},
+						},
+					},
+					{
+						Name:      "gpu",
+						Resources: []kueue.ResourceFederatedQuota{{Name: "nvidia.com/gpu", NominalQuota: resource.MustParse("2")}},
+					},
+				},
+			},
+		},
+		"largest worker quota": {
+			policy: ptr.To(kueue.MultiKueueQuotaPolicyMax),
+			wantQuota: &kueue.FederatedQuota{
+				Policy:   kueue.MultiKueueQuotaPolicyMax,
+				Clusters: []string{"worker1", "worker2"},
+				Flavors: []kueue.FlavorFederatedQuota{
+					{
+						Name: "default",
+						Resources: []kueue.ResourceFederatedQuota{
+							{Name: corev1.ResourceCPU, NominalQuota: resource.MustParse("6")},
+							{Name: corev1.ResourceMemory, NominalQuota: resource.MustParse("0")},
+						},
+					},
+					{
+						Name:      "gpu",
+						Resources: []kueue.ResourceFederatedQuota{{Name: "nvidia.com/gpu", NominalQuota: resource.MustParse("2")}},
+					},
+				},
+			},
+		},
+		"the unhealthy worker is ignored": {
+			policy:           ptr.To(kueue.MultiKueueQuotaPolicySum),
+			worker2Unhealthy: true,
+			wantQuota: &kueue.FederatedQuota{
+				Policy:   kueue.MultiKueueQuotaPolicySum,
+				Clusters: []string{"worker1"},
+				Flavors: []kueue.FlavorFederatedQuota{
+					{
+						Name: "default",
+						Resources: []kueue.ResourceFederatedQuota{
+							{Name: corev1.ResourceCPU, NominalQuota: resource.MustParse("4")},
+							{Name: corev1.ResourceMemory, NominalQuota: resource.MustParse("0")},
+						},
+					},
+					{
+						Name:      "gpu",
+						Resources: []kueue.ResourceFederatedQuota{{Name: "nvidia.com/gpu", NominalQuota: resource.MustParse("2")}},
+					},
+				},
+			},
+		},
+		"the worker without the ClusterQueue is ignored": {
+			policy:         ptr.To(kueue.MultiKueueQuotaPolicyMin),
+			worker2Missing: true,
+			wantQuota: &kueue.FederatedQuota{
+				Policy:   kueue.MultiKueueQuotaPolicyMin,
+				Clusters: []string{"worker1"},
+				Flavors: []kueue.FlavorFederatedQuota{
+					{
+						Name: "default",
+						Resources: []kueue.ResourceFederatedQuota{
+							{Name: corev1.ResourceCPU, NominalQuota: resource.MustParse("4")},
+							{Name: corev1.ResourceMemory, NominalQuota: resource.MustParse("0")},
+						},
+					},
+					{
+						Name:      "gpu",
+						Resources: []kueue.ResourceFederatedQuota{{Name: "nvidia.com/gpu", NominalQuota: resource.MustParse("2")}},
+					},
+				},
+			},
+		},
+		"no quota without a reachable worker": {
+			policy:           ptr.To(kueue.MultiKueueQuotaPolicySum),
+			workersUnhealthy: true,
+			wantQuota: &kueue.FederatedQuota{
+				Policy: kueue.MultiKueueQuotaPolicySum,
+				Flavors: []kueue.FlavorFederatedQuota{
+					{
+						Name: "default",
+						Resources: []kueue.ResourceFederatedQuota{
+							{Name: corev1.ResourceCPU, NominalQuota: resource.MustParse("0")},
+							{Name: corev1.ResourceMemory, NominalQuota: resource.MustParse("0")},
+						},
+					},
+					{
+						Name:      "gpu",
+						Resources: []kueue.ResourceFederatedQuota{{Name: "nvidia.com/gpu", NominalQuota: resource.MustParse("0")}},
+					},
+				},
+			},
+		},
+		"the federated quota is removed with the ClusterQueue": {
+			policy:   ptr.To(kueue.MultiKueueQuotaPolicySum),
+			deleteCQ: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := t.Context()
+			cq := utiltesting.MakeClusterQueue("cq").
+				ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+					Resource(corev1.ResourceCPU, "1").
+					Resource(corev1.ResourceMemory, "1Gi").
+					Obj()).
+				ResourceGroup(*utiltesting.MakeFlavorQuotas("gpu").
+					Resource("nvidia.com/gpu", "0").
+					Obj()).
+				AdmissionChecks("ac1").
+				Obj()
+			config := utiltesting.MakeMultiKueueConfig("config1").Clusters("worker1", "worker2").Obj()
+			config.Spec.QuotaPolicy = tc.policy
+
+			builder := getClientBuilder(ctx)
+			_ = indexer.Setup(ctx, utiltesting.AsIndexer(builder))
+			builder = builder.WithObjects(
+				cq,
+				config,
+				utiltesting.MakeAdmissionCheck("ac1").ControllerName(kueue.MultiKueueControllerName).
+					Parameters(kueue.GroupVersion.Group, "MultiKueueConfig", "config1").
+					Obj(),
+			)
+			c := builder.Build()
+
+			cqCache := cache.New(c)
+			cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("gpu").Obj())
+			if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Adding ClusterQueue: %v", err)
+			}
+			queues := queue.NewManager(c, cqCache)
+
+			cRec := newClustersReconciler(c, TestNamespace, 0, defaultOrigin, nil, nil)
+			addWorker := func(name string, quotas resources.FlavorResourceQuantities) *remoteClient {
+				rc := newRemoteClient(c, nil, nil, defaultOrigin, name, nil)
+				rc.connecting.Store(false)
+				rc.quotas = map[kueue.ClusterQueueReference]resources.FlavorResourceQuantities{}
+				if quotas != nil {
+					rc.quotas["cq"] = quotas
+				}
+				cRec.remoteClients[name] = rc
+				return rc
+			}
+			worker1 := addWorker("worker1", worker1Quotas)
+			worker2 := addWorker("worker2", worker2Quotas)
+			if tc.worker2Missing {
+				worker2.quotas = map[kueue.ClusterQueueReference]resources.FlavorResourceQuantities{}
+			}
+			if tc.workersUnhealthy {
+				worker1.health.unhealthy.Store(true)
+				worker2.health.unhealthy.Store(true)
+			}
+			if tc.worker2Unhealthy {
+				worker2.health.unhealthy.Store(true)
+			}
+
+			helper, _ := newMultiKueueStoreHelper(c)
+			reconciler := newFederatedQuotaReconciler(c, helper, cRec, cqCache, queues)
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "cq"}}
+			if _, err := reconciler.Reconcile(ctx, req); err != nil {
+				t.Fatalf("Unexpected reconcile error: %v", err)
+			}
+			if tc.deleteCQ {
+				if err := c.Delete(ctx, cq); err != nil {
+					t.Fatalf("Deleting ClusterQueue: %v", err)
+				}
+				if _, err := reconciler.Reconcile(ctx, req); err != nil {
+					t.Fatalf("Unexpected reconcile error: %v", err)
+				}
+			}
+
+			stats, err := cqCache.Usage(cq)
+			if err != nil {
+				t.Fatalf("Getting usage: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantQuota, stats.FederatedQuota); diff != "" {
+				t.Errorf("Unexpected federated quota (-want/+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -36,10 +36,17 @@ var (
 	errNotStarted = errors.New("not started")
 )
 
+// kubeletDataDir is the name of the symlink swapped by the kubelet when it
+// updates the files of a Secret or a projected volume.
+const kubeletDataDir = "..data"
+
+// KubeConfigFSWatcher watches the kubeconfig files of the clusters, and the
+// credential files they reference, like token files.
 type KubeConfigFSWatcher struct {
-	watcher       *fsnotify.Watcher
-	lock          sync.RWMutex
-	clusterToFile map[string]string
+	watcher *fsnotify.Watcher
+	lock    sync.RWMutex
+	// a single cluster can use multiple files
+	clusterToFiles map[string]set.Set[string]
 	// a single file can be potentially used by multiple clusters
 	fileToClusters   map[string]set.Set[string]
 	parentDirToFiles map[string]set.Set[string]
@@ -50,7 +57,7 @@ var _ manager.Runnable = (*KubeConfigFSWatcher)(nil)
 
 func newKubeConfigFSWatcher() *KubeConfigFSWatcher {
 	return &KubeConfigFSWatcher{
-		clusterToFile:    map[string]string{},
+		clusterToFiles:   map[string]set.Set[string]{},
 		fileToClusters:   map[string]set.Set[string]{},
 		parentDirToFiles: map[string]set.Set[string]{},
 		reconcile:        make(chan event.GenericEvent),
@@ -97,10 +104,18 @@ func (w *KubeConfigFSWatcher) Start(ctx context.Context) error {
 	return nil
 }
 
-func (w *KubeConfigFSWatcher) clustersForPath(path string) []string {
+func (w *KubeConfigFSWatcher) clustersForPath(filePath string) []string {
 	w.lock.RLock()
 	defer w.lock.RUnlock()
-	return w.fileToClusters[path].UnsortedList()
+	if path.Base(filePath) == kubeletDataDir {
+		// all the files of the directory are updated at once
+		clusters := set.New[string]()
+		for file := range w.parentDirToFiles[path.Dir(filePath)] {
+			clusters = clusters.Union(w.fileToClusters[file])
+		}
+		return clusters.UnsortedList()
+	}
+	return w.fileToClusters[filePath].UnsortedList()
 }
 
 func (w *KubeConfigFSWatcher) notifyPathWrite(path string) {
@@ -109,36 +124,40 @@ func (w *KubeConfigFSWatcher) notifyPathWrite(path string) {
 	}
 }
 
-func (w *KubeConfigFSWatcher) getClusterPath(cluster string) (string, bool) {
+func (w *KubeConfigFSWatcher) getClusterFiles(cluster string) (set.Set[string], bool) {
 	w.lock.RLock()
 	defer w.lock.RUnlock()
-	path, found := w.clusterToFile[cluster]
-	return path, found
+	files, found := w.clusterToFiles[cluster]
+	return files, found
 }
 
-func (w *KubeConfigFSWatcher) set(cluster, kcPath string) error {
+func (w *KubeConfigFSWatcher) set(cluster string, files set.Set[string]) error {
 	w.lock.Lock()
 	defer w.lock.Unlock()
 
-	dir := path.Dir(kcPath)
-	if _, found := w.parentDirToFiles[dir]; !found {
-		err := w.watcher.Add(dir)
-		if err != nil {
-			return err
+	for _, kcPath := range files.SortedList() {
+		dir := path.Dir(kcPath)
+		if _, found := w.parentDirToFiles[dir]; !found {
+			err := w.watcher.Add(dir)
+			if err != nil {
+				return err
+			}
 		}
 	}
 
-	w.clusterToFile[cluster] = kcPath
-	if _, found := w.fileToClusters[kcPath]; found {
-		w.fileToClusters[kcPath].Insert(cluster)
-	} else {
-		w.fileToClusters[kcPath] = set.New(cluster)
-	}
-	if _, found := w.parentDirToFiles[dir]; found {
-		w.parentDirToFiles[dir].Insert(kcPath)
-	} else {
-		w.parentDirToFiles[dir] = set.New(kcPath)
-		return w.watcher.Add(dir)
+	w.clusterToFiles[cluster] = files
+	for kcPath := range files {
+		dir := path.Dir(kcPath)
+		if _, found := w.fileToClusters[kcPath]; found {
+			w.fileToClusters[kcPath].Insert(cluster)
+		} else {
+			w.fileToClusters[kcPath] = set.New(cluster)
+		}
+		if _, found := w.parentDirToFiles[dir]; found {
+			w.parentDirToFiles[dir].Insert(kcPath)
+		} else {
+			w.parentDirToFiles[dir] = set.New(kcPath)
+		}
 	}
 	return nil
 }
@@ -147,27 +166,28 @@ func (w *KubeConfigFSWatcher) remove(cluster string) {
 	w.lock.Lock()
 	defer w.lock.Unlock()
 
-	kcPath := w.clusterToFile[cluster]
-	dir := path.Dir(kcPath)
+	for kcPath := range w.clusterToFiles[cluster] {
+		dir := path.Dir(kcPath)
 
-	fileRemoved := false
-	if s, found := w.fileToClusters[kcPath]; found {
-		s.Delete(cluster)
-		if s.Len() == 0 {
-			delete(w.fileToClusters, kcPath)
-			fileRemoved = true
+		fileRemoved := false
+		if s, found := w.fileToClusters[kcPath]; found {
+			s.Delete(cluster)
+			if s.Len() == 0 {
+				delete(w.fileToClusters, kcPath)
+				fileRemoved = true
+			}
 		}
-	}
 
-	if fileRemoved {
-		if s, found := w.parentDirToFiles[dir]; found {
-			s.Delete(kcPath)
-			if s.Len() == 0 {
-				delete(w.parentDirToFiles, dir)
+		if fileRemoved {
+			if s, found := w.parentDirToFiles[dir]; found {
+				s.Delete(kcPath)
+				if s.Len() == 0 {
+					delete(w.parentDirToFiles, dir)
+				}
 			}
 		}
 	}
-	delete(w.clusterToFile, cluster)
+	delete(w.clusterToFiles, cluster)
 }
 
 func (w *KubeConfigFSWatcher) cleanOldWatchDirs() error {
@@ -191,12 +211,17 @@ func (w *KubeConfigFSWatcher) Started() bool {
 	return w.watcher != nil
 }
 
-func (w *KubeConfigFSWatcher) AddOrUpdate(cluster, path string) error {
+// AddOrUpdate sets the files watched for cluster, replacing the previous ones.
+func (w *KubeConfigFSWatcher) AddOrUpdate(cluster string, paths ...string) error {
 	if !w.Started() {
 		return errNotStarted
 	}
-	cPath, found := w.getClusterPath(cluster)
-	if found && path == cPath {
+	if len(paths) == 0 {
+		return w.Remove(cluster)
+	}
+	files := set.New(paths...)
+	cFiles, found := w.getClusterFiles(cluster)
+	if found && files.Equal(cFiles) {
 		return nil
 	}
 
@@ -204,7 +229,7 @@ func (w *KubeConfigFSWatcher) AddOrUpdate(cluster, path string) error {
 		w.remove(cluster)
 	}
 
-	if err := w.set(cluster, path); err != nil {
+	if err := w.set(cluster, files); err != nil {
 		return err
 	}
 
@@ -215,7 +240,7 @@ func (w *KubeConfigFSWatcher) Remove(cluster string) error {
 	if !w.Started() {
 		return errNotStarted
 	}
-	_, found := w.getClusterPath(cluster)
+	_, found := w.getClusterFiles(cluster)
 	if !found {
 		return nil
 	}
@@ -110,6 +110,25 @@ func TestFSWatch(t *testing.T) {
 			},
 			wantEventsForClusters: set.New("c1"),
 		},
+		"kubelet data dir swap": {
+			prepareFnc: func(basePath string) error {
+				if err := os.Mkdir(filepath.Join(basePath, "..2025_01_01"), os.ModePerm); err != nil {
+					return err
+				}
+				if err := os.Mkdir(filepath.Join(basePath, "other"), os.ModePerm); err != nil {
+					return err
+				}
+				return os.Symlink(filepath.Join(basePath, "..2025_01_01"), filepath.Join(basePath, "..data_tmp"))
+			},
+			clusters: map[string]string{
+				"c1": "token",
+				"c2": "other/token",
+			},
+			opFnc: func(basePath string) error {
+				return os.Rename(filepath.Join(basePath, "..data_tmp"), filepath.Join(basePath, "..data"))
+			},
+			wantEventsForClusters: set.New("c1"),
+		},
 		"single remove link": {
 			// For some reason, on darwin platform, we don't take the symlink removal event.
 			// It's not critical to test it on MacOS, so we can skip it for now until it's
@@ -201,14 +220,14 @@ func TestFSWatchAddRm(t *testing.T) {
 	f3Dir := filepath.Join(basePath, "d1")
 	f3Path := filepath.Join(f3Dir, "file.three")
 	steps := []struct {
-		name               string
-		skipOnDarwin       bool
-		opFnc              func(*KubeConfigFSWatcher) error
-		wantOpErr          error
-		wantClustersToFile map[string]string
-		wantFileToClusters map[string]set.Set[string]
-		wantParentDirs     map[string]set.Set[string]
-		wantWatchList      []string
+		name                string
+		skipOnDarwin        bool
+		opFnc               func(*KubeConfigFSWatcher) error
+		wantOpErr           error
+		wantClustersToFiles map[string]set.Set[string]
+		wantFileToClusters  map[string]set.Set[string]
+		wantParentDirs      map[string]set.Set[string]
+		wantWatchList       []string
 	}{
 		{
 			name: "add cluster before start",
@@ -222,18 +241,18 @@ func TestFSWatchAddRm(t *testing.T) {
 			opFnc: func(kcf *KubeConfigFSWatcher) error {
 				return kcf.Start(ctx)
 			},
-			wantClustersToFile: map[string]string{},
-			wantFileToClusters: map[string]set.Set[string]{},
-			wantParentDirs:     map[string]set.Set[string]{},
-			wantWatchList:      []string{},
+			wantClustersToFiles: map[string]set.Set[string]{},
+			wantFileToClusters:  map[string]set.Set[string]{},
+			wantParentDirs:      map[string]set.Set[string]{},
+			wantWatchList:       []string{},
 		},
 		{
 			name: "add first cluster",
 			opFnc: func(kcf *KubeConfigFSWatcher) error {
 				return kcf.AddOrUpdate("c1", f1Path)
 			},
-			wantClustersToFile: map[string]string{
-				"c1": f1Path,
+			wantClustersToFiles: map[string]set.Set[string]{
+				"c1": set.New(f1Path),
 			},
 			wantFileToClusters: map[string]set.Set[string]{
 				f1Path: set.New("c1"),
@@ -250,9 +269,9 @@ func TestFSWatchAddRm(t *testing.T) {
 			opFnc: func(kcf *KubeConfigFSWatcher) error {
 				return kcf.AddOrUpdate("c2", f1Path)
 			},
-			wantClustersToFile: map[string]string{
-				"c1": f1Path,
-				"c2": f1Path,
+			wantClustersToFiles: map[string]set.Set[string]{
+				"c1": set.New(f1Path),
+				"c2": set.New(f1Path),
 			},
 			wantFileToClusters: map[string]set.Set[string]{
 				f1Path: set.New("c1", "c2"),
@@ -269,9 +288,9 @@ func TestFSWatchAddRm(t *testing.T) {
 			opFnc: func(kcf *KubeConfigFSWatcher) error {
 				return kcf.AddOrUpdate("c1", f1Path)
 			},
-			wantClustersToFile: map[string]string{
-				"c1": f1Path,
-				"c2": f1Path,
+			wantClustersToFiles: map[string]set.Set[string]{
+				"c1": set.New(f1Path),
+				"c2": set.New(f1Path),
 			},
 			wantFileToClusters: map[string]set.Set[string]{
 				f1Path: set.New("c1", "c2"),
@@ -288,10 +307,10 @@ func TestFSWatchAddRm(t *testing.T) {
 			opFnc: func(kcf *KubeConfigFSWatcher) error {
 				return kcf.AddOrUpdate("c3", f2Path)
 			},
-			wantClustersToFile: map[string]string{
-				"c1": f1Path,
-				"c2": f1Path,
-				"c3": f2Path,
+			wantClustersToFiles: map[string]set.Set[string]{
+				"c1": set.New(f1Path),
+				"c2": set.New(f1Path),
+				"c3": set.New(f2Path),
 			},
 			wantFileToClusters: map[string]set.Set[string]{
 				f1Path: set.New("c1", "c2"),
@@ -316,10 +335,10 @@ func TestFSWatchAddRm(t *testing.T) {
 				return kcf.AddOrUpdate("c4", f3Path)
 			},
 			wantOpErr: os.ErrNotExist,
-			wantClustersToFile: map[string]string{
-				"c1": f1Path,
-				"c2": f1Path,
-				"c3": f2Path,
+			wantClustersToFiles: map[string]set.Set[string]{
+				"c1": set.New(f1Path),
+				"c2": set.New(f1Path),
+				"c3": set.New(f2Path),
 			},
 			wantFileToClusters: map[string]set.Set[string]{
 				f1Path: set.New("c1", "c2"),
@@ -341,11 +360,11 @@ func TestFSWatchAddRm(t *testing.T) {
 				}
 				return kcf.AddOrUpdate("c4", f3Path)
 			},
-			wantClustersToFile: map[string]string{
-				"c1": f1Path,
-				"c2": f1Path,
-				"c3": f2Path,
-				"c4": f3Path,
+			wantClustersToFiles: map[string]set.Set[string]{
+				"c1": set.New(f1Path),
+				"c2": set.New(f1Path),
+				"c3": set.New(f2Path),
+				"c4": set.New(f3Path),
 			},
 			wantFileToClusters: map[string]set.Set[string]{
 				f1Path: set.New("c1", "c2"),
@@ -366,11 +385,11 @@ func TestFSWatchAddRm(t *testing.T) {
 			opFnc: func(kcf *KubeConfigFSWatcher) error {
 				return kcf.AddOrUpdate("c3", f3Path)
 			},
-			wantClustersToFile: map[string]string{
-				"c1": f1Path,
-				"c2": f1Path,
-				"c3": f3Path,
-				"c4": f3Path,
+			wantClustersToFiles: map[string]set.Set[string]{
+				"c1": set.New(f1Path),
+				"c2": set.New(f1Path),
+				"c3": set.New(f3Path),
+				"c4": set.New(f3Path),
 			},
 			wantFileToClusters: map[string]set.Set[string]{
 				f1Path: set.New("c1", "c2"),
@@ -394,8 +413,8 @@ func TestFSWatchAddRm(t *testing.T) {
 					kcf.Remove("c4"),
 				)
 			},
-			wantClustersToFile: map[string]string{
-				"c2": f1Path,
+			wantClustersToFiles: map[string]set.Set[string]{
+				"c2": set.New(f1Path),
 			},
 			wantFileToClusters: map[string]set.Set[string]{
 				f1Path: set.New("c2"),
@@ -412,8 +431,8 @@ func TestFSWatchAddRm(t *testing.T) {
 			opFnc: func(kcf *KubeConfigFSWatcher) error {
 				return kcf.Remove("c1")
 			},
-			wantClustersToFile: map[string]string{
-				"c2": f1Path,
+			wantClustersToFiles: map[string]set.Set[string]{
+				"c2": set.New(f1Path),
 			},
 			wantFileToClusters: map[string]set.Set[string]{
 				f1Path: set.New("c2"),
@@ -426,14 +445,39 @@ func TestFSWatchAddRm(t *testing.T) {
 			},
 		},
 		{
-			name: "remove the last cluster",
+			name: "add a cluster using multiple files",
 			opFnc: func(kcf *KubeConfigFSWatcher) error {
-				return kcf.Remove("c2")
+				return kcf.AddOrUpdate("c5", f1Path, f3Path)
+			},
+			wantClustersToFiles: map[string]set.Set[string]{
+				"c2": set.New(f1Path),
+				"c5": set.New(f1Path, f3Path),
+			},
+			wantFileToClusters: map[string]set.Set[string]{
+				f1Path: set.New("c2", "c5"),
+				f3Path: set.New("c5"),
+			},
+			wantParentDirs: map[string]set.Set[string]{
+				basePath: set.New(f1Path),
+				f3Dir:    set.New(f3Path),
+			},
+			wantWatchList: []string{
+				basePath,
+				f3Dir,
+			},
+		},
+		{
+			name: "remove the last clusters",
+			opFnc: func(kcf *KubeConfigFSWatcher) error {
+				return errors.Join(
+					kcf.Remove("c2"),
+					kcf.Remove("c5"),
+				)
 			},
-			wantClustersToFile: map[string]string{},
-			wantFileToClusters: map[string]set.Set[string]{},
-			wantParentDirs:     map[string]set.Set[string]{},
-			wantWatchList:      []string{},
+			wantClustersToFiles: map[string]set.Set[string]{},
+			wantFileToClusters:  map[string]set.Set[string]{},
+			wantParentDirs:      map[string]set.Set[string]{},
+			wantWatchList:       []string{},
 		},
 	}
 
@@ -450,9 +494,9 @@ func TestFSWatchAddRm(t *testing.T) {
 				t.Errorf("unexpected error(-want/+got):\n%s", diff)
 			}
 
-			if tc.wantClustersToFile != nil {
-				if diff := cmp.Diff(tc.wantClustersToFile, w.clusterToFile, cmpopts.EquateEmpty()); diff != "" {
-					t.Errorf("unexpected clusterToFile(-want/+got):\n%s", diff)
+			if tc.wantClustersToFiles != nil {
+				if diff := cmp.Diff(tc.wantClustersToFiles, w.clusterToFiles, cmpopts.EquateEmpty()); diff != "" {
+					t.Errorf("unexpected clusterToFiles(-want/+got):\n%s", diff)
 				}
 			}
 
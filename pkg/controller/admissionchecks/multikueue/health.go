@@ -0,0 +1,173 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multikueue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+const (
+	healthyReason                = "Healthy"
+	probeFailedReason            = "ProbeFailed"
+	incompatibleAPIVersionReason = "IncompatibleAPIVersion"
+	kueueUnreachableReason       = "KueueUnreachable"
+	canaryRejectedReason         = "CanaryRejected"
+
+	canaryWorkloadPrefix = "multikueue-canary-"
+)
+
+// clusterHealth - the circuit breaker of a worker cluster, driven by the health probes.
+type clusterHealth struct {
+	// unhealthy - if true, the circuit is open and no workload is dispatched to the cluster.
+	unhealthy atomic.Bool
+
+	// The following fields are only used by the clusters reconciler.
+	failures  int32
+	backoff   time.Duration
+	nextProbe time.Time
+	condition *metav1.Condition
+}
+
+// healthy returns false if the worker cluster failed its health probes.
+func (rc *remoteClient) healthy() bool {
+	return !rc.health.unhealthy.Load()
+}
+
+// unhealthyRetryInterval returns the time after which the dispatching of the
+// workloads, which left out unhealthy worker clusters, is retried.
+func (c *clustersReconciler) unhealthyRetryInterval() time.Duration {
+	if c.healthCheck == nil {
+		return 0
+	}
+	return c.healthCheck.InitialBackoff.Duration
+}
+
+// checkHealth probes the worker cluster, if the last probe is older than the interval
+// or the backoff, and updates its circuit breaker. It returns the Healthy condition of
+// the cluster and the time after which it should be probed again.
+func (c *clustersReconciler) checkHealth(ctx context.Context, rc *remoteClient, generation int64) (*metav1.Condition, time.Duration) {
+	h := &rc.health
+	now := c.clock.Now()
+	if h.condition == nil || !now.Before(h.nextProbe) {
+		cfg := c.healthCheck
+		reason, err := c.probe(ctx, rc)
+		if err == nil {
+			h.failures = 0
+			h.backoff = 0
+			h.unhealthy.Store(false)
+			h.nextProbe = now.Add(cfg.Interval.Duration)
+			h.condition = &metav1.Condition{
+				Type:    kueue.MultiKueueClusterHealthy,
+				Status:  metav1.ConditionTrue,
+				Reason:  healthyReason,
+				Message: "The health probes succeeded",
+			}
+		} else {
+			ctrl.LoggerFrom(ctx).V(2).Info("Health probe failed", "reason", reason, "err", err)
+			h.failures++
+			switch {
+			case h.unhealthy.Load():
+				h.backoff = min(2*h.backoff, cfg.MaxBackoff.Duration)
+			case h.failures >= *cfg.FailureThreshold:
+				h.unhealthy.Store(true)
+				h.backoff = cfg.InitialBackoff.Duration
+			}
+			if h.unhealthy.Load() {
+				h.nextProbe = now.Add(h.backoff)
+				h.condition = &metav1.Condition{
+					Type:    kueue.MultiKueueClusterHealthy,
+					Status:  metav1.ConditionFalse,
+					Reason:  reason,
+					Message: fmt.Sprintf("%s, probing again in %s", err, h.backoff),
+				}
+			} else {
+				// probe sooner to confirm the failure
+				h.nextProbe = now.Add(min(cfg.Interval.Duration, cfg.InitialBackoff.Duration))
+				h.condition = &metav1.Condition{
+					Type:    kueue.MultiKueueClusterHealthy,
+					Status:  metav1.ConditionTrue,
+					Reason:  healthyReason,
+					Message: fmt.Sprintf("%d/%d health probes failed: %s", h.failures, *cfg.FailureThreshold, err),
+				}
+			}
+		}
+	}
+	condition := *h.condition
+	condition.ObservedGeneration = generation
+	return &condition, h.nextProbe.Sub(now)
+}
+
+// probe checks that the worker cluster serves the Kueue API used by the manager,
+// and that its Kueue webhooks accept a canary Workload, created in dry-run mode.
+// It returns the reason of the failure, if any.
+func (c *clustersReconciler) probe(ctx context.Context, rc *remoteClient) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.healthCheck.Timeout.Duration)
+	defer cancel()
+	namespace := *c.healthCheck.CanaryNamespace
+
+	if err := rc.client.List(ctx, &kueue.WorkloadList{}, client.InNamespace(namespace), client.Limit(1)); err != nil {
+		if apierrors.IsNotFound(err) || apimeta.IsNoMatchError(err) {
+			return incompatibleAPIVersionReason, fmt.Errorf("the %s API is not served: %w", kueue.GroupVersion, err)
+		}
+		return probeFailedReason, err
+	}
+
+	if err := rc.client.Create(ctx, canaryWorkload(namespace), client.DryRunAll); err != nil {
+		if apierrors.IsInternalError(err) && strings.Contains(err.Error(), "failed calling webhook") {
+			return kueueUnreachableReason, err
+		}
+		return canaryRejectedReason, err
+	}
+	return "", nil
+}
+
+func canaryWorkload(namespace string) *kueue.Workload {
+	return &kueue.Workload{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: canaryWorkloadPrefix,
+			Namespace:    namespace,
+		},
+		Spec: kueue.WorkloadSpec{
+			PodSets: []kueue.PodSet{{
+				Name:  kueue.DefaultPodSetName,
+				Count: 1,
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						RestartPolicy: corev1.RestartPolicyNever,
+						Containers: []corev1.Container{{
+							Name:  "canary",
+							Image: "canary",
+						}},
+					},
+				},
+			}},
+		},
+	}
+}
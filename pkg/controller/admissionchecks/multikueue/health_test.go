@@ -0,0 +1,201 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multikueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+func TestCheckHealth(t *testing.T) {
+	webhookErr := apierrors.NewInternalError(errors.New(`failed calling webhook "vworkload.kb.io": connection refused`))
+	notServedErr := apierrors.NewNotFound(schema.GroupResource{Group: kueue.GroupVersion.Group, Resource: "workloads"}, "")
+	invalidErr := apierrors.NewInvalid(kueue.GroupVersion.WithKind("Workload").GroupKind(), "", field.ErrorList{field.Required(field.NewPath("spec", "queueName"), "")})
+
+	type step struct {
+		advance   time.Duration
+		listErr   error
+		createErr error
+
+		wantCondition  metav1.Condition
+		wantAfter      time.Duration
+		wantUnhealthy  bool
+		wantProbeCalls int
+	}
+	healthy := func(message string) metav1.Condition {
+		return metav1.Condition{
+			Type:               kueue.MultiKueueClusterHealthy,
+			Status:             metav1.ConditionTrue,
+			Reason:             "Healthy",
+			Message:            message,
+			ObservedGeneration: 1,
+		}
+	}
+	unhealthy := func(reason, message string) metav1.Condition {
+		return metav1.Condition{
+			Type:               kueue.MultiKueueClusterHealthy,
+			Status:             metav1.ConditionFalse,
+			Reason:             reason,
+			Message:            message,
+			ObservedGeneration: 1,
+		}
+	}
+
+	cases := map[string][]step{
+		"the circuit opens after the failure threshold and closes after a successful probe": {
+			{
+				wantCondition:  healthy("The health probes succeeded"),
+				wantAfter:      time.Minute,
+				wantProbeCalls: 1,
+			},
+			{
+				advance:       30 * time.Second,
+				createErr:     webhookErr,
+				wantCondition: healthy("The health probes succeeded"),
+				wantAfter:     30 * time.Second,
+			},
+			{
+				advance:        30 * time.Second,
+				createErr:      webhookErr,
+				wantCondition:  healthy(fmt.Sprintf("1/2 health probes failed: %s", webhookErr)),
+				wantAfter:      30 * time.Second,
+				wantProbeCalls: 1,
+			},
+			{
+				advance:        30 * time.Second,
+				createErr:      webhookErr,
+				wantCondition:  unhealthy("KueueUnreachable", fmt.Sprintf("%s, probing again in 30s", webhookErr)),
+				wantAfter:      30 * time.Second,
+				wantUnhealthy:  true,
+				wantProbeCalls: 1,
+			},
+			{
+				advance:        30 * time.Second,
+				listErr:        notServedErr,
+				wantCondition:  unhealthy("IncompatibleAPIVersion", fmt.Sprintf("the kueue.x-k8s.io/v1beta1 API is not served: %s, probing again in 1m0s", notServedErr)),
+				wantAfter:      time.Minute,
+				wantUnhealthy:  true,
+				wantProbeCalls: 1,
+			},
+			{
+				advance:        time.Minute,
+				createErr:      invalidErr,
+				wantCondition:  unhealthy("CanaryRejected", fmt.Sprintf("%s, probing again in 1m30s", invalidErr)),
+				wantAfter:      90 * time.Second,
+				wantUnhealthy:  true,
+				wantProbeCalls: 1,
+			},
+			{
+				advance:        90 * time.Second,
+				wantCondition:  healthy("The health probes succeeded"),
+				wantAfter:      time.Minute,
+				wantProbeCalls: 1,
+			},
+		},
+		"a single failure keeps the cluster healthy": {
+			{
+				listErr:        errors.New("timeout"),
+				wantCondition:  healthy("1/2 health probes failed: timeout"),
+				wantAfter:      30 * time.Second,
+				wantProbeCalls: 1,
+			},
+			{
+				advance:        30 * time.Second,
+				wantCondition:  healthy("The health probes succeeded"),
+				wantAfter:      time.Minute,
+				wantProbeCalls: 1,
+			},
+			{
+				advance:        time.Minute,
+				listErr:        errors.New("timeout"),
+				wantCondition:  healthy("1/2 health probes failed: timeout"),
+				wantAfter:      30 * time.Second,
+				wantProbeCalls: 1,
+			},
+		},
+	}
+	for name, steps := range cases {
+		t.Run(name, func(t *testing.T) {
+			var current *step
+			probeCalls := 0
+			workerClient := getClientBuilder(t.Context()).WithInterceptorFuncs(interceptor.Funcs{
+				List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+					probeCalls++
+					if current.listErr != nil {
+						return current.listErr
+					}
+					return c.List(ctx, list, opts...)
+				},
+				Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					if current.createErr != nil {
+						return current.createErr
+					}
+					return c.Create(ctx, obj, opts...)
+				},
+			}).Build()
+			rc := newRemoteClient(nil, nil, nil, defaultOrigin, "worker1", nil)
+			rc.client = workerClient
+
+			fakeClock := testingclock.NewFakeClock(time.Now())
+			reconciler := newClustersReconciler(nil, TestNamespace, 0, defaultOrigin, nil, nil)
+			reconciler.clock = fakeClock
+			reconciler.healthCheck = &configapi.MultiKueueHealthCheck{
+				Interval:         &metav1.Duration{Duration: time.Minute},
+				Timeout:          &metav1.Duration{Duration: time.Second},
+				FailureThreshold: ptr.To[int32](2),
+				InitialBackoff:   &metav1.Duration{Duration: 30 * time.Second},
+				MaxBackoff:       &metav1.Duration{Duration: 90 * time.Second},
+				CanaryNamespace:  ptr.To(TestNamespace),
+			}
+
+			for i, s := range steps {
+				current = &s
+				probeCalls = 0
+				fakeClock.Step(s.advance)
+				gotCondition, gotAfter := reconciler.checkHealth(t.Context(), rc, 1)
+				if diff := cmp.Diff(s.wantCondition, *gotCondition); diff != "" {
+					t.Errorf("step %d: unexpected condition (-want/+got):\n%s", i, diff)
+				}
+				if gotAfter != s.wantAfter {
+					t.Errorf("step %d: unexpected probe after, want=%s, got=%s", i, s.wantAfter, gotAfter)
+				}
+				if rc.healthy() == s.wantUnhealthy {
+					t.Errorf("step %d: unexpected healthy=%v", i, rc.healthy())
+				}
+				if probeCalls != s.wantProbeCalls {
+					t.Errorf("step %d: unexpected probe calls, want=%d, got=%d", i, s.wantProbeCalls, probeCalls)
+				}
+			}
+		})
+	}
+}
@@ -31,6 +31,7 @@ const (
 	UsingKubeConfigs             = "spec.kubeconfigs"
 	UsingMultiKueueClusters      = "spec.multiKueueClusters"
 	AdmissionCheckUsingConfigKey = "spec.multiKueueConfig"
+	UsingClusterProfiles         = "spec.clusterProfileRef"
 )
 
 var (
@@ -43,10 +44,21 @@ func getIndexUsingKubeConfigs(configNamespace string) func(obj client.Object) []
 		if !isCluster {
 			return nil
 		}
+		if cluster.Spec.KubeConfig == nil {
+			return nil
+		}
 		return []string{strings.Join([]string{configNamespace, cluster.Spec.KubeConfig.Location}, "/")}
 	}
 }
 
+func indexUsingClusterProfiles(obj client.Object) []string {
+	cluster, isCluster := obj.(*kueue.MultiKueueCluster)
+	if !isCluster || cluster.Spec.ClusterProfileRef == nil {
+		return nil
+	}
+	return []string{strings.Join([]string{cluster.Spec.ClusterProfileRef.Namespace, cluster.Spec.ClusterProfileRef.Name}, "/")}
+}
+
 func indexUsingMultiKueueClusters(obj client.Object) []string {
 	config, isConfig := obj.(*kueue.MultiKueueConfig)
 	if !isConfig {
@@ -59,6 +71,9 @@ func SetupIndexer(ctx context.Context, indexer client.FieldIndexer, configNamesp
 	if err := indexer.IndexField(ctx, &kueue.MultiKueueCluster{}, UsingKubeConfigs, getIndexUsingKubeConfigs(configNamespace)); err != nil {
 		return fmt.Errorf("setting index on clusters using kubeconfig: %w", err)
 	}
+	if err := indexer.IndexField(ctx, &kueue.MultiKueueCluster{}, UsingClusterProfiles, indexUsingClusterProfiles); err != nil {
+		return fmt.Errorf("setting index on clusters using cluster profiles: %w", err)
+	}
 	if err := indexer.IndexField(ctx, &kueue.MultiKueueConfig{}, UsingMultiKueueClusters, indexUsingMultiKueueClusters); err != nil {
 		return fmt.Errorf("setting index on configs using clusters: %w", err)
 	}
@@ -87,6 +87,21 @@ func TestListMultiKueueClustersUsingKubeConfig(t *testing.T) {
 			filter:   client.MatchingFields{UsingKubeConfigs: TestNamespace + "/secret1"},
 			wantList: []string{"cluster1"},
 		},
+		"cluster profile, single match": {
+			clusters: []*kueue.MultiKueueCluster{
+				utiltesting.MakeMultiKueueCluster("cluster1").ClusterProfile("fleet", "profile1").Obj(),
+				utiltesting.MakeMultiKueueCluster("cluster2").ClusterProfile("fleet", "profile2").Obj(),
+				utiltesting.MakeMultiKueueCluster("cluster3").KubeConfig(kueue.SecretLocationType, "secret1").Obj(),
+			},
+			filter:   client.MatchingFields{UsingClusterProfiles: "fleet/profile1"},
+			wantList: []string{"cluster1"},
+		},
+		"cluster profile is not a kubeconfig": {
+			clusters: []*kueue.MultiKueueCluster{
+				utiltesting.MakeMultiKueueCluster("cluster1").ClusterProfile(TestNamespace, "secret1").Obj(),
+			},
+			filter: client.MatchingFields{UsingKubeConfigs: TestNamespace + "/secret1"},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
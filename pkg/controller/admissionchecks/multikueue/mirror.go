@@ -0,0 +1,346 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multikueue
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/jobframework"
+	podconstants "sigs.k8s.io/kueue/pkg/controller/jobs/pod/constants"
+	utilmaps "sigs.k8s.io/kueue/pkg/util/maps"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+const (
+	// maxRemotePods is the maximum number of pods listed in the mirrored status.
+	maxRemotePods = 16
+	// maxRemotePodMessageLength is the maximum length of the mirrored pod messages.
+	maxRemotePodMessageLength = 256
+)
+
+// statusMirror mirrors, in the manager cluster, the events and the status of
+// the pods of the jobs running in the worker clusters.
+type statusMirror struct {
+	client   client.Client
+	recorder record.EventRecorder
+	clock    clock.Clock
+	cfg      configapi.MultiKueueStatusMirroring
+
+	// lastSync - the time of the last mirroring of the workloads, by workload key.
+	lastSync *utilmaps.SyncMap[string, time.Time]
+}
+
+func newStatusMirror(c client.Client, recorder record.EventRecorder, clk clock.Clock, cfg *configapi.MultiKueueStatusMirroring) *statusMirror {
+	if cfg == nil || (!cfg.Events && !cfg.Pods) {
+		return nil
+	}
+	return &statusMirror{
+		client:   c,
+		recorder: recorder,
+		clock:    clk,
+		cfg:      *cfg,
+		lastSync: utilmaps.NewSyncMap[string, time.Time](0),
+	}
+}
+
+func (m *statusMirror) interval() time.Duration {
+	if m.cfg.Interval == nil {
+		return configapi.DefaultMultiKueueStatusMirroringInterval
+	}
+	return m.cfg.Interval.Duration
+}
+
+// sync mirrors the status of the job of the group running in the worker cluster,
+// at most once per interval. It returns the time after which it should be called again.
+func (m *statusMirror) sync(ctx context.Context, group *wlGroup, cluster string) (time.Duration, error) {
+	log := ctrl.LoggerFrom(ctx).WithValues("op", "mirrorStatus", "workerCluster", cluster)
+	key := client.ObjectKeyFromObject(group.local).String()
+	lastSync, _ := m.lastSync.Get(key)
+	now := m.clock.Now()
+	if elapsed := now.Sub(lastSync); elapsed < m.interval() {
+		return m.interval() - elapsed, nil
+	}
+
+	remoteClient := group.remoteClients[cluster].client
+	remoteJob, selector, err := getRemoteJob(ctx, remoteClient, group)
+	if err != nil {
+		return 0, client.IgnoreNotFound(err)
+	}
+	pods, err := jobPods(ctx, remoteClient, remoteJob, selector)
+	if err != nil {
+		return 0, err
+	}
+	// the failed and the pending pods are listed first
+	slices.SortFunc(pods, func(a, b corev1.Pod) int {
+		return cmp.Or(cmp.Compare(podPhaseOrder(a.Status.Phase), podPhaseOrder(b.Status.Phase)), cmp.Compare(a.Name, b.Name))
+	})
+
+	if m.cfg.Pods {
+		if err := m.mirrorPods(ctx, group, cluster, pods); err != nil {
+			return 0, err
+		}
+	}
+	if m.cfg.Events {
+		if err := m.mirrorEvents(ctx, group, cluster, remoteClient, remoteJob, pods[:min(len(pods), maxRemotePods)]); err != nil {
+			return 0, err
+		}
+	}
+	log.V(3).Info("Mirrored the remote status", "pods", len(pods))
+	m.lastSync.Add(key, now)
+	return m.interval(), nil
+}
+
+// forget drops the mirroring state of the deleted workload.
+func (m *statusMirror) forget(key types.NamespacedName) {
+	m.lastSync.Delete(key.String())
+}
+
+// clear removes the mirrored status of the workload.
+func (m *statusMirror) clear(ctx context.Context, wl *kueue.Workload) error {
+	m.forget(client.ObjectKeyFromObject(wl))
+	if wl.Status.RemotePods == nil {
+		return nil
+	}
+	patch := []byte(`{"status":{"remotePods":null}}`)
+	return m.client.Status().Patch(ctx, wl, client.RawPatch(types.MergePatchType, patch))
+}
+
+// getRemoteJob returns the job of the group in the worker cluster, and the selector
+// of its pods, or nil if its pods can't be selected.
+func getRemoteJob(ctx context.Context, c client.Client, group *wlGroup) (client.Object, labels.Selector, error) {
+	if integration, found := jobframework.GetIntegrationByGVK(group.controllerGVK); found && integration.NewJob != nil {
+		job := integration.NewJob()
+		if err := c.Get(ctx, group.controllerKey, job.Object()); err != nil {
+			return nil, nil, err
+		}
+		jobWithPodLabelSelector, ok := job.(jobframework.JobWithPodLabelSelector)
+		if !ok {
+			return job.Object(), nil, nil
+		}
+		selector, err := labels.Parse(jobWithPodLabelSelector.PodLabelSelector())
+		return job.Object(), selector, err
+	}
+
+	// The serving workloads, like the Deployments and the StatefulSets, select their pods with spec.selector.
+	job := &unstructured.Unstructured{}
+	job.SetGroupVersionKind(group.controllerGVK)
+	if err := c.Get(ctx, group.controllerKey, job); err != nil {
+		return nil, nil, err
+	}
+	specSelector, found, err := unstructured.NestedMap(job.Object, "spec", "selector")
+	if err != nil || !found {
+		return job, nil, err
+	}
+	labelSelector := &metav1.LabelSelector{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(specSelector, labelSelector); err != nil {
+		return nil, nil, err
+	}
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	return job, selector, err
+}
+
+// jobPods returns the pods of the job matching the selector.
+func jobPods(ctx context.Context, c client.Client, job client.Object, selector labels.Selector) ([]corev1.Pod, error) {
+	if pod, isPod := job.(*corev1.Pod); isPod && pod.Labels[podconstants.GroupNameLabel] == "" {
+		return []corev1.Pod{*pod}, nil
+	}
+	if selector == nil || selector.Empty() {
+		return nil, nil
+	}
+	podList := &corev1.PodList{}
+	if err := c.List(ctx, podList, client.InNamespace(job.GetNamespace()), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+	return podList.Items, nil
+}
+
+func (m *statusMirror) mirrorPods(ctx context.Context, group *wlGroup, cluster string, pods []corev1.Pod) error {
+	status := &kueue.RemotePodsStatus{
+		ClusterName: cluster,
+	}
+	for _, pod := range pods {
+		switch pod.Status.Phase {
+		case corev1.PodRunning:
+			status.Running++
+		case corev1.PodSucceeded:
+			status.Succeeded++
+		case corev1.PodFailed:
+			status.Failed++
+		default:
+			status.Pending++
+		}
+	}
+	for _, pod := range pods[:min(len(pods), maxRemotePods)] {
+		status.Pods = append(status.Pods, remotePodStatus(&pod))
+	}
+
+	if current := group.local.Status.RemotePods; current != nil {
+		status.LastUpdateTime = current.LastUpdateTime
+		if equality.Semantic.DeepEqual(current, status) {
+			return nil
+		}
+	}
+	status.LastUpdateTime = metav1.NewTime(m.clock.Now())
+	wlPatch := workload.BaseSSAWorkload(group.local)
+	wlPatch.Status.RemotePods = status
+	return m.client.Status().Patch(ctx, wlPatch, client.Apply, client.FieldOwner(kueue.MultiKueueControllerName+"-mirror"), client.ForceOwnership)
+}
+
+// podPhaseOrder lists the failed and the pending pods first.
+func podPhaseOrder(phase corev1.PodPhase) int {
+	switch phase {
+	case corev1.PodFailed:
+		return 0
+	case corev1.PodRunning:
+		return 2
+	case corev1.PodSucceeded:
+		return 3
+	default:
+		return 1
+	}
+}
+
+func remotePodStatus(pod *corev1.Pod) kueue.RemotePodStatus {
+	status := kueue.RemotePodStatus{
+		Name:    pod.Name,
+		Phase:   pod.Status.Phase,
+		Reason:  pod.Status.Reason,
+		Message: pod.Status.Message,
+	}
+	if status.Phase == "" {
+		status.Phase = corev1.PodPending
+	}
+	for _, cs := range slices.Concat(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses) {
+		status.Restarts += cs.RestartCount
+		if status.Reason != "" {
+			continue
+		}
+		if cs.State.Waiting != nil {
+			status.Reason, status.Message = cs.State.Waiting.Reason, cs.State.Waiting.Message
+		} else if cs.State.Terminated != nil && cs.State.Terminated.ExitCode != 0 {
+			status.Reason, status.Message = cs.State.Terminated.Reason, cs.State.Terminated.Message
+		}
+	}
+	if len(status.Message) > maxRemotePodMessageLength {
+		status.Message = strings.ToValidUTF8(status.Message[:maxRemotePodMessageLength], "")
+	}
+	return status
+}
+
+// mirrorEvents records, on the local job and workload, the events of the remote
+// job and of the listed pods which weren't mirrored yet, and records the most
+// recent ones in the annotation of the local workload.
+func (m *statusMirror) mirrorEvents(ctx context.Context, group *wlGroup, cluster string, c client.Client, job client.Object, pods []corev1.Pod) error {
+	lastEvent, mirrored := mirroredEvents(group.local)
+	if lastEvent.IsZero() {
+		lastEvent = job.GetCreationTimestamp().Time
+	}
+	uids := []types.UID{job.GetUID()}
+	for _, pod := range pods {
+		uids = append(uids, pod.UID)
+	}
+	var newEvents []corev1.Event
+	for _, uid := range uids {
+		events := &corev1.EventList{}
+		if err := c.List(ctx, events, client.InNamespace(job.GetNamespace()), client.MatchingFields{"involvedObject.uid": string(uid)}); err != nil {
+			return err
+		}
+		for _, e := range events.Items {
+			// the timestamps of the events have a precision of one second, the events
+			// of the most recent second already mirrored are identified by their names.
+			if t := eventTime(&e); t.After(lastEvent) || (t.Equal(lastEvent) && !mirrored.Has(e.Name)) {
+				newEvents = append(newEvents, e)
+			}
+		}
+	}
+	if len(newEvents) == 0 {
+		return nil
+	}
+	slices.SortFunc(newEvents, func(a, b corev1.Event) int {
+		return cmp.Or(eventTime(&a).Compare(eventTime(&b)), cmp.Compare(a.Name, b.Name))
+	})
+	maxEvents := int(ptr.Deref(m.cfg.MaxEventsPerInterval, configapi.DefaultMultiKueueStatusMirroringMaxEvents))
+	newEvents = newEvents[max(0, len(newEvents)-maxEvents):]
+
+	localJob := &metav1.PartialObjectMetadata{}
+	localJob.SetGroupVersionKind(group.controllerGVK)
+	localJob.Name = group.controllerKey.Name
+	localJob.Namespace = group.controllerKey.Namespace
+	localJob.UID = group.controllerUID
+	for _, e := range newEvents {
+		message := fmt.Sprintf("%s %s in worker cluster %s: %s", e.InvolvedObject.Kind, e.InvolvedObject.Name, cluster, e.Message)
+		m.recorder.Event(localJob, e.Type, e.Reason, message)
+		m.recorder.Event(group.local, e.Type, e.Reason, message)
+	}
+
+	newLastEvent := eventTime(&newEvents[len(newEvents)-1])
+	if !newLastEvent.Equal(lastEvent) {
+		mirrored = sets.New[string]()
+	}
+	for _, e := range newEvents {
+		if eventTime(&e).Equal(newLastEvent) {
+			mirrored.Insert(e.Name)
+		}
+	}
+	value := strings.Join(append([]string{newLastEvent.UTC().Format(time.RFC3339Nano)}, sets.List(mirrored)...), ",")
+	return patchAnnotation(ctx, m.client, group.local, kueue.MultiKueueMirroredEventsAnnotation, &value)
+}
+
+// mirroredEvents returns the time of the most recent event mirrored for the
+// workload, and the names of the events mirrored at that time.
+func mirroredEvents(wl *kueue.Workload) (time.Time, sets.Set[string]) {
+	values := strings.Split(wl.Annotations[kueue.MultiKueueMirroredEventsAnnotation], ",")
+	lastEvent, err := time.Parse(time.RFC3339Nano, values[0])
+	if err != nil {
+		return time.Time{}, sets.New[string]()
+	}
+	return lastEvent, sets.New(values[1:]...)
+}
+
+func eventTime(e *corev1.Event) time.Time {
+	switch {
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	case !e.FirstTimestamp.IsZero():
+		return e.FirstTimestamp.Time
+	default:
+		return e.CreationTimestamp.Time
+	}
+}
@@ -0,0 +1,326 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multikueue
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	testingdeployment "sigs.k8s.io/kueue/pkg/util/testingjobs/deployment"
+	testingjob "sigs.k8s.io/kueue/pkg/util/testingjobs/job"
+	testingpod "sigs.k8s.io/kueue/pkg/util/testingjobs/pod"
+)
+
+func TestStatusMirror(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	jobGVK := batchv1.SchemeGroupVersion.WithKind("Job")
+	deploymentGVK := appsv1.SchemeGroupVersion.WithKind("Deployment")
+
+	remoteJob := testingjob.MakeJob("job1", TestNamespace).UID("job1").Obj()
+	remoteJob.CreationTimestamp = metav1.NewTime(now.Add(-time.Hour))
+	remoteDeployment := testingdeployment.MakeDeployment("deployment1", TestNamespace).UID("deployment1").Obj()
+
+	basePodBuilder := testingpod.MakePod("", TestNamespace).OwnerReference("job1", jobGVK).Label(batchv1.JobNameLabel, "job1")
+	remotePods := []corev1.Pod{
+		*basePodBuilder.Clone().Name("running").UID("running").StatusPhase(corev1.PodRunning).Obj(),
+		*basePodBuilder.Clone().Name("failed").UID("failed").StatusPhase(corev1.PodFailed).Obj(),
+		*basePodBuilder.Clone().Name("pending").UID("pending").Obj(),
+		*basePodBuilder.Clone().Name("succeeded").UID("succeeded").StatusPhase(corev1.PodSucceeded).Obj(),
+		*testingpod.MakePod("other", TestNamespace).UID("other").
+			OwnerReference("job2", jobGVK).
+			Label(batchv1.JobNameLabel, "job2").
+			StatusPhase(corev1.PodRunning).
+			Obj(),
+		*testingpod.MakePod("deployment-pod", TestNamespace).UID("deployment-pod").
+			Label("app", "deployment1-pod").
+			StatusPhase(corev1.PodRunning).
+			Obj(),
+	}
+	remotePods[1].Status.ContainerStatuses = []corev1.ContainerStatus{{
+		RestartCount: 2,
+		State: corev1.ContainerState{
+			Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Reason: "Error", Message: "exit code 1"},
+		},
+	}}
+	remotePods[2].Status.ContainerStatuses = []corev1.ContainerStatus{{
+		State: corev1.ContainerState{
+			Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: "Back-off pulling image"},
+		},
+	}}
+
+	makeEvent := func(name, kind, objName string, uid types.UID, t time.Time) corev1.Event {
+		return corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: TestNamespace},
+			InvolvedObject: corev1.ObjectReference{Kind: kind, Name: objName, UID: uid},
+			Type:           corev1.EventTypeWarning,
+			Reason:         "Reason-" + name,
+			Message:        "message " + name,
+			LastTimestamp:  metav1.NewTime(t),
+		}
+	}
+	remoteEvents := []corev1.Event{
+		makeEvent("before-job", "Job", "job1", "job1", now.Add(-2*time.Hour)),
+		makeEvent("job", "Job", "job1", "job1", now.Add(-3*time.Minute)),
+		makeEvent("failed", "Pod", "failed", "failed", now.Add(-time.Minute)),
+		makeEvent("succeeded", "Pod", "succeeded", "succeeded", now.Add(-2*time.Minute)),
+		makeEvent("same-second", "Pod", "running", "running", now.Add(-2*time.Minute)),
+		makeEvent("other", "Pod", "other", "other", now.Add(-time.Minute)),
+	}
+
+	baseWorkloadBuilder := utiltesting.MakeWorkload("wl1", TestNamespace).ControllerReference(jobGVK, "job1", "uid1")
+	wlKey := types.NamespacedName{Name: "wl1", Namespace: TestNamespace}
+	jobKey := types.NamespacedName{Name: "job1", Namespace: TestNamespace}
+	mirroredEventsAt := func(t time.Time, names ...string) string {
+		return strings.Join(append([]string{t.UTC().Format(time.RFC3339Nano)}, names...), ",")
+	}
+
+	cases := map[string]struct {
+		cfg       configapi.MultiKueueStatusMirroring
+		lastSync  *time.Time
+		workload  *kueue.Workload
+		clear     bool
+		noRemote  bool
+		wantAfter time.Duration
+		// controllerGVK is the GVK of the job, a batch/v1 Job if empty.
+		controllerGVK schema.GroupVersionKind
+		controllerKey types.NamespacedName
+
+		wantRemotePods     *kueue.RemotePodsStatus
+		wantEvents         []utiltesting.EventRecord
+		wantMirroredEvents string
+		wantLastSync       *time.Time
+	}{
+		"pods status is mirrored": {
+			cfg:       configapi.MultiKueueStatusMirroring{Pods: true},
+			workload:  baseWorkloadBuilder.Clone().Obj(),
+			wantAfter: 30 * time.Second,
+			wantRemotePods: &kueue.RemotePodsStatus{
+				ClusterName: "worker1",
+				Pending:     1,
+				Running:     1,
+				Succeeded:   1,
+				Failed:      1,
+				Pods: []kueue.RemotePodStatus{
+					{Name: "failed", Phase: corev1.PodFailed, Reason: "Error", Message: "exit code 1", Restarts: 2},
+					{Name: "pending", Phase: corev1.PodPending, Reason: "ImagePullBackOff", Message: "Back-off pulling image"},
+					{Name: "running", Phase: corev1.PodRunning},
+					{Name: "succeeded", Phase: corev1.PodSucceeded},
+				},
+				LastUpdateTime: metav1.NewTime(now),
+			},
+			wantLastSync: &now,
+		},
+		"pods of a deployment are selected with its spec.selector": {
+			cfg:           configapi.MultiKueueStatusMirroring{Pods: true},
+			workload:      baseWorkloadBuilder.Clone().Obj(),
+			wantAfter:     30 * time.Second,
+			controllerGVK: deploymentGVK,
+			controllerKey: types.NamespacedName{Name: "deployment1", Namespace: TestNamespace},
+			wantRemotePods: &kueue.RemotePodsStatus{
+				ClusterName: "worker1",
+				Running:     1,
+				Pods: []kueue.RemotePodStatus{
+					{Name: "deployment-pod", Phase: corev1.PodRunning},
+				},
+				LastUpdateTime: metav1.NewTime(now),
+			},
+			wantLastSync: &now,
+		},
+		"unchanged pods status is not updated": {
+			cfg: configapi.MultiKueueStatusMirroring{Pods: true},
+			workload: func() *kueue.Workload {
+				wl := baseWorkloadBuilder.Clone().Obj()
+				wl.Status.RemotePods = &kueue.RemotePodsStatus{
+					ClusterName: "worker1",
+					Pending:     1,
+					Running:     1,
+					Succeeded:   1,
+					Failed:      1,
+					Pods: []kueue.RemotePodStatus{
+						{Name: "failed", Phase: corev1.PodFailed, Reason: "Error", Message: "exit code 1", Restarts: 2},
+						{Name: "pending", Phase: corev1.PodPending, Reason: "ImagePullBackOff", Message: "Back-off pulling image"},
+						{Name: "running", Phase: corev1.PodRunning},
+						{Name: "succeeded", Phase: corev1.PodSucceeded},
+					},
+					LastUpdateTime: metav1.NewTime(now.Add(-time.Hour)),
+				}
+				return wl
+			}(),
+			wantAfter: 30 * time.Second,
+			wantRemotePods: &kueue.RemotePodsStatus{
+				ClusterName: "worker1",
+				Pending:     1,
+				Running:     1,
+				Succeeded:   1,
+				Failed:      1,
+				Pods: []kueue.RemotePodStatus{
+					{Name: "failed", Phase: corev1.PodFailed, Reason: "Error", Message: "exit code 1", Restarts: 2},
+					{Name: "pending", Phase: corev1.PodPending, Reason: "ImagePullBackOff", Message: "Back-off pulling image"},
+					{Name: "running", Phase: corev1.PodRunning},
+					{Name: "succeeded", Phase: corev1.PodSucceeded},
+				},
+				LastUpdateTime: metav1.NewTime(now.Add(-time.Hour)),
+			},
+			wantLastSync: &now,
+		},
+		"the most recent events are mirrored": {
+			cfg:       configapi.MultiKueueStatusMirroring{Events: true, MaxEventsPerInterval: ptr.To[int32](2)},
+			workload:  baseWorkloadBuilder.Clone().Obj(),
+			wantAfter: 30 * time.Second,
+			wantEvents: []utiltesting.EventRecord{
+				{Key: jobKey, EventType: corev1.EventTypeWarning, Reason: "Reason-succeeded", Message: "Pod succeeded in worker cluster worker1: message succeeded"},
+				{Key: wlKey, EventType: corev1.EventTypeWarning, Reason: "Reason-succeeded", Message: "Pod succeeded in worker cluster worker1: message succeeded"},
+				{Key: jobKey, EventType: corev1.EventTypeWarning, Reason: "Reason-failed", Message: "Pod failed in worker cluster worker1: message failed"},
+				{Key: wlKey, EventType: corev1.EventTypeWarning, Reason: "Reason-failed", Message: "Pod failed in worker cluster worker1: message failed"},
+			},
+			wantMirroredEvents: mirroredEventsAt(now.Add(-time.Minute), "failed"),
+			wantLastSync:       &now,
+		},
+		"the events already mirrored, before a restart, are skipped": {
+			cfg: configapi.MultiKueueStatusMirroring{Events: true},
+			workload: baseWorkloadBuilder.Clone().
+				Annotation(kueue.MultiKueueMirroredEventsAnnotation, mirroredEventsAt(now.Add(-2*time.Minute), "succeeded")).
+				Obj(),
+			wantAfter: 30 * time.Second,
+			wantEvents: []utiltesting.EventRecord{
+				{Key: jobKey, EventType: corev1.EventTypeWarning, Reason: "Reason-same-second", Message: "Pod running in worker cluster worker1: message same-second"},
+				{Key: wlKey, EventType: corev1.EventTypeWarning, Reason: "Reason-same-second", Message: "Pod running in worker cluster worker1: message same-second"},
+				{Key: jobKey, EventType: corev1.EventTypeWarning, Reason: "Reason-failed", Message: "Pod failed in worker cluster worker1: message failed"},
+				{Key: wlKey, EventType: corev1.EventTypeWarning, Reason: "Reason-failed", Message: "Pod failed in worker cluster worker1: message failed"},
+			},
+			wantMirroredEvents: mirroredEventsAt(now.Add(-time.Minute), "failed"),
+			wantLastSync:       &now,
+		},
+		"the events sharing the second of the last mirrored event are mirrored once": {
+			cfg: configapi.MultiKueueStatusMirroring{Events: true},
+			workload: baseWorkloadBuilder.Clone().
+				Annotation(kueue.MultiKueueMirroredEventsAnnotation, mirroredEventsAt(now.Add(-time.Minute), "failed")).
+				Obj(),
+			wantAfter:          30 * time.Second,
+			wantMirroredEvents: mirroredEventsAt(now.Add(-time.Minute), "failed"),
+			wantLastSync:       &now,
+		},
+		"mirrored less than an interval ago": {
+			cfg:          configapi.MultiKueueStatusMirroring{Events: true, Pods: true},
+			lastSync:     ptr.To(now.Add(-10 * time.Second)),
+			workload:     baseWorkloadBuilder.Clone().Obj(),
+			wantAfter:    20 * time.Second,
+			wantLastSync: ptr.To(now.Add(-10 * time.Second)),
+		},
+		"missing remote job": {
+			cfg:      configapi.MultiKueueStatusMirroring{Events: true, Pods: true},
+			workload: baseWorkloadBuilder.Clone().Obj(),
+			noRemote: true,
+		},
+		"clear the mirrored status": {
+			cfg:      configapi.MultiKueueStatusMirroring{Pods: true},
+			lastSync: ptr.To(now.Add(-10 * time.Second)),
+			workload: func() *kueue.Workload {
+				wl := baseWorkloadBuilder.Clone().Obj()
+				wl.Status.RemotePods = &kueue.RemotePodsStatus{ClusterName: "worker1", Running: 1}
+				return wl
+			}(),
+			clear: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			managerClient := getClientBuilder(t.Context()).
+				WithObjects(tc.workload).
+				WithStatusSubresource(tc.workload).
+				WithInterceptorFuncs(interceptor.Funcs{SubResourcePatch: utiltesting.TreatSSAAsStrategicMerge}).
+				Build()
+			workerBuilder := getClientBuilder(t.Context()).
+				WithLists(&corev1.PodList{Items: remotePods}, &corev1.EventList{Items: remoteEvents}).
+				WithIndex(&corev1.Event{}, "involvedObject.uid", func(o client.Object) []string {
+					return []string{string(o.(*corev1.Event).InvolvedObject.UID)}
+				})
+			if !tc.noRemote {
+				workerBuilder = workerBuilder.WithObjects(remoteJob, remoteDeployment)
+			}
+			recorder := &utiltesting.EventRecorder{}
+			tc.cfg.Interval = &metav1.Duration{Duration: 30 * time.Second}
+			mirror := newStatusMirror(managerClient, recorder, testingclock.NewFakeClock(now), &tc.cfg)
+			if tc.lastSync != nil {
+				mirror.lastSync.Add(wlKey.String(), *tc.lastSync)
+			}
+
+			group := &wlGroup{
+				local:         tc.workload,
+				remoteClients: map[string]*remoteClient{"worker1": {client: workerBuilder.Build()}},
+				controllerKey: jobKey,
+				controllerGVK: jobGVK,
+				controllerUID: "uid1",
+			}
+			if !tc.controllerGVK.Empty() {
+				group.controllerGVK = tc.controllerGVK
+				group.controllerKey = tc.controllerKey
+			}
+			if tc.clear {
+				if err := mirror.clear(t.Context(), tc.workload); err != nil {
+					t.Fatalf("unexpected clear error: %s", err)
+				}
+			} else {
+				gotAfter, err := mirror.sync(t.Context(), group, "worker1")
+				if err != nil {
+					t.Fatalf("unexpected sync error: %s", err)
+				}
+				if gotAfter != tc.wantAfter {
+					t.Errorf("unexpected requeue after, want=%s, got=%s", tc.wantAfter, gotAfter)
+				}
+			}
+
+			gotWl := &kueue.Workload{}
+			if err := managerClient.Get(t.Context(), wlKey, gotWl); err != nil {
+				t.Fatalf("unexpected get workload error: %s", err)
+			}
+			if diff := cmp.Diff(tc.wantRemotePods, gotWl.Status.RemotePods, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("unexpected remote pods status (-want/+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantMirroredEvents, gotWl.Annotations[kueue.MultiKueueMirroredEventsAnnotation]); diff != "" {
+				t.Errorf("unexpected mirrored events annotation (-want/+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantEvents, recorder.RecordedEvents); diff != "" {
+				t.Errorf("unexpected events (-want/+got):\n%s", diff)
+			}
+			gotLastSync, found := mirror.lastSync.Get(wlKey.String())
+			if diff := cmp.Diff(tc.wantLastSync, ptr.To(gotLastSync), cmpopts.EquateApproxTime(time.Second)); found && diff != "" {
+				t.Errorf("unexpected last sync (-want/+got):\n%s", diff)
+			}
+			if !found && tc.wantLastSync != nil {
+				t.Errorf("unexpected missing last sync")
+			}
+		})
+	}
+}
@@ -22,17 +22,21 @@ import (
 	"fmt"
 	"maps"
 	"os"
+	"path/filepath"
 	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -40,6 +44,7 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -50,8 +55,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/resources"
 )
 
 const (
@@ -60,6 +68,10 @@ const (
 	// this set will provide waiting time between 0 to 5m20s
 	retryIncrement = 5 * time.Second
 	retryMaxSteps  = 7
+
+	// capacityUpdatePeriod is the delay used to batch the updates of the
+	// capacity in the MultiKueueCluster status.
+	capacityUpdatePeriod = 5 * time.Second
 )
 
 // retryAfter returns an exponentially increasing interval between
@@ -87,6 +99,21 @@ type remoteClient struct {
 	connecting         atomic.Bool
 	failedConnAttempts uint
 
+	// capacityCh - if set, the capacity of the ClusterQueues in the worker cluster is tracked,
+	// and an event is sent when it changes.
+	capacityCh   chan<- event.GenericEvent
+	capacityLock sync.RWMutex
+	capacity     map[kueue.ClusterQueueReference]kueue.MultiKueueClusterQueueCapacity
+	// quotas - the nominal quotas of the ClusterQueues in the worker cluster, by flavor.
+	quotas map[kueue.ClusterQueueReference]resources.FlavorResourceQuantities
+	// reserved - the quotas reserved in the ClusterQueues in the worker cluster, by flavor.
+	reserved map[kueue.ClusterQueueReference]resources.FlavorResourceQuantities
+	// localQueues - the ClusterQueues pointed by the LocalQueues in the worker cluster.
+	localQueues map[types.NamespacedName]kueue.ClusterQueueReference
+
+	// health - the state of the health probes of the worker cluster.
+	health clusterHealth
+
 	// For unit testing only. There is now need of creating fully functional remote clients in the unit tests
 	// and creating valid kubeconfig content is not trivial.
 	// The full client creation and usage is validated in the integration and e2e tests.
@@ -179,6 +206,13 @@ func (rc *remoteClient) setConfig(watchCtx context.Context, kubeconfig []byte) (
 		}
 	}
 
+	if rc.capacityCh != nil {
+		if err := rc.startCapacityWatcher(watchCtx); err != nil {
+			rc.failedConnAttempts++
+			return ptr.To(retryAfter(rc.failedConnAttempts)), err
+		}
+	}
+
 	rc.connecting.Store(false)
 	rc.failedConnAttempts = 0
 	return nil, nil
@@ -211,20 +245,214 @@ func (rc *remoteClient) startWatcher(ctx context.Context, kind string, w jobfram
 				}
 			}
 		}
-		log.V(2).Info("Watch ended", "ctxErr", ctx.Err())
-		// If the context is not yet Done , queue a reconcile to attempt reconnection
-		if ctx.Err() == nil {
-			oldConnecting := rc.connecting.Swap(true)
-			// reconnect if this is the first watch failing.
-			if !oldConnecting {
-				log.V(2).Info("Queue reconcile for reconnect", "cluster", rc.clusterName)
-				rc.queueWatchEndedEvent(ctx)
+		rc.watchEnded(ctx, log)
+	}()
+	return nil
+}
+
+// startCapacityWatcher - watches the ClusterQueues and the LocalQueues of the
+// worker cluster, to keep track of their capacity.
+func (rc *remoteClient) startCapacityWatcher(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithValues("watchKind", "ClusterQueue")
+	newWatcher, err := rc.client.Watch(ctx, &kueue.ClusterQueueList{})
+	if err != nil {
+		return err
+	}
+	lqWatcher, err := rc.client.Watch(ctx, &kueue.LocalQueueList{})
+	if err != nil {
+		newWatcher.Stop()
+		return err
+	}
+
+	rc.capacityLock.Lock()
+	rc.capacity = make(map[kueue.ClusterQueueReference]kueue.MultiKueueClusterQueueCapacity)
+	rc.quotas = make(map[kueue.ClusterQueueReference]resources.FlavorResourceQuantities)
+	rc.reserved = make(map[kueue.ClusterQueueReference]resources.FlavorResourceQuantities)
+	rc.localQueues = make(map[types.NamespacedName]kueue.ClusterQueueReference)
+	rc.capacityLock.Unlock()
+
+	go func() {
+		lqLog := ctrl.LoggerFrom(ctx).WithValues("watchKind", "LocalQueue")
+		lqLog.V(2).Info("Starting watch")
+		for r := range lqWatcher.ResultChan() {
+			lq, isLQ := r.Object.(*kueue.LocalQueue)
+			if !isLQ {
+				lqLog.V(3).Info("Watch error or unexpected type", "type", fmt.Sprintf("%T", r.Object))
+				continue
+			}
+			rc.updateLocalQueue(lq, r.Type == watch.Deleted)
+		}
+		rc.watchEnded(ctx, lqLog)
+	}()
+
+	go func() {
+		log.V(2).Info("Starting watch")
+		for r := range newWatcher.ResultChan() {
+			cq, isCQ := r.Object.(*kueue.ClusterQueue)
+			if !isCQ {
+				log.V(3).Info("Watch error or unexpected type", "type", fmt.Sprintf("%T", r.Object))
+				continue
+			}
+			if rc.updateCapacity(cq, r.Type == watch.Deleted) {
+				rc.queueCapacityEvent()
 			}
 		}
+		rc.watchEnded(ctx, log)
 	}()
 	return nil
 }
 
+func (rc *remoteClient) watchEnded(ctx context.Context, log logr.Logger) {
+	log.V(2).Info("Watch ended", "ctxErr", ctx.Err())
+	// If the context is not yet Done , queue a reconcile to attempt reconnection
+	if ctx.Err() == nil {
+		oldConnecting := rc.connecting.Swap(true)
+		// reconnect if this is the first watch failing.
+		if !oldConnecting {
+			log.V(2).Info("Queue reconcile for reconnect", "cluster", rc.clusterName)
+			rc.queueWatchEndedEvent(ctx)
+		}
+	}
+}
+
+// updateCapacity - updates the capacity of the ClusterQueue, returns true if it changed.
+func (rc *remoteClient) updateCapacity(cq *kueue.ClusterQueue, deleted bool) bool {
+	rc.capacityLock.Lock()
+	defer rc.capacityLock.Unlock()
+	name := kueue.ClusterQueueReference(cq.Name)
+	oldCapacity, found := rc.capacity[name]
+	if deleted {
+		delete(rc.capacity, name)
+		delete(rc.quotas, name)
+		delete(rc.reserved, name)
+		return found
+	}
+	newCapacity := clusterQueueCapacity(cq)
+	newQuotas := clusterQueueQuotas(cq)
+	newReserved := clusterQueueReserved(cq)
+	if found && equality.Semantic.DeepEqual(oldCapacity, newCapacity) && maps.Equal(rc.quotas[name], newQuotas) && maps.Equal(rc.reserved[name], newReserved) {
+		return false
+	}
+	rc.capacity[name] = newCapacity
+	rc.quotas[name] = newQuotas
+	rc.reserved[name] = newReserved
+	return true
+}
+
+// updateLocalQueue - updates the ClusterQueue pointed by the LocalQueue.
+func (rc *remoteClient) updateLocalQueue(lq *kueue.LocalQueue, deleted bool) {
+	rc.capacityLock.Lock()
+	defer rc.capacityLock.Unlock()
+	key := client.ObjectKeyFromObject(lq)
+	if deleted {
+		delete(rc.localQueues, key)
+		return
+	}
+	rc.localQueues[key] = lq.Spec.ClusterQueue
+}
+
+// localQueueClusterQueue - returns the ClusterQueue pointed by the LocalQueue.
+func (rc *remoteClient) localQueueClusterQueue(key types.NamespacedName) (kueue.ClusterQueueReference, bool) {
+	rc.capacityLock.RLock()
+	defer rc.capacityLock.RUnlock()
+	cq, found := rc.localQueues[key]
+	return cq, found
+}
+
+// clusterQueueQuotas - returns the nominal quotas of the ClusterQueue, by flavor.
+func (rc *remoteClient) clusterQueueQuotas(name kueue.ClusterQueueReference) (resources.FlavorResourceQuantities, bool) {
+	rc.capacityLock.RLock()
+	defer rc.capacityLock.RUnlock()
+	quotas, found := rc.quotas[name]
+	return quotas, found
+}
+
+// clusterQueueReserved - returns the quotas reserved in the ClusterQueue, by flavor.
+func (rc *remoteClient) clusterQueueReserved(name kueue.ClusterQueueReference) resources.FlavorResourceQuantities {
+	rc.capacityLock.RLock()
+	defer rc.capacityLock.RUnlock()
+	return rc.reserved[name]
+}
+
+// clusterQueueCapacity - returns the capacity of the ClusterQueue.
+func (rc *remoteClient) clusterQueueCapacity(name kueue.ClusterQueueReference) (kueue.MultiKueueClusterQueueCapacity, bool) {
+	rc.capacityLock.RLock()
+	defer rc.capacityLock.RUnlock()
+	capacity, found := rc.capacity[name]
+	return capacity, found
+}
+
+// capacitySummary - returns the capacity of the worker cluster, nil if it's not tracked.
+func (rc *remoteClient) capacitySummary() *kueue.MultiKueueClusterCapacity {
+	rc.capacityLock.RLock()
+	defer rc.capacityLock.RUnlock()
+	if rc.capacity == nil {
+		return nil
+	}
+	summary := &kueue.MultiKueueClusterCapacity{}
+	for _, name := range slices.Sorted(maps.Keys(rc.capacity)) {
+		cqCapacity := rc.capacity[name]
+		summary.ClusterQueues = append(summary.ClusterQueues, *cqCapacity.DeepCopy())
+	}
+	return summary
+}
+
+func clusterQueueCapacity(cq *kueue.ClusterQueue) kueue.MultiKueueClusterQueueCapacity {
+	nominal := make(map[corev1.ResourceName]resource.Quantity)
+	reserved := make(map[corev1.ResourceName]resource.Quantity)
+	for _, rg := range cq.Spec.ResourceGroups {
+		for _, fq := range rg.Flavors {
+			for _, r := range fq.Resources {
+				q := nominal[r.Name]
+				q.Add(r.NominalQuota)
+				nominal[r.Name] = q
+			}
+		}
+	}
+	for _, fu := range cq.Status.FlavorsReservation {
+		for _, r := range fu.Resources {
+			q := reserved[r.Name]
+			q.Add(r.Total)
+			reserved[r.Name] = q
+		}
+	}
+	capacity := kueue.MultiKueueClusterQueueCapacity{
+		Name:             kueue.ClusterQueueReference(cq.Name),
+		Cohort:           cq.Spec.Cohort,
+		PendingWorkloads: cq.Status.PendingWorkloads,
+	}
+	for _, name := range slices.Sorted(maps.Keys(nominal)) {
+		capacity.Resources = append(capacity.Resources, kueue.MultiKueueResourceCapacity{
+			Name:         name,
+			NominalQuota: nominal[name],
+			Reserved:     reserved[name],
+		})
+	}
+	return capacity
+}
+
+func clusterQueueQuotas(cq *kueue.ClusterQueue) resources.FlavorResourceQuantities {
+	quotas := make(resources.FlavorResourceQuantities)
+	for _, rg := range cq.Spec.ResourceGroups {
+		for _, fq := range rg.Flavors {
+			for _, r := range fq.Resources {
+				quotas[resources.FlavorResource{Flavor: fq.Name, Resource: r.Name}] = resources.ResourceValue(r.Name, r.NominalQuota)
+			}
+		}
+	}
+	return quotas
+}
+
+func clusterQueueReserved(cq *kueue.ClusterQueue) resources.FlavorResourceQuantities {
+	reserved := make(resources.FlavorResourceQuantities)
+	for _, fu := range cq.Status.FlavorsReservation {
+		for _, r := range fu.Resources {
+			reserved[resources.FlavorResource{Flavor: fu.Name, Resource: r.Name}] = resources.ResourceValue(r.Name, r.Total)
+		}
+	}
+	return reserved
+}
+
 func (rc *remoteClient) StopWatchers() {
 	if rc.watchCancel != nil {
 		rc.watchCancel()
@@ -240,6 +468,10 @@ func (rc *remoteClient) queueWorkloadEvent(ctx context.Context, wlKey types.Name
 	}
 }
 
+func (rc *remoteClient) queueCapacityEvent() {
+	rc.capacityCh <- event.GenericEvent{Object: &kueue.MultiKueueCluster{ObjectMeta: metav1.ObjectMeta{Name: rc.clusterName}}}
+}
+
 func (rc *remoteClient) queueWatchEndedEvent(ctx context.Context) {
 	cluster := &kueue.MultiKueueCluster{}
 	if err := rc.localClient.Get(ctx, types.NamespacedName{Name: rc.clusterName}, cluster); err == nil {
@@ -335,7 +567,23 @@ type clustersReconciler struct {
 
 	fsWatcher *KubeConfigFSWatcher
 
+	// credentialsProvider - provides the kubeconfig of the clusters described by a ClusterProfile.
+	credentialsProvider ClusterProfileCredentialsProvider
+
 	adapters map[string]jobframework.MultiKueueAdapter
+
+	// trackCapacity - if true, the capacity of the ClusterQueues in the worker clusters is tracked.
+	trackCapacity bool
+	// capacityCh - an event chan used to request the update of the capacity in the status of the clusters.
+	capacityCh chan event.GenericEvent
+
+	// quotaCh - if set, an event is sent for every reconciled cluster, to
+	// update the federated quotas of the ClusterQueues.
+	quotaCh chan event.GenericEvent
+
+	// healthCheck - if set, the worker clusters are actively probed.
+	healthCheck *configapi.MultiKueueHealthCheck
+	clock       clock.Clock
 }
 
 var _ manager.Runnable = (*clustersReconciler)(nil)
@@ -366,6 +614,9 @@ func (c *clustersReconciler) setRemoteClientConfig(ctx context.Context, clusterN
 		if c.builderOverride != nil {
 			client.builderOverride = c.builderOverride
 		}
+		if c.trackCapacity {
+			client.capacityCh = c.capacityCh
+		}
 		c.remoteClients[clusterName] = client
 	}
 
@@ -398,34 +649,71 @@ func (c *clustersReconciler) Reconcile(ctx context.Context, req reconcile.Reques
 	log := ctrl.LoggerFrom(ctx)
 	log.V(2).Info("Reconcile MultiKueueCluster")
 
+	if c.quotaCh != nil {
+		// the cluster could have joined, left or changed its quotas.
+		defer c.queueQuotaEvent(req.Name)
+	}
+
 	if err != nil || !cluster.DeletionTimestamp.IsZero() {
 		c.stopAndRemoveCluster(req.Name)
 		return reconcile.Result{}, nil //nolint:nilerr // nil is intentional, as either the cluster is deleted, or not found
 	}
 
 	// get the kubeconfig
-	kubeConfig, retry, err := c.getKubeConfig(ctx, &cluster.Spec.KubeConfig)
+	kubeConfig, retry, err := c.getKubeConfig(ctx, cluster)
 	if retry {
 		return reconcile.Result{}, err
 	}
+	creds := &kubeConfigCredentials{}
+	if err == nil {
+		kubeConfig, creds, err = flattenKubeConfig(kubeConfig, kubeConfigDir(cluster))
+	}
 	if err != nil {
 		log.Error(err, "reading kubeconfig")
 		c.stopAndRemoveCluster(req.Name)
-		return reconcile.Result{}, c.updateStatus(ctx, cluster, false, "BadConfig", err.Error())
+		return reconcile.Result{}, c.updateStatus(ctx, cluster, false, "BadConfig", err.Error(), nil, nil, nil)
 	}
+	c.watchFiles(ctx, cluster, creds.files)
+	credentials := credentialsCondition(cluster, creds.expiry, c.clock.Now())
 
 	if retryAfter, err := c.setRemoteClientConfig(ctx, cluster.Name, kubeConfig, c.origin); err != nil {
 		log.Error(err, "setting kubeconfig", "retryAfter", retryAfter)
-		if err := c.updateStatus(ctx, cluster, false, "ClientConnectionFailed", err.Error()); err != nil {
+		if err := c.updateStatus(ctx, cluster, false, "ClientConnectionFailed", err.Error(), nil, credentials, nil); err != nil {
 			return reconcile.Result{}, err
 		} else {
 			return reconcile.Result{RequeueAfter: ptr.Deref(retryAfter, 0)}, nil
 		}
 	}
-	return reconcile.Result{}, c.updateStatus(ctx, cluster, true, "Active", "Connected")
+	var capacity *kueue.MultiKueueClusterCapacity
+	var health *metav1.Condition
+	var requeueAfter time.Duration
+	if rc, found := c.controllerFor(cluster.Name); found {
+		capacity = rc.capacitySummary()
+		if c.healthCheck != nil {
+			health, requeueAfter = c.checkHealth(ctx, rc, cluster.Generation)
+		}
+	}
+	if creds.expiry != nil {
+		// update the credentials condition when they expire
+		if expiresIn := creds.expiry.Sub(c.clock.Now()); expiresIn > 0 && (requeueAfter == 0 || expiresIn < requeueAfter) {
+			requeueAfter = expiresIn
+		}
+	}
+	return reconcile.Result{RequeueAfter: requeueAfter}, c.updateStatus(ctx, cluster, true, "Active", "Connected", capacity, credentials, health)
+}
+
+func (c *clustersReconciler) queueQuotaEvent(clusterName string) {
+	c.quotaCh <- event.GenericEvent{Object: &kueue.MultiKueueCluster{ObjectMeta: metav1.ObjectMeta{Name: clusterName}}}
 }
 
-func (c *clustersReconciler) getKubeConfig(ctx context.Context, ref *kueue.KubeConfig) ([]byte, bool, error) {
+func (c *clustersReconciler) getKubeConfig(ctx context.Context, cluster *kueue.MultiKueueCluster) ([]byte, bool, error) {
+	if ref := cluster.Spec.ClusterProfileRef; ref != nil {
+		return c.getKubeConfigFromClusterProfile(ctx, ref)
+	}
+	ref := cluster.Spec.KubeConfig
+	if ref == nil {
+		return nil, false, errors.New("neither kubeConfig nor clusterProfileRef is set")
+	}
 	if ref.LocationType == kueue.SecretLocationType {
 		return c.getKubeConfigFromSecret(ctx, ref.Location)
 	}
@@ -433,6 +721,67 @@ func (c *clustersReconciler) getKubeConfig(ctx context.Context, ref *kueue.KubeC
 	return c.getKubeConfigFromPath(ref.Location)
 }
 
+func (c *clustersReconciler) getKubeConfigFromClusterProfile(ctx context.Context, ref *kueue.ClusterProfileReference) ([]byte, bool, error) {
+	if !features.Enabled(features.MultiKueueClusterProfile) {
+		return nil, false, fmt.Errorf("the %s feature gate is disabled", features.MultiKueueClusterProfile)
+	}
+	if c.credentialsProvider == nil {
+		return nil, false, errNoCredentialsProvider
+	}
+	clusterProfile := &unstructured.Unstructured{}
+	clusterProfile.SetGroupVersionKind(clusterProfileGVK)
+	err := c.localClient.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, clusterProfile)
+	if err != nil {
+		return nil, !apierrors.IsNotFound(err), err
+	}
+	kubeConfig, err := c.credentialsProvider.KubeConfig(ctx, clusterProfile)
+	return kubeConfig, false, err
+}
+
+// kubeConfigDir returns the directory used to resolve the relative paths of
+// the kubeconfig of the cluster.
+func kubeConfigDir(cluster *kueue.MultiKueueCluster) string {
+	if kc := cluster.Spec.KubeConfig; kc != nil && cluster.Spec.ClusterProfileRef == nil && kc.LocationType == kueue.PathLocationType {
+		return filepath.Dir(kc.Location)
+	}
+	return ""
+}
+
+// watchFiles watches the kubeconfig file and the credential files used by the cluster.
+func (c *clustersReconciler) watchFiles(ctx context.Context, cluster *kueue.MultiKueueCluster, credentialFiles []string) {
+	if c.fsWatcher == nil {
+		return
+	}
+	files := credentialFiles
+	if kubeConfigDir(cluster) != "" {
+		files = append([]string{cluster.Spec.KubeConfig.Location}, files...)
+	}
+	if err := c.fsWatcher.AddOrUpdate(cluster.Name, files...); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "AddOrUpdate FS watch")
+	}
+}
+
+// credentialsCondition returns the condition reporting the expiry of the
+// credentials of the cluster at now, nil if unknown.
+func credentialsCondition(cluster *kueue.MultiKueueCluster, expiry *time.Time, now time.Time) *metav1.Condition {
+	if expiry == nil {
+		return nil
+	}
+	cond := &metav1.Condition{
+		Type:               kueue.MultiKueueClusterCredentialsValid,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Valid",
+		Message:            fmt.Sprintf("The credentials expire at %s", expiry.UTC().Format(time.RFC3339)),
+		ObservedGeneration: cluster.Generation,
+	}
+	if !now.Before(*expiry) {
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "Expired"
+		cond.Message = fmt.Sprintf("The credentials expired at %s", expiry.UTC().Format(time.RFC3339))
+	}
+	return cond
+}
+
 func (c *clustersReconciler) getKubeConfigFromSecret(ctx context.Context, secretName string) ([]byte, bool, error) {
 	sec := corev1.Secret{}
 	secretObjKey := types.NamespacedName{
@@ -457,7 +806,7 @@ func (c *clustersReconciler) getKubeConfigFromPath(path string) ([]byte, bool, e
 	return content, false, err
 }
 
-func (c *clustersReconciler) updateStatus(ctx context.Context, cluster *kueue.MultiKueueCluster, active bool, reason, message string) error {
+func (c *clustersReconciler) updateStatus(ctx context.Context, cluster *kueue.MultiKueueCluster, active bool, reason, message string, capacity *kueue.MultiKueueClusterCapacity, credentials, health *metav1.Condition) error {
 	newCondition := metav1.Condition{
 		Type:               kueue.MultiKueueClusterActive,
 		Status:             metav1.ConditionFalse,
@@ -469,16 +818,41 @@ func (c *clustersReconciler) updateStatus(ctx context.Context, cluster *kueue.Mu
 		newCondition.Status = metav1.ConditionTrue
 	}
 
-	// if the condition is up-to-date
+	// if the conditions are up-to-date
 	oldCondition := apimeta.FindStatusCondition(cluster.Status.Conditions, kueue.MultiKueueClusterActive)
-	if cmpConditionState(oldCondition, &newCondition) {
+	if cmpConditionState(oldCondition, &newCondition) &&
+		optionalConditionUpToDate(cluster.Status.Conditions, kueue.MultiKueueClusterCredentialsValid, credentials) &&
+		optionalConditionUpToDate(cluster.Status.Conditions, kueue.MultiKueueClusterHealthy, health) &&
+		equality.Semantic.DeepEqual(cluster.Status.Capacity, capacity) {
 		return nil
 	}
 
 	apimeta.SetStatusCondition(&cluster.Status.Conditions, newCondition)
+	setOptionalCondition(&cluster.Status.Conditions, kueue.MultiKueueClusterCredentialsValid, credentials)
+	setOptionalCondition(&cluster.Status.Conditions, kueue.MultiKueueClusterHealthy, health)
+	cluster.Status.Capacity = capacity
 	return c.localClient.Status().Update(ctx, cluster)
 }
 
+// optionalConditionUpToDate returns true if the condition of the given type is
+// in the expected state, or is missing if the expected condition is nil.
+func optionalConditionUpToDate(conditions []metav1.Condition, conditionType string, expected *metav1.Condition) bool {
+	current := apimeta.FindStatusCondition(conditions, conditionType)
+	if current == nil || expected == nil {
+		return current == nil && expected == nil
+	}
+	return cmpConditionState(current, expected)
+}
+
+// setOptionalCondition sets the condition of the given type, or removes it if nil.
+func setOptionalCondition(conditions *[]metav1.Condition, conditionType string, condition *metav1.Condition) {
+	if condition != nil {
+		apimeta.SetStatusCondition(conditions, *condition)
+	} else {
+		apimeta.RemoveStatusCondition(conditions, conditionType)
+	}
+}
+
 func (c *clustersReconciler) runGC(ctx context.Context) {
 	log := ctrl.LoggerFrom(ctx).WithName("MultiKueueGC")
 	if c.gcInterval == 0 {
@@ -509,6 +883,7 @@ func (c *clustersReconciler) getRemoteClients() []*remoteClient {
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;watch;update
 // +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=multikueueclusters,verbs=get;list;watch
 // +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=multikueueclusters/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=multicluster.x-k8s.io,resources=clusterprofiles,verbs=get;list;watch
 
 func newClustersReconciler(c client.Client, namespace string, gcInterval time.Duration, origin string, fsWatcher *KubeConfigFSWatcher, adapters map[string]jobframework.MultiKueueAdapter) *clustersReconciler {
 	return &clustersReconciler{
@@ -519,8 +894,10 @@ func newClustersReconciler(c client.Client, namespace string, gcInterval time.Du
 		gcInterval:      gcInterval,
 		origin:          origin,
 		watchEndedCh:    make(chan event.GenericEvent, eventChBufferSize),
+		capacityCh:      make(chan event.GenericEvent, eventChBufferSize),
 		fsWatcher:       fsWatcher,
 		adapters:        adapters,
+		clock:           realClock,
 	}
 }
 
@@ -547,11 +924,20 @@ func (c *clustersReconciler) setupWithManager(mgr ctrl.Manager) error {
 		},
 	}
 
+	capacityHndl := handler.Funcs{
+		GenericFunc: func(_ context.Context, e event.GenericEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			// batch the capacity updates
+			q.AddAfter(reconcile.Request{NamespacedName: types.NamespacedName{
+				Name: e.Object.GetName(),
+			}}, capacityUpdatePeriod)
+		},
+	}
+
 	filterLog := mgr.GetLogger().WithName("MultiKueueCluster filter")
 	filter := predicate.Funcs{
 		CreateFunc: func(ce event.CreateEvent) bool {
 			if cluster, isCluster := ce.Object.(*kueue.MultiKueueCluster); isCluster {
-				if cluster.Spec.KubeConfig.LocationType == kueue.PathLocationType {
+				if kubeConfigDir(cluster) != "" {
 					err := c.fsWatcher.AddOrUpdate(cluster.Name, cluster.Spec.KubeConfig.Location)
 					if err != nil {
 						filterLog.Error(err, "AddOrUpdate FS watch", "cluster", klog.KObj(cluster))
@@ -567,41 +953,69 @@ func (c *clustersReconciler) setupWithManager(mgr ctrl.Manager) error {
 				return true
 			}
 
-			if clusterNew.Spec.KubeConfig.LocationType == kueue.SecretLocationType && clusterOld.Spec.KubeConfig.LocationType == kueue.PathLocationType {
-				err := c.fsWatcher.Remove(clusterOld.Name)
-				if err != nil {
-					filterLog.Error(err, "Remove FS watch", "cluster", klog.KObj(clusterOld))
-				}
+			// the credential files are added by the reconciler
+			if equality.Semantic.DeepEqual(clusterNew.Spec, clusterOld.Spec) {
+				return true
 			}
 
-			if clusterNew.Spec.KubeConfig.LocationType == kueue.PathLocationType {
+			if kubeConfigDir(clusterNew) != "" {
 				err := c.fsWatcher.AddOrUpdate(clusterNew.Name, clusterNew.Spec.KubeConfig.Location)
 				if err != nil {
 					filterLog.Error(err, "AddOrUpdate FS watch", "cluster", klog.KObj(clusterNew))
 				}
+			} else {
+				err := c.fsWatcher.Remove(clusterOld.Name)
+				if err != nil {
+					filterLog.Error(err, "Remove FS watch", "cluster", klog.KObj(clusterOld))
+				}
 			}
 			return true
 		},
 		DeleteFunc: func(de event.DeleteEvent) bool {
 			if cluster, isCluster := de.Object.(*kueue.MultiKueueCluster); isCluster {
-				if cluster.Spec.KubeConfig.LocationType == kueue.PathLocationType {
-					err := c.fsWatcher.Remove(cluster.Name)
-					if err != nil {
-						filterLog.Error(err, "Remove FS watch", "cluster", klog.KObj(cluster))
-					}
+				err := c.fsWatcher.Remove(cluster.Name)
+				if err != nil {
+					filterLog.Error(err, "Remove FS watch", "cluster", klog.KObj(cluster))
 				}
 			}
 			return true
 		},
 	}
 
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&kueue.MultiKueueCluster{}).
 		Watches(&corev1.Secret{}, &secretHandler{client: c.localClient}).
 		WatchesRawSource(source.Channel(c.watchEndedCh, syncHndl)).
 		WatchesRawSource(source.Channel(c.fsWatcher.reconcile, fsWatcherHndl)).
-		WithEventFilter(filter).
-		Complete(c)
+		WatchesRawSource(source.Channel(c.capacityCh, capacityHndl)).
+		WithEventFilter(filter)
+	if features.Enabled(features.MultiKueueClusterProfile) {
+		// The ClusterProfiles are only watched when their CRD is installed,
+		// the watch would otherwise prevent the manager from starting.
+		if _, err := mgr.GetRESTMapper().RESTMapping(clusterProfileGVK.GroupKind(), clusterProfileGVK.Version); err == nil {
+			clusterProfile := &unstructured.Unstructured{}
+			clusterProfile.SetGroupVersionKind(clusterProfileGVK)
+			b = b.Watches(clusterProfile, handler.EnqueueRequestsFromMapFunc(c.clustersUsingClusterProfile))
+		} else if apimeta.IsNoMatchError(err) {
+			mgr.GetLogger().Info("The ClusterProfile API is not installed, the ClusterProfiles are not watched", "gvk", clusterProfileGVK)
+		} else {
+			return err
+		}
+	}
+	return b.Complete(c)
+}
+
+func (c *clustersReconciler) clustersUsingClusterProfile(ctx context.Context, clusterProfile client.Object) []reconcile.Request {
+	users := &kueue.MultiKueueClusterList{}
+	if err := c.localClient.List(ctx, users, client.MatchingFields{UsingClusterProfiles: client.ObjectKeyFromObject(clusterProfile).String()}); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Listing the clusters using the ClusterProfile", "clusterProfile", klog.KObj(clusterProfile))
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(users.Items))
+	for _, user := range users.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: user.Name}})
+	}
+	return requests
 }
 
 type secretHandler struct {
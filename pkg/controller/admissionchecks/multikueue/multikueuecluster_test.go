@@ -19,6 +19,8 @@ package multikueue
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -26,16 +28,23 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/watch"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/resources"
 	"sigs.k8s.io/kueue/pkg/util/slices"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	testingjob "sigs.k8s.io/kueue/pkg/util/testingjobs/job"
@@ -97,6 +106,33 @@ func makeTestSecret(name string, kubeconfig string) corev1.Secret {
 	}
 }
 
+type fakeCredentialsProvider struct{}
+
+func (*fakeCredentialsProvider) KubeConfig(_ context.Context, clusterProfile *unstructured.Unstructured) ([]byte, error) {
+	return []byte(client.ObjectKeyFromObject(clusterProfile).String() + " kubeconfig"), nil
+}
+
+func makeTestClusterProfile(namespace, name string) *unstructured.Unstructured {
+	clusterProfile := &unstructured.Unstructured{}
+	clusterProfile.SetGroupVersionKind(clusterProfileGVK)
+	clusterProfile.SetNamespace(namespace)
+	clusterProfile.SetName(name)
+	return clusterProfile
+}
+
+func mustFlattenKubeConfig(t *testing.T, path string) []byte {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	flattened, _, err := flattenKubeConfig(content, filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return flattened
+}
+
 func TestUpdateConfig(t *testing.T) {
 	cancelCalledCount := 0
 	cancelCalled := func() { cancelCalledCount++ }
@@ -106,6 +142,12 @@ func TestUpdateConfig(t *testing.T) {
 		remoteClients map[string]*remoteClient
 		clusters      []kueue.MultiKueueCluster
 		secrets       []corev1.Secret
+		// clusterProfiles are the ClusterProfiles of the Cluster Inventory API.
+		clusterProfiles      []unstructured.Unstructured
+		enableClusterProfile bool
+		healthCheck          *configapi.MultiKueueHealthCheck
+		// now is the time of the reconciler clock, the current time if zero.
+		now time.Time
 
 		wantRemoteClients map[string]*remoteClient
 		wantClusters      []kueue.MultiKueueCluster
@@ -136,6 +178,40 @@ func TestUpdateConfig(t *testing.T) {
 				},
 			},
 		},
+		"new valid client is added and probed": {
+			reconcileFor: "worker1",
+			clusters: []kueue.MultiKueueCluster{
+				*utiltesting.MakeMultiKueueCluster("worker1").
+					KubeConfig(kueue.SecretLocationType, "worker1").
+					Generation(1).
+					Obj(),
+			},
+			secrets: []corev1.Secret{
+				makeTestSecret("worker1", "worker1 kubeconfig"),
+			},
+			healthCheck: &configapi.MultiKueueHealthCheck{
+				Interval:         &metav1.Duration{Duration: time.Minute},
+				Timeout:          &metav1.Duration{Duration: time.Second},
+				FailureThreshold: ptr.To[int32](3),
+				InitialBackoff:   &metav1.Duration{Duration: 30 * time.Second},
+				MaxBackoff:       &metav1.Duration{Duration: 10 * time.Minute},
+				CanaryNamespace:  ptr.To(TestNamespace),
+			},
+			wantClusters: []kueue.MultiKueueCluster{
+				*utiltesting.MakeMultiKueueCluster("worker1").
+					KubeConfig(kueue.SecretLocationType, "worker1").
+					Active(metav1.ConditionTrue, "Active", "Connected", 1).
+					Healthy(metav1.ConditionTrue, "Healthy", "The health probes succeeded", 1).
+					Generation(1).
+					Obj(),
+			},
+			wantRemoteClients: map[string]*remoteClient{
+				"worker1": {
+					kubeconfig: []byte("worker1 kubeconfig"),
+				},
+			},
+			wantRequeueAfter: time.Minute,
+		},
 		"update client with valid secret config": {
 			reconcileFor: "worker1",
 			clusters: []kueue.MultiKueueCluster{
@@ -164,6 +240,59 @@ func TestUpdateConfig(t *testing.T) {
 			},
 			wantCancelCalled: 1,
 		},
+		"active client reports the capacity": {
+			reconcileFor: "worker1",
+			clusters: []kueue.MultiKueueCluster{
+				*utiltesting.MakeMultiKueueCluster("worker1").
+					KubeConfig(kueue.SecretLocationType, "worker1").
+					Generation(1).
+					Obj(),
+			},
+			secrets: []corev1.Secret{
+				makeTestSecret("worker1", "worker1 kubeconfig"),
+			},
+			remoteClients: map[string]*remoteClient{
+				"worker1": func() *remoteClient {
+					rc := newTestClient(t.Context(), "worker1 kubeconfig", cancelCalled)
+					rc.capacity = map[kueue.ClusterQueueReference]kueue.MultiKueueClusterQueueCapacity{
+						"cq2": clusterQueueCapacity(utiltesting.MakeClusterQueue("cq2").Obj()),
+						"cq1": clusterQueueCapacity(utiltesting.MakeClusterQueue("cq1").
+							ResourceGroup(*utiltesting.MakeFlavorQuotas("f1").Resource(corev1.ResourceCPU, "4").Obj()).
+							PendingWorkloads(1).
+							Obj()),
+					}
+					return rc
+				}(),
+			},
+			wantClusters: []kueue.MultiKueueCluster{
+				func() kueue.MultiKueueCluster {
+					cluster := utiltesting.MakeMultiKueueCluster("worker1").
+						KubeConfig(kueue.SecretLocationType, "worker1").
+						Active(metav1.ConditionTrue, "Active", "Connected", 1).
+						Generation(1).
+						Obj()
+					cluster.Status.Capacity = &kueue.MultiKueueClusterCapacity{
+						ClusterQueues: []kueue.MultiKueueClusterQueueCapacity{
+							{
+								Name:             "cq1",
+								PendingWorkloads: 1,
+								Resources: []kueue.MultiKueueResourceCapacity{{
+									Name:         corev1.ResourceCPU,
+									NominalQuota: resource.MustParse("4"),
+								}},
+							},
+							{Name: "cq2"},
+						},
+					}
+					return *cluster
+				}(),
+			},
+			wantRemoteClients: map[string]*remoteClient{
+				"worker1": {
+					kubeconfig: []byte("worker1 kubeconfig"),
+				},
+			},
+		},
 		"update client with valid path config": {
 			reconcileFor: "worker1",
 			clusters: []kueue.MultiKueueCluster{
@@ -235,6 +364,113 @@ func TestUpdateConfig(t *testing.T) {
 			},
 			wantCancelCalled: 1,
 		},
+		"update client with expired token file": {
+			reconcileFor: "worker1",
+			clusters: []kueue.MultiKueueCluster{
+				*utiltesting.MakeMultiKueueCluster("worker1").
+					KubeConfig(kueue.PathLocationType, "testdata/expiredTokenKubeConfig").
+					Generation(1).
+					Obj(),
+			},
+			wantRemoteClients: map[string]*remoteClient{
+				"worker1": {
+					kubeconfig: mustFlattenKubeConfig(t, "testdata/expiredTokenKubeConfig"),
+				},
+			},
+			wantClusters: []kueue.MultiKueueCluster{
+				*utiltesting.MakeMultiKueueCluster("worker1").
+					KubeConfig(kueue.PathLocationType, "testdata/expiredTokenKubeConfig").
+					Active(metav1.ConditionTrue, "Active", "Connected", 1).
+					CredentialsValid(metav1.ConditionFalse, "Expired", "The credentials expired at 2001-09-09T01:46:40Z", 1).
+					Generation(1).
+					Obj(),
+			},
+		},
+		"update client with token file not yet expired": {
+			reconcileFor: "worker1",
+			now:          time.Unix(1_000_000_000, 0).Add(-time.Hour),
+			clusters: []kueue.MultiKueueCluster{
+				*utiltesting.MakeMultiKueueCluster("worker1").
+					KubeConfig(kueue.PathLocationType, "testdata/expiredTokenKubeConfig").
+					Generation(1).
+					Obj(),
+			},
+			wantRemoteClients: map[string]*remoteClient{
+				"worker1": {
+					kubeconfig: mustFlattenKubeConfig(t, "testdata/expiredTokenKubeConfig"),
+				},
+			},
+			wantClusters: []kueue.MultiKueueCluster{
+				*utiltesting.MakeMultiKueueCluster("worker1").
+					KubeConfig(kueue.PathLocationType, "testdata/expiredTokenKubeConfig").
+					Active(metav1.ConditionTrue, "Active", "Connected", 1).
+					CredentialsValid(metav1.ConditionTrue, "Valid", "The credentials expire at 2001-09-09T01:46:40Z", 1).
+					Generation(1).
+					Obj(),
+			},
+			wantRequeueAfter: time.Hour,
+		},
+		"new client using a cluster profile": {
+			reconcileFor:         "worker1",
+			enableClusterProfile: true,
+			clusters: []kueue.MultiKueueCluster{
+				*utiltesting.MakeMultiKueueCluster("worker1").
+					ClusterProfile("fleet", "worker1").
+					Generation(1).
+					Obj(),
+			},
+			clusterProfiles: []unstructured.Unstructured{
+				*makeTestClusterProfile("fleet", "worker1"),
+			},
+			wantRemoteClients: map[string]*remoteClient{
+				"worker1": {
+					kubeconfig: []byte("fleet/worker1 kubeconfig"),
+				},
+			},
+			wantClusters: []kueue.MultiKueueCluster{
+				*utiltesting.MakeMultiKueueCluster("worker1").
+					ClusterProfile("fleet", "worker1").
+					Active(metav1.ConditionTrue, "Active", "Connected", 1).
+					Generation(1).
+					Obj(),
+			},
+		},
+		"missing cluster profile": {
+			reconcileFor:         "worker1",
+			enableClusterProfile: true,
+			clusters: []kueue.MultiKueueCluster{
+				*utiltesting.MakeMultiKueueCluster("worker1").
+					ClusterProfile("fleet", "worker1").
+					Generation(1).
+					Obj(),
+			},
+			wantClusters: []kueue.MultiKueueCluster{
+				*utiltesting.MakeMultiKueueCluster("worker1").
+					ClusterProfile("fleet", "worker1").
+					Active(metav1.ConditionFalse, "BadConfig", `clusterprofiles.multicluster.x-k8s.io "worker1" not found`, 1).
+					Generation(1).
+					Obj(),
+			},
+		},
+		"cluster profile with the feature gate disabled": {
+			reconcileFor: "worker1",
+			clusters: []kueue.MultiKueueCluster{
+				*utiltesting.MakeMultiKueueCluster("worker1").
+					ClusterProfile("fleet", "worker1").
+					Generation(1).
+					Obj(),
+			},
+			clusterProfiles: []unstructured.Unstructured{
+				*makeTestClusterProfile("fleet", "worker1"),
+			},
+			wantClusters: []kueue.MultiKueueCluster{
+				*utiltesting.MakeMultiKueueCluster("worker1").
+					ClusterProfile("fleet", "worker1").
+					Active(metav1.ConditionFalse, "BadConfig", "the MultiKueueClusterProfile feature gate is disabled", 1).
+					Generation(1).
+					Obj(),
+			},
+		},
 		"missing cluster is removed": {
 			reconcileFor: "worker2",
 			clusters: []kueue.MultiKueueCluster{
@@ -376,6 +612,9 @@ func TestUpdateConfig(t *testing.T) {
 			builder := getClientBuilder(t.Context())
 			builder = builder.WithLists(&kueue.MultiKueueClusterList{Items: tc.clusters})
 			builder = builder.WithLists(&corev1.SecretList{Items: tc.secrets})
+			for i := range tc.clusterProfiles {
+				builder = builder.WithObjects(&tc.clusterProfiles[i])
+			}
 			builder = builder.WithStatusSubresource(slices.Map(tc.clusters, func(c *kueue.MultiKueueCluster) client.Object { return c })...)
 			c := builder.Build()
 
@@ -383,6 +622,14 @@ func TestUpdateConfig(t *testing.T) {
 			reconciler := newClustersReconciler(c, TestNamespace, 0, defaultOrigin, nil, adapters)
 
 			reconciler.rootContext = t.Context()
+			reconciler.credentialsProvider = &fakeCredentialsProvider{}
+			reconciler.healthCheck = tc.healthCheck
+			now := tc.now
+			if now.IsZero() {
+				now = time.Now()
+			}
+			reconciler.clock = testingclock.NewFakeClock(now)
+			features.SetFeatureGateDuringTest(t, features.MultiKueueClusterProfile, tc.enableClusterProfile)
 
 			if len(tc.remoteClients) > 0 {
 				reconciler.remoteClients = tc.remoteClients
@@ -409,7 +656,7 @@ func TestUpdateConfig(t *testing.T) {
 				t.Errorf("unexpected list clusters error: %s", gotErr)
 			}
 
-			if diff := cmp.Diff(tc.wantClusters, lst.Items, cmpopts.EquateEmpty(),
+			if diff := cmp.Diff(tc.wantClusters, lst.Items, cmpopts.EquateEmpty(), cmp.Comparer(func(a, b resource.Quantity) bool { return a.Cmp(b) == 0 }),
 				cmpopts.IgnoreFields(metav1.ObjectMeta{}, "ResourceVersion"),
 				cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime")); diff != "" {
 				t.Errorf("unexpected clusters (-want/+got):\n%s", diff)
@@ -569,3 +816,85 @@ func TestRemoteClientGC(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterQueueCapacity(t *testing.T) {
+	cq := utiltesting.MakeClusterQueue("cq").
+		Cohort("cohort").
+		ResourceGroup(
+			*utiltesting.MakeFlavorQuotas("f1").Resource(corev1.ResourceCPU, "4").Resource(corev1.ResourceMemory, "4Gi").Obj(),
+			*utiltesting.MakeFlavorQuotas("f2").Resource(corev1.ResourceCPU, "2").Resource(corev1.ResourceMemory, "2Gi").Obj(),
+		).
+		PendingWorkloads(3).
+		Obj()
+	cq.Status.FlavorsReservation = []kueue.FlavorUsage{
+		{
+			Name: "f1",
+			Resources: []kueue.ResourceUsage{
+				{Name: corev1.ResourceCPU, Total: resource.MustParse("3")},
+				{Name: corev1.ResourceMemory, Total: resource.MustParse("1Gi")},
+			},
+		},
+		{
+			Name: "f2",
+			Resources: []kueue.ResourceUsage{
+				{Name: corev1.ResourceCPU, Total: resource.MustParse("1500m")},
+			},
+		},
+	}
+	want := kueue.MultiKueueClusterQueueCapacity{
+		Name:             "cq",
+		Cohort:           "cohort",
+		PendingWorkloads: 3,
+		Resources: []kueue.MultiKueueResourceCapacity{
+			{Name: corev1.ResourceCPU, NominalQuota: resource.MustParse("6"), Reserved: resource.MustParse("4500m")},
+			{Name: corev1.ResourceMemory, NominalQuota: resource.MustParse("6Gi"), Reserved: resource.MustParse("1Gi")},
+		},
+	}
+
+	rc := newTrackingRemoteClient("worker1")
+	if !rc.updateCapacity(cq, false) {
+		t.Errorf("Expected the capacity to change when the ClusterQueue is added")
+	}
+	if rc.updateCapacity(cq, false) {
+		t.Errorf("Unexpected capacity change when the ClusterQueue is not modified")
+	}
+	got, found := rc.clusterQueueCapacity("cq")
+	if !found {
+		t.Fatalf("The capacity of the ClusterQueue is not found")
+	}
+	if diff := cmp.Diff(want, got, cmp.Comparer(func(a, b resource.Quantity) bool { return a.Cmp(b) == 0 })); diff != "" {
+		t.Errorf("Unexpected capacity (-want/+got):\n%s", diff)
+	}
+	wantQuotas := resources.FlavorResourceQuantities{
+		{Flavor: "f1", Resource: corev1.ResourceCPU}:    4_000,
+		{Flavor: "f1", Resource: corev1.ResourceMemory}: 4 * 1024 * 1024 * 1024,
+		{Flavor: "f2", Resource: corev1.ResourceCPU}:    2_000,
+		{Flavor: "f2", Resource: corev1.ResourceMemory}: 2 * 1024 * 1024 * 1024,
+	}
+	gotQuotas, _ := rc.clusterQueueQuotas("cq")
+	if diff := cmp.Diff(wantQuotas, gotQuotas); diff != "" {
+		t.Errorf("Unexpected quotas (-want/+got):\n%s", diff)
+	}
+	wantReserved := resources.FlavorResourceQuantities{
+		{Flavor: "f1", Resource: corev1.ResourceCPU}:    3_000,
+		{Flavor: "f1", Resource: corev1.ResourceMemory}: 1024 * 1024 * 1024,
+		{Flavor: "f2", Resource: corev1.ResourceCPU}:    1_500,
+	}
+	if diff := cmp.Diff(wantReserved, rc.clusterQueueReserved("cq")); diff != "" {
+		t.Errorf("Unexpected reserved quotas (-want/+got):\n%s", diff)
+	}
+	rc.updateLocalQueue(utiltesting.MakeLocalQueue("lq", TestNamespace).ClusterQueue("cq").Obj(), false)
+	if gotCQ, _ := rc.localQueueClusterQueue(types.NamespacedName{Namespace: TestNamespace, Name: "lq"}); gotCQ != "cq" {
+		t.Errorf("Unexpected ClusterQueue of the LocalQueue, want cq, got %q", gotCQ)
+	}
+	rc.updateLocalQueue(utiltesting.MakeLocalQueue("lq", TestNamespace).ClusterQueue("cq").Obj(), true)
+	if _, found := rc.localQueueClusterQueue(types.NamespacedName{Namespace: TestNamespace, Name: "lq"}); found {
+		t.Errorf("Unexpected ClusterQueue of the deleted LocalQueue")
+	}
+	if !rc.updateCapacity(cq, true) {
+		t.Errorf("Expected the capacity to change when the ClusterQueue is deleted")
+	}
+	if diff := cmp.Diff(&kueue.MultiKueueClusterCapacity{}, rc.capacitySummary()); diff != "" {
+		t.Errorf("Unexpected capacity summary (-want/+got):\n%s", diff)
+	}
+}
@@ -18,8 +18,12 @@ package multikueue
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -27,11 +31,14 @@ import (
 	"k8s.io/apimachinery/pkg/api/equality"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -40,7 +47,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
 	"sigs.k8s.io/kueue/pkg/util/admissioncheck"
 	"sigs.k8s.io/kueue/pkg/util/api"
@@ -64,6 +73,9 @@ type wlReconciler struct {
 	adapters          map[string]jobframework.MultiKueueAdapter
 	recorder          record.EventRecorder
 	clock             clock.Clock
+	dispatcher        Dispatcher
+	workerLostPolicy  configapi.MultiKueueWorkerLostPolicy
+	mirror            *statusMirror
 }
 
 var _ reconcile.Reconciler = (*wlReconciler)(nil)
@@ -75,16 +87,23 @@ type wlGroup struct {
 	acName        kueue.AdmissionCheckReference
 	jobAdapter    jobframework.MultiKueueAdapter
 	controllerKey types.NamespacedName
+	controllerGVK schema.GroupVersionKind
+	controllerUID types.UID
 }
 
 type options struct {
-	clock clock.Clock
+	clock            clock.Clock
+	dispatcher       Dispatcher
+	workerLostPolicy configapi.MultiKueueWorkerLostPolicy
+	statusMirroring  *configapi.MultiKueueStatusMirroring
 }
 
 type Option func(*options)
 
 var defaultOptions = options{
-	clock: realClock,
+	clock:            realClock,
+	dispatcher:       &AllAtOnceDispatcher{},
+	workerLostPolicy: configapi.MultiKueueWorkerLostPolicyRequeue,
 }
 
 func WithClock(_ testing.TB, c clock.Clock) Option {
@@ -93,6 +112,24 @@ func WithClock(_ testing.TB, c clock.Clock) Option {
 	}
 }
 
+func withDispatcher(d Dispatcher) Option {
+	return func(o *options) {
+		o.dispatcher = d
+	}
+}
+
+func withWorkerLostPolicy(p configapi.MultiKueueWorkerLostPolicy) Option {
+	return func(o *options) {
+		o.workerLostPolicy = p
+	}
+}
+
+func withStatusMirroring(m *configapi.MultiKueueStatusMirroring) Option {
+	return func(o *options) {
+		o.statusMirroring = m
+	}
+}
+
 // IsFinished returns true if the local workload is finished.
 func (g *wlGroup) IsFinished() bool {
 	return apimeta.IsStatusConditionTrue(g.local.Status.Conditions, kueue.WorkloadFinished)
@@ -134,14 +171,30 @@ func (g *wlGroup) RemoteFinishedCondition() (*metav1.Condition, string) {
 }
 
 func (g *wlGroup) RemoveRemoteObjects(ctx context.Context, cluster string) error {
-	remWl := g.remotes[cluster]
-	if remWl == nil {
+	if g.remotes[cluster] == nil {
 		return nil
 	}
+	if err := g.RemoveRemoteController(ctx, cluster); err != nil {
+		return err
+	}
+	return g.RemoveRemoteWorkload(ctx, cluster)
+}
+
+// RemoveRemoteController deletes the remote controller object in cluster.
+func (g *wlGroup) RemoveRemoteController(ctx context.Context, cluster string) error {
 	if err := g.jobAdapter.DeleteRemoteObject(ctx, g.remoteClients[cluster].client, g.controllerKey); err != nil {
 		return fmt.Errorf("deleting remote controller object: %w", err)
 	}
+	return nil
+}
 
+// RemoveRemoteWorkload deletes the remote workload in cluster, keeping the
+// remote controller object.
+func (g *wlGroup) RemoveRemoteWorkload(ctx context.Context, cluster string) error {
+	remWl := g.remotes[cluster]
+	if remWl == nil {
+		return nil
+	}
 	if controllerutil.RemoveFinalizer(remWl, kueue.ResourceInUseFinalizerName) {
 		if err := g.remoteClients[cluster].client.Update(ctx, remWl); err != nil {
 			return fmt.Errorf("removing remote workloads finalizer: %w", err)
@@ -167,6 +220,9 @@ func (w *wlReconciler) Reconcile(ctx context.Context, req reconcile.Request) (re
 	case client.IgnoreNotFound(err) != nil:
 		return reconcile.Result{}, err
 	case err != nil:
+		if w.mirror != nil {
+			w.mirror.forget(req.NamespacedName)
+		}
 		oldWl, found := w.deletedWlCache.Get(req.String())
 		if !found {
 			return reconcile.Result{}, nil
@@ -222,15 +278,19 @@ func (w *wlReconciler) Reconcile(ctx context.Context, req reconcile.Request) (re
 		}
 	}
 
-	grp, err := w.readGroup(ctx, wl, mkAc.Name, adapter, owner.Name)
+	grp, err := w.readGroup(ctx, wl, mkAc.Name, adapter, owner)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
 	if isDeleted {
 		for cluster := range grp.remotes {
-			err := grp.RemoveRemoteObjects(ctx, cluster)
-			if err != nil {
+			// The remote controller object is kept without a remote workload
+			// while the workload is resized.
+			if err := grp.RemoveRemoteController(ctx, cluster); err != nil {
+				return reconcile.Result{}, err
+			}
+			if err := grp.RemoveRemoteWorkload(ctx, cluster); err != nil {
 				return reconcile.Result{}, err
 			}
 		}
@@ -295,7 +355,7 @@ func (w *wlReconciler) adapter(local *kueue.Workload) (jobframework.MultiKueueAd
 	return nil, nil
 }
 
-func (w *wlReconciler) readGroup(ctx context.Context, local *kueue.Workload, acName kueue.AdmissionCheckReference, adapter jobframework.MultiKueueAdapter, controllerName string) (*wlGroup, error) {
+func (w *wlReconciler) readGroup(ctx context.Context, local *kueue.Workload, acName kueue.AdmissionCheckReference, adapter jobframework.MultiKueueAdapter, owner *metav1.OwnerReference) (*wlGroup, error) {
 	rClients, err := w.remoteClientsForAC(ctx, acName)
 	if err != nil {
 		return nil, fmt.Errorf("admission check %q: %w", acName, err)
@@ -307,7 +367,9 @@ func (w *wlReconciler) readGroup(ctx context.Context, local *kueue.Workload, acN
 		remoteClients: rClients,
 		acName:        acName,
 		jobAdapter:    adapter,
-		controllerKey: types.NamespacedName{Name: controllerName, Namespace: local.Namespace},
+		controllerKey: types.NamespacedName{Name: owner.Name, Namespace: local.Namespace},
+		controllerGVK: schema.FromAPIVersionAndKind(owner.APIVersion, owner.Kind),
+		controllerUID: owner.UID,
 	}
 
 	for remote, rClient := range rClients {
@@ -330,15 +392,30 @@ func (w *wlReconciler) reconcileGroup(ctx context.Context, group *wlGroup) (reco
 
 	acs := workload.FindAdmissionCheck(group.local.Status.AdmissionChecks, group.acName)
 
-	// 1. delete all remote workloads when finished or the local wl has no reservation
+	// 1. delete all remote workloads when finished or the local wl has no reservation,
+	// the remote controller objects are kept while the workload is resized
 	if group.IsFinished() || !workload.HasQuotaReservation(group.local) {
+		remove := group.RemoveRemoteObjects
+		if !group.IsFinished() && jobframework.IsEvictedByMultiKueueResize(group.local) {
+			remove = group.RemoveRemoteWorkload
+		}
 		var errs []error
 		for rem := range group.remotes {
-			if err := group.RemoveRemoteObjects(ctx, rem); err != nil {
+			if err := remove(ctx, rem); err != nil {
 				errs = append(errs, err)
 				log.V(2).Error(err, "Deleting remote workload", "workerCluster", rem)
 			}
 		}
+		if w.mirror != nil {
+			if err := w.mirror.clear(ctx, group.local); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if !group.IsFinished() && workload.MultiKueueWorkerCluster(group.local) != "" {
+			if err := patchAnnotation(ctx, w.client, group.local, kueue.MultiKueueReservingClusterAnnotation, nil); err != nil {
+				errs = append(errs, err)
+			}
+		}
 		return reconcile.Result{}, errors.Join(errs...)
 	}
 
@@ -367,6 +444,12 @@ func (w *wlReconciler) reconcileGroup(ctx context.Context, group *wlGroup) (reco
 		return reconcile.Result{}, w.client.Status().Patch(ctx, wlPatch, client.Apply, client.FieldOwner(kueue.MultiKueueControllerName+"-finish"), client.ForceOwnership)
 	}
 
+	// delete the stale remote objects in the lost worker clusters which reconnected
+	if err := w.removeFromLostClusters(ctx, group); err != nil {
+		log.V(2).Error(err, "Deleting the remote objects in the lost worker clusters")
+		return reconcile.Result{}, err
+	}
+
 	// 2. delete all workloads that are out of sync or are not in the chosen worker
 	for rem, remWl := range group.remotes {
 		if remWl != nil && !equality.Semantic.DeepEqual(group.local.Spec, remWl.Spec) {
@@ -380,6 +463,8 @@ func (w *wlReconciler) reconcileGroup(ctx context.Context, group *wlGroup) (reco
 
 	// 3. get the first reserving
 	hasReserving, reservingRemote := group.FirstReserving()
+	// the time left to migrate the workload to another worker cluster, if migrating
+	var migrateRemaining time.Duration
 	if hasReserving {
 		// remove the non-reserving worker workloads
 		for rem, remWl := range group.remotes {
@@ -393,6 +478,18 @@ func (w *wlReconciler) reconcileGroup(ctx context.Context, group *wlGroup) (reco
 		}
 
 		acs := workload.FindAdmissionCheck(group.local.Status.AdmissionChecks, group.acName)
+		if acs.State != kueue.CheckStateReady {
+			// remove the remote controller objects kept in the other worker clusters
+			// while the workload was resized
+			for rem, remWl := range group.remotes {
+				if remWl == nil && rem != reservingRemote {
+					if err := group.RemoveRemoteController(ctx, rem); err != nil {
+						log.V(2).Error(err, "Deleting stale remote controller object", "remote", rem)
+						return reconcile.Result{}, err
+					}
+				}
+			}
+		}
 		if err := group.jobAdapter.SyncJob(ctx, w.client, group.remoteClients[reservingRemote].client, group.controllerKey, group.local.Name, w.origin); err != nil {
 			log.V(2).Error(err, "creating remote controller object", "remote", reservingRemote)
 			// We'll retry this in the next reconcile.
@@ -405,8 +502,13 @@ func (w *wlReconciler) reconcileGroup(ctx context.Context, group *wlGroup) (reco
 			} else {
 				acs.State = kueue.CheckStateReady
 			}
+			if workload.MultiKueueWorkerCluster(group.local) != reservingRemote {
+				if err := patchAnnotation(ctx, w.client, group.local, kueue.MultiKueueReservingClusterAnnotation, &reservingRemote); err != nil {
+					return reconcile.Result{}, err
+				}
+			}
 			// update the message
-			acs.Message = fmt.Sprintf("The workload got reservation on %q", reservingRemote)
+			acs.Message = workload.MultiKueueReservationMessage(reservingRemote)
 			// update the transition time since is used to detect the lost worker state.
 			acs.LastTransitionTime = metav1.NewTime(w.clock.Now())
 
@@ -418,15 +520,31 @@ func (w *wlReconciler) reconcileGroup(ctx context.Context, group *wlGroup) (reco
 			}
 			w.recorder.Eventf(wlPatch, corev1.EventTypeNormal, "MultiKueue", acs.Message)
 		}
-		return reconcile.Result{RequeueAfter: w.workerLostTimeout}, nil
+		requeueAfter := w.workerLostTimeout
+		if w.mirror != nil {
+			mirrorAfter, err := w.mirror.sync(ctx, group, reservingRemote)
+			if err != nil {
+				log.V(2).Error(err, "Mirroring the remote status", "remote", reservingRemote)
+				return reconcile.Result{}, err
+			}
+			requeueAfter = min(requeueAfter, mirrorAfter)
+		}
+		return reconcile.Result{RequeueAfter: requeueAfter}, nil
 	} else if acs.State == kueue.CheckStateReady {
 		// If there is no reserving and the AC is ready, the connection with the reserving remote might
 		// be lost, keep the workload admitted for keepReadyTimeout and put it back in the queue after that.
-		remainingWaitTime := w.workerLostTimeout - time.Since(acs.LastTransitionTime.Time)
+		lostFor := w.clock.Since(acs.LastTransitionTime.Time)
+		remainingWaitTime := w.workerLostTimeout - lostFor
 		if remainingWaitTime > 0 {
 			log.V(3).Info("Reserving remote lost, retry", "retryAfter", remainingWaitTime)
 			return reconcile.Result{RequeueAfter: remainingWaitTime}, nil
-		} else {
+		}
+		// The workload is only migrated if its reserving worker cluster is disconnected, and
+		// is put back in the queue if no other worker cluster reserves it within another
+		// workerLostTimeout.
+		lostCluster := workload.MultiKueueWorkerCluster(group.local)
+		_, connected := group.remoteClients[lostCluster]
+		if !w.canMigrate(group.local) || lostCluster == "" || connected || lostFor >= 2*w.workerLostTimeout {
 			acs.State = kueue.CheckStateRetry
 			acs.Message = "Reserving remote lost"
 			acs.LastTransitionTime = metav1.NewTime(w.clock.Now())
@@ -434,14 +552,62 @@ func (w *wlReconciler) reconcileGroup(ctx context.Context, group *wlGroup) (reco
 			workload.SetAdmissionCheckState(&wlPatch.Status.AdmissionChecks, *acs, w.clock)
 			return reconcile.Result{}, w.client.Status().Patch(ctx, wlPatch, client.Apply, client.FieldOwner(kueue.MultiKueueControllerName), client.ForceOwnership)
 		}
+		// Keep the quota reservation and dispatch the workload to another worker cluster.
+		log.V(3).Info("Reserving remote lost, migrate", "lostCluster", lostCluster)
+		if err := w.addLostCluster(ctx, group, lostCluster); err != nil {
+			return reconcile.Result{}, err
+		}
+		migrateRemaining = 2*w.workerLostTimeout - lostFor
+	}
+
+	// 4. select the workers to dispatch to, and delete the workloads in the other ones
+	dispatched := sets.New[string]()
+	dispatchTimes := make(map[string]time.Time)
+	for rem, remWl := range group.remotes {
+		if remWl != nil {
+			dispatched.Insert(rem)
+			dispatchTimes[rem] = remWl.CreationTimestamp.Time
+		}
+	}
+	// the unhealthy worker clusters are left out until they recover
+	healthy := make([]string, 0, len(group.remoteClients))
+	for _, rem := range slices.Sorted(maps.Keys(group.remoteClients)) {
+		if group.remoteClients[rem].healthy() {
+			healthy = append(healthy, rem)
+		}
+	}
+	nominated, requeueAfter := w.dispatcher.Dispatch(ctx, DispatchInput{
+		Workload:      group.local,
+		Clusters:      healthy,
+		Dispatched:    dispatched,
+		DispatchTimes: dispatchTimes,
+		Now:           w.clock.Now(),
+	})
+	if len(healthy) < len(group.remoteClients) {
+		if retryAfter := w.clusters.unhealthyRetryInterval(); requeueAfter == 0 || retryAfter < requeueAfter {
+			requeueAfter = retryAfter
+		}
+	}
+	if migrateRemaining > 0 && (requeueAfter == 0 || migrateRemaining < requeueAfter) {
+		requeueAfter = migrateRemaining
+	}
+	log.V(3).Info("Dispatching the workload", "workerClusters", nominated, "requeueAfter", requeueAfter)
+	nominatedSet := sets.New(nominated...)
+	for rem := range dispatched {
+		if !nominatedSet.Has(rem) {
+			if err := group.RemoveRemoteObjects(ctx, rem); err != nil {
+				log.V(2).Error(err, "Deleting remote objects not dispatched", "remote", rem)
+				return reconcile.Result{}, err
+			}
+		}
 	}
 
 	// finally - create missing workloads
 	var errs []error
-	for rem, remWl := range group.remotes {
-		if remWl == nil {
-			clone := cloneForCreate(group.local, group.remoteClients[rem].origin)
-			err := group.remoteClients[rem].client.Create(ctx, clone)
+	for _, rem := range nominated {
+		if rClient, found := group.remoteClients[rem]; found && group.remotes[rem] == nil {
+			clone := cloneForCreate(group.local, rClient.origin)
+			err := rClient.client.Create(ctx, clone)
 			if err != nil {
 				// just log the error for a single remote
 				log.V(2).Error(err, "creating remote object", "remote", rem)
@@ -449,7 +615,84 @@ func (w *wlReconciler) reconcileGroup(ctx context.Context, group *wlGroup) (reco
 			}
 		}
 	}
-	return reconcile.Result{}, errors.Join(errs...)
+	return reconcile.Result{RequeueAfter: requeueAfter}, errors.Join(errs...)
+}
+
+// canMigrate returns true if the workload is dispatched to another worker
+// cluster when its reserving worker cluster is lost.
+func (w *wlReconciler) canMigrate(wl *kueue.Workload) bool {
+	return w.workerLostPolicy == configapi.MultiKueueWorkerLostPolicyMigrate && wl.Annotations[constants.CheckpointableAnnotation] == "true"
+}
+
+// addLostCluster records the worker cluster which can't be reached as lost
+// in the local workload, and in its job which is created with the migration
+// annotation in the next worker cluster.
+func (w *wlReconciler) addLostCluster(ctx context.Context, group *wlGroup, cluster string) error {
+	lost := lostClusters(group.local)
+	if lost.Has(cluster) {
+		return nil
+	}
+	lost.Insert(cluster)
+	value := strings.Join(sets.List(lost), ",")
+	job := &unstructured.Unstructured{}
+	job.SetGroupVersionKind(group.controllerGVK)
+	job.SetName(group.controllerKey.Name)
+	job.SetNamespace(group.controllerKey.Namespace)
+	if err := patchAnnotation(ctx, w.client, job, kueue.MultiKueueMigratedFromAnnotation, &value); err != nil {
+		return fmt.Errorf("annotating the job: %w", err)
+	}
+	if err := patchAnnotation(ctx, w.client, group.local, kueue.MultiKueueLostClustersAnnotation, &value); err != nil {
+		return err
+	}
+	w.recorder.Eventf(group.local, corev1.EventTypeNormal, "MultiKueue", "Migrating the workload from the lost worker clusters %s", value)
+	return nil
+}
+
+// removeFromLostClusters deletes the stale remote objects in the lost worker
+// clusters which reconnected, and removes them from the lost clusters.
+func (w *wlReconciler) removeFromLostClusters(ctx context.Context, group *wlGroup) error {
+	lost := lostClusters(group.local)
+	if lost.Len() == 0 {
+		return nil
+	}
+	for _, cluster := range sets.List(lost) {
+		if _, connected := group.remotes[cluster]; !connected {
+			continue
+		}
+		if err := group.RemoveRemoteObjects(ctx, cluster); err != nil {
+			return err
+		}
+		lost.Delete(cluster)
+	}
+	if lost.Equal(lostClusters(group.local)) {
+		return nil
+	}
+	var value *string
+	if lost.Len() > 0 {
+		value = ptr.To(strings.Join(sets.List(lost), ","))
+	}
+	return patchAnnotation(ctx, w.client, group.local, kueue.MultiKueueLostClustersAnnotation, value)
+}
+
+func lostClusters(wl *kueue.Workload) sets.Set[string] {
+	lost := sets.New[string]()
+	if value := wl.Annotations[kueue.MultiKueueLostClustersAnnotation]; value != "" {
+		lost.Insert(strings.Split(value, ",")...)
+	}
+	return lost
+}
+
+// patchAnnotation sets the annotation of the object, or removes it if value is nil.
+func patchAnnotation(ctx context.Context, c client.Client, obj client.Object, key string, value *string) error {
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]*string{key: value},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return c.Patch(ctx, obj, client.RawPatch(types.MergePatchType, patch))
 }
 
 func (w *wlReconciler) Create(_ event.CreateEvent) bool {
@@ -489,6 +732,9 @@ func newWlReconciler(c client.Client, helper *multiKueueStoreHelper, cRec *clust
 		adapters:          adapters,
 		recorder:          recorder,
 		clock:             options.clock,
+		dispatcher:        options.dispatcher,
+		workerLostPolicy:  options.workerLostPolicy,
+		mirror:            newStatusMirror(c, recorder, options.clock, options.statusMirroring),
 	}
 }
 
@@ -517,6 +763,7 @@ func cloneForCreate(orig *kueue.Workload, origin string) *kueue.Workload {
 		remoteWl.Labels = make(map[string]string)
 	}
 	remoteWl.Labels[kueue.MultiKueueOriginLabel] = origin
+	delete(remoteWl.Annotations, kueue.MultiKueueReservingClusterAnnotation)
 	orig.Spec.DeepCopyInto(&remoteWl.Spec)
 	return remoteWl
 }
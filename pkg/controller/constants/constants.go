@@ -46,4 +46,25 @@ const (
 
 	// MaxExecTimeSecondsLabel is the label key in the job that holds the maximum execution time.
 	MaxExecTimeSecondsLabel = `kueue.x-k8s.io/max-exec-time-seconds`
+
+	// SubmittedByAnnotation is the annotation key in the job and the workload
+	// that holds the user or service account which submitted the job.
+	// It is set by Kueue when the job is created, and it is immutable.
+	SubmittedByAnnotation = "kueue.x-k8s.io/submitted-by"
+
+	// CheckpointableAnnotation is the annotation key in the job and the workload
+	// which indicates, when set to "true", that the job can be stopped and resumed
+	// from a checkpoint, so that the TAS defragmentation can evict it.
+	CheckpointableAnnotation = "kueue.x-k8s.io/checkpointable"
+
+	// ManagedByLabel is the label key of the objects without a managedBy field,
+	// like Deployments, StatefulSets and LeaderWorkerSets, holding the name of
+	// the controller managing the object. When set to kueue.x-k8s.io/multikueue
+	// the object is dispatched as a whole to a MultiKueue worker cluster.
+	ManagedByLabel = "kueue.x-k8s.io/managed-by"
+
+	// MultiKueueSchedulingGate is the scheduling gate added to the Pods of the
+	// objects dispatched as a whole by MultiKueue, to prevent them from running
+	// in the manager cluster.
+	MultiKueueSchedulingGate = "kueue.x-k8s.io/multikueue"
 )
@@ -53,6 +53,7 @@ import (
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/metrics"
 	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/resources"
 	"sigs.k8s.io/kueue/pkg/util/resource"
 	utilslices "sigs.k8s.io/kueue/pkg/util/slices"
 	"sigs.k8s.io/kueue/pkg/workload"
@@ -231,6 +232,23 @@ func (r *ClusterQueueReconciler) NotifyTopologyUpdate(oldTopology, newTopology *
 	}
 }
 
+// NotifyNodeCapacityUpdate signals the controller to reconcile the
+// ClusterQueues whose nominal quotas changed with the capacity of the nodes.
+func (r *ClusterQueueReconciler) NotifyNodeCapacityUpdate(cqNames sets.Set[kueue.ClusterQueueReference]) {
+	r.nonCQObjectUpdateCh <- event.TypedGenericEvent[iter.Seq[kueue.ClusterQueueReference]]{
+		Object: slices.Values(sets.List(cqNames)),
+	}
+}
+
+// NotifyFederatedQuotaUpdate signals the controller to reconcile the
+// ClusterQueues whose nominal quotas changed with the quotas of the
+// MultiKueue worker clusters.
+func (r *ClusterQueueReconciler) NotifyFederatedQuotaUpdate(cqNames sets.Set[kueue.ClusterQueueReference]) {
+	r.nonCQObjectUpdateCh <- event.TypedGenericEvent[iter.Seq[kueue.ClusterQueueReference]]{
+		Object: slices.Values(sets.List(cqNames)),
+	}
+}
+
 // NotifyWorkloadUpdate signals the controller to reconcile the ClusterQueue
 // associated to the workload in the event.
 func (r *ClusterQueueReconciler) NotifyWorkloadUpdate(oldWl, newWl *kueue.Workload) {
@@ -380,6 +398,14 @@ func (r *ClusterQueueReconciler) Generic(e event.TypedGenericEvent[*kueue.Cluste
 }
 
 func recordResourceMetrics(cq *kueue.ClusterQueue) {
+	effectiveQuotas := make(map[resources.FlavorResource]float64)
+	for fi := range cq.Status.OvercommittedQuotas {
+		fq := &cq.Status.OvercommittedQuotas[fi]
+		for ri := range fq.Resources {
+			r := &fq.Resources[ri]
+			effectiveQuotas[resources.FlavorResource{Flavor: fq.Name, Resource: r.Name}] = resource.QuantityToFloat(&r.EffectiveNominalQuota)
+		}
+	}
 	for rgi := range cq.Spec.ResourceGroups {
 		rg := &cq.Spec.ResourceGroups[rgi]
 		for fqi := range rg.Flavors {
@@ -390,10 +416,24 @@ func recordResourceMetrics(cq *kueue.ClusterQueue) {
 				borrow := resource.QuantityToFloat(r.BorrowingLimit)
 				lend := resource.QuantityToFloat(r.LendingLimit)
 				metrics.ReportClusterQueueQuotas(cq.Spec.Cohort, cq.Name, string(fq.Name), string(r.Name), nominal, borrow, lend)
+				if features.Enabled(features.FlavorOvercommit) {
+					effective, found := effectiveQuotas[resources.FlavorResource{Flavor: fq.Name, Resource: r.Name}]
+					if !found {
+						effective = nominal
+					}
+					metrics.ReportClusterQueueEffectiveNominalQuota(cq.Spec.Cohort, cq.Name, string(fq.Name), string(r.Name), effective)
+				}
 			}
 		}
 	}
 
+	if cq.Spec.MaxAdmittedWorkloads != nil && features.Enabled(features.MaxAdmittedWorkloads) {
+		metrics.ReportClusterQueueMaxAdmittedWorkloads(cq.Spec.Cohort, cq.Name, float64(*cq.Spec.MaxAdmittedWorkloads))
+	}
+	if cq.Status.BorrowedWorkloads != nil {
+		metrics.ReportClusterQueueBorrowedWorkloads(cq.Spec.Cohort, cq.Name, float64(*cq.Status.BorrowedWorkloads))
+	}
+
 	for fri := range cq.Status.FlavorsReservation {
 		fr := &cq.Status.FlavorsReservation[fri]
 		for ri := range fr.Resources {
@@ -423,6 +463,10 @@ func updateResourceMetrics(oldCq, newCq *kueue.ClusterQueue) {
 }
 
 func clearOldResourceQuotas(oldCq, newCq *kueue.ClusterQueue) {
+	if newCq.Spec.MaxAdmittedWorkloads == nil || newCq.Status.BorrowedWorkloads == nil {
+		metrics.ClearClusterQueueWorkloadCountMetrics(oldCq.Name)
+	}
+
 	for rgi := range oldCq.Spec.ResourceGroups {
 		oldRG := &oldCq.Spec.ResourceGroups[rgi]
 		newFlavors := map[kueue.ResourceFlavorReference]*kueue.FlavorQuotas{}
@@ -617,7 +661,15 @@ func (r *ClusterQueueReconciler) updateCqStatusIfChanged(
 	cq.Status.FlavorsUsage = stats.AdmittedResources
 	cq.Status.ReservingWorkloads = int32(stats.ReservingWorkloads)
 	cq.Status.AdmittedWorkloads = int32(stats.AdmittedWorkloads)
+	if stats.BorrowedWorkloads != nil {
+		cq.Status.BorrowedWorkloads = ptr.To(int32(*stats.BorrowedWorkloads))
+	} else {
+		cq.Status.BorrowedWorkloads = nil
+	}
 	cq.Status.PendingWorkloads = int32(pendingWorkloads)
+	cq.Status.NodeCapacityQuotas = stats.NodeCapacityQuotas
+	cq.Status.OvercommittedQuotas = stats.OvercommittedQuotas
+	cq.Status.FederatedQuota = stats.FederatedQuota
 	cq.Status.PendingWorkloadsStatus = r.getWorkloadsStatus(cq)
 	meta.SetStatusCondition(&cq.Status.Conditions, metav1.Condition{
 		Type:               kueue.ClusterQueueActive,
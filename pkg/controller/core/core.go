@@ -89,6 +89,8 @@ func SetupControllers(mgr ctrl.Manager, qManager *queue.Manager, cc *cache.Cache
 		return "Workload", err
 	}
 	qManager.AddTopologyUpdateWatcher(cqRec)
+	qManager.AddNodeCapacityUpdateWatcher(cqRec)
+	qManager.AddFederatedQuotaUpdateWatcher(cqRec)
 	return "", nil
 }
 
@@ -25,6 +25,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
@@ -188,6 +189,12 @@ func (r *LocalQueueReconciler) Update(e event.TypedUpdateEvent[*kueue.LocalQueue
 		if err := r.cache.UpdateLocalQueue(e.ObjectOld, e.ObjectNew); err != nil {
 			log.Error(err, "Failed to update localQueue in the cache")
 		}
+		if !equality.Semantic.DeepEqual(e.ObjectOld.Spec.Limits, e.ObjectNew.Spec.Limits) ||
+			!ptr.Equal(e.ObjectOld.Spec.MaxAdmittedWorkloads, e.ObjectNew.Spec.MaxAdmittedWorkloads) {
+			// The workloads blocked by the old limits might fit now.
+			ctx := logr.NewContext(context.Background(), log)
+			r.queues.QueueInadmissibleWorkloads(ctx, sets.New(e.ObjectNew.Spec.ClusterQueue))
+		}
 		return true
 	}
 
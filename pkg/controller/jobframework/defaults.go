@@ -21,10 +21,12 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
@@ -95,6 +97,56 @@ func ApplyDefaultLocalQueue(jobObj client.Object, defaultQueueExist func(string)
 	}
 }
 
+// ApplyDefaultForSubmittedBy records the user or service account creating
+// jobObj, as authenticated by the API server, in the SubmittedByAnnotation,
+// overriding any value set by the user.
+func ApplyDefaultForSubmittedBy(ctx context.Context, jobObj client.Object) {
+	if !features.Enabled(features.PerUserLimits) {
+		return
+	}
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil || req.Operation != admissionv1.Create {
+		return
+	}
+	annotations := jobObj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string, 1)
+	}
+	annotations[constants.SubmittedByAnnotation] = req.UserInfo.Username
+	jobObj.SetAnnotations(annotations)
+}
+
+// ApplyDefaultForSubmittedByFromAncestor records in the SubmittedByAnnotation
+// of obj, created by the controller of a job managed by Kueue, the user which
+// submitted that job. The annotation set in the pod template of the job
+// can't be trusted, as anyone can create an object which looks like it was
+// created by the controller of the job. When obj doesn't have such an
+// ancestor, it records the user creating obj.
+func ApplyDefaultForSubmittedByFromAncestor(ctx context.Context, k8sClient client.Client, obj client.Object, manageJobsWithoutQueueName bool) error {
+	if !features.Enabled(features.PerUserLimits) {
+		return nil
+	}
+	ancestorJob, err := FindAncestorJobManagedByKueue(ctx, k8sClient, obj, manageJobsWithoutQueueName)
+	if err != nil {
+		return err
+	}
+	if ancestorJob == nil {
+		ApplyDefaultForSubmittedBy(ctx, obj)
+		return nil
+	}
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string, 1)
+	}
+	if submittedBy, found := ancestorJob.GetAnnotations()[constants.SubmittedByAnnotation]; found {
+		annotations[constants.SubmittedByAnnotation] = submittedBy
+	} else {
+		delete(annotations, constants.SubmittedByAnnotation)
+	}
+	obj.SetAnnotations(annotations)
+	return nil
+}
+
 func ApplyDefaultForManagedBy(job GenericJob, queues *queue.Manager, cache *cache.Cache, log logr.Logger) {
 	if managedJob, ok := job.(JobWithManagedBy); ok {
 		if managedJob.CanDefaultManagedBy() {
@@ -19,11 +19,17 @@ package jobframework
 import (
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	"sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/features"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	utiltestingjob "sigs.k8s.io/kueue/pkg/util/testingjobs/job"
@@ -121,3 +127,100 @@ func TestWorkloadShouldBeSuspended(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyDefaultForSubmittedBy(t *testing.T) {
+	cases := map[string]struct {
+		obj                client.Object
+		operation          admissionv1.Operation
+		featureGateEnabled bool
+		wantAnnotations    map[string]string
+	}{
+		"records the user on create": {
+			obj:                utiltestingjob.MakeJob("test-job", "ns").Obj(),
+			operation:          admissionv1.Create,
+			featureGateEnabled: true,
+			wantAnnotations:    map[string]string{constants.SubmittedByAnnotation: "alice"},
+		},
+		"overrides the value set by the user": {
+			obj:                utiltestingjob.MakeJob("test-job", "ns").SetAnnotation(constants.SubmittedByAnnotation, "bob").Obj(),
+			operation:          admissionv1.Create,
+			featureGateEnabled: true,
+			wantAnnotations:    map[string]string{constants.SubmittedByAnnotation: "alice"},
+		},
+		"keeps the value on update": {
+			obj:                utiltestingjob.MakeJob("test-job", "ns").SetAnnotation(constants.SubmittedByAnnotation, "bob").Obj(),
+			operation:          admissionv1.Update,
+			featureGateEnabled: true,
+			wantAnnotations:    map[string]string{constants.SubmittedByAnnotation: "bob"},
+		},
+		"feature disabled": {
+			obj:       utiltestingjob.MakeJob("test-job", "ns").Obj(),
+			operation: admissionv1.Create,
+		},
+	}
+
+	for tcName, tc := range cases {
+		t.Run(tcName, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.PerUserLimits, tc.featureGateEnabled)
+			ctx, _ := utiltesting.ContextWithLog(t)
+			ctx = admission.NewContextWithRequest(ctx, admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: tc.operation,
+					UserInfo:  authenticationv1.UserInfo{Username: "alice"},
+				},
+			})
+			ApplyDefaultForSubmittedBy(ctx, tc.obj)
+			if diff := cmp.Diff(tc.wantAnnotations, tc.obj.GetAnnotations(), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected annotations (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestApplyDefaultForSubmittedByFromAncestor(t *testing.T) {
+	t.Cleanup(EnableIntegrationsForTest(t, "batch/job"))
+	parent := utiltestingjob.MakeJob("parent", "ns").
+		UID("parent").
+		Queue("default").
+		SetAnnotation(constants.SubmittedByAnnotation, "bob").
+		Obj()
+
+	cases := map[string]struct {
+		obj             client.Object
+		wantAnnotations map[string]string
+	}{
+		"takes the user from the ancestor": {
+			obj: utiltestingjob.MakeJob("child", "ns").
+				OwnerReference(parent.Name, batchv1.SchemeGroupVersion.WithKind("Job")).
+				SetAnnotation(constants.SubmittedByAnnotation, "mallory").
+				Obj(),
+			wantAnnotations: map[string]string{constants.SubmittedByAnnotation: "bob"},
+		},
+		"records the user without ancestor": {
+			obj: utiltestingjob.MakeJob("child", "ns").
+				SetAnnotation(constants.SubmittedByAnnotation, "mallory").
+				Obj(),
+			wantAnnotations: map[string]string{constants.SubmittedByAnnotation: "alice"},
+		},
+	}
+
+	for tcName, tc := range cases {
+		t.Run(tcName, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.PerUserLimits, true)
+			k8sClient := utiltesting.NewClientBuilder().WithObjects(parent).Build()
+			ctx, _ := utiltesting.ContextWithLog(t)
+			ctx = admission.NewContextWithRequest(ctx, admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					UserInfo:  authenticationv1.UserInfo{Username: "alice"},
+				},
+			})
+			if err := ApplyDefaultForSubmittedByFromAncestor(ctx, k8sClient, tc.obj, false); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantAnnotations, tc.obj.GetAnnotations(), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected annotations (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -221,7 +221,7 @@ func NewWorkload(name string, obj client.Object, podSets []kueue.PodSet, labelKe
 			Namespace:   obj.GetNamespace(),
 			Labels:      maps.FilterKeys(obj.GetLabels(), labelKeysToCopy),
 			Finalizers:  []string{kueue.ResourceInUseFinalizerName},
-			Annotations: admissioncheck.FilterProvReqAnnotations(obj.GetAnnotations()),
+			Annotations: workloadAnnotations(obj),
 		},
 		Spec: kueue.WorkloadSpec{
 			QueueName:                   QueueNameForObject(obj),
@@ -231,6 +231,19 @@ func NewWorkload(name string, obj client.Object, podSets []kueue.PodSet, labelKe
 	}
 }
 
+// workloadAnnotations returns the annotations of obj which are copied to its
+// workload.
+func workloadAnnotations(obj client.Object) map[string]string {
+	annotations := admissioncheck.FilterProvReqAnnotations(obj.GetAnnotations())
+	if submittedBy, found := obj.GetAnnotations()[constants.SubmittedByAnnotation]; found {
+		annotations[constants.SubmittedByAnnotation] = submittedBy
+	}
+	if checkpointable, found := obj.GetAnnotations()[constants.CheckpointableAnnotation]; found {
+		annotations[constants.CheckpointableAnnotation] = checkpointable
+	}
+	return annotations
+}
+
 // MultiKueueAdapter interface needed for MultiKueue job delegation.
 type MultiKueueAdapter interface {
 	// SyncJob creates the Job object in the worker cluster using remote client, if not already created.
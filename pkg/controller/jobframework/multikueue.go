@@ -0,0 +1,234 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobframework
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// WorkloadEvictedByResize is the reason of the eviction of the workload of an
+// object dispatched as a whole by MultiKueue, when the number of replicas of
+// the object changes.
+const WorkloadEvictedByResize = "Resized"
+
+// IsEvictedByMultiKueueResize returns true if wl is evicted to be resized for
+// a new number of replicas of its object.
+func IsEvictedByMultiKueueResize(wl *kueue.Workload) bool {
+	evCond := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadEvicted)
+	return evCond != nil && evCond.Status == metav1.ConditionTrue && evCond.Reason == WorkloadEvictedByResize
+}
+
+// IsManagedByMultiKueue returns true if obj, an object without a managedBy
+// field, is dispatched as a whole to a MultiKueue worker cluster.
+func IsManagedByMultiKueue(obj client.Object) bool {
+	return obj.GetLabels()[constants.ManagedByLabel] == kueue.MultiKueueControllerName
+}
+
+// IsManagedByMultiKueueReason returns the reason for which an object without
+// a managedBy field is not dispatched by MultiKueue.
+func IsManagedByMultiKueueReason(obj client.Object) string {
+	return fmt.Sprintf("Expecting the %s label to be %q not %q", constants.ManagedByLabel, kueue.MultiKueueControllerName, obj.GetLabels()[constants.ManagedByLabel])
+}
+
+// IsMultiKueueRemoteObject returns true if obj was created by MultiKueue in
+// a worker cluster. Since any user can set the MultiKueue origin label, obj is
+// only trusted if its prebuilt workload, created by MultiKueue with the same
+// origin, has a quota reservation covering the Pods of obj.
+func IsMultiKueueRemoteObject(ctx context.Context, c client.Client, obj client.Object) (bool, error) {
+	origin, found := obj.GetLabels()[kueue.MultiKueueOriginLabel]
+	if !found {
+		return false, nil
+	}
+	wlName, found := obj.GetLabels()[constants.PrebuiltWorkloadLabel]
+	if !found {
+		return false, nil
+	}
+	wl := &kueue.Workload{}
+	if err := c.Get(ctx, types.NamespacedName{Name: wlName, Namespace: obj.GetNamespace()}, wl); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	return wl.Labels[kueue.MultiKueueOriginLabel] == origin && workload.HasQuotaReservation(wl), nil
+}
+
+// GateForMultiKueue adds the MultiKueue scheduling gate to spec, so that
+// the Pods created by the local controllers never run in the manager cluster.
+func GateForMultiKueue(spec *corev1.PodSpec) {
+	if !slices.ContainsFunc(spec.SchedulingGates, isMultiKueueGate) {
+		spec.SchedulingGates = append(spec.SchedulingGates, corev1.PodSchedulingGate{Name: constants.MultiKueueSchedulingGate})
+	}
+}
+
+// UngateForMultiKueue removes the MultiKueue scheduling gate from spec.
+func UngateForMultiKueue(spec *corev1.PodSpec) {
+	spec.SchedulingGates = slices.DeleteFunc(spec.SchedulingGates, isMultiKueueGate)
+	if len(spec.SchedulingGates) == 0 {
+		spec.SchedulingGates = nil
+	}
+}
+
+func isMultiKueueGate(gate corev1.PodSchedulingGate) bool {
+	return gate.Name == constants.MultiKueueSchedulingGate
+}
+
+// multiKueueRemoteObjectMeta returns the metadata of the copy, created in a
+// worker cluster, of an object dispatched as a whole by MultiKueue.
+// The copy is not queued in the worker cluster, its quota is held by the copy
+// of the workload.
+func multiKueueRemoteObjectMeta(local client.Object, workloadName, origin string) metav1.ObjectMeta {
+	meta := metav1.ObjectMeta{
+		Name:        local.GetName(),
+		Namespace:   local.GetNamespace(),
+		Labels:      maps.Clone(local.GetLabels()),
+		Annotations: maps.Clone(local.GetAnnotations()),
+	}
+	if meta.Labels == nil {
+		meta.Labels = make(map[string]string, 2)
+	}
+	delete(meta.Labels, constants.QueueLabel)
+	delete(meta.Labels, constants.ManagedByLabel)
+	meta.Labels[constants.PrebuiltWorkloadLabel] = workloadName
+	meta.Labels[kueue.MultiKueueOriginLabel] = origin
+	return meta
+}
+
+// MultiKueueWorkloadName returns the name of the workload of an object
+// dispatched as a whole by MultiKueue.
+func MultiKueueWorkloadName(obj client.Object, gvk schema.GroupVersionKind) string {
+	return GetWorkloadNameForOwnerWithGVK(obj.GetName(), obj.GetUID(), gvk)
+}
+
+// EnsureMultiKueueWorkload creates the workload of an object dispatched as a
+// whole by MultiKueue, if missing, and keeps it sized for the replicas of the
+// object. Since no Pod runs in the manager cluster, the quota reservation of
+// an evicted workload is released right away.
+// When the number of replicas changes, the workload is evicted if it has a
+// quota reservation, then resized, so that it is admitted again for the new
+// number of replicas. The remote object is kept meanwhile, and scaled once the
+// workload is admitted again.
+func EnsureMultiKueueWorkload(ctx context.Context, c client.Client, recorder record.EventRecorder, clk clock.Clock, obj client.Object, gvk schema.GroupVersionKind, podSets []kueue.PodSet, labelKeysToCopy []string) error {
+	name := MultiKueueWorkloadName(obj, gvk)
+	wl := &kueue.Workload{}
+	err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: obj.GetNamespace()}, wl)
+	if err == nil {
+		return syncMultiKueueWorkload(ctx, c, recorder, clk, obj, wl, podSets)
+	}
+	if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	wl = NewWorkload(name, obj, podSets, labelKeysToCopy)
+	if wl.Labels == nil {
+		wl.Labels = make(map[string]string, 1)
+	}
+	wl.Labels[constants.JobUIDLabel] = string(obj.GetUID())
+	if err := ctrl.SetControllerReference(obj, wl, c.Scheme()); err != nil {
+		return err
+	}
+
+	priorityClassName, source, p, err := ExtractPriority(ctx, c, obj, podSets, nil)
+	if err != nil {
+		return err
+	}
+	wl.Spec.PriorityClassName = priorityClassName
+	wl.Spec.Priority = &p
+	wl.Spec.PriorityClassSource = source
+
+	if err := c.Create(ctx, wl); err != nil {
+		return client.IgnoreAlreadyExists(err)
+	}
+	recorder.Eventf(obj, corev1.EventTypeNormal, ReasonCreatedWorkload, "Created Workload: %v", workload.Key(wl))
+	return nil
+}
+
+// syncMultiKueueWorkload releases the quota reservation of the evicted wl, and
+// resizes wl for podSets.
+func syncMultiKueueWorkload(ctx context.Context, c client.Client, recorder record.EventRecorder, clk clock.Clock, obj client.Object, wl *kueue.Workload, podSets []kueue.PodSet) error {
+	if workload.IsFinished(wl) {
+		return nil
+	}
+
+	evCond := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadEvicted)
+	if evCond != nil && evCond.Status == metav1.ConditionTrue && workload.HasQuotaReservation(wl) {
+		workload.SetRequeuedCondition(wl, evCond.Reason, evCond.Message, evCond.Reason == kueue.WorkloadEvictedByPreemption)
+		_ = workload.UnsetQuotaReservationWithCondition(wl, "Pending", evCond.Message, clk.Now())
+		if err := workload.ApplyAdmissionStatus(ctx, c, wl, true, clk); err != nil {
+			return fmt.Errorf("clearing admission: %w", err)
+		}
+		return nil
+	}
+
+	if multiKueueWorkloadSized(wl, podSets) {
+		return nil
+	}
+	if !workload.HasQuotaReservation(wl) {
+		wl.Spec.PodSets = podSets
+		if err := c.Update(ctx, wl); err != nil {
+			return err
+		}
+		recorder.Eventf(obj, corev1.EventTypeNormal, ReasonUpdatedWorkload, "Resized Workload: %v", workload.Key(wl))
+		return nil
+	}
+	workload.SetEvictedCondition(wl, WorkloadEvictedByResize, "The number of replicas changed")
+	workload.ResetChecksOnEviction(wl, clk.Now())
+	return workload.ApplyAdmissionStatus(ctx, c, wl, true, clk)
+}
+
+// multiKueueWorkloadSized returns true if the PodSets of wl have the counts of
+// podSets.
+func multiKueueWorkloadSized(wl *kueue.Workload, podSets []kueue.PodSet) bool {
+	return slices.EqualFunc(wl.Spec.PodSets, podSets, func(a, b kueue.PodSet) bool {
+		return a.Name == b.Name && a.Count == b.Count
+	})
+}
+
+// FinalizeMultiKueueWorkloads removes the finalizer from the workloads of a
+// deleted object, dispatched as a whole by MultiKueue. The workloads are then
+// garbage collected, and MultiKueue deletes the remote objects.
+func FinalizeMultiKueueWorkloads(ctx context.Context, c client.Client, key types.NamespacedName, gvk schema.GroupVersionKind) error {
+	var workloads kueue.WorkloadList
+	if err := c.List(ctx, &workloads, client.InNamespace(key.Namespace), client.MatchingFields{GetOwnerKey(gvk): key.Name}); err != nil {
+		return err
+	}
+	for i := range workloads.Items {
+		wl := &workloads.Items[i]
+		if owner := metav1.GetControllerOf(wl); owner == nil || owner.Kind != gvk.Kind || owner.Name != key.Name {
+			continue
+		}
+		if err := client.IgnoreNotFound(workload.RemoveFinalizer(ctx, c, wl)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
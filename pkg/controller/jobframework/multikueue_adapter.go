@@ -0,0 +1,177 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobframework
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+	clientutil "sigs.k8s.io/kueue/pkg/util/client"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// MultiKueueObject is the pointer type of an object, without a managedBy
+// field, dispatched as a whole by MultiKueue.
+type MultiKueueObject[T any] interface {
+	*T
+	client.Object
+}
+
+// MultiKueueObjectFuncs holds the type specific parts of a
+// MultiKueueObjectAdapter.
+type MultiKueueObjectFuncs[PT any] struct {
+	// NewList returns an empty list of the objects.
+	NewList func() client.ObjectList
+	// NewRemote returns the copy of local, with the metadata meta, to create
+	// in a worker cluster.
+	NewRemote func(local PT, meta metav1.ObjectMeta) PT
+	// PodSpecs returns the specs of the Pod templates of obj.
+	PodSpecs func(obj PT) []*corev1.PodSpec
+	// PodSets returns the PodSets of the workload of obj.
+	PodSets func(obj PT) []kueue.PodSet
+	// CopyReplicas copies the number of replicas of local into remote.
+	CopyReplicas func(remote, local PT)
+	// CopyStatus copies the status of remote into local. The generation
+	// observed in the worker cluster is not copied, it is unrelated to the
+	// generation of local.
+	CopyStatus func(local, remote PT)
+}
+
+// MultiKueueObjectAdapter is the MultiKueueAdapter of the objects, without a
+// managedBy field, dispatched as a whole by MultiKueue.
+type MultiKueueObjectAdapter[T any, PT MultiKueueObject[T]] struct {
+	gvk   schema.GroupVersionKind
+	funcs MultiKueueObjectFuncs[PT]
+}
+
+var _ MultiKueueAdapter = (*MultiKueueObjectAdapter[corev1.Pod, *corev1.Pod])(nil)
+var _ MultiKueueWatcher = (*MultiKueueObjectAdapter[corev1.Pod, *corev1.Pod])(nil)
+
+// NewMultiKueueObjectAdapter returns the MultiKueueAdapter of the objects of
+// kind gvk.
+func NewMultiKueueObjectAdapter[T any, PT MultiKueueObject[T]](gvk schema.GroupVersionKind, funcs MultiKueueObjectFuncs[PT]) *MultiKueueObjectAdapter[T, PT] {
+	return &MultiKueueObjectAdapter[T, PT]{gvk: gvk, funcs: funcs}
+}
+
+// SyncJob creates the copy of the local object in the worker cluster, or
+// copies the status of the remote object into the local object.
+// A change of the number of replicas of the local object is only propagated
+// to the remote object once the workload is resized and admitted again.
+func (a *MultiKueueObjectAdapter[T, PT]) SyncJob(ctx context.Context, localClient client.Client, remoteClient client.Client, key types.NamespacedName, workloadName, origin string) error {
+	local := PT(new(T))
+	if err := localClient.Get(ctx, key, local); err != nil {
+		return err
+	}
+
+	remote := PT(new(T))
+	err := remoteClient.Get(ctx, key, remote)
+	if client.IgnoreNotFound(err) != nil {
+		return err
+	}
+
+	// if the remote exists, propagate the scale and copy the status
+	if err == nil {
+		if err := a.syncReplicas(ctx, localClient, remoteClient, local, remote, workloadName); err != nil {
+			return err
+		}
+		return clientutil.PatchStatus(ctx, localClient, local, func() (bool, error) {
+			original := local.DeepCopyObject()
+			a.funcs.CopyStatus(local, remote)
+			return !equality.Semantic.DeepEqual(original, local), nil
+		})
+	}
+
+	remote = a.funcs.NewRemote(local, multiKueueRemoteObjectMeta(local, workloadName, origin))
+	for _, spec := range a.funcs.PodSpecs(remote) {
+		UngateForMultiKueue(spec)
+	}
+	return remoteClient.Create(ctx, remote)
+}
+
+// syncReplicas patches the number of replicas of remote with the one of
+// local, if the workload has a quota reservation for it.
+func (a *MultiKueueObjectAdapter[T, PT]) syncReplicas(ctx context.Context, localClient client.Client, remoteClient client.Client, local, remote PT, workloadName string) error {
+	wl := &kueue.Workload{}
+	if err := localClient.Get(ctx, types.NamespacedName{Name: workloadName, Namespace: local.GetNamespace()}, wl); err != nil {
+		return err
+	}
+	if !workload.HasQuotaReservation(wl) || !multiKueueWorkloadSized(wl, a.funcs.PodSets(local)) {
+		return nil
+	}
+	return clientutil.Patch(ctx, remoteClient, remote, false, func() (bool, error) {
+		original := remote.DeepCopyObject()
+		a.funcs.CopyReplicas(remote, local)
+		return !equality.Semantic.DeepEqual(original, remote), nil
+	})
+}
+
+func (a *MultiKueueObjectAdapter[T, PT]) DeleteRemoteObject(ctx context.Context, remoteClient client.Client, key types.NamespacedName) error {
+	obj := PT(new(T))
+	err := remoteClient.Get(ctx, key, obj)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	return client.IgnoreNotFound(remoteClient.Delete(ctx, obj))
+}
+
+func (a *MultiKueueObjectAdapter[T, PT]) KeepAdmissionCheckPending() bool {
+	return false
+}
+
+func (a *MultiKueueObjectAdapter[T, PT]) IsJobManagedByKueue(ctx context.Context, c client.Client, key types.NamespacedName) (bool, string, error) {
+	obj := PT(new(T))
+	if err := c.Get(ctx, key, obj); err != nil {
+		return false, "", err
+	}
+	if !IsManagedByMultiKueue(obj) {
+		return false, IsManagedByMultiKueueReason(obj), nil
+	}
+	return true, "", nil
+}
+
+func (a *MultiKueueObjectAdapter[T, PT]) GVK() schema.GroupVersionKind {
+	return a.gvk
+}
+
+func (a *MultiKueueObjectAdapter[T, PT]) GetEmptyList() client.ObjectList {
+	return a.funcs.NewList()
+}
+
+func (a *MultiKueueObjectAdapter[T, PT]) WorkloadKeyFor(o runtime.Object) (types.NamespacedName, error) {
+	obj, isObj := o.(PT)
+	if !isObj {
+		return types.NamespacedName{}, fmt.Errorf("not a %s", a.gvk.Kind)
+	}
+
+	prebuiltWl, hasPrebuiltWorkload := obj.GetLabels()[constants.PrebuiltWorkloadLabel]
+	if !hasPrebuiltWorkload {
+		return types.NamespacedName{}, fmt.Errorf("no prebuilt workload found for %s: %s", a.gvk.Kind, klog.KObj(obj))
+	}
+
+	return types.NamespacedName{Name: prebuiltWl, Namespace: obj.GetNamespace()}, nil
+}
@@ -0,0 +1,320 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobframework
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/util/slices"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	testingdeployment "sigs.k8s.io/kueue/pkg/util/testingjobs/deployment"
+)
+
+func newTestMultiKueueObjectAdapter() *MultiKueueObjectAdapter[appsv1.Deployment, *appsv1.Deployment] {
+	return NewMultiKueueObjectAdapter(appsv1.SchemeGroupVersion.WithKind("Deployment"), MultiKueueObjectFuncs[*appsv1.Deployment]{
+		NewList: func() client.ObjectList {
+			return &appsv1.DeploymentList{}
+		},
+		NewRemote: func(local *appsv1.Deployment, meta metav1.ObjectMeta) *appsv1.Deployment {
+			return &appsv1.Deployment{
+				ObjectMeta: meta,
+				Spec:       *local.Spec.DeepCopy(),
+			}
+		},
+		PodSpecs: func(deployment *appsv1.Deployment) []*corev1.PodSpec {
+			return []*corev1.PodSpec{&deployment.Spec.Template.Spec}
+		},
+		PodSets: func(deployment *appsv1.Deployment) []kueue.PodSet {
+			return []kueue.PodSet{*utiltesting.MakePodSet(kueue.DefaultPodSetName, int(ptr.Deref(deployment.Spec.Replicas, 1))).Obj()}
+		},
+		CopyReplicas: func(remote, local *appsv1.Deployment) {
+			remote.Spec.Replicas = local.Spec.Replicas
+		},
+		CopyStatus: func(local, remote *appsv1.Deployment) {
+			observedGeneration := local.Status.ObservedGeneration
+			local.Status = *remote.Status.DeepCopy()
+			local.Status.ObservedGeneration = observedGeneration
+		},
+	})
+}
+
+func TestMultiKueueObjectAdapter(t *testing.T) {
+	objCheckOpts := cmp.Options{
+		cmpopts.IgnoreFields(metav1.ObjectMeta{}, "ResourceVersion"),
+		cmpopts.EquateEmpty(),
+	}
+	key := types.NamespacedName{Name: "deployment1", Namespace: "ns"}
+
+	baseDeploymentBuilder := testingdeployment.MakeDeployment("deployment1", "ns").Replicas(3)
+	localDeploymentBuilder := baseDeploymentBuilder.Clone().
+		Queue("queue").
+		Label(constants.ManagedByLabel, kueue.MultiKueueControllerName).
+		PodTemplateSpecSchedulingGate(constants.MultiKueueSchedulingGate)
+	remoteDeploymentBuilder := baseDeploymentBuilder.Clone().
+		Label(constants.PrebuiltWorkloadLabel, "wl1").
+		Label(kueue.MultiKueueOriginLabel, "origin1")
+	workloadBuilder := utiltesting.MakeWorkload("wl1", "ns").
+		PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 3).Obj()).
+		ReserveQuota(utiltesting.MakeAdmission("cq").Obj())
+
+	cases := map[string]struct {
+		managersDeployments []appsv1.Deployment
+		managersWorkloads   []kueue.Workload
+		workerDeployments   []appsv1.Deployment
+
+		operation func(ctx context.Context, adapter *MultiKueueObjectAdapter[appsv1.Deployment, *appsv1.Deployment], managerClient, workerClient client.Client) error
+
+		wantError               error
+		wantManagersDeployments []appsv1.Deployment
+		wantWorkerDeployments   []appsv1.Deployment
+		wantStatusPatches       int
+	}{
+		"sync creates the missing remote object without the scheduling gate": {
+			managersDeployments: []appsv1.Deployment{
+				*localDeploymentBuilder.Clone().Obj(),
+			},
+			operation: func(ctx context.Context, adapter *MultiKueueObjectAdapter[appsv1.Deployment, *appsv1.Deployment], managerClient, workerClient client.Client) error {
+				return adapter.SyncJob(ctx, managerClient, workerClient, key, "wl1", "origin1")
+			},
+			wantManagersDeployments: []appsv1.Deployment{
+				*localDeploymentBuilder.Clone().Obj(),
+			},
+			wantWorkerDeployments: []appsv1.Deployment{
+				*remoteDeploymentBuilder.Clone().Obj(),
+			},
+		},
+		"sync copies the status from the remote object, except for the observed generation": {
+			managersDeployments: []appsv1.Deployment{
+				*localDeploymentBuilder.Clone().ObservedGeneration(1).Obj(),
+			},
+			managersWorkloads: []kueue.Workload{
+				*workloadBuilder.Clone().Obj(),
+			},
+			workerDeployments: []appsv1.Deployment{
+				*remoteDeploymentBuilder.Clone().ReadyReplicas(3).AvailableReplicas(2).ObservedGeneration(4).Obj(),
+			},
+			operation: func(ctx context.Context, adapter *MultiKueueObjectAdapter[appsv1.Deployment, *appsv1.Deployment], managerClient, workerClient client.Client) error {
+				return adapter.SyncJob(ctx, managerClient, workerClient, key, "wl1", "origin1")
+			},
+			wantManagersDeployments: []appsv1.Deployment{
+				*localDeploymentBuilder.Clone().ReadyReplicas(3).AvailableReplicas(2).ObservedGeneration(1).Obj(),
+			},
+			wantWorkerDeployments: []appsv1.Deployment{
+				*remoteDeploymentBuilder.Clone().ReadyReplicas(3).AvailableReplicas(2).ObservedGeneration(4).Obj(),
+			},
+			wantStatusPatches: 1,
+		},
+		"sync doesn't patch an unchanged status": {
+			managersDeployments: []appsv1.Deployment{
+				*localDeploymentBuilder.Clone().ReadyReplicas(3).ObservedGeneration(1).Obj(),
+			},
+			managersWorkloads: []kueue.Workload{
+				*workloadBuilder.Clone().Obj(),
+			},
+			workerDeployments: []appsv1.Deployment{
+				*remoteDeploymentBuilder.Clone().ReadyReplicas(3).ObservedGeneration(4).Obj(),
+			},
+			operation: func(ctx context.Context, adapter *MultiKueueObjectAdapter[appsv1.Deployment, *appsv1.Deployment], managerClient, workerClient client.Client) error {
+				return adapter.SyncJob(ctx, managerClient, workerClient, key, "wl1", "origin1")
+			},
+			wantManagersDeployments: []appsv1.Deployment{
+				*localDeploymentBuilder.Clone().ReadyReplicas(3).ObservedGeneration(1).Obj(),
+			},
+			wantWorkerDeployments: []appsv1.Deployment{
+				*remoteDeploymentBuilder.Clone().ReadyReplicas(3).ObservedGeneration(4).Obj(),
+			},
+		},
+		"sync doesn't propagate the scale before the workload is resized": {
+			managersDeployments: []appsv1.Deployment{
+				*localDeploymentBuilder.Clone().Replicas(5).ReadyReplicas(3).Obj(),
+			},
+			managersWorkloads: []kueue.Workload{
+				*workloadBuilder.Clone().Obj(),
+			},
+			workerDeployments: []appsv1.Deployment{
+				*remoteDeploymentBuilder.Clone().ReadyReplicas(3).Obj(),
+			},
+			operation: func(ctx context.Context, adapter *MultiKueueObjectAdapter[appsv1.Deployment, *appsv1.Deployment], managerClient, workerClient client.Client) error {
+				return adapter.SyncJob(ctx, managerClient, workerClient, key, "wl1", "origin1")
+			},
+			wantManagersDeployments: []appsv1.Deployment{
+				*localDeploymentBuilder.Clone().Replicas(5).ReadyReplicas(3).Obj(),
+			},
+			wantWorkerDeployments: []appsv1.Deployment{
+				*remoteDeploymentBuilder.Clone().ReadyReplicas(3).Obj(),
+			},
+		},
+		"sync doesn't propagate the scale before the resized workload is admitted again": {
+			managersDeployments: []appsv1.Deployment{
+				*localDeploymentBuilder.Clone().Replicas(5).ReadyReplicas(3).Obj(),
+			},
+			managersWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("wl1", "ns").PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 5).Obj()).Obj(),
+			},
+			workerDeployments: []appsv1.Deployment{
+				*remoteDeploymentBuilder.Clone().ReadyReplicas(3).Obj(),
+			},
+			operation: func(ctx context.Context, adapter *MultiKueueObjectAdapter[appsv1.Deployment, *appsv1.Deployment], managerClient, workerClient client.Client) error {
+				return adapter.SyncJob(ctx, managerClient, workerClient, key, "wl1", "origin1")
+			},
+			wantManagersDeployments: []appsv1.Deployment{
+				*localDeploymentBuilder.Clone().Replicas(5).ReadyReplicas(3).Obj(),
+			},
+			wantWorkerDeployments: []appsv1.Deployment{
+				*remoteDeploymentBuilder.Clone().ReadyReplicas(3).Obj(),
+			},
+		},
+		"sync propagates the scale once the resized workload is admitted again": {
+			managersDeployments: []appsv1.Deployment{
+				*localDeploymentBuilder.Clone().Replicas(5).ReadyReplicas(3).Obj(),
+			},
+			managersWorkloads: []kueue.Workload{
+				*workloadBuilder.Clone().PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 5).Obj()).Obj(),
+			},
+			workerDeployments: []appsv1.Deployment{
+				*remoteDeploymentBuilder.Clone().ReadyReplicas(3).Obj(),
+			},
+			operation: func(ctx context.Context, adapter *MultiKueueObjectAdapter[appsv1.Deployment, *appsv1.Deployment], managerClient, workerClient client.Client) error {
+				return adapter.SyncJob(ctx, managerClient, workerClient, key, "wl1", "origin1")
+			},
+			wantManagersDeployments: []appsv1.Deployment{
+				*localDeploymentBuilder.Clone().Replicas(5).ReadyReplicas(3).Obj(),
+			},
+			wantWorkerDeployments: []appsv1.Deployment{
+				*remoteDeploymentBuilder.Clone().Replicas(5).ReadyReplicas(3).Obj(),
+			},
+		},
+		"remote object is deleted": {
+			workerDeployments: []appsv1.Deployment{
+				*remoteDeploymentBuilder.Clone().ReadyReplicas(3).Obj(),
+			},
+			operation: func(ctx context.Context, adapter *MultiKueueObjectAdapter[appsv1.Deployment, *appsv1.Deployment], managerClient, workerClient client.Client) error {
+				return adapter.DeleteRemoteObject(ctx, workerClient, key)
+			},
+		},
+		"missing object is not considered managed": {
+			operation: func(ctx context.Context, adapter *MultiKueueObjectAdapter[appsv1.Deployment, *appsv1.Deployment], managerClient, workerClient client.Client) error {
+				if isManged, _, _ := adapter.IsJobManagedByKueue(ctx, managerClient, key); isManged {
+					return errors.New("expecting false")
+				}
+				return nil
+			},
+		},
+		"object without the managed-by label is not considered managed": {
+			managersDeployments: []appsv1.Deployment{
+				*baseDeploymentBuilder.Clone().Queue("queue").Obj(),
+			},
+			operation: func(ctx context.Context, adapter *MultiKueueObjectAdapter[appsv1.Deployment, *appsv1.Deployment], managerClient, workerClient client.Client) error {
+				if isManged, _, _ := adapter.IsJobManagedByKueue(ctx, managerClient, key); isManged {
+					return errors.New("expecting false")
+				}
+				return nil
+			},
+			wantManagersDeployments: []appsv1.Deployment{
+				*baseDeploymentBuilder.Clone().Queue("queue").Obj(),
+			},
+		},
+		"object managed by multikueue": {
+			managersDeployments: []appsv1.Deployment{
+				*localDeploymentBuilder.Clone().Obj(),
+			},
+			operation: func(ctx context.Context, adapter *MultiKueueObjectAdapter[appsv1.Deployment, *appsv1.Deployment], managerClient, workerClient client.Client) error {
+				if isManged, _, _ := adapter.IsJobManagedByKueue(ctx, managerClient, key); !isManged {
+					return errors.New("expecting true")
+				}
+				return nil
+			},
+			wantManagersDeployments: []appsv1.Deployment{
+				*localDeploymentBuilder.Clone().Obj(),
+			},
+		},
+		"workload key of the remote object": {
+			operation: func(ctx context.Context, adapter *MultiKueueObjectAdapter[appsv1.Deployment, *appsv1.Deployment], managerClient, workerClient client.Client) error {
+				wlKey, err := adapter.WorkloadKeyFor(remoteDeploymentBuilder.Clone().Obj())
+				if err != nil {
+					return err
+				}
+				if wlKey != (types.NamespacedName{Name: "wl1", Namespace: "ns"}) {
+					return errors.New("unexpected workload key")
+				}
+				return nil
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			statusPatches := 0
+			managerBuilder := utiltesting.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+				SubResourcePatch: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+					statusPatches++
+					return c.SubResource(subResourceName).Patch(ctx, obj, patch, opts...)
+				},
+			})
+			managerBuilder = managerBuilder.WithLists(&appsv1.DeploymentList{Items: tc.managersDeployments}, &kueue.WorkloadList{Items: tc.managersWorkloads})
+			managerBuilder = managerBuilder.WithStatusSubresource(slices.Map(tc.managersDeployments, func(d *appsv1.Deployment) client.Object { return d })...)
+			managerClient := managerBuilder.Build()
+
+			workerBuilder := utiltesting.NewClientBuilder()
+			workerBuilder = workerBuilder.WithLists(&appsv1.DeploymentList{Items: tc.workerDeployments})
+			workerClient := workerBuilder.Build()
+
+			ctx, _ := utiltesting.ContextWithLog(t)
+
+			gotErr := tc.operation(ctx, newTestMultiKueueObjectAdapter(), managerClient, workerClient)
+
+			if diff := cmp.Diff(tc.wantError, gotErr, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("unexpected error (-want/+got):\n%s", diff)
+			}
+
+			if statusPatches != tc.wantStatusPatches {
+				t.Errorf("unexpected number of status patches, want %d, got %d", tc.wantStatusPatches, statusPatches)
+			}
+
+			gotManagersDeployments := &appsv1.DeploymentList{}
+			if err := managerClient.List(ctx, gotManagersDeployments); err != nil {
+				t.Errorf("unexpected list manager's deployments error %s", err)
+			} else {
+				if diff := cmp.Diff(tc.wantManagersDeployments, gotManagersDeployments.Items, objCheckOpts...); diff != "" {
+					t.Errorf("unexpected manager's deployments (-want/+got):\n%s", diff)
+				}
+			}
+
+			gotWorkerDeployments := &appsv1.DeploymentList{}
+			if err := workerClient.List(ctx, gotWorkerDeployments); err != nil {
+				t.Errorf("unexpected list worker's deployments error %s", err)
+			} else {
+				if diff := cmp.Diff(tc.wantWorkerDeployments, gotWorkerDeployments.Items, objCheckOpts...); diff != "" {
+					t.Errorf("unexpected worker's deployments (-want/+got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
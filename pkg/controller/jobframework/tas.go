@@ -20,17 +20,21 @@ import (
 	"strconv"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/features"
 )
 
 func PodSetTopologyRequest(meta *metav1.ObjectMeta, podIndexLabel *string, subGroupIndexLabel *string, subGroupCount *int32) *kueue.PodSetTopologyRequest {
 	requiredValue, requiredFound := meta.Annotations[kueuealpha.PodSetRequiredTopologyAnnotation]
 	preferredValue, preferredFound := meta.Annotations[kueuealpha.PodSetPreferredTopologyAnnotation]
 	unconstrained, unconstrainedFound := meta.Annotations[kueuealpha.PodSetUnconstrainedTopologyAnnotation]
+	balancedValue, balancedFound := meta.Annotations[kueuealpha.PodSetBalancedTopologyAnnotation]
+	balancedFound = balancedFound && features.Enabled(features.TASBalancedPlacement)
 
-	if requiredFound || preferredFound || unconstrainedFound {
+	if requiredFound || preferredFound || unconstrainedFound || balancedFound {
 		psTopologyReq := &kueue.PodSetTopologyRequest{
 			PodIndexLabel:      podIndexLabel,
 			SubGroupIndexLabel: subGroupIndexLabel,
@@ -44,6 +48,19 @@ func PodSetTopologyRequest(meta *metav1.ObjectMeta, podIndexLabel *string, subGr
 		case unconstrainedFound:
 			unconstrained, _ := strconv.ParseBool(unconstrained)
 			psTopologyReq.Unconstrained = &unconstrained
+		case balancedFound:
+			psTopologyReq.Balanced = &balancedValue
+		}
+		if groupName, found := meta.Annotations[kueuealpha.PodSetGroupNameAnnotation]; found && features.Enabled(features.TASPodSetGroups) {
+			psTopologyReq.PodSetGroupName = &groupName
+		}
+		if (requiredFound || preferredFound) && features.Enabled(features.TASPodSetSlices) {
+			sliceLevel, sliceLevelFound := meta.Annotations[kueuealpha.PodSetSliceRequiredTopologyAnnotation]
+			sliceSize, err := strconv.ParseInt(meta.Annotations[kueuealpha.PodSetSliceSizeAnnotation], 10, 32)
+			if sliceLevelFound && err == nil {
+				psTopologyReq.PodSetSliceRequiredTopology = &sliceLevel
+				psTopologyReq.PodSetSliceSize = ptr.To(int32(sliceSize))
+			}
 		}
 		return psTopologyReq
 	}
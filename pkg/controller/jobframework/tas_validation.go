@@ -18,9 +18,11 @@ package jobframework
 
 import (
 	"fmt"
+	"strconv"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metavalidation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
@@ -31,8 +33,9 @@ func ValidateTASPodSetRequest(replicaPath *field.Path, replicaMetadata *metav1.O
 	requiredValue, requiredFound := replicaMetadata.Annotations[kueuealpha.PodSetRequiredTopologyAnnotation]
 	preferredValue, preferredFound := replicaMetadata.Annotations[kueuealpha.PodSetPreferredTopologyAnnotation]
 	_, unconstrainedFound := replicaMetadata.Annotations[kueuealpha.PodSetUnconstrainedTopologyAnnotation]
+	balancedValue, balancedFound := replicaMetadata.Annotations[kueuealpha.PodSetBalancedTopologyAnnotation]
 	annotationFoundCount := 0
-	for _, found := range []bool{requiredFound, preferredFound, unconstrainedFound} {
+	for _, found := range []bool{requiredFound, preferredFound, unconstrainedFound, balancedFound} {
 		if found {
 			annotationFoundCount++
 		}
@@ -40,10 +43,11 @@ func ValidateTASPodSetRequest(replicaPath *field.Path, replicaMetadata *metav1.O
 	annotationsPath := replicaPath.Child("annotations")
 	if annotationFoundCount > 1 {
 		allErrs = append(allErrs, field.Invalid(annotationsPath, field.OmitValueType{},
-			fmt.Sprintf("must not contain more than one topology annotation: [%q, %q, %q]",
+			fmt.Sprintf("must not contain more than one topology annotation: [%q, %q, %q, %q]",
 				kueuealpha.PodSetRequiredTopologyAnnotation,
 				kueuealpha.PodSetPreferredTopologyAnnotation,
-				kueuealpha.PodSetUnconstrainedTopologyAnnotation),
+				kueuealpha.PodSetUnconstrainedTopologyAnnotation,
+				kueuealpha.PodSetBalancedTopologyAnnotation),
 		))
 	}
 	if requiredFound {
@@ -52,5 +56,55 @@ func ValidateTASPodSetRequest(replicaPath *field.Path, replicaMetadata *metav1.O
 	if preferredFound {
 		allErrs = append(allErrs, metavalidation.ValidateLabelName(preferredValue, annotationsPath.Key(kueuealpha.PodSetPreferredTopologyAnnotation))...)
 	}
+	if balancedFound {
+		allErrs = append(allErrs, metavalidation.ValidateLabelName(balancedValue, annotationsPath.Key(kueuealpha.PodSetBalancedTopologyAnnotation))...)
+	}
+	if groupName, groupFound := replicaMetadata.Annotations[kueuealpha.PodSetGroupNameAnnotation]; groupFound {
+		groupPath := annotationsPath.Key(kueuealpha.PodSetGroupNameAnnotation)
+		for _, msg := range validation.IsDNS1123Label(groupName) {
+			allErrs = append(allErrs, field.Invalid(groupPath, groupName, msg))
+		}
+		if !requiredFound && !preferredFound {
+			allErrs = append(allErrs, field.Invalid(groupPath, groupName,
+				fmt.Sprintf("must be used together with %q or %q",
+					kueuealpha.PodSetRequiredTopologyAnnotation,
+					kueuealpha.PodSetPreferredTopologyAnnotation)))
+		}
+	}
+	allErrs = append(allErrs, validateTASPodSetSlices(annotationsPath, replicaMetadata, requiredFound || preferredFound)...)
+	return allErrs
+}
+
+func validateTASPodSetSlices(annotationsPath *field.Path, replicaMetadata *metav1.ObjectMeta, levelFound bool) field.ErrorList {
+	var allErrs field.ErrorList
+	sliceLevel, sliceLevelFound := replicaMetadata.Annotations[kueuealpha.PodSetSliceRequiredTopologyAnnotation]
+	sliceSize, sliceSizeFound := replicaMetadata.Annotations[kueuealpha.PodSetSliceSizeAnnotation]
+	if !sliceLevelFound && !sliceSizeFound {
+		return nil
+	}
+	sliceLevelPath := annotationsPath.Key(kueuealpha.PodSetSliceRequiredTopologyAnnotation)
+	sliceSizePath := annotationsPath.Key(kueuealpha.PodSetSliceSizeAnnotation)
+	if sliceLevelFound {
+		allErrs = append(allErrs, metavalidation.ValidateLabelName(sliceLevel, sliceLevelPath)...)
+		if !levelFound {
+			allErrs = append(allErrs, field.Invalid(sliceLevelPath, sliceLevel,
+				fmt.Sprintf("must be used together with %q or %q",
+					kueuealpha.PodSetRequiredTopologyAnnotation,
+					kueuealpha.PodSetPreferredTopologyAnnotation)))
+		}
+		if !sliceSizeFound {
+			allErrs = append(allErrs, field.Required(sliceSizePath,
+				fmt.Sprintf("must be set together with %q", kueuealpha.PodSetSliceRequiredTopologyAnnotation)))
+		}
+	}
+	if sliceSizeFound {
+		if size, err := strconv.ParseInt(sliceSize, 10, 32); err != nil || size < 1 {
+			allErrs = append(allErrs, field.Invalid(sliceSizePath, sliceSize, "must be a positive integer"))
+		}
+		if !sliceLevelFound {
+			allErrs = append(allErrs, field.Required(sliceLevelPath,
+				fmt.Sprintf("must be set together with %q", kueuealpha.PodSetSliceSizeAnnotation)))
+		}
+	}
 	return allErrs
 }
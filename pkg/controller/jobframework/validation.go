@@ -37,6 +37,7 @@ import (
 	jobset "sigs.k8s.io/jobset/api/jobset/v1alpha2"
 
 	"sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/features"
 	utilpod "sigs.k8s.io/kueue/pkg/util/pod"
 )
 
@@ -45,6 +46,7 @@ var (
 	labelsPath                    = field.NewPath("metadata", "labels")
 	queueNameLabelPath            = labelsPath.Key(constants.QueueLabel)
 	maxExecTimeLabelPath          = labelsPath.Key(constants.MaxExecTimeSecondsLabel)
+	submittedByAnnotationPath     = annotationsPath.Key(constants.SubmittedByAnnotation)
 	workloadPriorityClassNamePath = labelsPath.Key(constants.WorkloadPriorityClassLabel)
 	supportedPrebuiltWlJobGVKs    = sets.New(
 		batchv1.SchemeGroupVersion.WithKind("Job").String(),
@@ -75,6 +77,7 @@ func ValidateJobOnUpdate(oldJob, newJob GenericJob) field.ErrorList {
 	allErrs = append(allErrs, validateUpdateForPrebuiltWorkload(oldJob, newJob)...)
 	allErrs = append(allErrs, ValidateUpdateForWorkloadPriorityClassName(oldJob.Object(), newJob.Object())...)
 	allErrs = append(allErrs, validateUpdateForMaxExecTime(oldJob, newJob)...)
+	allErrs = append(allErrs, ValidateUpdateForSubmittedBy(oldJob.Object(), newJob.Object())...)
 	return allErrs
 }
 
@@ -145,6 +148,13 @@ func ValidateUpdateForWorkloadPriorityClassName(oldObj, newObj client.Object) fi
 	return allErrs
 }
 
+func ValidateUpdateForSubmittedBy(oldObj, newObj client.Object) field.ErrorList {
+	if !features.Enabled(features.PerUserLimits) {
+		return nil
+	}
+	return apivalidation.ValidateImmutableField(newObj.GetAnnotations()[constants.SubmittedByAnnotation], oldObj.GetAnnotations()[constants.SubmittedByAnnotation], submittedByAnnotationPath)
+}
+
 func validateCreateForMaxExecTime(job GenericJob) field.ErrorList {
 	if strVal, found := job.Object().GetLabels()[constants.MaxExecTimeSecondsLabel]; found {
 		v, err := strconv.Atoi(strVal)
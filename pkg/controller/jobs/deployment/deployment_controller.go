@@ -38,19 +38,21 @@ const (
 
 func init() {
 	utilruntime.Must(jobframework.RegisterIntegration(FrameworkName, jobframework.IntegrationCallbacks{
-		SetupIndexes:   SetupIndexes,
-		NewReconciler:  jobframework.NewNoopReconcilerFactory(gvk),
-		GVK:            gvk,
-		SetupWebhook:   SetupWebhook,
-		JobType:        &appsv1.Deployment{},
-		AddToScheme:    appsv1.AddToScheme,
-		DependencyList: []string{"pod"},
+		SetupIndexes:      SetupIndexes,
+		NewReconciler:     NewReconciler,
+		GVK:               gvk,
+		SetupWebhook:      SetupWebhook,
+		JobType:           &appsv1.Deployment{},
+		AddToScheme:       appsv1.AddToScheme,
+		DependencyList:    []string{"pod"},
+		MultiKueueAdapter: newMultiKueueAdapter(),
 	}))
 }
 
 type Deployment appsv1.Deployment
 
 // +kubebuilder:rbac:groups="apps",resources=deployments,verbs=get;list;watch
+// +kubebuilder:rbac:groups="apps",resources=deployments/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups="apps",resources=replicasets,verbs=get;list;watch
 
 func fromObject(o runtime.Object) *Deployment {
@@ -65,6 +67,6 @@ func (d *Deployment) GVK() schema.GroupVersionKind {
 	return gvk
 }
 
-func SetupIndexes(context.Context, client.FieldIndexer) error {
-	return nil
+func SetupIndexes(ctx context.Context, indexer client.FieldIndexer) error {
+	return jobframework.SetupWorkloadOwnerIndex(ctx, indexer, gvk)
 }
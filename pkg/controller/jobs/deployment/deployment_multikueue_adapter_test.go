@@ -0,0 +1,65 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployment
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+
+	testingdeployment "sigs.k8s.io/kueue/pkg/util/testingjobs/deployment"
+)
+
+func TestMultiKueueObjectFuncs(t *testing.T) {
+	baseDeploymentBuilder := testingdeployment.MakeDeployment("deployment1", "ns").Replicas(3)
+
+	cases := map[string]struct {
+		local  *appsv1.Deployment
+		remote *appsv1.Deployment
+
+		wantLocal  *appsv1.Deployment
+		wantRemote *appsv1.Deployment
+	}{
+		"status is copied, except for the observed generation": {
+			local:      baseDeploymentBuilder.Clone().ObservedGeneration(1).Obj(),
+			remote:     baseDeploymentBuilder.Clone().ReadyReplicas(3).AvailableReplicas(2).ObservedGeneration(4).Obj(),
+			wantLocal:  baseDeploymentBuilder.Clone().ReadyReplicas(3).AvailableReplicas(2).ObservedGeneration(1).Obj(),
+			wantRemote: baseDeploymentBuilder.Clone().ReadyReplicas(3).AvailableReplicas(2).ObservedGeneration(4).Obj(),
+		},
+		"replicas are copied": {
+			local:      baseDeploymentBuilder.Clone().Replicas(5).Obj(),
+			remote:     baseDeploymentBuilder.Clone().Obj(),
+			wantLocal:  baseDeploymentBuilder.Clone().Replicas(5).Obj(),
+			wantRemote: baseDeploymentBuilder.Clone().Replicas(5).Obj(),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			funcs := multiKueueObjectFuncs()
+			funcs.CopyStatus(tc.local, tc.remote)
+			funcs.CopyReplicas(tc.remote, tc.local)
+
+			if diff := cmp.Diff(tc.wantLocal, tc.local); diff != "" {
+				t.Errorf("unexpected local deployment (-want/+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantRemote, tc.remote); diff != "" {
+				t.Errorf("unexpected remote deployment (-want/+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployment
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/jobframework"
+)
+
+// Reconciler creates the workloads of the Deployments dispatched as a whole
+// by MultiKueue. The Pods of the other Deployments are managed by the pod
+// integration.
+type Reconciler struct {
+	client                       client.Client
+	log                          logr.Logger
+	record                       record.EventRecorder
+	clock                        clock.Clock
+	labelKeysToCopy              []string
+	manageJobsWithoutQueueName   bool
+	managedJobsNamespaceSelector labels.Selector
+}
+
+func NewReconciler(client client.Client, eventRecorder record.EventRecorder, opts ...jobframework.Option) jobframework.JobReconcilerInterface {
+	options := jobframework.ProcessOptions(opts...)
+
+	return &Reconciler{
+		client:                       client,
+		log:                          ctrl.Log.WithName("deployment-reconciler"),
+		record:                       eventRecorder,
+		clock:                        options.Clock,
+		labelKeysToCopy:              options.LabelKeysToCopy,
+		manageJobsWithoutQueueName:   options.ManageJobsWithoutQueueName,
+		managedJobsNamespaceSelector: options.ManagedJobsNamespaceSelector,
+	}
+}
+
+var _ jobframework.JobReconcilerInterface = (*Reconciler)(nil)
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	ctrl.Log.V(3).Info("Setting up Deployment reconciler")
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&appsv1.Deployment{}).
+		Owns(&kueue.Workload{}).
+		Named("deployment").
+		WithEventFilter(r).
+		Complete(r)
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	log.V(2).Info("Reconcile Deployment")
+
+	deployment := &appsv1.Deployment{}
+	err := r.client.Get(ctx, req.NamespacedName, deployment)
+	if err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, jobframework.FinalizeMultiKueueWorkloads(ctx, r.client, req.NamespacedName, gvk)
+	}
+
+	if !deployment.DeletionTimestamp.IsZero() || !jobframework.IsManagedByMultiKueue(deployment) {
+		return ctrl.Result{}, nil
+	}
+
+	return ctrl.Result{}, jobframework.EnsureMultiKueueWorkload(ctx, r.client, r.record, r.clock, deployment, gvk, podSets(deployment), r.labelKeysToCopy)
+}
+
+func podSets(deployment *appsv1.Deployment) []kueue.PodSet {
+	podSet := kueue.PodSet{
+		Name:  kueue.DefaultPodSetName,
+		Count: ptr.Deref(deployment.Spec.Replicas, 1),
+		Template: corev1.PodTemplateSpec{
+			Spec: *deployment.Spec.Template.Spec.DeepCopy(),
+		},
+	}
+	jobframework.UngateForMultiKueue(&podSet.Template.Spec)
+	return []kueue.PodSet{podSet}
+}
+
+var _ predicate.Predicate = (*Reconciler)(nil)
+
+func (r *Reconciler) Generic(event.GenericEvent) bool {
+	return false
+}
+
+func (r *Reconciler) Create(e event.CreateEvent) bool {
+	return r.handle(e.Object)
+}
+
+func (r *Reconciler) Update(e event.UpdateEvent) bool {
+	return r.handle(e.ObjectNew)
+}
+
+func (r *Reconciler) Delete(e event.DeleteEvent) bool {
+	return r.handle(e.Object)
+}
+
+func (r *Reconciler) handle(obj client.Object) bool {
+	deployment, isDeployment := obj.(*appsv1.Deployment)
+	if !isDeployment {
+		return true
+	}
+
+	if !jobframework.IsManagedByMultiKueue(deployment) {
+		return false
+	}
+
+	ctx := context.Background()
+	log := r.log.WithValues("deployment", klog.KObj(deployment))
+	ctrl.LoggerInto(ctx, log)
+
+	// Handle only deployments managed by kueue.
+	suspend, err := jobframework.WorkloadShouldBeSuspended(ctx, deployment, r.client, r.manageJobsWithoutQueueName, r.managedJobsNamespaceSelector)
+	if err != nil {
+		log.Error(err, "Failed to determine if the Deployment should be managed by Kueue")
+	}
+
+	return suspend
+}
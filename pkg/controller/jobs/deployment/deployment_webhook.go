@@ -69,16 +69,34 @@ func (wh *Webhook) Default(ctx context.Context, obj runtime.Object) error {
 	log := ctrl.LoggerFrom(ctx).WithName("deployment-webhook")
 	log.V(5).Info("Propagating queue-name")
 
+	// The copy created by MultiKueue in a worker cluster uses the quota of the
+	// copy of the workload.
+	isRemote, err := jobframework.IsMultiKueueRemoteObject(ctx, wh.client, deployment.Object())
+	if err != nil {
+		return err
+	}
+	if isRemote {
+		return nil
+	}
+
 	jobframework.ApplyDefaultLocalQueue(deployment.Object(), wh.queues.DefaultLocalQueueExist)
+	jobframework.ApplyDefaultForSubmittedBy(ctx, deployment.Object())
 	suspend, err := jobframework.WorkloadShouldBeSuspended(ctx, deployment.Object(), wh.client, wh.manageJobsWithoutQueueName, wh.managedJobsNamespaceSelector)
 	if err != nil {
 		return err
 	}
 	if suspend {
+		if jobframework.IsManagedByMultiKueue(deployment.Object()) {
+			jobframework.GateForMultiKueue(&deployment.Spec.Template.Spec)
+			return nil
+		}
 		if deployment.Spec.Template.Annotations == nil {
 			deployment.Spec.Template.Annotations = make(map[string]string, 1)
 		}
 		deployment.Spec.Template.Annotations[podconstants.SuspendedByParentAnnotation] = FrameworkName
+		if submittedBy, found := deployment.Annotations[controllerconstants.SubmittedByAnnotation]; found {
+			deployment.Spec.Template.Annotations[controllerconstants.SubmittedByAnnotation] = submittedBy
+		}
 		if deployment.Spec.Template.Labels == nil {
 			deployment.Spec.Template.Labels = make(map[string]string, 1)
 		}
@@ -113,6 +131,7 @@ func (wh *Webhook) ValidateCreate(ctx context.Context, obj runtime.Object) (warn
 var (
 	labelsPath         = field.NewPath("metadata", "labels")
 	queueNameLabelPath = labelsPath.Key(controllerconstants.QueueLabel)
+	specTemplatePath   = field.NewPath("spec", "template")
 )
 
 func (wh *Webhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (warnings admission.Warnings, err error) {
@@ -127,6 +146,7 @@ func (wh *Webhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Ob
 
 	allErrs := jobframework.ValidateQueueName(newDeployment.Object())
 	allErrs = append(allErrs, jobframework.ValidateUpdateForWorkloadPriorityClassName(oldDeployment.Object(), newDeployment.Object())...)
+	allErrs = append(allErrs, jobframework.ValidateUpdateForSubmittedBy(oldDeployment.Object(), newDeployment.Object())...)
 
 	// Prevents updating the queue-name if at least one Pod is not suspended
 	// or if the queue-name has been deleted.
@@ -134,6 +154,12 @@ func (wh *Webhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Ob
 		allErrs = append(allErrs, apivalidation.ValidateImmutableField(oldQueueName, newQueueName, queueNameLabelPath)...)
 	}
 
+	// The Pod template of a Deployment dispatched as a whole by MultiKueue is
+	// not propagated to the worker cluster, only its scale is.
+	if jobframework.IsManagedByMultiKueue(newDeployment.Object()) {
+		allErrs = append(allErrs, apivalidation.ValidateImmutableField(newDeployment.Spec.Template, oldDeployment.Spec.Template, specTemplatePath)...)
+	}
+
 	return warnings, allErrs.ToAggregate()
 }
 
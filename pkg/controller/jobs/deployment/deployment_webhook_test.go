@@ -25,6 +25,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
@@ -40,6 +41,7 @@ func TestDefault(t *testing.T) {
 		deployment           *appsv1.Deployment
 		localQueueDefaulting bool
 		defaultLqExist       bool
+		workloads            []kueue.Workload
 		want                 *appsv1.Deployment
 	}{
 		"deployment without queue": {
@@ -69,6 +71,56 @@ func TestDefault(t *testing.T) {
 				PodTemplateAnnotation(podconstants.SuspendedByParentAnnotation, FrameworkName).
 				Obj(),
 		},
+		"deployment with queue managed by multikueue": {
+			deployment: testingdeployment.MakeDeployment("test-pod", "").
+				Queue("test-queue").
+				Label(constants.ManagedByLabel, kueue.MultiKueueControllerName).
+				Obj(),
+			want: testingdeployment.MakeDeployment("test-pod", "").
+				Queue("test-queue").
+				Label(constants.ManagedByLabel, kueue.MultiKueueControllerName).
+				PodTemplateSpecSchedulingGate(constants.MultiKueueSchedulingGate).
+				Obj(),
+		},
+		"deployment created by multikueue in a worker cluster": {
+			localQueueDefaulting: true,
+			defaultLqExist:       true,
+			deployment: testingdeployment.MakeDeployment("test-pod", "default").
+				Label(constants.PrebuiltWorkloadLabel, "wl").
+				Label(kueue.MultiKueueOriginLabel, "origin").
+				Obj(),
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("wl", "default").
+					Label(kueue.MultiKueueOriginLabel, "origin").
+					ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+					Obj(),
+			},
+			want: testingdeployment.MakeDeployment("test-pod", "default").
+				Label(constants.PrebuiltWorkloadLabel, "wl").
+				Label(kueue.MultiKueueOriginLabel, "origin").
+				Obj(),
+		},
+		"deployment with the multikueue origin label set by a user": {
+			localQueueDefaulting: true,
+			defaultLqExist:       true,
+			deployment: testingdeployment.MakeDeployment("test-pod", "default").
+				Label(constants.PrebuiltWorkloadLabel, "wl").
+				Label(kueue.MultiKueueOriginLabel, "origin").
+				Obj(),
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("wl", "default").
+					Label(kueue.MultiKueueOriginLabel, "origin").
+					Obj(),
+			},
+			want: testingdeployment.MakeDeployment("test-pod", "default").
+				Label(constants.PrebuiltWorkloadLabel, "wl").
+				Label(kueue.MultiKueueOriginLabel, "origin").
+				Queue("default").
+				PodTemplateSpecManagedByKueue().
+				PodTemplateSpecQueue("default").
+				PodTemplateAnnotation(podconstants.SuspendedByParentAnnotation, FrameworkName).
+				Obj(),
+		},
 		"deployment without queue with pod template spec queue": {
 			deployment: testingdeployment.MakeDeployment("test-pod", "").PodTemplateSpecQueue("test-queue").Obj(),
 			want:       testingdeployment.MakeDeployment("test-pod", "").PodTemplateSpecQueue("test-queue").Obj(),
@@ -149,7 +201,7 @@ func TestDefault(t *testing.T) {
 			ctx, _ := utiltesting.ContextWithLog(t)
 			features.SetFeatureGateDuringTest(t, features.LocalQueueDefaulting, tc.localQueueDefaulting)
 			t.Cleanup(jobframework.EnableIntegrationsForTest(t, "pod"))
-			builder := utiltesting.NewClientBuilder()
+			builder := utiltesting.NewClientBuilder().WithLists(&kueue.WorkloadList{Items: tc.workloads})
 			client := builder.Build()
 			cqCache := cache.New(client)
 			queueManager := queue.NewManager(client, cqCache)
@@ -291,6 +343,46 @@ func TestValidateUpdate(t *testing.T) {
 				},
 			}.ToAggregate(),
 		},
+		"change in replicas (managed by multikueue)": {
+			oldDeployment: testingdeployment.MakeDeployment("test-pod", "").
+				Queue("test-queue").
+				Label(constants.ManagedByLabel, kueue.MultiKueueControllerName).
+				Replicas(3).
+				Obj(),
+			newDeployment: testingdeployment.MakeDeployment("test-pod", "").
+				Queue("test-queue").
+				Label(constants.ManagedByLabel, kueue.MultiKueueControllerName).
+				Replicas(4).
+				Obj(),
+		},
+		"change in pod template (managed by multikueue)": {
+			oldDeployment: testingdeployment.MakeDeployment("test-pod", "").
+				Queue("test-queue").
+				Label(constants.ManagedByLabel, kueue.MultiKueueControllerName).
+				Image("image:v1", nil).
+				Obj(),
+			newDeployment: testingdeployment.MakeDeployment("test-pod", "").
+				Queue("test-queue").
+				Label(constants.ManagedByLabel, kueue.MultiKueueControllerName).
+				Image("image:v2", nil).
+				Obj(),
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "spec.template",
+				},
+			}.ToAggregate(),
+		},
+		"change in pod template": {
+			oldDeployment: testingdeployment.MakeDeployment("test-pod", "").
+				Queue("test-queue").
+				Image("image:v1", nil).
+				Obj(),
+			newDeployment: testingdeployment.MakeDeployment("test-pod", "").
+				Queue("test-queue").
+				Image("image:v2", nil).
+				Obj(),
+		},
 		"update priority-class": {
 			oldDeployment: testingdeployment.MakeDeployment("test-pod", "").
 				Queue("test-queue").
@@ -46,6 +46,7 @@ func init() {
 		AddToScheme:              leaderworkersetv1.AddToScheme,
 		DependencyList:           []string{"pod"},
 		GVK:                      gvk,
+		MultiKueueAdapter:        newMultiKueueAdapter(),
 	}))
 }
 
@@ -63,6 +64,6 @@ func (lws *LeaderWorkerSet) GVK() schema.GroupVersionKind {
 	return gvk
 }
 
-func SetupIndexes(context.Context, client.FieldIndexer) error {
-	return nil
+func SetupIndexes(ctx context.Context, indexer client.FieldIndexer) error {
+	return jobframework.SetupWorkloadOwnerIndex(ctx, indexer, gvk)
 }
@@ -0,0 +1,61 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package leaderworkerset
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	leaderworkersetv1 "sigs.k8s.io/lws/api/leaderworkerset/v1"
+
+	"sigs.k8s.io/kueue/pkg/controller/jobframework"
+)
+
+func newMultiKueueAdapter() *jobframework.MultiKueueObjectAdapter[leaderworkersetv1.LeaderWorkerSet, *leaderworkersetv1.LeaderWorkerSet] {
+	return jobframework.NewMultiKueueObjectAdapter(gvk, multiKueueObjectFuncs())
+}
+
+func multiKueueObjectFuncs() jobframework.MultiKueueObjectFuncs[*leaderworkersetv1.LeaderWorkerSet] {
+	return jobframework.MultiKueueObjectFuncs[*leaderworkersetv1.LeaderWorkerSet]{
+		NewList: func() client.ObjectList {
+			return &leaderworkersetv1.LeaderWorkerSetList{}
+		},
+		NewRemote: func(local *leaderworkersetv1.LeaderWorkerSet, meta metav1.ObjectMeta) *leaderworkersetv1.LeaderWorkerSet {
+			return &leaderworkersetv1.LeaderWorkerSet{
+				ObjectMeta: meta,
+				Spec:       *local.Spec.DeepCopy(),
+			}
+		},
+		PodSpecs: func(lws *leaderworkersetv1.LeaderWorkerSet) []*corev1.PodSpec {
+			specs := []*corev1.PodSpec{&lws.Spec.LeaderWorkerTemplate.WorkerTemplate.Spec}
+			if lws.Spec.LeaderWorkerTemplate.LeaderTemplate != nil {
+				specs = append(specs, &lws.Spec.LeaderWorkerTemplate.LeaderTemplate.Spec)
+			}
+			return specs
+		},
+		PodSets: multiKueuePodSets,
+		CopyReplicas: func(remote, local *leaderworkersetv1.LeaderWorkerSet) {
+			remote.Spec.Replicas = local.Spec.Replicas
+		},
+		CopyStatus: func(local, remote *leaderworkersetv1.LeaderWorkerSet) {
+			local.Status = *remote.Status.DeepCopy()
+			for i := range local.Status.Conditions {
+				local.Status.Conditions[i].ObservedGeneration = 0
+			}
+		},
+	}
+}
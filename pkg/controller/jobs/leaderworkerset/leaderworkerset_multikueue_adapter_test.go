@@ -0,0 +1,87 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package leaderworkerset
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	leaderworkersetv1 "sigs.k8s.io/lws/api/leaderworkerset/v1"
+
+	testingleaderworkerset "sigs.k8s.io/kueue/pkg/util/testingjobs/leaderworkerset"
+)
+
+func TestMultiKueueObjectFuncs(t *testing.T) {
+	baseLeaderWorkerSetBuilder := testingleaderworkerset.MakeLeaderWorkerSet("lws1", "ns").Replicas(3)
+	availableCondition := metav1.Condition{
+		Type:   string(leaderworkersetv1.LeaderWorkerSetAvailable),
+		Status: metav1.ConditionTrue,
+		Reason: "AllGroupsReady",
+	}
+	remoteAvailableCondition := *availableCondition.DeepCopy()
+	remoteAvailableCondition.ObservedGeneration = 2
+
+	cases := map[string]struct {
+		local  *leaderworkersetv1.LeaderWorkerSet
+		remote *leaderworkersetv1.LeaderWorkerSet
+
+		wantLocal    *leaderworkersetv1.LeaderWorkerSet
+		wantRemote   *leaderworkersetv1.LeaderWorkerSet
+		wantPodSpecs int
+	}{
+		"status is copied, except for the observed generation of the conditions": {
+			local:        baseLeaderWorkerSetBuilder.Clone().Obj(),
+			remote:       baseLeaderWorkerSetBuilder.Clone().ReadyReplicas(3).StatusConditions(remoteAvailableCondition).Obj(),
+			wantLocal:    baseLeaderWorkerSetBuilder.Clone().ReadyReplicas(3).StatusConditions(availableCondition).Obj(),
+			wantRemote:   baseLeaderWorkerSetBuilder.Clone().ReadyReplicas(3).StatusConditions(remoteAvailableCondition).Obj(),
+			wantPodSpecs: 1,
+		},
+		"replicas are copied": {
+			local:        baseLeaderWorkerSetBuilder.Clone().Replicas(5).Obj(),
+			remote:       baseLeaderWorkerSetBuilder.Clone().Obj(),
+			wantLocal:    baseLeaderWorkerSetBuilder.Clone().Replicas(5).Obj(),
+			wantRemote:   baseLeaderWorkerSetBuilder.Clone().Replicas(5).Obj(),
+			wantPodSpecs: 1,
+		},
+		"pod specs of the leader and the workers": {
+			local:        baseLeaderWorkerSetBuilder.Clone().LeaderTemplate(corev1.PodTemplateSpec{}).Obj(),
+			remote:       baseLeaderWorkerSetBuilder.Clone().LeaderTemplate(corev1.PodTemplateSpec{}).Obj(),
+			wantLocal:    baseLeaderWorkerSetBuilder.Clone().LeaderTemplate(corev1.PodTemplateSpec{}).Obj(),
+			wantRemote:   baseLeaderWorkerSetBuilder.Clone().LeaderTemplate(corev1.PodTemplateSpec{}).Obj(),
+			wantPodSpecs: 2,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			funcs := multiKueueObjectFuncs()
+			funcs.CopyStatus(tc.local, tc.remote)
+			funcs.CopyReplicas(tc.remote, tc.local)
+
+			if diff := cmp.Diff(tc.wantLocal, tc.local); diff != "" {
+				t.Errorf("unexpected local leaderworkerset (-want/+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantRemote, tc.remote); diff != "" {
+				t.Errorf("unexpected remote leaderworkerset (-want/+got):\n%s", diff)
+			}
+			if got := len(funcs.PodSpecs(tc.remote)); got != tc.wantPodSpecs {
+				t.Errorf("unexpected number of pod specs, want %d, got %d", tc.wantPodSpecs, got)
+			}
+		})
+	}
+}
@@ -123,6 +123,12 @@ func (r *PodReconciler) setDefault(ctx context.Context, pod *corev1.Pod) (bool,
 		return false, nil
 	}
 
+	// The Pods of a LeaderWorkerSet dispatched as a whole by MultiKueue stay
+	// gated in the manager cluster.
+	if jobframework.IsManagedByMultiKueue(lws) {
+		return false, nil
+	}
+
 	wlName := GetWorkloadName(lws.UID, lws.Name, pod.Labels[leaderworkersetv1.GroupIndexLabelKey])
 
 	pod.Labels[constants.ManagedByKueueLabelKey] = constants.ManagedByKueueLabelValue
@@ -28,6 +28,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -56,6 +57,7 @@ type Reconciler struct {
 	client                       client.Client
 	log                          logr.Logger
 	record                       record.EventRecorder
+	clock                        clock.Clock
 	labelKeysToCopy              []string
 	manageJobsWithoutQueueName   bool
 	managedJobsNamespaceSelector labels.Selector
@@ -68,6 +70,7 @@ func NewReconciler(client client.Client, eventRecorder record.EventRecorder, opt
 		client:                       client,
 		log:                          ctrl.Log.WithName("leaderworkerset-reconciler"),
 		record:                       eventRecorder,
+		clock:                        options.Clock,
 		labelKeysToCopy:              options.LabelKeysToCopy,
 		manageJobsWithoutQueueName:   options.ManageJobsWithoutQueueName,
 		managedJobsNamespaceSelector: options.ManagedJobsNamespaceSelector,
@@ -81,24 +84,35 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&leaderworkersetv1.LeaderWorkerSet{}).
+		Owns(&kueue.Workload{}).
 		Named("leaderworkerset").
 		WithEventFilter(r).
 		Complete(r)
 }
 
 // +kubebuilder:rbac:groups=leaderworkerset.x-k8s.io,resources=leaderworkersets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=leaderworkerset.x-k8s.io,resources=leaderworkersets/status,verbs=get;update;patch
 
 func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
 	lws := &leaderworkersetv1.LeaderWorkerSet{}
 	err := r.client.Get(ctx, req.NamespacedName, lws)
 	if err != nil {
-		// we'll ignore not-found errors, since there is nothing to do.
-		return ctrl.Result{}, client.IgnoreNotFound(err)
+		if client.IgnoreNotFound(err) != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, jobframework.FinalizeMultiKueueWorkloads(ctx, r.client, req.NamespacedName, gvk)
 	}
 
 	log := ctrl.LoggerFrom(ctx)
 	log.V(2).Info("Reconcile LeaderWorkerSet")
 
+	if jobframework.IsManagedByMultiKueue(lws) {
+		if !lws.DeletionTimestamp.IsZero() {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, jobframework.EnsureMultiKueueWorkload(ctx, r.client, r.record, r.clock, lws, gvk, multiKueuePodSets(lws), r.labelKeysToCopy)
+	}
+
 	wlList := &kueue.WorkloadList{}
 	if err := r.client.List(ctx, wlList, client.InNamespace(lws.GetNamespace()),
 		client.MatchingFields{indexer.OwnerReferenceUID: string(lws.GetUID())},
@@ -184,14 +198,14 @@ func (r *Reconciler) createPrebuiltWorkload(ctx context.Context, lws *leaderwork
 }
 
 func (r *Reconciler) constructWorkload(lws *leaderworkersetv1.LeaderWorkerSet, workloadName string) (*kueue.Workload, error) {
-	createdWorkload := podcontroller.NewGroupWorkload(workloadName, lws, r.podSets(lws), r.labelKeysToCopy)
+	createdWorkload := podcontroller.NewGroupWorkload(workloadName, lws, podSets(lws), r.labelKeysToCopy)
 	if err := controllerutil.SetOwnerReference(lws, createdWorkload, r.client.Scheme()); err != nil {
 		return nil, err
 	}
 	return createdWorkload, nil
 }
 
-func (r *Reconciler) podSets(lws *leaderworkersetv1.LeaderWorkerSet) []kueue.PodSet {
+func podSets(lws *leaderworkersetv1.LeaderWorkerSet) []kueue.PodSet {
 	podSets := make([]kueue.PodSet, 0, 2)
 
 	if lws.Spec.LeaderWorkerTemplate.LeaderTemplate != nil {
@@ -242,6 +256,18 @@ func (r *Reconciler) podSets(lws *leaderworkersetv1.LeaderWorkerSet) []kueue.Pod
 	return podSets
 }
 
+// multiKueuePodSets returns the PodSets of the single workload of a
+// LeaderWorkerSet dispatched as a whole by MultiKueue, covering all the groups.
+func multiKueuePodSets(lws *leaderworkersetv1.LeaderWorkerSet) []kueue.PodSet {
+	podSets := podSets(lws)
+	replicas := ptr.Deref(lws.Spec.Replicas, 1)
+	for i := range podSets {
+		podSets[i].Count *= replicas
+		jobframework.UngateForMultiKueue(&podSets[i].Template.Spec)
+	}
+	return podSets
+}
+
 func (r *Reconciler) removeOwnerReference(ctx context.Context, lws *leaderworkersetv1.LeaderWorkerSet, wl *kueue.Workload) error {
 	err := controllerutil.RemoveOwnerReference(lws, wl, r.client.Scheme())
 	if err != nil {
@@ -271,7 +297,8 @@ func (r *Reconciler) Delete(event.DeleteEvent) bool {
 func (r *Reconciler) handle(obj client.Object) bool {
 	lws, isLws := obj.(*leaderworkersetv1.LeaderWorkerSet)
 	if !isLws {
-		return false
+		// The workloads of the LeaderWorkerSets dispatched as a whole by MultiKueue.
+		return true
 	}
 
 	ctx := context.Background()
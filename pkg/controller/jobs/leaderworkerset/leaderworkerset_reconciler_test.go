@@ -34,6 +34,7 @@ import (
 	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/constants"
+	controllerconstants "sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
 	podconstants "sigs.k8s.io/kueue/pkg/controller/jobs/pod/constants"
 	"sigs.k8s.io/kueue/pkg/features"
@@ -104,6 +105,85 @@ func TestReconciler(t *testing.T) {
 			},
 			enableTopologyAwareScheduling: false,
 		},
+		"should create a single workload for a leaderworkerset managed by multikueue": {
+			leaderWorkerSet: leaderworkerset.MakeLeaderWorkerSet(testLWS, testNS).
+				UID(testUID).
+				Queue("queue").
+				Label(controllerconstants.ManagedByLabel, kueue.MultiKueueControllerName).
+				Replicas(2).
+				Size(3).
+				LeaderTemplate(corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{Name: "c", Image: "pause"},
+						},
+						SchedulingGates: []corev1.PodSchedulingGate{{Name: controllerconstants.MultiKueueSchedulingGate}},
+					},
+				}).
+				WorkerTemplateSpecSchedulingGate(controllerconstants.MultiKueueSchedulingGate).
+				Obj(),
+			wantLeaderWorkerSet: leaderworkerset.MakeLeaderWorkerSet(testLWS, testNS).
+				UID(testUID).
+				Queue("queue").
+				Label(controllerconstants.ManagedByLabel, kueue.MultiKueueControllerName).
+				Replicas(2).
+				Size(3).
+				LeaderTemplate(corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{Name: "c", Image: "pause"},
+						},
+						SchedulingGates: []corev1.PodSchedulingGate{{Name: controllerconstants.MultiKueueSchedulingGate}},
+					},
+				}).
+				WorkerTemplateSpecSchedulingGate(controllerconstants.MultiKueueSchedulingGate).
+				Obj(),
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload(jobframework.GetWorkloadNameForOwnerWithGVK(testLWS, types.UID(testUID), gvk), testNS).
+					ControllerReference(gvk, testLWS, testUID).
+					Label(controllerconstants.JobUIDLabel, testUID).
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					Queue("queue").
+					PodSets(
+						kueue.PodSet{
+							Name: leaderPodSetName,
+							Template: corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{
+									Containers: []corev1.Container{
+										{Name: "c", Image: "pause"},
+									},
+								},
+							},
+							Count: 2,
+						},
+						kueue.PodSet{
+							Name: workerPodSetName,
+							Template: corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{
+									Containers: []corev1.Container{
+										{Name: "c", Image: "pause"},
+									},
+								},
+							},
+							Count: 4,
+						},
+					).
+					Priority(0).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: testLWS, Namespace: testNS},
+					EventType: corev1.EventTypeNormal,
+					Reason:    jobframework.ReasonCreatedWorkload,
+					Message: fmt.Sprintf(
+						"Created Workload: %s/%s",
+						testNS,
+						jobframework.GetWorkloadNameForOwnerWithGVK(testLWS, types.UID(testUID), gvk),
+					),
+				},
+			},
+		},
 		"should create prebuilt workload with leader template": {
 			leaderWorkerSet: leaderworkerset.MakeLeaderWorkerSet(testLWS, testNS).
 				UID(testUID).
@@ -69,12 +69,28 @@ func (wh *Webhook) Default(ctx context.Context, obj runtime.Object) error {
 	log := ctrl.LoggerFrom(ctx).WithName("leaderworkerset-webhook")
 	log.V(5).Info("Applying defaults")
 
+	// The copy created by MultiKueue in a worker cluster uses the quota of the
+	// copy of the workload.
+	isRemote, err := jobframework.IsMultiKueueRemoteObject(ctx, wh.client, lws.Object())
+	if err != nil {
+		return err
+	}
+	if isRemote {
+		return nil
+	}
+
 	jobframework.ApplyDefaultLocalQueue(lws.Object(), wh.queues.DefaultLocalQueueExist)
+	jobframework.ApplyDefaultForSubmittedBy(ctx, lws.Object())
 	suspend, err := jobframework.WorkloadShouldBeSuspended(ctx, lws.Object(), wh.client, wh.manageJobsWithoutQueueName, wh.managedJobsNamespaceSelector)
 	if err != nil {
 		return err
 	}
-	if suspend {
+	if suspend && jobframework.IsManagedByMultiKueue(lws.Object()) {
+		if lws.Spec.LeaderWorkerTemplate.LeaderTemplate != nil {
+			jobframework.GateForMultiKueue(&lws.Spec.LeaderWorkerTemplate.LeaderTemplate.Spec)
+		}
+		jobframework.GateForMultiKueue(&lws.Spec.LeaderWorkerTemplate.WorkerTemplate.Spec)
+	} else if suspend {
 		if lws.Spec.LeaderWorkerTemplate.LeaderTemplate != nil {
 			wh.podTemplateSpecDefault(lws, lws.Spec.LeaderWorkerTemplate.LeaderTemplate)
 		}
@@ -96,6 +112,9 @@ func (wh *Webhook) podTemplateSpecDefault(lws *LeaderWorkerSet, podTemplateSpec
 		podTemplateSpec.Annotations = make(map[string]string, 2)
 	}
 	podTemplateSpec.Annotations[podconstants.SuspendedByParentAnnotation] = FrameworkName
+	if submittedBy, found := lws.Annotations[constants.SubmittedByAnnotation]; found {
+		podTemplateSpec.Annotations[constants.SubmittedByAnnotation] = submittedBy
+	}
 	podTemplateSpec.Annotations[podconstants.GroupServingAnnotationKey] = podconstants.GroupServingAnnotationValue
 }
 
@@ -144,6 +163,10 @@ func (wh *Webhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Ob
 		newLeaderWorkerSet.Object(),
 		oldLeaderWorkerSet.Object(),
 	)...)
+	allErrs = append(allErrs, jobframework.ValidateUpdateForSubmittedBy(
+		oldLeaderWorkerSet.Object(),
+		newLeaderWorkerSet.Object(),
+	)...)
 
 	suspend, err := jobframework.WorkloadShouldBeSuspended(ctx, newLeaderWorkerSet.Object(), wh.client, wh.manageJobsWithoutQueueName, wh.managedJobsNamespaceSelector)
 	if err != nil {
@@ -162,6 +185,12 @@ func (wh *Webhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Ob
 		)...)
 	}
 
+	// The Pod templates of a LeaderWorkerSet dispatched as a whole by
+	// MultiKueue are not propagated to the worker cluster, only its scale is.
+	if jobframework.IsManagedByMultiKueue(newLeaderWorkerSet.Object()) {
+		allErrs = append(allErrs, apivalidation.ValidateImmutableField(newLeaderWorkerSet.Spec.LeaderWorkerTemplate, oldLeaderWorkerSet.Spec.LeaderWorkerTemplate, leaderWorkerTemplatePath)...)
+	}
+
 	return warnings, allErrs.ToAggregate()
 }
 
@@ -29,6 +29,7 @@ import (
 	leaderworkersetv1 "sigs.k8s.io/lws/api/leaderworkerset/v1"
 
 	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
@@ -46,6 +47,7 @@ func TestDefault(t *testing.T) {
 		localQueueDefaulting       bool
 		defaultLqExist             bool
 		enableIntegrations         []string
+		workloads                  []kueue.Workload
 		want                       *leaderworkersetv1.LeaderWorkerSet
 	}{
 		"LeaderWorkerSet with WorkloadPriorityClass": {
@@ -98,6 +100,50 @@ func TestDefault(t *testing.T) {
 				WorkerTemplateSpecAnnotation(podconstants.GroupServingAnnotationKey, podconstants.GroupServingAnnotationValue).
 				Obj(),
 		},
+		"LeaderWorkerSet with queue managed by multikueue": {
+			lws: testingleaderworkerset.MakeLeaderWorkerSet("test-lws", "").
+				Queue("test-queue").
+				Label(constants.ManagedByLabel, kueue.MultiKueueControllerName).
+				Obj(),
+			want: testingleaderworkerset.MakeLeaderWorkerSet("test-lws", "").
+				Queue("test-queue").
+				Label(constants.ManagedByLabel, kueue.MultiKueueControllerName).
+				WorkerTemplateSpecSchedulingGate(constants.MultiKueueSchedulingGate).
+				Obj(),
+		},
+		"LeaderWorkerSet created by multikueue in a worker cluster": {
+			localQueueDefaulting: true,
+			defaultLqExist:       true,
+			lws: testingleaderworkerset.MakeLeaderWorkerSet("test-lws", "default").
+				Label(constants.PrebuiltWorkloadLabel, "wl").
+				Label(kueue.MultiKueueOriginLabel, "origin").
+				Obj(),
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("wl", "default").
+					Label(kueue.MultiKueueOriginLabel, "origin").
+					ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+					Obj(),
+			},
+			want: testingleaderworkerset.MakeLeaderWorkerSet("test-lws", "default").
+				Label(constants.PrebuiltWorkloadLabel, "wl").
+				Label(kueue.MultiKueueOriginLabel, "origin").
+				Obj(),
+		},
+		"LeaderWorkerSet with the multikueue origin label set by a user": {
+			localQueueDefaulting: true,
+			defaultLqExist:       true,
+			lws: testingleaderworkerset.MakeLeaderWorkerSet("test-lws", "default").
+				Label(constants.PrebuiltWorkloadLabel, "wl").
+				Label(kueue.MultiKueueOriginLabel, "origin").
+				Obj(),
+			want: testingleaderworkerset.MakeLeaderWorkerSet("test-lws", "default").
+				Label(constants.PrebuiltWorkloadLabel, "wl").
+				Label(kueue.MultiKueueOriginLabel, "origin").
+				Queue("default").
+				WorkerTemplateSpecAnnotation(podconstants.SuspendedByParentAnnotation, FrameworkName).
+				WorkerTemplateSpecAnnotation(podconstants.GroupServingAnnotationKey, podconstants.GroupServingAnnotationValue).
+				Obj(),
+		},
 		"LocalQueueDefaulting enabled, default lq isn't created, job doesn't have queue label": {
 			localQueueDefaulting: true,
 			defaultLqExist:       false,
@@ -116,7 +162,7 @@ func TestDefault(t *testing.T) {
 			t.Cleanup(jobframework.EnableIntegrationsForTest(t, tc.enableIntegrations...))
 			ctx, _ := utiltesting.ContextWithLog(t)
 
-			builder := utiltesting.NewClientBuilder()
+			builder := utiltesting.NewClientBuilder().WithLists(&kueue.WorkloadList{Items: tc.workloads})
 			cli := builder.Build()
 			cqCache := cache.New(cli)
 			queueManager := queue.NewManager(cli, cqCache)
@@ -387,6 +433,36 @@ func TestValidateUpdate(t *testing.T) {
 				WorkerTemplateSpecAnnotation(podconstants.SuspendedByParentAnnotation, FrameworkName).
 				Obj(),
 		},
+		"change image (managed by multikueue)": {
+			oldObj: testingleaderworkerset.MakeLeaderWorkerSet("test-lws", "").
+				Queue("test-queue").
+				Label(constants.ManagedByLabel, kueue.MultiKueueControllerName).
+				Image("pause:0.1.0", nil).
+				Obj(),
+			newObj: testingleaderworkerset.MakeLeaderWorkerSet("test-lws", "").
+				Queue("test-queue").
+				Label(constants.ManagedByLabel, kueue.MultiKueueControllerName).
+				Image("pause:0.1.1", nil).
+				Obj(),
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: leaderWorkerTemplatePath.String(),
+				},
+			}.ToAggregate(),
+		},
+		"change replicas (managed by multikueue)": {
+			oldObj: testingleaderworkerset.MakeLeaderWorkerSet("test-lws", "").
+				Queue("test-queue").
+				Label(constants.ManagedByLabel, kueue.MultiKueueControllerName).
+				Replicas(3).
+				Obj(),
+			newObj: testingleaderworkerset.MakeLeaderWorkerSet("test-lws", "").
+				Queue("test-queue").
+				Label(constants.ManagedByLabel, kueue.MultiKueueControllerName).
+				Replicas(4).
+				Obj(),
+		},
 		"change resources in container": {
 			oldObj: testingleaderworkerset.MakeLeaderWorkerSet("test-lws", "").
 				LeaderTemplate(corev1.PodTemplateSpec{
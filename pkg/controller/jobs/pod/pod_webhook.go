@@ -129,7 +129,11 @@ func (w *PodWebhook) Default(ctx context.Context, obj runtime.Object) error {
 	log.V(5).Info("Applying defaults")
 
 	_, suspend := pod.pod.GetAnnotations()[podconstants.SuspendedByParentAnnotation]
-	if !suspend {
+	if suspend {
+		if err := jobframework.ApplyDefaultForSubmittedByFromAncestor(ctx, w.client, pod.Object(), w.manageJobsWithoutQueueName); err != nil {
+			return err
+		}
+	} else {
 		// Namespace filtering
 		ns := corev1.Namespace{}
 		err := w.client.Get(ctx, client.ObjectKey{Name: pod.pod.GetNamespace()}, &ns)
@@ -184,6 +188,7 @@ func (w *PodWebhook) Default(ctx context.Context, obj runtime.Object) error {
 				pod.pod.Labels = make(map[string]string)
 			}
 			pod.pod.Labels[constants.ManagedByKueueLabelKey] = constants.ManagedByKueueLabelValue
+			jobframework.ApplyDefaultForSubmittedBy(ctx, pod.Object())
 		}
 	}
 
@@ -0,0 +1,56 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statefulset
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/kueue/pkg/controller/jobframework"
+)
+
+func newMultiKueueAdapter() *jobframework.MultiKueueObjectAdapter[appsv1.StatefulSet, *appsv1.StatefulSet] {
+	return jobframework.NewMultiKueueObjectAdapter(gvk, multiKueueObjectFuncs())
+}
+
+func multiKueueObjectFuncs() jobframework.MultiKueueObjectFuncs[*appsv1.StatefulSet] {
+	return jobframework.MultiKueueObjectFuncs[*appsv1.StatefulSet]{
+		NewList: func() client.ObjectList {
+			return &appsv1.StatefulSetList{}
+		},
+		NewRemote: func(local *appsv1.StatefulSet, meta metav1.ObjectMeta) *appsv1.StatefulSet {
+			return &appsv1.StatefulSet{
+				ObjectMeta: meta,
+				Spec:       *local.Spec.DeepCopy(),
+			}
+		},
+		PodSpecs: func(sts *appsv1.StatefulSet) []*corev1.PodSpec {
+			return []*corev1.PodSpec{&sts.Spec.Template.Spec}
+		},
+		PodSets: podSets,
+		CopyReplicas: func(remote, local *appsv1.StatefulSet) {
+			remote.Spec.Replicas = local.Spec.Replicas
+		},
+		CopyStatus: func(local, remote *appsv1.StatefulSet) {
+			observedGeneration := local.Status.ObservedGeneration
+			local.Status = *remote.Status.DeepCopy()
+			local.Status.ObservedGeneration = observedGeneration
+		},
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statefulset
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+
+	testingstatefulset "sigs.k8s.io/kueue/pkg/util/testingjobs/statefulset"
+)
+
+func TestMultiKueueObjectFuncs(t *testing.T) {
+	baseStatefulSetBuilder := testingstatefulset.MakeStatefulSet("sts1", "ns").Replicas(3)
+
+	cases := map[string]struct {
+		local  *appsv1.StatefulSet
+		remote *appsv1.StatefulSet
+
+		wantLocal  *appsv1.StatefulSet
+		wantRemote *appsv1.StatefulSet
+	}{
+		"status is copied, except for the observed generation": {
+			local:      baseStatefulSetBuilder.Clone().ObservedGeneration(1).Obj(),
+			remote:     baseStatefulSetBuilder.Clone().ReadyReplicas(3).StatusReplicas(2).ObservedGeneration(2).Obj(),
+			wantLocal:  baseStatefulSetBuilder.Clone().ReadyReplicas(3).StatusReplicas(2).ObservedGeneration(1).Obj(),
+			wantRemote: baseStatefulSetBuilder.Clone().ReadyReplicas(3).StatusReplicas(2).ObservedGeneration(2).Obj(),
+		},
+		"replicas are copied": {
+			local:      baseStatefulSetBuilder.Clone().Replicas(5).Obj(),
+			remote:     baseStatefulSetBuilder.Clone().Obj(),
+			wantLocal:  baseStatefulSetBuilder.Clone().Replicas(5).Obj(),
+			wantRemote: baseStatefulSetBuilder.Clone().Replicas(5).Obj(),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			funcs := multiKueueObjectFuncs()
+			funcs.CopyStatus(tc.local, tc.remote)
+			funcs.CopyReplicas(tc.remote, tc.local)
+
+			if diff := cmp.Diff(tc.wantLocal, tc.local); diff != "" {
+				t.Errorf("unexpected local statefulset (-want/+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantRemote, tc.remote); diff != "" {
+				t.Errorf("unexpected remote statefulset (-want/+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -29,6 +29,8 @@ import (
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -37,6 +39,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
 	podcontroller "sigs.k8s.io/kueue/pkg/controller/jobs/pod/constants"
 	clientutil "sigs.k8s.io/kueue/pkg/util/client"
@@ -49,6 +52,7 @@ const (
 )
 
 // +kubebuilder:rbac:groups="apps",resources=statefulsets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="apps",resources=statefulsets/status,verbs=get;update;patch
 
 var (
 	_ jobframework.JobReconcilerInterface = (*Reconciler)(nil)
@@ -57,6 +61,9 @@ var (
 type Reconciler struct {
 	client                       client.Client
 	log                          logr.Logger
+	record                       record.EventRecorder
+	clock                        clock.Clock
+	labelKeysToCopy              []string
 	manageJobsWithoutQueueName   bool
 	managedJobsNamespaceSelector labels.Selector
 }
@@ -65,10 +72,47 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 	log := ctrl.LoggerFrom(ctx)
 	log.V(2).Info("Reconcile StatefulSet")
 
+	if err := r.reconcileMultiKueueWorkload(ctx, req); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	err := r.fetchAndFinalizePods(ctx, req)
 	return ctrl.Result{}, err
 }
 
+// reconcileMultiKueueWorkload creates the workload of a StatefulSet dispatched
+// as a whole by MultiKueue, and finalizes it once the StatefulSet is deleted.
+func (r *Reconciler) reconcileMultiKueueWorkload(ctx context.Context, req reconcile.Request) error {
+	sts := &appsv1.StatefulSet{}
+	err := r.client.Get(ctx, req.NamespacedName, sts)
+	if err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		return jobframework.FinalizeMultiKueueWorkloads(ctx, r.client, req.NamespacedName, gvk)
+	}
+
+	if !sts.DeletionTimestamp.IsZero() || !jobframework.IsManagedByMultiKueue(sts) {
+		return nil
+	}
+
+	return jobframework.EnsureMultiKueueWorkload(ctx, r.client, r.record, r.clock, sts, gvk, podSets(sts), r.labelKeysToCopy)
+}
+
+// podSets returns the PodSets of the workload of a StatefulSet dispatched as
+// a whole by MultiKueue.
+func podSets(sts *appsv1.StatefulSet) []kueue.PodSet {
+	podSet := kueue.PodSet{
+		Name:  kueue.DefaultPodSetName,
+		Count: ptr.Deref(sts.Spec.Replicas, 1),
+		Template: corev1.PodTemplateSpec{
+			Spec: *sts.Spec.Template.Spec.DeepCopy(),
+		},
+	}
+	jobframework.UngateForMultiKueue(&podSet.Template.Spec)
+	return []kueue.PodSet{podSet}
+}
+
 func (r *Reconciler) fetchAndFinalizePods(ctx context.Context, req reconcile.Request) error {
 	podList := &corev1.PodList{}
 	if err := r.client.List(ctx, podList, client.InNamespace(req.Namespace), client.MatchingLabels{
@@ -144,16 +188,20 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&appsv1.StatefulSet{}).
 		WithEventFilter(r).
+		Owns(&kueue.Workload{}).
 		Watches(&corev1.Pod{}, &podHandler{}).
 		Complete(r)
 }
 
-func NewReconciler(client client.Client, _ record.EventRecorder, opts ...jobframework.Option) jobframework.JobReconcilerInterface {
+func NewReconciler(client client.Client, eventRecorder record.EventRecorder, opts ...jobframework.Option) jobframework.JobReconcilerInterface {
 	options := jobframework.ProcessOptions(opts...)
 
 	return &Reconciler{
 		client:                       client,
 		log:                          ctrl.Log.WithName("statefulset-reconciler"),
+		record:                       eventRecorder,
+		clock:                        options.Clock,
+		labelKeysToCopy:              options.LabelKeysToCopy,
 		manageJobsWithoutQueueName:   options.ManageJobsWithoutQueueName,
 		managedJobsNamespaceSelector: options.ManagedJobsNamespaceSelector,
 	}
@@ -25,8 +25,12 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	controllerconstants "sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/controller/jobframework"
 	podconstants "sigs.k8s.io/kueue/pkg/controller/jobs/pod/constants"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	testingjobspod "sigs.k8s.io/kueue/pkg/util/testingjobs/pod"
@@ -41,14 +45,198 @@ var (
 )
 
 func TestReconciler(t *testing.T) {
+	multiKueuePodSet := func(count int32) kueue.PodSet {
+		return kueue.PodSet{
+			Name:  kueue.DefaultPodSetName,
+			Count: count,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers:   []corev1.Container{{Name: "c", Image: "pause"}},
+					NodeSelector: map[string]string{},
+				},
+			},
+		}
+	}
+	multiKueueWorkload := utiltesting.MakeWorkload(jobframework.GetWorkloadNameForOwnerWithGVK("sts", "sts-uid", gvk), "ns").
+		ControllerReference(gvk, "sts", "sts-uid").
+		Label(controllerconstants.JobUIDLabel, "sts-uid").
+		Finalizers(kueue.ResourceInUseFinalizerName).
+		Queue("queue").
+		Priority(0)
+
 	cases := map[string]struct {
 		stsKey          client.ObjectKey
 		statefulSet     *appsv1.StatefulSet
 		pods            []corev1.Pod
+		workloads       []kueue.Workload
 		wantStatefulSet *appsv1.StatefulSet
 		wantPods        []corev1.Pod
+		wantWorkloads   []kueue.Workload
 		wantErr         error
 	}{
+		"should create the workload of a statefulset managed by multikueue": {
+			stsKey: client.ObjectKey{Name: "sts", Namespace: "ns"},
+			statefulSet: statefulsettesting.MakeStatefulSet("sts", "ns").
+				UID("sts-uid").
+				Queue("queue").
+				Label(controllerconstants.ManagedByLabel, kueue.MultiKueueControllerName).
+				Replicas(3).
+				PodTemplateSpecSchedulingGate(controllerconstants.MultiKueueSchedulingGate).
+				Obj(),
+			wantStatefulSet: statefulsettesting.MakeStatefulSet("sts", "ns").
+				UID("sts-uid").
+				Queue("queue").
+				Label(controllerconstants.ManagedByLabel, kueue.MultiKueueControllerName).
+				Replicas(3).
+				PodTemplateSpecSchedulingGate(controllerconstants.MultiKueueSchedulingGate).
+				Obj(),
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload(jobframework.GetWorkloadNameForOwnerWithGVK("sts", "sts-uid", gvk), "ns").
+					ControllerReference(gvk, "sts", "sts-uid").
+					Label(controllerconstants.JobUIDLabel, "sts-uid").
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					Queue("queue").
+					PodSets(kueue.PodSet{
+						Name:  kueue.DefaultPodSetName,
+						Count: 3,
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers:   []corev1.Container{{Name: "c", Image: "pause"}},
+								NodeSelector: map[string]string{},
+							},
+						},
+					}).
+					Priority(0).
+					Obj(),
+			},
+		},
+		"should finalize the workload of a deleted statefulset managed by multikueue": {
+			stsKey: client.ObjectKey{Name: "sts", Namespace: "ns"},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload(jobframework.GetWorkloadNameForOwnerWithGVK("sts", "sts-uid", gvk), "ns").
+					ControllerReference(gvk, "sts", "sts-uid").
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload(jobframework.GetWorkloadNameForOwnerWithGVK("sts", "sts-uid", gvk), "ns").
+					ControllerReference(gvk, "sts", "sts-uid").
+					Obj(),
+			},
+		},
+		"should resize the pending workload of a scaled statefulset managed by multikueue": {
+			stsKey: client.ObjectKey{Name: "sts", Namespace: "ns"},
+			statefulSet: statefulsettesting.MakeStatefulSet("sts", "ns").
+				UID("sts-uid").
+				Queue("queue").
+				Label(controllerconstants.ManagedByLabel, kueue.MultiKueueControllerName).
+				Replicas(5).
+				PodTemplateSpecSchedulingGate(controllerconstants.MultiKueueSchedulingGate).
+				Obj(),
+			workloads: []kueue.Workload{
+				*multiKueueWorkload.Clone().PodSets(multiKueuePodSet(3)).Obj(),
+			},
+			wantStatefulSet: statefulsettesting.MakeStatefulSet("sts", "ns").
+				UID("sts-uid").
+				Queue("queue").
+				Label(controllerconstants.ManagedByLabel, kueue.MultiKueueControllerName).
+				Replicas(5).
+				PodTemplateSpecSchedulingGate(controllerconstants.MultiKueueSchedulingGate).
+				Obj(),
+			wantWorkloads: []kueue.Workload{
+				*multiKueueWorkload.Clone().PodSets(multiKueuePodSet(5)).Obj(),
+			},
+		},
+		"should evict the admitted workload of a scaled statefulset managed by multikueue": {
+			stsKey: client.ObjectKey{Name: "sts", Namespace: "ns"},
+			statefulSet: statefulsettesting.MakeStatefulSet("sts", "ns").
+				UID("sts-uid").
+				Queue("queue").
+				Label(controllerconstants.ManagedByLabel, kueue.MultiKueueControllerName).
+				Replicas(5).
+				PodTemplateSpecSchedulingGate(controllerconstants.MultiKueueSchedulingGate).
+				Obj(),
+			workloads: []kueue.Workload{
+				*multiKueueWorkload.Clone().
+					PodSets(multiKueuePodSet(3)).
+					ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+					Obj(),
+			},
+			wantStatefulSet: statefulsettesting.MakeStatefulSet("sts", "ns").
+				UID("sts-uid").
+				Queue("queue").
+				Label(controllerconstants.ManagedByLabel, kueue.MultiKueueControllerName).
+				Replicas(5).
+				PodTemplateSpecSchedulingGate(controllerconstants.MultiKueueSchedulingGate).
+				Obj(),
+			wantWorkloads: []kueue.Workload{
+				*multiKueueWorkload.Clone().
+					PodSets(multiKueuePodSet(3)).
+					ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadEvicted,
+						Status:  metav1.ConditionTrue,
+						Reason:  jobframework.WorkloadEvictedByResize,
+						Message: "The number of replicas changed",
+					}).
+					Obj(),
+			},
+		},
+		"should release the quota reservation of the evicted workload of a statefulset managed by multikueue": {
+			stsKey: client.ObjectKey{Name: "sts", Namespace: "ns"},
+			statefulSet: statefulsettesting.MakeStatefulSet("sts", "ns").
+				UID("sts-uid").
+				Queue("queue").
+				Label(controllerconstants.ManagedByLabel, kueue.MultiKueueControllerName).
+				Replicas(5).
+				PodTemplateSpecSchedulingGate(controllerconstants.MultiKueueSchedulingGate).
+				Obj(),
+			workloads: []kueue.Workload{
+				*multiKueueWorkload.Clone().
+					PodSets(multiKueuePodSet(3)).
+					ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadEvicted,
+						Status:  metav1.ConditionTrue,
+						Reason:  jobframework.WorkloadEvictedByResize,
+						Message: "The number of replicas changed",
+					}).
+					Obj(),
+			},
+			wantStatefulSet: statefulsettesting.MakeStatefulSet("sts", "ns").
+				UID("sts-uid").
+				Queue("queue").
+				Label(controllerconstants.ManagedByLabel, kueue.MultiKueueControllerName).
+				Replicas(5).
+				PodTemplateSpecSchedulingGate(controllerconstants.MultiKueueSchedulingGate).
+				Obj(),
+			wantWorkloads: []kueue.Workload{
+				// The admission is kept since the fake client doesn't remove
+				// the fields missing from the server-side apply patches.
+				*multiKueueWorkload.Clone().
+					PodSets(multiKueuePodSet(3)).
+					ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+					SetOrReplaceCondition(metav1.Condition{
+						Type:    kueue.WorkloadQuotaReserved,
+						Status:  metav1.ConditionFalse,
+						Reason:  "Pending",
+						Message: "The number of replicas changed",
+					}).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadEvicted,
+						Status:  metav1.ConditionTrue,
+						Reason:  jobframework.WorkloadEvictedByResize,
+						Message: "The number of replicas changed",
+					}).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadRequeued,
+						Status:  metav1.ConditionFalse,
+						Reason:  jobframework.WorkloadEvictedByResize,
+						Message: "The number of replicas changed",
+					}).
+					Obj(),
+			},
+		},
 		"statefulset not found": {
 			stsKey: client.ObjectKey{Name: "sts", Namespace: "ns"},
 			pods: []corev1.Pod{
@@ -174,9 +362,14 @@ func TestReconciler(t *testing.T) {
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
 			ctx, _ := utiltesting.ContextWithLog(t)
-			clientBuilder := utiltesting.NewClientBuilder()
+			clientBuilder := utiltesting.NewClientBuilder().
+				WithStatusSubresource(&kueue.Workload{}).
+				WithInterceptorFuncs(interceptor.Funcs{SubResourcePatch: utiltesting.TreatSSAAsStrategicMerge})
+			if err := SetupIndexes(ctx, utiltesting.AsIndexer(clientBuilder)); err != nil {
+				t.Fatalf("Could not setup indexes: %v", err)
+			}
 
-			objs := make([]client.Object, 0, len(tc.pods)+1)
+			objs := make([]client.Object, 0, len(tc.pods)+len(tc.workloads)+1)
 			if tc.statefulSet != nil {
 				objs = append(objs, tc.statefulSet)
 			}
@@ -185,9 +378,13 @@ func TestReconciler(t *testing.T) {
 				objs = append(objs, p.DeepCopy())
 			}
 
+			for _, wl := range tc.workloads {
+				objs = append(objs, wl.DeepCopy())
+			}
+
 			kClient := clientBuilder.WithObjects(objs...).Build()
 
-			reconciler := NewReconciler(kClient, nil)
+			reconciler := NewReconciler(kClient, &utiltesting.EventRecorder{})
 
 			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: tc.stsKey})
 			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
@@ -215,6 +412,15 @@ func TestReconciler(t *testing.T) {
 			if diff := cmp.Diff(tc.wantPods, gotPodList.Items, baseCmpOpts...); diff != "" {
 				t.Errorf("Pods after reconcile (-want,+got):\n%s", diff)
 			}
+
+			gotWorkloads := &kueue.WorkloadList{}
+			if err := kClient.List(ctx, gotWorkloads); err != nil {
+				t.Fatalf("Could not get Workloads after reconcile: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.wantWorkloads, gotWorkloads.Items, baseCmpOpts, cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("Workloads after reconcile (-want,+got):\n%s", diff)
+			}
 		})
 	}
 }
@@ -70,16 +70,34 @@ func (wh *Webhook) Default(ctx context.Context, obj runtime.Object) error {
 	log := ctrl.LoggerFrom(ctx).WithName("statefulset-webhook")
 	log.V(5).Info("Propagating queue-name")
 
+	// The copy created by MultiKueue in a worker cluster uses the quota of the
+	// copy of the workload.
+	isRemote, err := jobframework.IsMultiKueueRemoteObject(ctx, wh.client, ss.Object())
+	if err != nil {
+		return err
+	}
+	if isRemote {
+		return nil
+	}
+
 	jobframework.ApplyDefaultLocalQueue(ss.Object(), wh.queues.DefaultLocalQueueExist)
+	jobframework.ApplyDefaultForSubmittedBy(ctx, ss.Object())
 	suspend, err := jobframework.WorkloadShouldBeSuspended(ctx, ss.Object(), wh.client, wh.manageJobsWithoutQueueName, wh.managedJobsNamespaceSelector)
 	if err != nil {
 		return err
 	}
 	if suspend {
+		if jobframework.IsManagedByMultiKueue(ss.Object()) {
+			jobframework.GateForMultiKueue(&ss.Spec.Template.Spec)
+			return nil
+		}
 		if ss.Spec.Template.Annotations == nil {
 			ss.Spec.Template.Annotations = make(map[string]string, 1)
 		}
 		ss.Spec.Template.Annotations[podconstants.SuspendedByParentAnnotation] = FrameworkName
+		if submittedBy, found := ss.Annotations[controllerconstants.SubmittedByAnnotation]; found {
+			ss.Spec.Template.Annotations[controllerconstants.SubmittedByAnnotation] = submittedBy
+		}
 		if ss.Spec.Template.Labels == nil {
 			ss.Spec.Template.Labels = make(map[string]string, 1)
 		}
@@ -147,6 +165,10 @@ func (wh *Webhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Ob
 		oldStatefulSet.Object(),
 		newStatefulSet.Object(),
 	)...)
+	allErrs = append(allErrs, jobframework.ValidateUpdateForSubmittedBy(
+		oldStatefulSet.Object(),
+		newStatefulSet.Object(),
+	)...)
 
 	suspend, err := jobframework.WorkloadShouldBeSuspended(ctx, newStatefulSet.Object(), wh.client, wh.manageJobsWithoutQueueName, wh.managedJobsNamespaceSelector)
 	if err != nil {
@@ -158,7 +180,13 @@ func (wh *Webhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Ob
 			&oldStatefulSet.Spec.Template.Spec,
 			podSpecPath,
 		)...)
+	}
 
+	// The scale of a StatefulSet dispatched as a whole by MultiKueue is
+	// propagated to the worker cluster, its Pod template is not.
+	if jobframework.IsManagedByMultiKueue(newStatefulSet.Object()) {
+		allErrs = append(allErrs, apivalidation.ValidateImmutableField(newStatefulSet.Spec.Template, oldStatefulSet.Spec.Template, specTemplatePath)...)
+	} else if suspend {
 		oldReplicas := ptr.Deref(oldStatefulSet.Spec.Replicas, 1)
 		newReplicas := ptr.Deref(newStatefulSet.Spec.Replicas, 1)
 
@@ -32,6 +32,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 	leaderworkersetv1 "sigs.k8s.io/lws/api/leaderworkerset/v1"
 
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
@@ -53,6 +54,7 @@ func TestDefault(t *testing.T) {
 		localQueueDefaulting       bool
 		defaultLqExist             bool
 		enableIntegrations         []string
+		workloads                  []kueue.Workload
 		want                       *appsv1.StatefulSet
 	}{
 		"statefulset with queue": {
@@ -74,6 +76,71 @@ func TestDefault(t *testing.T) {
 				PodTemplateSpecPodGroupPodIndexLabelAnnotation(appsv1.PodIndexLabel).
 				Obj(),
 		},
+		"statefulset with queue managed by multikueue": {
+			enableIntegrations: []string{"pod"},
+			statefulset: testingstatefulset.MakeStatefulSet("test-pod", "").
+				Replicas(10).
+				Queue("test-queue").
+				Label(constants.ManagedByLabel, kueue.MultiKueueControllerName).
+				Obj(),
+			want: testingstatefulset.MakeStatefulSet("test-pod", "").
+				Replicas(10).
+				Queue("test-queue").
+				Label(constants.ManagedByLabel, kueue.MultiKueueControllerName).
+				PodTemplateSpecSchedulingGate(constants.MultiKueueSchedulingGate).
+				Obj(),
+		},
+		"statefulset created by multikueue in a worker cluster": {
+			enableIntegrations:   []string{"pod"},
+			localQueueDefaulting: true,
+			defaultLqExist:       true,
+			statefulset: testingstatefulset.MakeStatefulSet("test-pod", "default").
+				Replicas(10).
+				Label(constants.PrebuiltWorkloadLabel, "wl").
+				Label(kueue.MultiKueueOriginLabel, "origin").
+				Obj(),
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("wl", "default").
+					Label(kueue.MultiKueueOriginLabel, "origin").
+					ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+					Obj(),
+			},
+			want: testingstatefulset.MakeStatefulSet("test-pod", "default").
+				Replicas(10).
+				Label(constants.PrebuiltWorkloadLabel, "wl").
+				Label(kueue.MultiKueueOriginLabel, "origin").
+				Obj(),
+		},
+		"statefulset with the multikueue origin label set by a user": {
+			enableIntegrations:   []string{"pod"},
+			localQueueDefaulting: true,
+			defaultLqExist:       true,
+			statefulset: testingstatefulset.MakeStatefulSet("test-pod", "default").
+				Replicas(10).
+				Label(constants.PrebuiltWorkloadLabel, "wl").
+				Label(kueue.MultiKueueOriginLabel, "origin").
+				Obj(),
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("wl", "default").
+					Label(kueue.MultiKueueOriginLabel, "other").
+					ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+					Obj(),
+			},
+			want: testingstatefulset.MakeStatefulSet("test-pod", "default").
+				Replicas(10).
+				Label(constants.PrebuiltWorkloadLabel, "wl").
+				Label(kueue.MultiKueueOriginLabel, "origin").
+				Queue("default").
+				PodTemplateSpecQueue("default").
+				PodTemplateManagedByKueue().
+				PodTemplateAnnotation(podconstants.SuspendedByParentAnnotation, FrameworkName).
+				PodTemplateSpecPodGroupNameLabel("test-pod", "", gvk).
+				PodTemplateSpecPodGroupTotalCountAnnotation(10).
+				PodTemplateSpecPodGroupFastAdmissionAnnotation().
+				PodTemplateSpecPodGroupServingAnnotation().
+				PodTemplateSpecPodGroupPodIndexLabelAnnotation(appsv1.PodIndexLabel).
+				Obj(),
+		},
 		"statefulset with queue and priority class": {
 			enableIntegrations: []string{"pod"},
 			statefulset: testingstatefulset.MakeStatefulSet("test-pod", "").
@@ -159,7 +226,7 @@ func TestDefault(t *testing.T) {
 			t.Cleanup(jobframework.EnableIntegrationsForTest(t, tc.enableIntegrations...))
 			ctx, _ := utiltesting.ContextWithLog(t)
 
-			builder := utiltesting.NewClientBuilder()
+			builder := utiltesting.NewClientBuilder().WithLists(&kueue.WorkloadList{Items: tc.workloads})
 			cli := builder.Build()
 			cqCache := cache.New(cli)
 			queueManager := queue.NewManager(cli, cqCache)
@@ -419,6 +486,36 @@ func TestValidateUpdate(t *testing.T) {
 				},
 			}.ToAggregate(),
 		},
+		"change in replicas (scale up managed by multikueue)": {
+			oldObj: testingstatefulset.MakeStatefulSet("test-sts", "test-ns").
+				Queue("test-queue").
+				Label(constants.ManagedByLabel, kueue.MultiKueueControllerName).
+				Replicas(3).
+				Obj(),
+			newObj: testingstatefulset.MakeStatefulSet("test-sts", "test-ns").
+				Queue("test-queue").
+				Label(constants.ManagedByLabel, kueue.MultiKueueControllerName).
+				Replicas(4).
+				Obj(),
+		},
+		"change in pod template (managed by multikueue)": {
+			oldObj: testingstatefulset.MakeStatefulSet("test-sts", "test-ns").
+				Queue("test-queue").
+				Label(constants.ManagedByLabel, kueue.MultiKueueControllerName).
+				Image("image:v1", nil).
+				Obj(),
+			newObj: testingstatefulset.MakeStatefulSet("test-sts", "test-ns").
+				Queue("test-queue").
+				Label(constants.ManagedByLabel, kueue.MultiKueueControllerName).
+				Image("image:v2", nil).
+				Obj(),
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: specTemplatePath.String(),
+				},
+			}.ToAggregate(),
+		},
 
 		"change in replicas (scale up without queue-name while the previous scaling operation is still in progress)": {
 			oldObj: testingstatefulset.MakeStatefulSet("test-sts", "test-ns").
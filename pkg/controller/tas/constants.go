@@ -17,7 +17,12 @@ limitations under the License.
 package tas
 
 const (
-	TASTopologyController       = "tas-topology-controller"
-	TASResourceFlavorController = "tas-resource-flavor-controller"
-	TASTopologyUngater          = "tas-topology-ungater"
+	TASTopologyController        = "tas-topology-controller"
+	TASResourceFlavorController  = "tas-resource-flavor-controller"
+	TASTopologyUngater           = "tas-topology-ungater"
+	TASNodeCapacityController    = "tas-node-capacity-controller"
+	TASNodeFailureController     = "tas-node-failure-controller"
+	TASDefragmentationController = "tas-defragmentation-controller"
+	TASDomainMetricsReporter     = "tas-domain-metrics-reporter"
+	TASNodeDomainsController     = "tas-node-domains-controller"
 )
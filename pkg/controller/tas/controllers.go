@@ -21,6 +21,7 @@ import (
 
 	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/queue"
 )
 
@@ -38,5 +39,35 @@ func SetupControllers(mgr ctrl.Manager, queues *queue.Manager, cache *cache.Cach
 	if ctrlName, err := topologyUngater.setupWithManager(mgr, cfg); err != nil {
 		return ctrlName, err
 	}
+	if features.Enabled(features.TASNodeDomainsSource) {
+		nodeDomainsRec := newNodeDomainsReconciler(mgr.GetClient(), queues, cache, cfg)
+		if ctrlName, err := nodeDomainsRec.setupWithManager(mgr); err != nil {
+			return ctrlName, err
+		}
+	}
+	if features.Enabled(features.TASFailedNodeReplacement) {
+		nodeFailureRec := newNodeFailureReconciler(mgr.GetClient(), cache, mgr.GetEventRecorderFor(TASNodeFailureController))
+		if ctrlName, err := nodeFailureRec.setupWithManager(mgr, cfg); err != nil {
+			return ctrlName, err
+		}
+	}
+	if cfg.TASDefragmentation != nil && cfg.TASDefragmentation.Enable {
+		defragmenter := newDefragmenter(mgr.GetClient(), cache, mgr.GetEventRecorderFor(TASDefragmentationController), cfg.TASDefragmentation)
+		if ctrlName, err := defragmenter.setupWithManager(mgr); err != nil {
+			return ctrlName, err
+		}
+	}
+	if cfg.Metrics.EnableTASDomainResources {
+		reporter := newDomainMetricsReporter(cache, &cfg.Metrics)
+		if ctrlName, err := reporter.setupWithManager(mgr); err != nil {
+			return ctrlName, err
+		}
+	}
+	if features.Enabled(features.NodeCapacityQuotas) {
+		nodeCapacityRec := newNodeCapacityReconciler(mgr.GetClient(), queues, cache)
+		if ctrlName, err := nodeCapacityRec.setupWithManager(mgr); err != nil {
+			return ctrlName, err
+		}
+	}
 	return "", nil
 }
@@ -0,0 +1,209 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/util/priority"
+	utiltas "sigs.k8s.io/kueue/pkg/util/tas"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// defragmenter periodically evicts the checkpointable workloads admitted by
+// TAS, with a priority not higher than the configured one, in order to free
+// topology domains when there are not enough of them free from the TAS
+// workloads. The evicted workloads are admitted again in the other domains.
+// In the dry run mode the planned evictions are only reported as events on
+// the ResourceFlavors.
+type defragmenter struct {
+	client   client.Client
+	tasCache *cache.TASCache
+	recorder record.EventRecorder
+	clock    clock.Clock
+	cfg      configapi.TASDefragmentation
+}
+
+var _ manager.Runnable = (*defragmenter)(nil)
+var _ manager.LeaderElectionRunnable = (*defragmenter)(nil)
+
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;watch;update;patch
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=resourceflavors,verbs=get;list;watch
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads,verbs=get;list;watch
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads/status,verbs=get;update;patch
+
+func newDefragmenter(c client.Client, cache *cache.Cache, recorder record.EventRecorder, cfg *configapi.TASDefragmentation) *defragmenter {
+	return &defragmenter{
+		client:   c,
+		tasCache: cache.TASCache(),
+		recorder: recorder,
+		clock:    clock.RealClock{},
+		cfg:      *cfg,
+	}
+}
+
+func (d *defragmenter) setupWithManager(mgr ctrl.Manager) (string, error) {
+	return TASDefragmentationController, mgr.Add(d)
+}
+
+// Start implements the Runnable interface to run the defragmentation
+// periodically.
+func (d *defragmenter) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName(TASDefragmentationController)
+	ctx = ctrl.LoggerInto(ctx, log)
+	interval := configapi.DefaultTASDefragmentationInterval
+	if d.cfg.Interval != nil {
+		interval = d.cfg.Interval.Duration
+	}
+	go wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := d.defragment(ctx); err != nil {
+			log.Error(err, "Failed to defragment the TAS flavors")
+		}
+	}, interval)
+	return nil
+}
+
+// NeedLeaderElection implements the LeaderElectionRunnable interface, so that
+// the workloads are only evicted by the leader.
+func (d *defragmenter) NeedLeaderElection() bool {
+	return true
+}
+
+func (d *defragmenter) defragment(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx)
+	var workloads kueue.WorkloadList
+	if err := d.client.List(ctx, &workloads); err != nil {
+		return err
+	}
+	budget := int(ptr.Deref(d.cfg.MaxEvictionsPerInterval, configapi.DefaultTASDefragmentationMaxEvictions))
+	minFreeDomains := int(ptr.Deref(d.cfg.MinFreeDomains, configapi.DefaultTASDefragmentationMinFreeDomains))
+	var errs []error
+	for _, flavor := range slices.Sorted(maps.Keys(d.tasCache.Clone())) {
+		if budget <= 0 {
+			break
+		}
+		snapshot, err := d.tasCache.FlavorSnapshot(ctx, flavor)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if snapshot == nil {
+			continue
+		}
+		plan := snapshot.PlanDefragmentation(ptr.Deref(d.cfg.Level, ""), minFreeDomains, budget, d.candidates(workloads.Items, flavor))
+		if plan == nil {
+			continue
+		}
+		log.V(2).Info("Defragmenting TAS flavor", "flavor", flavor, "domain", plan.Domain, "workloads", len(plan.Workloads), "dryRun", d.cfg.DryRun)
+		budget -= len(plan.Workloads)
+		if d.cfg.DryRun {
+			if err := d.reportPlan(ctx, flavor, plan); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		for _, wi := range plan.Workloads {
+			if err := d.evict(ctx, wi.Obj, flavor, plan.Domain); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// candidates returns the workloads using the flavor which can be evicted by
+// the defragmentation.
+func (d *defragmenter) candidates(workloads []kueue.Workload, flavor kueue.ResourceFlavorReference) []cache.TASDefragmentationCandidate {
+	maxPriority := ptr.Deref(d.cfg.MaxPriority, configapi.DefaultTASDefragmentationMaxPriority)
+	var candidates []cache.TASDefragmentationCandidate
+	for i := range workloads {
+		wl := &workloads[i]
+		if !isAdmittedByTAS(wl) || apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadEvicted) ||
+			wl.Annotations[constants.CheckpointableAnnotation] != "true" || priority.Priority(wl) > maxPriority {
+			continue
+		}
+		info := workload.NewInfo(wl)
+		usage := info.TASUsage()[flavor]
+		if len(usage) == 0 {
+			continue
+		}
+		candidate := cache.TASDefragmentationCandidate{Workload: info, Usage: usage}
+		for j := range wl.Status.Admission.PodSetAssignments {
+			psa := &wl.Status.Admission.PodSetAssignments[j]
+			if psa.TopologyAssignment == nil {
+				continue
+			}
+			tasRequests, err := podSetRequests(wl, info, psa)
+			if err != nil || tasRequests.Flavor != flavor {
+				continue
+			}
+			candidate.Requests = append(candidate.Requests, tasRequests)
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates
+}
+
+// reportPlan records an event on the ResourceFlavor with the workloads which
+// would be evicted in the dry run mode.
+func (d *defragmenter) reportPlan(ctx context.Context, flavor kueue.ResourceFlavorReference, plan *cache.TASDefragmentationPlan) error {
+	rf := &kueue.ResourceFlavor{}
+	if err := d.client.Get(ctx, types.NamespacedName{Name: string(flavor)}, rf); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	names := make([]string, 0, len(plan.Workloads))
+	for _, wi := range plan.Workloads {
+		names = append(names, workload.Key(wi.Obj))
+	}
+	d.recorder.Eventf(rf, corev1.EventTypeNormal, "DefragmentationPlanned",
+		"Evicting the workloads %s would free the topology domain %q", strings.Join(names, ", "), plan.Domain)
+	return nil
+}
+
+func (d *defragmenter) evict(ctx context.Context, wl *kueue.Workload, flavor kueue.ResourceFlavorReference, domain utiltas.TopologyDomainID) error {
+	log := ctrl.LoggerFrom(ctx).WithValues("workload", klog.KObj(wl))
+	message := fmt.Sprintf("Evicted to free the topology domain %q of the flavor %q", domain, flavor)
+	log.V(2).Info("Evicting workload due to TAS defragmentation", "flavor", flavor, "domain", domain)
+	workload.SetEvictedCondition(wl, kueue.WorkloadEvictedDueToDefragmentation, message)
+	workload.ResetChecksOnEviction(wl, d.clock.Now())
+	if err := workload.ApplyAdmissionStatus(ctx, d.client, wl, true, d.clock); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	workload.ReportEvictedWorkload(d.recorder, wl, wl.Status.Admission.ClusterQueue, kueue.WorkloadEvictedDueToDefragmentation, message)
+	return nil
+}
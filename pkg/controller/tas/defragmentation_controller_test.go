@@ -0,0 +1,197 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tas
+
+import (
+	"testing"
+	"time"
+
+	gocmp "github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/controller/tas/indexer"
+	"sigs.k8s.io/kueue/pkg/features"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	testingnode "sigs.k8s.io/kueue/pkg/util/testingjobs/node"
+)
+
+func TestDefragment(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	levels := []string{tasRackLabel, corev1.LabelHostname}
+
+	node := func(name, rack string) corev1.Node {
+		return *testingnode.MakeNode(name).
+			Label("node-group", "tas").
+			Label(tasRackLabel, rack).
+			Label(corev1.LabelHostname, name).
+			StatusAllocatable(corev1.ResourceList{
+				corev1.ResourceCPU:  resource.MustParse("2"),
+				corev1.ResourcePods: resource.MustParse("10"),
+			}).
+			Ready().
+			Obj()
+	}
+	admitted := func(name, node string) *utiltesting.WorkloadWrapper {
+		return utiltesting.MakeWorkload(name, "ns").
+			PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
+				PreferredTopologyRequest(tasRackLabel).
+				Request(corev1.ResourceCPU, "1").
+				Obj()).
+			ReserveQuota(utiltesting.MakeAdmission("cq").
+				PodSets(kueue.PodSetAssignment{
+					Name:          kueue.DefaultPodSetName,
+					Flavors:       map[corev1.ResourceName]kueue.ResourceFlavorReference{corev1.ResourceCPU: "tas"},
+					ResourceUsage: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+					Count:         ptr.To[int32](1),
+					TopologyAssignment: &kueue.TopologyAssignment{
+						Levels:  []string{corev1.LabelHostname},
+						Domains: []kueue.TopologyDomainAssignment{{Values: []string{node}, Count: 1}},
+					},
+				}).Obj()).
+			Admitted(true)
+	}
+	baseNodes := []corev1.Node{node("x1", "r1"), node("x2", "r2"), node("x3", "r3")}
+	baseWorkloads := []kueue.Workload{
+		*admitted("a", "x1").Annotation(constants.CheckpointableAnnotation, "true").Obj(),
+		*admitted("b", "x2").Annotation(constants.CheckpointableAnnotation, "true").Obj(),
+		*admitted("c", "x3").Obj(),
+	}
+
+	cases := map[string]struct {
+		nodes       []corev1.Node
+		workloads   []kueue.Workload
+		dryRun      bool
+		wantEvicted map[string]string
+		wantEvents  []utiltesting.EventRecord
+	}{
+		"evicts the checkpointable workload to free a domain": {
+			nodes:       baseNodes,
+			workloads:   baseWorkloads,
+			wantEvicted: map[string]string{"a": kueue.WorkloadEvictedDueToDefragmentation},
+			wantEvents: []utiltesting.EventRecord{{
+				Key:       types.NamespacedName{Namespace: "ns", Name: "a"},
+				EventType: corev1.EventTypeNormal,
+				Reason:    "EvictedDueToDefragmentation",
+				Message:   `Evicted to free the topology domain "r1" of the flavor "tas"`,
+			}},
+		},
+		"dry run only reports the plan": {
+			nodes:     baseNodes,
+			workloads: baseWorkloads,
+			dryRun:    true,
+			wantEvents: []utiltesting.EventRecord{{
+				Key:       types.NamespacedName{Name: "tas"},
+				EventType: corev1.EventTypeNormal,
+				Reason:    "DefragmentationPlanned",
+				Message:   `Evicting the workloads ns/a would free the topology domain "r1"`,
+			}},
+		},
+		"workload with a priority above the max priority is not evicted": {
+			nodes: baseNodes,
+			workloads: []kueue.Workload{
+				*admitted("a", "x1").Annotation(constants.CheckpointableAnnotation, "true").Priority(10).Obj(),
+				*admitted("b", "x2").Annotation(constants.CheckpointableAnnotation, "true").Obj(),
+				*admitted("c", "x3").Obj(),
+			},
+			wantEvicted: map[string]string{"b": kueue.WorkloadEvictedDueToDefragmentation},
+			wantEvents: []utiltesting.EventRecord{{
+				Key:       types.NamespacedName{Namespace: "ns", Name: "b"},
+				EventType: corev1.EventTypeNormal,
+				Reason:    "EvictedDueToDefragmentation",
+				Message:   `Evicted to free the topology domain "r2" of the flavor "tas"`,
+			}},
+		},
+		"no eviction when there is a free domain": {
+			nodes:     append(baseNodes, node("x4", "r4")),
+			workloads: baseWorkloads,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.TopologyAwareScheduling, true)
+			ctx, _ := utiltesting.ContextWithLog(t)
+			flavor := utiltesting.MakeResourceFlavor("tas").
+				NodeLabel("node-group", "tas").
+				TopologyName("default").
+				Obj()
+			cq := utiltesting.MakeClusterQueue("cq").
+				ResourceGroup(*utiltesting.MakeFlavorQuotas("tas").Resource(corev1.ResourceCPU, "10").Obj()).
+				Obj()
+			clientBuilder := utiltesting.NewClientBuilder().
+				WithInterceptorFuncs(interceptor.Funcs{SubResourcePatch: utiltesting.TreatSSAAsStrategicMerge}).
+				WithStatusSubresource(&kueue.Workload{}).
+				WithLists(&corev1.NodeList{Items: tc.nodes}, &kueue.WorkloadList{Items: tc.workloads}).
+				WithObjects(flavor)
+			if err := indexer.SetupIndexes(ctx, utiltesting.AsIndexer(clientBuilder)); err != nil {
+				t.Fatalf("Could not setup indexes: %v", err)
+			}
+			kClient := clientBuilder.Build()
+
+			cqCache := cache.New(kClient)
+			cqCache.AddOrUpdateResourceFlavor(flavor)
+			cqCache.AddOrUpdateTopologyForFlavor(utiltesting.MakeTopology("default").Levels(levels...).Obj(), flavor)
+			if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Could not add the ClusterQueue: %v", err)
+			}
+
+			recorder := &utiltesting.EventRecorder{}
+			defragmenter := newDefragmenter(kClient, cqCache, recorder, &configapi.TASDefragmentation{
+				Enable:                  true,
+				DryRun:                  tc.dryRun,
+				MinFreeDomains:          ptr.To[int32](1),
+				MaxEvictionsPerInterval: ptr.To[int32](1),
+				MaxPriority:             ptr.To[int32](0),
+			})
+			defragmenter.clock = testingclock.NewFakeClock(now)
+
+			if err := defragmenter.defragment(ctx); err != nil {
+				t.Fatalf("Defragment returned error: %v", err)
+			}
+
+			var gotWorkloads kueue.WorkloadList
+			if err := kClient.List(ctx, &gotWorkloads); err != nil {
+				t.Fatalf("Could not list workloads: %v", err)
+			}
+			var gotEvicted map[string]string
+			for _, wl := range gotWorkloads.Items {
+				if cond := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadEvicted); cond != nil && cond.Status == metav1.ConditionTrue {
+					if gotEvicted == nil {
+						gotEvicted = make(map[string]string)
+					}
+					gotEvicted[wl.Name] = cond.Reason
+				}
+			}
+			if diff := gocmp.Diff(tc.wantEvicted, gotEvicted); diff != "" {
+				t.Errorf("Unexpected evicted workloads (-want,+got):\n%s", diff)
+			}
+			if diff := gocmp.Diff(tc.wantEvents, recorder.RecordedEvents); diff != "" {
+				t.Errorf("Unexpected events (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tas
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/metrics"
+	"sigs.k8s.io/kueue/pkg/resources"
+	utilresource "sigs.k8s.io/kueue/pkg/util/resource"
+)
+
+const domainMetricsInterval = 30 * time.Second
+
+// domainMetricsReporter periodically reports the capacity and the usage of
+// the topology domains of the TAS flavors, along with the largest free
+// capacity among the domains at each topology level. In order to bound the
+// cardinality, only the domains with the most TAS Pods are reported at each
+// level. The largest free capacity is computed independently for each
+// resource, so the values for different resources may come from different
+// domains.
+type domainMetricsReporter struct {
+	tasCache   *cache.TASCache
+	maxDomains int
+	reported   map[kueue.ResourceFlavorReference]*flavorSeries
+}
+
+type domainSeries struct {
+	level, domain, resource string
+}
+
+type levelSeries struct {
+	level, resource string
+}
+
+// flavorSeries holds the series reported for a flavor, so that only the
+// series which are no longer reported are deleted, without a gap in the
+// series which are reported again.
+type flavorSeries struct {
+	domains     sets.Set[domainSeries]
+	largestFree sets.Set[levelSeries]
+}
+
+var _ manager.Runnable = (*domainMetricsReporter)(nil)
+var _ manager.LeaderElectionRunnable = (*domainMetricsReporter)(nil)
+
+func newDomainMetricsReporter(cache *cache.Cache, cfg *configapi.ControllerMetrics) *domainMetricsReporter {
+	return &domainMetricsReporter{
+		tasCache:   cache.TASCache(),
+		maxDomains: int(ptr.Deref(cfg.TASDomainResourcesMaxDomains, configapi.DefaultTASDomainResourcesMaxDomains)),
+		reported:   make(map[kueue.ResourceFlavorReference]*flavorSeries),
+	}
+}
+
+func (r *domainMetricsReporter) setupWithManager(mgr ctrl.Manager) (string, error) {
+	return TASDomainMetricsReporter, mgr.Add(r)
+}
+
+// Start implements the Runnable interface to report the metrics
+// periodically.
+func (r *domainMetricsReporter) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName(TASDomainMetricsReporter)
+	ctx = ctrl.LoggerInto(ctx, log)
+	go wait.UntilWithContext(ctx, r.report, domainMetricsInterval)
+	return nil
+}
+
+// NeedLeaderElection implements the LeaderElectionRunnable interface, so
+// that the metrics are reported by all the replicas.
+func (r *domainMetricsReporter) NeedLeaderElection() bool {
+	return false
+}
+
+func (r *domainMetricsReporter) report(ctx context.Context) {
+	log := ctrl.LoggerFrom(ctx)
+	reported := make(map[kueue.ResourceFlavorReference]*flavorSeries)
+	for _, flavor := range slices.Sorted(maps.Keys(r.tasCache.Clone())) {
+		snapshot, err := r.tasCache.FlavorSnapshot(ctx, flavor)
+		if err != nil {
+			log.Error(err, "Failed to build the TAS snapshot", "flavor", flavor)
+			continue
+		}
+		series := &flavorSeries{
+			domains:     sets.New[domainSeries](),
+			largestFree: sets.New[levelSeries](),
+		}
+		for level, domains := range snapshot.DomainResources() {
+			r.reportLevel(string(flavor), level, domains, series)
+		}
+		reported[flavor] = series
+		if previous, found := r.reported[flavor]; found {
+			for s := range previous.domains.Difference(series.domains) {
+				metrics.ClearTASDomainResources(string(flavor), s.level, s.domain, s.resource)
+			}
+			for s := range previous.largestFree.Difference(series.largestFree) {
+				metrics.ClearTASLargestFreeDomain(string(flavor), s.level, s.resource)
+			}
+		}
+	}
+	for flavor := range r.reported {
+		if _, found := reported[flavor]; !found {
+			metrics.ClearTASFlavorMetrics(string(flavor))
+		}
+	}
+	r.reported = reported
+}
+
+func (r *domainMetricsReporter) reportLevel(flavor, level string, domains []cache.TASDomainResources, series *flavorSeries) {
+	largestFree := make(map[corev1.ResourceName]int64)
+	for _, domain := range domains {
+		for name, free := range domain.Free() {
+			if current, found := largestFree[name]; !found || free > current {
+				largestFree[name] = free
+			}
+		}
+	}
+	for name, free := range largestFree {
+		metrics.ReportTASLargestFreeDomain(flavor, level, string(name), toFloat(name, free))
+		series.largestFree.Insert(levelSeries{level: level, resource: string(name)})
+	}
+
+	slices.SortStableFunc(domains, func(a, b cache.TASDomainResources) int {
+		return cmp.Compare(b.TASUsage[corev1.ResourcePods], a.TASUsage[corev1.ResourcePods])
+	})
+	for _, domain := range domains[:min(len(domains), r.maxDomains)] {
+		for name, capacity := range domain.Capacity {
+			metrics.ReportTASDomainResources(flavor, level, string(domain.Domain), string(name),
+				toFloat(name, capacity), toFloat(name, domain.TASUsage[name]), toFloat(name, domain.NonTASUsage[name]))
+			series.domains.Insert(domainSeries{level: level, domain: string(domain.Domain), resource: string(name)})
+		}
+	}
+}
+
+func toFloat(name corev1.ResourceName, v int64) float64 {
+	q := resources.ResourceQuantity(name, v)
+	return utilresource.QuantityToFloat(&q)
+}
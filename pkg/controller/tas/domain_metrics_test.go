@@ -0,0 +1,106 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tas
+
+import (
+	"testing"
+
+	gocmp "github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/controller/tas/indexer"
+	"sigs.k8s.io/kueue/pkg/metrics"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	testingnode "sigs.k8s.io/kueue/pkg/util/testingjobs/node"
+	testingpod "sigs.k8s.io/kueue/pkg/util/testingjobs/pod"
+)
+
+func TestDomainMetricsReport(t *testing.T) {
+	levels := []string{tasRackLabel, corev1.LabelHostname}
+	node := func(name, rack, cpu string) *corev1.Node {
+		return testingnode.MakeNode(name).
+			Label(tasRackLabel, rack).
+			Label(corev1.LabelHostname, name).
+			StatusAllocatable(corev1.ResourceList{
+				corev1.ResourceCPU:  resource.MustParse(cpu),
+				corev1.ResourcePods: resource.MustParse("10"),
+			}).
+			Ready().
+			Obj()
+	}
+	ctx, _ := utiltesting.ContextWithLog(t)
+	clientBuilder := utiltesting.NewClientBuilder()
+	if err := indexer.SetupIndexes(ctx, utiltesting.AsIndexer(clientBuilder)); err != nil {
+		t.Fatalf("Could not setup indexes: %v", err)
+	}
+	kClient := clientBuilder.
+		WithObjects(
+			node("x1", "r1", "4"),
+			node("x2", "r1", "4"),
+			node("x3", "r2", "2"),
+			testingpod.MakePod("daemon", "ns").NodeName("x1").Request(corev1.ResourceCPU, "1").Obj(),
+		).
+		Build()
+	cqCache := cache.New(kClient)
+	flavor := utiltesting.MakeResourceFlavor("tas-metrics").TopologyName("default").Obj()
+	cqCache.AddOrUpdateResourceFlavor(flavor)
+	cqCache.AddOrUpdateTopologyForFlavor(utiltesting.MakeTopology("default").Levels(levels...).Obj(), flavor)
+
+	reporter := newDomainMetricsReporter(cqCache, &configapi.ControllerMetrics{TASDomainResourcesMaxDomains: ptr.To[int32](1)})
+	reporter.report(ctx)
+
+	if got := testutil.CollectAndCount(metrics.TASDomainCapacity); got != 4 {
+		t.Errorf("Unexpected number of the reported domain capacity series, want 4 (one domain for 2 levels and 2 resources), got %d", got)
+	}
+	gotCapacity := testutil.ToFloat64(metrics.TASDomainCapacity.WithLabelValues("tas-metrics", tasRackLabel, "r1", "cpu"))
+	if diff := gocmp.Diff(8.0, gotCapacity); diff != "" {
+		t.Errorf("Unexpected capacity of the rack r1 (-want,+got):\n%s", diff)
+	}
+	gotNonTASUsage := testutil.ToFloat64(metrics.TASDomainNonTASUsage.WithLabelValues("tas-metrics", tasRackLabel, "r1", "cpu"))
+	if diff := gocmp.Diff(1.0, gotNonTASUsage); diff != "" {
+		t.Errorf("Unexpected non-TAS usage of the rack r1 (-want,+got):\n%s", diff)
+	}
+	gotLargestFree := testutil.ToFloat64(metrics.TASLargestFreeDomain.WithLabelValues("tas-metrics", corev1.LabelHostname, "cpu"))
+	if diff := gocmp.Diff(4.0, gotLargestFree); diff != "" {
+		t.Errorf("Unexpected largest free node (-want,+got):\n%s", diff)
+	}
+
+	for _, name := range []string{"x1", "x2"} {
+		if err := kClient.Delete(ctx, node(name, "r1", "4")); err != nil {
+			t.Fatalf("Could not delete the node %s: %v", name, err)
+		}
+	}
+	reporter.report(ctx)
+	if got := testutil.CollectAndCount(metrics.TASDomainCapacity); got != 4 {
+		t.Errorf("Unexpected number of the reported domain capacity series after the rack r1 is removed, want 4, got %d", got)
+	}
+	gotCapacity = testutil.ToFloat64(metrics.TASDomainCapacity.WithLabelValues("tas-metrics", tasRackLabel, "r2", "cpu"))
+	if diff := gocmp.Diff(2.0, gotCapacity); diff != "" {
+		t.Errorf("Unexpected capacity of the rack r2 (-want,+got):\n%s", diff)
+	}
+
+	cqCache.TASCache().Delete("tas-metrics")
+	reporter.report(ctx)
+	if got := testutil.CollectAndCount(metrics.TASDomainCapacity); got != 0 {
+		t.Errorf("Unexpected domain capacity series after the flavor is deleted, got %d", got)
+	}
+}
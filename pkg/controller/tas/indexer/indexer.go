@@ -33,6 +33,7 @@ const (
 	ReadyNode                     = "metadata.ready"
 	SchedulableNode               = "spec.schedulable"
 	ResourceFlavorTopologyNameKey = "spec.topologyName"
+	WorkloadAssignedNodeKey       = "status.admission.topologyAssignment.node"
 )
 
 func indexPodWorkload(o client.Object) []string {
@@ -76,6 +77,20 @@ func indexResourceFlavorTopologyName(o client.Object) []string {
 	return []string{string(*flavor.Spec.TopologyName)}
 }
 
+// indexWorkloadAssignedNode indexes the workloads by the nodes in their
+// topology assignments, when the lowest level of the assignment is the node.
+func indexWorkloadAssignedNode(o client.Object) []string {
+	wl, ok := o.(*kueue.Workload)
+	if !ok || wl.Status.Admission == nil {
+		return nil
+	}
+	var nodes []string
+	for _, psa := range wl.Status.Admission.PodSetAssignments {
+		nodes = append(nodes, utiltas.AssignedHostnames(psa.TopologyAssignment)...)
+	}
+	return nodes
+}
+
 func SetupIndexes(ctx context.Context, indexer client.FieldIndexer) error {
 	if err := indexer.IndexField(ctx, &corev1.Pod{}, WorkloadNameKey, indexPodWorkload); err != nil {
 		return fmt.Errorf("setting index pod workload: %w", err)
@@ -93,5 +108,9 @@ func SetupIndexes(ctx context.Context, indexer client.FieldIndexer) error {
 		return fmt.Errorf("setting index resource flavor topology name: %w", err)
 	}
 
+	if err := indexer.IndexField(ctx, &kueue.Workload{}, WorkloadAssignedNodeKey, indexWorkloadAssignedNode); err != nil {
+		return fmt.Errorf("setting index workload assigned node: %w", err)
+	}
+
 	return nil
 }
@@ -0,0 +1,223 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tas
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/constants"
+	"sigs.k8s.io/kueue/pkg/queue"
+)
+
+// nodeCapacityReconciler computes the capacity of the nodes of the
+// ResourceFlavors used by ClusterQueues to derive nominal quotas, with
+// nodeCapacityPercentage. It runs in all the replicas, as it only
+// updates the cache.
+type nodeCapacityReconciler struct {
+	client   client.Client
+	queues   *queue.Manager
+	cache    *cache.Cache
+	tasCache *cache.TASCache
+}
+
+var _ reconcile.Reconciler = (*nodeCapacityReconciler)(nil)
+
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=clusterqueues,verbs=get;list;watch
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=resourceflavors,verbs=get;list;watch
+
+func newNodeCapacityReconciler(c client.Client, queues *queue.Manager, cache *cache.Cache) *nodeCapacityReconciler {
+	return &nodeCapacityReconciler{
+		client:   c,
+		queues:   queues,
+		cache:    cache,
+		tasCache: cache.TASCache(),
+	}
+}
+
+func (r *nodeCapacityReconciler) setupWithManager(mgr ctrl.Manager) (string, error) {
+	return TASNodeCapacityController, builder.ControllerManagedBy(mgr).
+		Named("tas_node_capacity_controller").
+		Watches(&kueue.ResourceFlavor{}, &handler.EnqueueRequestForObject{}).
+		Watches(&kueue.ClusterQueue{}, &nodeCapacityClusterQueueHandler{}).
+		Watches(&corev1.Node{}, &nodeCapacityNodeHandler{tasCache: r.tasCache}).
+		WithOptions(controller.Options{NeedLeaderElection: ptr.To(false)}).
+		Complete(r)
+}
+
+func (r *nodeCapacityReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	log.V(2).Info("Reconcile node capacity of ResourceFlavor")
+
+	flvName := kueue.ResourceFlavorReference(req.Name)
+	flv := &kueue.ResourceFlavor{}
+	if err := r.client.Get(ctx, req.NamespacedName, flv); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return reconcile.Result{}, err
+		}
+		r.updateNodeCapacity(ctx, flvName, nil)
+		return reconcile.Result{}, nil
+	}
+
+	inUse, err := r.flavorUsesNodeCapacity(ctx, flvName)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if !inUse {
+		r.updateNodeCapacity(ctx, flvName, nil)
+		return reconcile.Result{}, nil
+	}
+
+	capacity, err := r.tasCache.ComputeNodeCapacity(ctx, flv)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	log.V(3).Info("Computed node capacity", "nodes", capacity.Nodes, "allocatable", capacity.Allocatable)
+	r.updateNodeCapacity(ctx, flvName, capacity)
+	return reconcile.Result{}, nil
+}
+
+func (r *nodeCapacityReconciler) updateNodeCapacity(ctx context.Context, flvName kueue.ResourceFlavorReference, capacity *cache.NodeCapacity) {
+	cqNames := r.cache.UpdateNodeCapacity(flvName, capacity)
+	if len(cqNames) == 0 {
+		return
+	}
+	r.queues.NotifyNodeCapacityUpdateWatchers(cqNames)
+	// more quota can allow admitting workloads which were previously
+	// inadmissible.
+	r.queues.QueueInadmissibleWorkloads(ctx, cqNames)
+}
+
+// flavorUsesNodeCapacity returns whether any ClusterQueue derives nominal
+// quotas from the capacity of the nodes of the flavor. The ClusterQueues are
+// listed from the API, rather than from the cache, so that the capacity is
+// known before the ClusterQueues are added to the cache.
+func (r *nodeCapacityReconciler) flavorUsesNodeCapacity(ctx context.Context, flvName kueue.ResourceFlavorReference) (bool, error) {
+	cqs := &kueue.ClusterQueueList{}
+	if err := r.client.List(ctx, cqs); err != nil {
+		return false, err
+	}
+	for i := range cqs.Items {
+		for _, name := range nodeCapacityFlavors(&cqs.Items[i]) {
+			if name == flvName {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// nodeCapacityFlavors returns the flavors of the ClusterQueue with resources
+// setting nodeCapacityPercentage.
+func nodeCapacityFlavors(cq *kueue.ClusterQueue) []kueue.ResourceFlavorReference {
+	var flavors []kueue.ResourceFlavorReference
+	for _, rg := range cq.Spec.ResourceGroups {
+		for _, flv := range rg.Flavors {
+			for _, quota := range flv.Resources {
+				if quota.NodeCapacityPercentage != nil {
+					flavors = append(flavors, flv.Name)
+					break
+				}
+			}
+		}
+	}
+	return flavors
+}
+
+var _ handler.EventHandler = (*nodeCapacityClusterQueueHandler)(nil)
+
+// nodeCapacityClusterQueueHandler triggers the recomputation of the node
+// capacity of the flavors with resources setting nodeCapacityPercentage in
+// the ClusterQueue. On updates, the flavors of the old ClusterQueue are
+// reconciled as well, so that the capacity of the flavors no longer in use
+// is dropped.
+type nodeCapacityClusterQueueHandler struct{}
+
+func (h *nodeCapacityClusterQueueHandler) Create(_ context.Context, e event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.queueReconcileForFlavors(q, e.Object)
+}
+
+func (h *nodeCapacityClusterQueueHandler) Update(_ context.Context, e event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.queueReconcileForFlavors(q, e.ObjectOld, e.ObjectNew)
+}
+
+func (h *nodeCapacityClusterQueueHandler) Delete(_ context.Context, e event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.queueReconcileForFlavors(q, e.Object)
+}
+
+func (h *nodeCapacityClusterQueueHandler) Generic(context.Context, event.GenericEvent, workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+func (h *nodeCapacityClusterQueueHandler) queueReconcileForFlavors(q workqueue.TypedRateLimitingInterface[reconcile.Request], objs ...client.Object) {
+	flavors := sets.New[kueue.ResourceFlavorReference]()
+	for _, obj := range objs {
+		if cq, isCQ := obj.(*kueue.ClusterQueue); isCQ {
+			flavors.Insert(nodeCapacityFlavors(cq)...)
+		}
+	}
+	for name := range flavors {
+		q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Name: string(name)}})
+	}
+}
+
+var _ handler.EventHandler = (*nodeCapacityNodeHandler)(nil)
+
+// nodeCapacityNodeHandler triggers the recomputation of the node capacity
+// of the tracked flavors on node events.
+type nodeCapacityNodeHandler struct {
+	tasCache *cache.TASCache
+}
+
+func (h *nodeCapacityNodeHandler) Create(_ context.Context, _ event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.queueReconcileForFlavors(q)
+}
+
+func (h *nodeCapacityNodeHandler) Update(_ context.Context, _ event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.queueReconcileForFlavors(q)
+}
+
+func (h *nodeCapacityNodeHandler) Delete(_ context.Context, _ event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.queueReconcileForFlavors(q)
+}
+
+func (h *nodeCapacityNodeHandler) Generic(context.Context, event.GenericEvent, workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+func (h *nodeCapacityNodeHandler) queueReconcileForFlavors(q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	// The node events are batched, as the capacity is computed from all
+	// the nodes of the flavor.
+	for _, name := range h.tasCache.NodeCapacityFlavors() {
+		q.AddAfter(reconcile.Request{NamespacedName: types.NamespacedName{
+			Name: string(name),
+		}}, constants.UpdatesBatchPeriod)
+	}
+}
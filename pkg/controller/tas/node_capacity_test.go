@@ -0,0 +1,105 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tas
+
+import (
+	"context"
+	"testing"
+
+	gocmp "github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestNodeCapacityClusterQueueHandler(t *testing.T) {
+	cqWithNodeCapacity := func(flavors ...string) *kueue.ClusterQueue {
+		quotas := make([]kueue.FlavorQuotas, 0, len(flavors))
+		for _, flv := range flavors {
+			quotas = append(quotas, *utiltesting.MakeFlavorQuotas(flv).
+				ResourceQuotaWrapper(corev1.ResourceCPU).NodeCapacityPercentage(50).Append().
+				Obj())
+		}
+		return utiltesting.MakeClusterQueue("cq").ResourceGroup(quotas...).Obj()
+	}
+	cases := map[string]struct {
+		event        func(h *nodeCapacityClusterQueueHandler, q workqueue.TypedRateLimitingInterface[reconcile.Request])
+		wantRequests []string
+	}{
+		"create": {
+			event: func(h *nodeCapacityClusterQueueHandler, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+				h.Create(context.Background(), event.CreateEvent{Object: cqWithNodeCapacity("tas-a")}, q)
+			},
+			wantRequests: []string{"tas-a"},
+		},
+		"create without nodeCapacityPercentage": {
+			event: func(h *nodeCapacityClusterQueueHandler, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+				cq := utiltesting.MakeClusterQueue("cq").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("tas-a").Resource(corev1.ResourceCPU, "5").Obj()).
+					Obj()
+				h.Create(context.Background(), event.CreateEvent{Object: cq}, q)
+			},
+		},
+		"update replacing the flavor": {
+			event: func(h *nodeCapacityClusterQueueHandler, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+				h.Update(context.Background(), event.UpdateEvent{
+					ObjectOld: cqWithNodeCapacity("tas-a", "tas-b"),
+					ObjectNew: cqWithNodeCapacity("tas-b", "tas-c"),
+				}, q)
+			},
+			wantRequests: []string{"tas-a", "tas-b", "tas-c"},
+		},
+		"update dropping nodeCapacityPercentage": {
+			event: func(h *nodeCapacityClusterQueueHandler, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+				h.Update(context.Background(), event.UpdateEvent{
+					ObjectOld: cqWithNodeCapacity("tas-a"),
+					ObjectNew: utiltesting.MakeClusterQueue("cq").
+						ResourceGroup(*utiltesting.MakeFlavorQuotas("tas-a").Resource(corev1.ResourceCPU, "5").Obj()).
+						Obj(),
+				}, q)
+			},
+			wantRequests: []string{"tas-a"},
+		},
+		"delete": {
+			event: func(h *nodeCapacityClusterQueueHandler, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+				h.Delete(context.Background(), event.DeleteEvent{Object: cqWithNodeCapacity("tas-a")}, q)
+			},
+			wantRequests: []string{"tas-a"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			q := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+			defer q.ShutDown()
+			tc.event(&nodeCapacityClusterQueueHandler{}, q)
+			var gotRequests []string
+			for q.Len() > 0 {
+				req, _ := q.Get()
+				q.Done(req)
+				gotRequests = append(gotRequests, req.Name)
+			}
+			if diff := gocmp.Diff(tc.wantRequests, gotRequests, cmpopts.SortSlices(func(a, b string) bool { return a < b }), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected requests (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tas
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/queue"
+	utiltas "sigs.k8s.io/kueue/pkg/util/tas"
+)
+
+// nodeDomainsReconciler reads the topology domains of the nodes from the
+// ConfigMaps referenced by the Topologies, in the namespace of Kueue. It runs
+// in all the replicas, as it only updates the cache.
+type nodeDomainsReconciler struct {
+	client    client.Client
+	queues    *queue.Manager
+	cache     *cache.Cache
+	tasCache  *cache.TASCache
+	namespace string
+}
+
+var _ reconcile.Reconciler = (*nodeDomainsReconciler)(nil)
+
+// The access to the ConfigMaps in the namespace of Kueue is granted by the
+// node-domains-role Role, and the manager only caches the ConfigMaps of
+// this namespace.
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=topologies,verbs=get;list;watch
+
+func newNodeDomainsReconciler(c client.Client, queues *queue.Manager, cache *cache.Cache, cfg *configapi.Configuration) *nodeDomainsReconciler {
+	return &nodeDomainsReconciler{
+		client:    c,
+		queues:    queues,
+		cache:     cache,
+		tasCache:  cache.TASCache(),
+		namespace: ptr.Deref(cfg.Namespace, configapi.DefaultNamespace),
+	}
+}
+
+func (r *nodeDomainsReconciler) setupWithManager(mgr ctrl.Manager) (string, error) {
+	return TASNodeDomainsController, builder.ControllerManagedBy(mgr).
+		Named("tas_node_domains_controller").
+		Watches(&kueuealpha.Topology{}, &handler.EnqueueRequestForObject{}).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.topologiesUsingConfigMap)).
+		WithOptions(controller.Options{NeedLeaderElection: ptr.To(false)}).
+		Complete(r)
+}
+
+func (r *nodeDomainsReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	log.V(2).Info("Reconcile node domains of Topology")
+
+	topologyName := kueue.TopologyReference(req.Name)
+	topology := &kueuealpha.Topology{}
+	if err := r.client.Get(ctx, req.NamespacedName, topology); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return reconcile.Result{}, err
+		}
+		r.tasCache.SetNodeDomains(topologyName, nil)
+		return reconcile.Result{}, nil
+	}
+	if topology.Spec.NodeDomainsSource == nil {
+		r.tasCache.SetNodeDomains(topologyName, nil)
+		return reconcile.Result{}, nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	key := types.NamespacedName{Namespace: r.namespace, Name: topology.Spec.NodeDomainsSource.ConfigMapName}
+	if err := r.client.Get(ctx, key, configMap); client.IgnoreNotFound(err) != nil {
+		return reconcile.Result{}, err
+	}
+	domains := cache.ParseNodeDomains(log, configMap, len(utiltas.Levels(topology))-1)
+	if !r.tasCache.SetNodeDomains(topologyName, domains) {
+		return reconcile.Result{}, nil
+	}
+	log.V(3).Info("Updated node domains", "configMap", klog.KObj(configMap), "nodes", len(domains))
+	// the nodes of the topology changed, which can allow admitting
+	// workloads which were previously inadmissible.
+	if cqNames := r.cache.ActiveClusterQueues(); len(cqNames) > 0 {
+		r.queues.QueueInadmissibleWorkloads(ctx, cqNames)
+	}
+	return reconcile.Result{}, nil
+}
+
+func (r *nodeDomainsReconciler) topologiesUsingConfigMap(ctx context.Context, obj client.Object) []reconcile.Request {
+	configMap, isConfigMap := obj.(*corev1.ConfigMap)
+	if !isConfigMap || configMap.Namespace != r.namespace {
+		return nil
+	}
+	topologies := &kueuealpha.TopologyList{}
+	if err := r.client.List(ctx, topologies); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Could not list topologies")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, topology := range topologies.Items {
+		if source := topology.Spec.NodeDomainsSource; source != nil && source.ConfigMapName == configMap.Name {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: topology.Name}})
+		}
+	}
+	return requests
+}
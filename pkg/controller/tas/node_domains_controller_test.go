@@ -0,0 +1,129 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tas
+
+import (
+	"testing"
+
+	gocmp "github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/controller/tas/indexer"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/queue"
+	utiltas "sigs.k8s.io/kueue/pkg/util/tas"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	testingnode "sigs.k8s.io/kueue/pkg/util/testingjobs/node"
+)
+
+func TestNodeDomainsReconcile(t *testing.T) {
+	const tasSpineLabel = "cloud.com/topology-spine"
+	node := func(name string) *corev1.Node {
+		return testingnode.MakeNode(name).
+			Label(corev1.LabelHostname, name).
+			StatusAllocatable(corev1.ResourceList{
+				corev1.ResourceCPU:  resource.MustParse("1"),
+				corev1.ResourcePods: resource.MustParse("10"),
+			}).
+			Ready().
+			Obj()
+	}
+	topology := utiltesting.MakeTopology("default").Levels(tasSpineLabel, tasRackLabel, corev1.LabelHostname).Obj()
+	topology.Spec.NodeDomainsSource = &kueuealpha.TopologyNodeDomainsSource{ConfigMapName: "node-domains"}
+
+	cases := map[string]struct {
+		configMapData map[string]string
+		wantDomains   map[string][]utiltas.TopologyDomainID
+	}{
+		"domains are read from the ConfigMap": {
+			configMapData: map[string]string{
+				"x1": "s1/r1",
+				"x2": "s1/r2",
+				"x3": "s2/r3",
+			},
+			wantDomains: map[string][]utiltas.TopologyDomainID{
+				tasSpineLabel:        {"s1", "s2"},
+				tasRackLabel:         {"s1,r1", "s1,r2", "s2,r3"},
+				corev1.LabelHostname: {"s1,r1,x1", "s1,r2,x2", "s2,r3,x3"},
+			},
+		},
+		"invalid entries are skipped": {
+			configMapData: map[string]string{
+				"x1": "s1/r1",
+				"x2": "r2",
+				"x3": "s2/r,3",
+			},
+			wantDomains: map[string][]utiltas.TopologyDomainID{
+				tasSpineLabel:        {"s1"},
+				tasRackLabel:         {"s1,r1"},
+				corev1.LabelHostname: {"s1,r1,x1"},
+			},
+		},
+		"no nodes without the ConfigMap": {
+			wantDomains: map[string][]utiltas.TopologyDomainID{},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.TASNodeDomainsSource, true)
+			ctx, _ := utiltesting.ContextWithLog(t)
+			clientBuilder := utiltesting.NewClientBuilder()
+			if err := indexer.SetupIndexes(ctx, utiltesting.AsIndexer(clientBuilder)); err != nil {
+				t.Fatalf("Could not setup indexes: %v", err)
+			}
+			clientBuilder = clientBuilder.WithObjects(topology.DeepCopy(), node("x1"), node("x2"), node("x3"))
+			if tc.configMapData != nil {
+				clientBuilder = clientBuilder.WithObjects(&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "node-domains", Namespace: configapi.DefaultNamespace},
+					Data:       tc.configMapData,
+				})
+			}
+			kClient := clientBuilder.Build()
+
+			cqCache := cache.New(kClient)
+			flavor := utiltesting.MakeResourceFlavor("tas").TopologyName("default").Obj()
+			cqCache.AddOrUpdateResourceFlavor(flavor)
+			cqCache.AddOrUpdateTopologyForFlavor(topology, flavor)
+
+			reconciler := newNodeDomainsReconciler(kClient, queue.NewManager(kClient, nil), cqCache, &configapi.Configuration{})
+			if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "default"}}); err != nil {
+				t.Fatalf("Reconcile returned error: %v", err)
+			}
+
+			snapshot, err := cqCache.TASCache().FlavorSnapshot(ctx, "tas")
+			if err != nil {
+				t.Fatalf("Could not build the snapshot: %v", err)
+			}
+			gotDomains := make(map[string][]utiltas.TopologyDomainID)
+			for level, domains := range snapshot.DomainResources() {
+				for _, domain := range domains {
+					gotDomains[level] = append(gotDomains[level], domain.Domain)
+				}
+			}
+			if diff := gocmp.Diff(tc.wantDomains, gotDomains); diff != "" {
+				t.Errorf("Unexpected domains (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,301 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/controller/core"
+	"sigs.k8s.io/kueue/pkg/controller/tas/indexer"
+	utilpod "sigs.k8s.io/kueue/pkg/util/pod"
+	utiltas "sigs.k8s.io/kueue/pkg/util/tas"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// nodeFailureDelay is the time a node needs to be not ready for, before it
+// is considered failed.
+const nodeFailureDelay = 30 * time.Second
+
+// nodeFailureReconciler replaces the failed nodes in the topology assignments
+// of the workloads admitted by TAS. A node is failed when it is deleted, or
+// when it is not ready for longer than nodeFailureDelay. The pods assigned to
+// a failed node are moved to other nodes, as close as possible in the
+// topology, and the workload is evicted when no replacement is found.
+// The topology assignments hold the hostname label values of the nodes,
+// which can differ from the node names, so the reconcile requests are keyed
+// by the hostname label value.
+type nodeFailureReconciler struct {
+	client   client.Client
+	tasCache *cache.TASCache
+	recorder record.EventRecorder
+	clock    clock.Clock
+}
+
+var _ reconcile.Reconciler = (*nodeFailureReconciler)(nil)
+
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;watch;update;patch
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads,verbs=get;list;watch
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads/status,verbs=get;update;patch
+
+func newNodeFailureReconciler(c client.Client, cache *cache.Cache, recorder record.EventRecorder) *nodeFailureReconciler {
+	return &nodeFailureReconciler{
+		client:   c,
+		tasCache: cache.TASCache(),
+		recorder: recorder,
+		clock:    clock.RealClock{},
+	}
+}
+
+func (r *nodeFailureReconciler) setupWithManager(mgr ctrl.Manager, cfg *configapi.Configuration) (string, error) {
+	return TASNodeFailureController, builder.ControllerManagedBy(mgr).
+		Named("tas_node_failure_controller").
+		Watches(&corev1.Node{}, handler.EnqueueRequestsFromMapFunc(nodeHostname)).
+		Watches(&kueue.Workload{}, handler.EnqueueRequestsFromMapFunc(assignedHostnames)).
+		WithOptions(controller.Options{NeedLeaderElection: ptr.To(false)}).
+		Complete(core.WithLeadingManager(mgr, r, &corev1.Node{}, cfg))
+}
+
+// nodeHostname queues the hostname label value of the node.
+func nodeHostname(_ context.Context, obj client.Object) []reconcile.Request {
+	hostname, found := obj.GetLabels()[corev1.LabelHostname]
+	if !found {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: hostname}}}
+}
+
+// assignedHostnames queues the nodes of the topology assignments of the
+// workload, so that the workloads admitted to nodes which already failed
+// are handled.
+func assignedHostnames(_ context.Context, obj client.Object) []reconcile.Request {
+	wl, isWorkload := obj.(*kueue.Workload)
+	if !isWorkload || !isAdmittedByTAS(wl) {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, psa := range wl.Status.Admission.PodSetAssignments {
+		for _, hostname := range utiltas.AssignedHostnames(psa.TopologyAssignment) {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: hostname}})
+		}
+	}
+	return requests
+}
+
+func (r *nodeFailureReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	log.V(2).Info("Reconcile TAS node failure")
+
+	hostname := req.Name
+	var nodes corev1.NodeList
+	if err := r.client.List(ctx, &nodes, client.MatchingLabels{corev1.LabelHostname: hostname}); err != nil {
+		return reconcile.Result{}, err
+	}
+	var failedNode *corev1.Node
+	nodeNames := sets.New[string]()
+	if len(nodes.Items) == 0 {
+		log.V(3).Info("Node not found")
+	}
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if utiltas.IsNodeStatusConditionTrue(node.Status.Conditions, corev1.NodeReady) {
+			return reconcile.Result{}, nil
+		}
+		if wait := r.failureDelayRemaining(node); wait > 0 {
+			log.V(3).Info("Node not ready, waiting before considering it failed", "node", klog.KObj(node), "wait", wait)
+			return reconcile.Result{RequeueAfter: wait}, nil
+		}
+		failedNode = node
+		nodeNames.Insert(node.Name)
+	}
+
+	var workloads kueue.WorkloadList
+	if err := r.client.List(ctx, &workloads, client.MatchingFields{indexer.WorkloadAssignedNodeKey: hostname}); err != nil {
+		return reconcile.Result{}, err
+	}
+	// The snapshots are shared by the workloads, so that the replacements
+	// found for a workload account for the replacements of the previous ones.
+	snapshots := make(map[kueue.ResourceFlavorReference]*cache.TASFlavorSnapshot)
+	var errs []error
+	for i := range workloads.Items {
+		wl := &workloads.Items[i]
+		if !isAdmittedByTAS(wl) || apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadEvicted) {
+			continue
+		}
+		if err := r.replaceFailedNode(ctx, wl, hostname, nodeNames, failedNode, snapshots); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return reconcile.Result{}, errors.Join(errs...)
+}
+
+// failureDelayRemaining returns the time until the not ready node is
+// considered failed.
+func (r *nodeFailureReconciler) failureDelayRemaining(node *corev1.Node) time.Duration {
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == corev1.NodeReady {
+			return cond.LastTransitionTime.Add(nodeFailureDelay).Sub(r.clock.Now())
+		}
+	}
+	return 0
+}
+
+// replaceFailedNode updates the topology assignments of the workload, moving
+// the pods assigned to the failed node to other nodes, and deletes the pods
+// of the workload on the failed node so that they are recreated. The workload
+// is evicted when no replacement is found. The workloads with pods on the
+// failed node which are not recreated once deleted, like plain Pods, are
+// left unchanged. The failed node is identified by its hostname label value,
+// nodeNames holds the names of the nodes with it, and failedNode is one of
+// them, if still present.
+func (r *nodeFailureReconciler) replaceFailedNode(ctx context.Context, wl *kueue.Workload, hostname string, nodeNames sets.Set[string], failedNode *corev1.Node, snapshots map[kueue.ResourceFlavorReference]*cache.TASFlavorSnapshot) error {
+	log := ctrl.LoggerFrom(ctx).WithValues("workload", klog.KObj(wl))
+	pods, err := r.podsOnNode(ctx, wl, hostname, nodeNames)
+	if err != nil {
+		return err
+	}
+	if slices.ContainsFunc(pods, func(pod *corev1.Pod) bool { return metav1.GetControllerOfNoCopy(pod) == nil }) {
+		log.V(2).Info("Not replacing the failed node, as the pods on it are not recreated once deleted", "node", hostname)
+		return nil
+	}
+	info := workload.NewInfo(wl)
+	wlCopy := wl.DeepCopy()
+	for i := range wlCopy.Status.Admission.PodSetAssignments {
+		psa := &wlCopy.Status.Admission.PodSetAssignments[i]
+		if !slices.Contains(utiltas.AssignedHostnames(psa.TopologyAssignment), hostname) {
+			continue
+		}
+		tasRequests, err := podSetRequests(wl, info, psa)
+		if err != nil {
+			return r.evict(ctx, wl, hostname, err.Error())
+		}
+		snapshot, found := snapshots[tasRequests.Flavor]
+		if !found {
+			snapshot, err = r.tasCache.FlavorSnapshot(ctx, tasRequests.Flavor)
+			if err != nil {
+				return err
+			}
+			if snapshot == nil {
+				return r.evict(ctx, wl, hostname, fmt.Sprintf("flavor %q is not a TAS flavor", tasRequests.Flavor))
+			}
+			snapshots[tasRequests.Flavor] = snapshot
+		}
+		assignment, reason := snapshot.FindReplacementAssignment(tasRequests, psa.TopologyAssignment, hostname, failedNode)
+		if reason != "" {
+			return r.evict(ctx, wl, hostname, reason)
+		}
+		log.V(2).Info("Replacing failed node in topology assignment", "podset", psa.Name, "node", hostname, "assignment", assignment)
+		psa.TopologyAssignment = assignment
+	}
+	if err := workload.ApplyAdmissionStatus(ctx, r.client, wlCopy, true, r.clock); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	r.recorder.Eventf(wl, corev1.EventTypeNormal, "NodeReplaced", "Replaced the failed node %q in the topology assignment", hostname)
+	return r.deletePods(ctx, pods, hostname)
+}
+
+// podSetRequests returns the TAS requests of the PodSet admitted with the
+// PodSet assignment.
+func podSetRequests(wl *kueue.Workload, info *workload.Info, psa *kueue.PodSetAssignment) (cache.TASPodSetRequests, error) {
+	psIdx := slices.IndexFunc(wl.Spec.PodSets, func(ps kueue.PodSet) bool { return ps.Name == psa.Name })
+	psrIdx := slices.IndexFunc(info.TotalRequests, func(psr workload.PodSetResources) bool { return psr.Name == psa.Name })
+	if psIdx < 0 || psrIdx < 0 || len(psa.Flavors) == 0 {
+		return cache.TASPodSetRequests{}, fmt.Errorf("podset %q not found", psa.Name)
+	}
+	psr := &info.TotalRequests[psrIdx]
+	var flavor kueue.ResourceFlavorReference
+	for _, flv := range psa.Flavors {
+		// TAS podsets are assigned a single flavor.
+		flavor = flv
+		break
+	}
+	return cache.TASPodSetRequests{
+		PodSet:            &wl.Spec.PodSets[psIdx],
+		SinglePodRequests: psr.SinglePodRequests(),
+		Count:             psr.Count,
+		Flavor:            flavor,
+	}, nil
+}
+
+func (r *nodeFailureReconciler) evict(ctx context.Context, wl *kueue.Workload, nodeName, reason string) error {
+	log := ctrl.LoggerFrom(ctx).WithValues("workload", klog.KObj(wl))
+	message := fmt.Sprintf("Node %q failed and no replacement was found: %s", nodeName, reason)
+	log.V(2).Info("Evicting workload due to node failure", "node", nodeName, "reason", reason)
+	workload.SetEvictedCondition(wl, kueue.WorkloadEvictedDueToNodeFailures, message)
+	workload.ResetChecksOnEviction(wl, r.clock.Now())
+	if err := workload.ApplyAdmissionStatus(ctx, r.client, wl, true, r.clock); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	workload.ReportEvictedWorkload(r.recorder, wl, wl.Status.Admission.ClusterQueue, kueue.WorkloadEvictedDueToNodeFailures, message)
+	return nil
+}
+
+// podsOnNode returns the pods of the workload bound, or assigned by the node
+// selector on the hostname label, to the failed node.
+func (r *nodeFailureReconciler) podsOnNode(ctx context.Context, wl *kueue.Workload, hostname string, nodeNames sets.Set[string]) ([]*corev1.Pod, error) {
+	var pods corev1.PodList
+	if err := r.client.List(ctx, &pods, client.InNamespace(wl.Namespace), client.MatchingFields{
+		indexer.WorkloadNameKey: wl.Name,
+	}); err != nil {
+		return nil, err
+	}
+	var result []*corev1.Pod
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if utilpod.IsTerminated(pod) || (!nodeNames.Has(pod.Spec.NodeName) && pod.Spec.NodeSelector[corev1.LabelHostname] != hostname) {
+			continue
+		}
+		result = append(result, pod)
+	}
+	return result, nil
+}
+
+// deletePods deletes the pods on the failed node, so that they are recreated.
+func (r *nodeFailureReconciler) deletePods(ctx context.Context, pods []*corev1.Pod, nodeName string) error {
+	var errs []error
+	for _, pod := range pods {
+		ctrl.LoggerFrom(ctx).V(3).Info("Deleting pod on failed node", "pod", klog.KObj(pod), "node", nodeName)
+		if err := r.client.Delete(ctx, pod); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,381 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tas
+
+import (
+	"testing"
+	"time"
+
+	gocmp "github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/controller/tas/indexer"
+	utiltas "sigs.k8s.io/kueue/pkg/util/tas"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	testingnode "sigs.k8s.io/kueue/pkg/util/testingjobs/node"
+	testingpod "sigs.k8s.io/kueue/pkg/util/testingjobs/pod"
+)
+
+func TestNodeFailureReconcile(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	levels := []string{tasBlockLabel, tasRackLabel, corev1.LabelHostname}
+
+	baseNode := func(name, block, rack string) *testingnode.NodeWrapper {
+		return testingnode.MakeNode(name).
+			Label("node-group", "tas").
+			Label(tasBlockLabel, block).
+			Label(tasRackLabel, rack).
+			Label(corev1.LabelHostname, name).
+			StatusAllocatable(corev1.ResourceList{
+				corev1.ResourceCPU:  resource.MustParse("1"),
+				corev1.ResourcePods: resource.MustParse("10"),
+			})
+	}
+	assignment := func(nodes ...string) *kueue.TopologyAssignment {
+		ta := &kueue.TopologyAssignment{Levels: []string{corev1.LabelHostname}}
+		for _, node := range nodes {
+			ta.Domains = append(ta.Domains, kueue.TopologyDomainAssignment{Values: []string{node}, Count: 1})
+		}
+		return ta
+	}
+	baseWorkload := utiltesting.MakeWorkload("wl", "ns").
+		PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 2).
+			PreferredTopologyRequest(tasRackLabel).
+			Request(corev1.ResourceCPU, "1").
+			Obj()).
+		ReserveQuota(utiltesting.MakeAdmission("cq").
+			PodSets(kueue.PodSetAssignment{
+				Name:               kueue.DefaultPodSetName,
+				Flavors:            map[corev1.ResourceName]kueue.ResourceFlavorReference{corev1.ResourceCPU: "tas"},
+				ResourceUsage:      corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+				Count:              ptr.To[int32](2),
+				TopologyAssignment: assignment("x1", "x3"),
+			}).Obj()).
+		Admitted(true)
+	basePod := func(name, node string) *testingpod.PodWrapper {
+		return testingpod.MakePod(name, "ns").
+			Annotation(kueuealpha.WorkloadAnnotation, "wl").
+			Label(kueuealpha.TASLabel, "true").
+			OwnerReference("job", batchv1.SchemeGroupVersion.WithKind("Job")).
+			NodeSelector(corev1.LabelHostname, node).
+			NodeName(node)
+	}
+
+	cases := map[string]struct {
+		nodes             []corev1.Node
+		pods              []corev1.Pod
+		wantResult        reconcile.Result
+		wantAssignment    *kueue.TopologyAssignment
+		wantEvictedReason string
+		wantPods          []string
+		wantEvents        []utiltesting.EventRecord
+	}{
+		"ready node": {
+			nodes: []corev1.Node{
+				*baseNode("x1", "b1", "r1").Ready().Obj(),
+				*baseNode("x2", "b1", "r1").Ready().Obj(),
+				*baseNode("x3", "b1", "r2").Ready().Obj(),
+			},
+			pods: []corev1.Pod{
+				*basePod("p1", "x1").Obj(),
+				*basePod("p3", "x3").Obj(),
+			},
+			wantAssignment: assignment("x1", "x3"),
+			wantPods:       []string{"p1", "p3"},
+		},
+		"not ready node within the failure delay": {
+			nodes: []corev1.Node{
+				*baseNode("x1", "b1", "r1").StatusConditions(corev1.NodeCondition{
+					Type:               corev1.NodeReady,
+					Status:             corev1.ConditionFalse,
+					LastTransitionTime: metav1.NewTime(now.Add(-10 * time.Second)),
+				}).Obj(),
+				*baseNode("x2", "b1", "r1").Ready().Obj(),
+				*baseNode("x3", "b1", "r2").Ready().Obj(),
+			},
+			pods: []corev1.Pod{
+				*basePod("p1", "x1").Obj(),
+				*basePod("p3", "x3").Obj(),
+			},
+			wantResult:     reconcile.Result{RequeueAfter: 20 * time.Second},
+			wantAssignment: assignment("x1", "x3"),
+			wantPods:       []string{"p1", "p3"},
+		},
+		"not ready node is replaced in the same rack": {
+			nodes: []corev1.Node{
+				*baseNode("x1", "b1", "r1").NotReady().Obj(),
+				*baseNode("x2", "b1", "r1").Ready().Obj(),
+				*baseNode("x3", "b1", "r2").Ready().Obj(),
+				*baseNode("x4", "b1", "r2").Ready().Obj(),
+			},
+			pods: []corev1.Pod{
+				*basePod("p1", "x1").Obj(),
+				*basePod("p3", "x3").Obj(),
+			},
+			wantAssignment: assignment("x2", "x3"),
+			wantPods:       []string{"p3"},
+			wantEvents: []utiltesting.EventRecord{{
+				Key:       types.NamespacedName{Namespace: "ns", Name: "wl"},
+				EventType: corev1.EventTypeNormal,
+				Reason:    "NodeReplaced",
+				Message:   `Replaced the failed node "x1" in the topology assignment`,
+			}},
+		},
+		"not ready node with a name differing from its hostname is replaced": {
+			nodes: []corev1.Node{
+				*baseNode("node-1", "b1", "r1").Label(corev1.LabelHostname, "x1").NotReady().Obj(),
+				*baseNode("node-2", "b1", "r1").Label(corev1.LabelHostname, "x2").Ready().Obj(),
+				*baseNode("node-3", "b1", "r2").Label(corev1.LabelHostname, "x3").Ready().Obj(),
+			},
+			pods: []corev1.Pod{
+				*testingpod.MakePod("p1", "ns").
+					Annotation(kueuealpha.WorkloadAnnotation, "wl").
+					Label(kueuealpha.TASLabel, "true").
+					OwnerReference("job", batchv1.SchemeGroupVersion.WithKind("Job")).
+					NodeName("node-1").
+					Obj(),
+				*basePod("p3", "x3").NodeName("node-3").Obj(),
+			},
+			wantAssignment: assignment("x2", "x3"),
+			wantPods:       []string{"p3"},
+			wantEvents: []utiltesting.EventRecord{{
+				Key:       types.NamespacedName{Namespace: "ns", Name: "wl"},
+				EventType: corev1.EventTypeNormal,
+				Reason:    "NodeReplaced",
+				Message:   `Replaced the failed node "x1" in the topology assignment`,
+			}},
+		},
+		"deleted node is replaced next to the other assigned nodes": {
+			nodes: []corev1.Node{
+				*baseNode("x2", "b2", "r1").Ready().Obj(),
+				*baseNode("x3", "b1", "r2").Ready().Obj(),
+				*baseNode("x4", "b1", "r2").Ready().Obj(),
+			},
+			pods: []corev1.Pod{
+				*basePod("p1", "x1").Obj(),
+				*basePod("p3", "x3").Obj(),
+			},
+			wantAssignment: assignment("x4", "x3"),
+			wantPods:       []string{"p3"},
+			wantEvents: []utiltesting.EventRecord{{
+				Key:       types.NamespacedName{Namespace: "ns", Name: "wl"},
+				EventType: corev1.EventTypeNormal,
+				Reason:    "NodeReplaced",
+				Message:   `Replaced the failed node "x1" in the topology assignment`,
+			}},
+		},
+		"plain pods are not replaced": {
+			nodes: []corev1.Node{
+				*baseNode("x1", "b1", "r1").NotReady().Obj(),
+				*baseNode("x2", "b1", "r1").Ready().Obj(),
+				*baseNode("x3", "b1", "r2").Ready().Obj(),
+			},
+			pods: []corev1.Pod{
+				*testingpod.MakePod("p1", "ns").
+					Annotation(kueuealpha.WorkloadAnnotation, "wl").
+					Label(kueuealpha.TASLabel, "true").
+					NodeName("x1").
+					Obj(),
+				*basePod("p3", "x3").Obj(),
+			},
+			wantAssignment: assignment("x1", "x3"),
+			wantPods:       []string{"p1", "p3"},
+		},
+		"workload is evicted when there is no replacement": {
+			nodes: []corev1.Node{
+				*baseNode("x1", "b1", "r1").NotReady().Obj(),
+				*baseNode("x3", "b1", "r2").Ready().Obj(),
+			},
+			pods: []corev1.Pod{
+				*basePod("p1", "x1").Obj(),
+				*basePod("p3", "x3").Obj(),
+			},
+			wantAssignment:    assignment("x1", "x3"),
+			wantEvictedReason: kueue.WorkloadEvictedDueToNodeFailures,
+			wantPods:          []string{"p1", "p3"},
+			wantEvents: []utiltesting.EventRecord{{
+				Key:       types.NamespacedName{Namespace: "ns", Name: "wl"},
+				EventType: corev1.EventTypeNormal,
+				Reason:    "EvictedDueToNodeFailures",
+				Message:   `Node "x1" failed and no replacement was found: topology "default" doesn't allow to fit the 1 pod(s) assigned to the failed node "x1"`,
+			}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			wl := baseWorkload.Clone().Obj()
+			clientBuilder := utiltesting.NewClientBuilder().
+				WithInterceptorFuncs(interceptor.Funcs{SubResourcePatch: utiltesting.TreatSSAAsStrategicMerge}).
+				WithStatusSubresource(&kueue.Workload{})
+			if err := indexer.SetupIndexes(ctx, utiltesting.AsIndexer(clientBuilder)); err != nil {
+				t.Fatalf("Could not setup indexes: %v", err)
+			}
+			for i := range tc.nodes {
+				clientBuilder = clientBuilder.WithObjects(&tc.nodes[i])
+			}
+			for i := range tc.pods {
+				clientBuilder = clientBuilder.WithObjects(&tc.pods[i])
+			}
+			kClient := clientBuilder.WithObjects(wl).Build()
+
+			cqCache := cache.New(kClient)
+			flavor := utiltesting.MakeResourceFlavor("tas").
+				NodeLabel("node-group", "tas").
+				TopologyName("default").
+				Obj()
+			cqCache.AddOrUpdateResourceFlavor(flavor)
+			cqCache.AddOrUpdateTopologyForFlavor(utiltesting.MakeTopology("default").Levels(levels...).Obj(), flavor)
+
+			recorder := &utiltesting.EventRecorder{}
+			reconciler := newNodeFailureReconciler(kClient, cqCache, recorder)
+			reconciler.clock = testingclock.NewFakeClock(now)
+
+			gotResult, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "x1"}})
+			if err != nil {
+				t.Fatalf("Reconcile returned error: %v", err)
+			}
+			if diff := gocmp.Diff(tc.wantResult, gotResult); diff != "" {
+				t.Errorf("Unexpected result (-want,+got):\n%s", diff)
+			}
+
+			var gotWorkload kueue.Workload
+			if err := kClient.Get(ctx, client.ObjectKeyFromObject(wl), &gotWorkload); err != nil {
+				t.Fatalf("Could not get workload: %v", err)
+			}
+			if diff := gocmp.Diff(tc.wantAssignment, gotWorkload.Status.Admission.PodSetAssignments[0].TopologyAssignment); diff != "" {
+				t.Errorf("Unexpected topology assignment (-want,+got):\n%s", diff)
+			}
+			var gotEvictedReason string
+			if cond := apimeta.FindStatusCondition(gotWorkload.Status.Conditions, kueue.WorkloadEvicted); cond != nil && cond.Status == metav1.ConditionTrue {
+				gotEvictedReason = cond.Reason
+			}
+			if diff := gocmp.Diff(tc.wantEvictedReason, gotEvictedReason); diff != "" {
+				t.Errorf("Unexpected eviction reason (-want,+got):\n%s", diff)
+			}
+
+			var gotPods corev1.PodList
+			if err := kClient.List(ctx, &gotPods); err != nil {
+				t.Fatalf("Could not list pods: %v", err)
+			}
+			var gotPodNames []string
+			for _, pod := range gotPods.Items {
+				gotPodNames = append(gotPodNames, pod.Name)
+			}
+			if diff := gocmp.Diff(tc.wantPods, gotPodNames, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("Unexpected pods (-want,+got):\n%s", diff)
+			}
+
+			if diff := gocmp.Diff(tc.wantEvents, recorder.RecordedEvents); diff != "" {
+				t.Errorf("Unexpected events (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNodeFailureReconcileSharesCapacity(t *testing.T) {
+	ctx, _ := utiltesting.ContextWithLog(t)
+	levels := []string{tasBlockLabel, tasRackLabel, corev1.LabelHostname}
+	baseNode := func(name string) *testingnode.NodeWrapper {
+		return testingnode.MakeNode(name).
+			Label("node-group", "tas").
+			Label(tasBlockLabel, "b1").
+			Label(tasRackLabel, "r1").
+			Label(corev1.LabelHostname, name).
+			StatusAllocatable(corev1.ResourceList{
+				corev1.ResourceCPU:  resource.MustParse("1"),
+				corev1.ResourcePods: resource.MustParse("10"),
+			})
+	}
+	baseWorkload := func(name string) *kueue.Workload {
+		return utiltesting.MakeWorkload(name, "ns").
+			PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
+				PreferredTopologyRequest(tasRackLabel).
+				Request(corev1.ResourceCPU, "500m").
+				Obj()).
+			ReserveQuota(utiltesting.MakeAdmission("cq").
+				PodSets(kueue.PodSetAssignment{
+					Name:          kueue.DefaultPodSetName,
+					Flavors:       map[corev1.ResourceName]kueue.ResourceFlavorReference{corev1.ResourceCPU: "tas"},
+					ResourceUsage: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+					Count:         ptr.To[int32](1),
+					TopologyAssignment: &kueue.TopologyAssignment{
+						Levels:  []string{corev1.LabelHostname},
+						Domains: []kueue.TopologyDomainAssignment{{Values: []string{"x1"}, Count: 1}},
+					},
+				}).Obj()).
+			Admitted(true).
+			Obj()
+	}
+	workloads := []*kueue.Workload{baseWorkload("wl1"), baseWorkload("wl2"), baseWorkload("wl3")}
+
+	clientBuilder := utiltesting.NewClientBuilder().
+		WithInterceptorFuncs(interceptor.Funcs{SubResourcePatch: utiltesting.TreatSSAAsStrategicMerge}).
+		WithStatusSubresource(&kueue.Workload{})
+	if err := indexer.SetupIndexes(ctx, utiltesting.AsIndexer(clientBuilder)); err != nil {
+		t.Fatalf("Could not setup indexes: %v", err)
+	}
+	clientBuilder = clientBuilder.WithObjects(baseNode("x2").Ready().Obj())
+	for _, wl := range workloads {
+		clientBuilder = clientBuilder.WithObjects(wl)
+	}
+	kClient := clientBuilder.Build()
+
+	cqCache := cache.New(kClient)
+	flavor := utiltesting.MakeResourceFlavor("tas").
+		NodeLabel("node-group", "tas").
+		TopologyName("default").
+		Obj()
+	cqCache.AddOrUpdateResourceFlavor(flavor)
+	cqCache.AddOrUpdateTopologyForFlavor(utiltesting.MakeTopology("default").Levels(levels...).Obj(), flavor)
+
+	reconciler := newNodeFailureReconciler(kClient, cqCache, &utiltesting.EventRecorder{})
+	if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "x1"}}); err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+
+	// The free capacity of x2 only fits the pods of two of the workloads.
+	var replaced, evicted int
+	for _, wl := range workloads {
+		var gotWorkload kueue.Workload
+		if err := kClient.Get(ctx, client.ObjectKeyFromObject(wl), &gotWorkload); err != nil {
+			t.Fatalf("Could not get workload: %v", err)
+		}
+		if apimeta.IsStatusConditionTrue(gotWorkload.Status.Conditions, kueue.WorkloadEvicted) {
+			evicted++
+		} else if utiltas.AssignedHostnames(gotWorkload.Status.Admission.PodSetAssignments[0].TopologyAssignment)[0] == "x2" {
+			replaced++
+		}
+	}
+	if replaced != 2 || evicted != 1 {
+		t.Errorf("Unexpected number of replaced and evicted workloads, want=2,1, got=%d,%d", replaced, evicted)
+	}
+}
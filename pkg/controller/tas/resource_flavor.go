@@ -124,7 +124,7 @@ func (h *nodeHandler) queueReconcileForNode(node *corev1.Node, q workqueue.Typed
 	}
 	// trigger reconcile for TAS flavors affected by the node being created or updated
 	for name, flavor := range h.tasCache.Clone() {
-		if nodeBelongsToFlavor(node, flavor.NodeLabels, flavor.Levels) {
+		if nodeBelongsToFlavor(node, flavor) {
 			q.AddAfter(reconcile.Request{NamespacedName: types.NamespacedName{
 				Name: string(name),
 			}}, constants.UpdatesBatchPeriod)
@@ -196,16 +196,12 @@ func (r *rfReconciler) Generic(event event.TypedGenericEvent[*kueue.ResourceFlav
 	return false
 }
 
-func nodeBelongsToFlavor(node *corev1.Node, nodeLabels map[string]string, levels []string) bool {
-	for k, v := range nodeLabels {
+func nodeBelongsToFlavor(node *corev1.Node, flavor *cache.TASFlavorCache) bool {
+	for k, v := range flavor.NodeLabels {
 		if node.Labels[k] != v {
 			return false
 		}
 	}
-	for i := range levels {
-		if _, ok := node.Labels[levels[i]]; !ok {
-			return false
-		}
-	}
-	return true
+	_, found := flavor.TopologyLabels(node)
+	return found
 }
@@ -163,6 +163,95 @@ const (
 	//
 	// Enable hierarchical cohorts
 	HierarchicalCohorts featuregate.Feature = "HierarchicalCohorts"
+
+	// owner: @kerthcet
+	//
+	// Enable the LocalQueue limits, capping the quota the workloads of a
+	// LocalQueue can reserve in its ClusterQueue.
+	LocalQueueLimits featuregate.Feature = "LocalQueueLimits"
+
+	// owner: @kerthcet
+	//
+	// Enable limiting the number of workloads admitted by ClusterQueues,
+	// LocalQueues and Cohorts, with maxAdmittedWorkloads.
+	MaxAdmittedWorkloads featuregate.Feature = "MaxAdmittedWorkloads"
+
+	// owner: @kerthcet
+	//
+	// Enable recording the user submitting each job, limiting the usage of
+	// each user with perUserLimits in LocalQueues and ClusterQueues, and
+	// ordering the pending workloads fairly across users.
+	PerUserLimits featuregate.Feature = "PerUserLimits"
+
+	// owner: @kerthcet
+	//
+	// Enable deriving the nominal quota of ClusterQueues from the allocatable
+	// capacity of the nodes of the ResourceFlavors, with nodeCapacityPercentage.
+	// Requires the TopologyAwareScheduling feature gate.
+	NodeCapacityQuotas featuregate.Feature = "NodeCapacityQuotas"
+
+	// owner: @kerthcet
+	//
+	// Enable multiplying the quotas of the resources in a ResourceFlavor by
+	// the overcommit ratios of the ResourceFlavor.
+	FlavorOvercommit featuregate.Feature = "FlavorOvercommit"
+
+	// owner: @kerthcet
+	//
+	// Enable replacing the failed nodes in the topology assignments of the
+	// workloads admitted by TAS, falling back to eviction.
+	// Requires the TopologyAwareScheduling feature gate.
+	TASFailedNodeReplacement featuregate.Feature = "TASFailedNodeReplacement"
+
+	// owner: @kerthcet
+	//
+	// Enable the balanced TAS placement, spreading the pods of a PodSet evenly
+	// across the topology domains at the requested level.
+	// Requires the TopologyAwareScheduling feature gate.
+	TASBalancedPlacement featuregate.Feature = "TASBalancedPlacement"
+
+	// owner: @kerthcet
+	//
+	// Enable placing the PodSets of a workload, which belong to the same PodSet
+	// group, together within a single topology domain.
+	// Requires the TopologyAwareScheduling feature gate.
+	TASPodSetGroups featuregate.Feature = "TASPodSetGroups"
+
+	// owner: @kerthcet
+	//
+	// Enable preferring the preemption of workloads confined to a single
+	// topology domain, at the level requested by the preempting TAS workload.
+	// Requires the TopologyAwareScheduling feature gate.
+	TASTopologyAwarePreemption featuregate.Feature = "TASTopologyAwarePreemption"
+
+	// owner: @kerthcet
+	//
+	// Enable splitting the pods of a PodSet into slices of a fixed size, each
+	// placed within a single topology domain at the requested slice level.
+	// Requires the TopologyAwareScheduling feature gate.
+	TASPodSetSlices featuregate.Feature = "TASPodSetSlices"
+
+	// owner: @kerthcet
+	//
+	// Enable reading the topology domains of the nodes from the source
+	// referenced by the Topology, instead of the node labels.
+	// Requires the TopologyAwareScheduling feature gate.
+	TASNodeDomainsSource featuregate.Feature = "TASNodeDomainsSource"
+
+	// owner: @kerthcet
+	//
+	// Enable connecting to the MultiKueue worker clusters described by a
+	// ClusterProfile of the Cluster Inventory API.
+	// Requires the MultiKueue feature gate.
+	MultiKueueClusterProfile featuregate.Feature = "MultiKueueClusterProfile"
+
+	// owner: @kerthcet
+	//
+	// Enable computing the nominal quotas of the MultiKueue manager
+	// ClusterQueues from the quotas of the worker clusters, with the
+	// quotaPolicy of the MultiKueueConfig.
+	// Requires the MultiKueue feature gate.
+	MultiKueueFederatedQuota featuregate.Feature = "MultiKueueFederatedQuota"
 )
 
 func init() {
@@ -252,6 +341,45 @@ var defaultVersionedFeatureGates = map[featuregate.Feature]featuregate.Versioned
 	HierarchicalCohorts: {
 		{Version: version.MustParse("0.11"), Default: true, PreRelease: featuregate.Beta},
 	},
+	LocalQueueLimits: {
+		{Version: version.MustParse("0.12"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	MaxAdmittedWorkloads: {
+		{Version: version.MustParse("0.12"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	PerUserLimits: {
+		{Version: version.MustParse("0.12"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	NodeCapacityQuotas: {
+		{Version: version.MustParse("0.12"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	FlavorOvercommit: {
+		{Version: version.MustParse("0.12"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	TASFailedNodeReplacement: {
+		{Version: version.MustParse("0.12"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	TASBalancedPlacement: {
+		{Version: version.MustParse("0.12"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	TASPodSetGroups: {
+		{Version: version.MustParse("0.12"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	TASTopologyAwarePreemption: {
+		{Version: version.MustParse("0.12"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	TASPodSetSlices: {
+		{Version: version.MustParse("0.12"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	TASNodeDomainsSource: {
+		{Version: version.MustParse("0.12"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	MultiKueueClusterProfile: {
+		{Version: version.MustParse("0.12"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	MultiKueueFederatedQuota: {
+		{Version: version.MustParse("0.12"), Default: false, PreRelease: featuregate.Alpha},
+	},
 }
 
 func SetFeatureGateDuringTest(tb testing.TB, f featuregate.Feature, value bool) {
@@ -357,6 +357,30 @@ For a LocalQueue, the metric only reports a value of 1 for one of the statuses.`
 		}, []string{"cohort", "cluster_queue", "flavor", "resource"},
 	)
 
+	ClusterQueueResourceEffectiveNominalQuota = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: constants.KueueName,
+			Name:      "cluster_queue_effective_nominal_quota",
+			Help:      `Reports the cluster_queue's resource nominal quota within all the flavors, multiplied by the overcommit ratios of the flavors`,
+		}, []string{"cohort", "cluster_queue", "flavor", "resource"},
+	)
+
+	ClusterQueueMaxAdmittedWorkloads = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: constants.KueueName,
+			Name:      "cluster_queue_max_admitted_workloads",
+			Help:      `Reports the cluster_queue's maximum number of admitted workloads`,
+		}, []string{"cohort", "cluster_queue"},
+	)
+
+	ClusterQueueBorrowedWorkloads = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: constants.KueueName,
+			Name:      "cluster_queue_borrowed_workloads",
+			Help:      `Reports the number of workloads reserving quota in the cluster_queue above its maximum number of admitted workloads`,
+		}, []string{"cohort", "cluster_queue"},
+	)
+
 	ClusterQueueWeightedShare = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Subsystem: constants.KueueName,
@@ -382,6 +406,42 @@ If the Cohort has a weight of zero and is borrowing, this will return 9223372036
 the maximum possible share value.`,
 		}, []string{"cohort"},
 	)
+
+	// Metrics tied to the TAS flavors
+
+	TASDomainCapacity = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: constants.KueueName,
+			Name:      "tas_domain_capacity",
+			Help:      `Reports the allocatable capacity of the ready and schedulable nodes in the topology domain of the TAS flavor, per 'flavor', 'level', 'domain' and 'resource'`,
+		}, []string{"flavor", "level", "domain", "resource"},
+	)
+
+	TASDomainTASUsage = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: constants.KueueName,
+			Name:      "tas_domain_tas_usage",
+			Help:      `Reports the usage of the workloads admitted by TAS in the topology domain of the TAS flavor, per 'flavor', 'level', 'domain' and 'resource'`,
+		}, []string{"flavor", "level", "domain", "resource"},
+	)
+
+	TASDomainNonTASUsage = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: constants.KueueName,
+			Name:      "tas_domain_non_tas_usage",
+			Help:      `Reports the usage of the pods not admitted by TAS, like the DaemonSet pods, in the topology domain of the TAS flavor, per 'flavor', 'level', 'domain' and 'resource'`,
+		}, []string{"flavor", "level", "domain", "resource"},
+	)
+
+	TASLargestFreeDomain = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: constants.KueueName,
+			Name:      "tas_largest_free_domain",
+			Help: `Reports the highest free capacity of a single topology domain at the level of the TAS flavor, per 'flavor', 'level' and 'resource'.
+The value is computed independently for each resource, so the values for different resources may come from different domains.
+A value much lower than the capacity of the domains indicates that the free capacity is fragmented across the domains`,
+		}, []string{"flavor", "level", "resource"},
+	)
 )
 
 func generateExponentialBuckets(count int) []float64 {
@@ -531,6 +591,26 @@ func ReportClusterQueueQuotas(cohort kueue.CohortReference, queue, flavor, resou
 	}
 }
 
+func ReportClusterQueueEffectiveNominalQuota(cohort kueue.CohortReference, queue, flavor, resource string, effectiveNominal float64) {
+	ClusterQueueResourceEffectiveNominalQuota.WithLabelValues(string(cohort), queue, flavor, resource).Set(effectiveNominal)
+}
+
+func ReportClusterQueueMaxAdmittedWorkloads(cohort kueue.CohortReference, queue string, maxAdmittedWorkloads float64) {
+	ClusterQueueMaxAdmittedWorkloads.WithLabelValues(string(cohort), queue).Set(maxAdmittedWorkloads)
+}
+
+func ReportClusterQueueBorrowedWorkloads(cohort kueue.CohortReference, queue string, borrowed float64) {
+	ClusterQueueBorrowedWorkloads.WithLabelValues(string(cohort), queue).Set(borrowed)
+}
+
+func ClearClusterQueueWorkloadCountMetrics(cqName string) {
+	lbls := prometheus.Labels{
+		"cluster_queue": cqName,
+	}
+	ClusterQueueMaxAdmittedWorkloads.DeletePartialMatch(lbls)
+	ClusterQueueBorrowedWorkloads.DeletePartialMatch(lbls)
+}
+
 func ReportClusterQueueResourceReservations(cohort kueue.CohortReference, queue, flavor, resource string, usage float64) {
 	ClusterQueueResourceReservations.WithLabelValues(string(cohort), queue, flavor, resource).Set(usage)
 }
@@ -560,12 +640,14 @@ func ClearClusterQueueResourceMetrics(cqName string) {
 		"cluster_queue": cqName,
 	}
 	ClusterQueueResourceNominalQuota.DeletePartialMatch(lbls)
+	ClusterQueueResourceEffectiveNominalQuota.DeletePartialMatch(lbls)
 	ClusterQueueResourceBorrowingLimit.DeletePartialMatch(lbls)
 	if features.Enabled(features.LendingLimit) {
 		ClusterQueueResourceLendingLimit.DeletePartialMatch(lbls)
 	}
 	ClusterQueueResourceUsage.DeletePartialMatch(lbls)
 	ClusterQueueResourceReservations.DeletePartialMatch(lbls)
+	ClearClusterQueueWorkloadCountMetrics(cqName)
 }
 
 func ClearLocalQueueResourceMetrics(lq LocalQueueReference) {
@@ -588,6 +670,7 @@ func ClearClusterQueueResourceQuotas(cqName, flavor, resource string) {
 	}
 
 	ClusterQueueResourceNominalQuota.DeletePartialMatch(lbls)
+	ClusterQueueResourceEffectiveNominalQuota.DeletePartialMatch(lbls)
 	ClusterQueueResourceBorrowingLimit.DeletePartialMatch(lbls)
 	if features.Enabled(features.LendingLimit) {
 		ClusterQueueResourceLendingLimit.DeletePartialMatch(lbls)
@@ -620,6 +703,34 @@ func ClearClusterQueueResourceReservations(cqName, flavor, resource string) {
 	ClusterQueueResourceReservations.DeletePartialMatch(lbls)
 }
 
+func ReportTASDomainResources(flavor, level, domain, resource string, capacity, tasUsage, nonTASUsage float64) {
+	TASDomainCapacity.WithLabelValues(flavor, level, domain, resource).Set(capacity)
+	TASDomainTASUsage.WithLabelValues(flavor, level, domain, resource).Set(tasUsage)
+	TASDomainNonTASUsage.WithLabelValues(flavor, level, domain, resource).Set(nonTASUsage)
+}
+
+func ReportTASLargestFreeDomain(flavor, level, resource string, free float64) {
+	TASLargestFreeDomain.WithLabelValues(flavor, level, resource).Set(free)
+}
+
+func ClearTASDomainResources(flavor, level, domain, resource string) {
+	TASDomainCapacity.DeleteLabelValues(flavor, level, domain, resource)
+	TASDomainTASUsage.DeleteLabelValues(flavor, level, domain, resource)
+	TASDomainNonTASUsage.DeleteLabelValues(flavor, level, domain, resource)
+}
+
+func ClearTASLargestFreeDomain(flavor, level, resource string) {
+	TASLargestFreeDomain.DeleteLabelValues(flavor, level, resource)
+}
+
+func ClearTASFlavorMetrics(flavor string) {
+	lbls := prometheus.Labels{"flavor": flavor}
+	TASDomainCapacity.DeletePartialMatch(lbls)
+	TASDomainTASUsage.DeletePartialMatch(lbls)
+	TASDomainNonTASUsage.DeletePartialMatch(lbls)
+	TASLargestFreeDomain.DeletePartialMatch(lbls)
+}
+
 func Register() {
 	metrics.Registry.MustRegister(
 		AdmissionAttemptsTotal,
@@ -641,8 +752,15 @@ func Register() {
 		ClusterQueueResourceNominalQuota,
 		ClusterQueueResourceBorrowingLimit,
 		ClusterQueueResourceLendingLimit,
+		ClusterQueueResourceEffectiveNominalQuota,
+		ClusterQueueMaxAdmittedWorkloads,
+		ClusterQueueBorrowedWorkloads,
 		ClusterQueueWeightedShare,
 		CohortWeightedShare,
+		TASDomainCapacity,
+		TASDomainTASUsage,
+		TASDomainNonTASUsage,
+		TASLargestFreeDomain,
 	)
 	if features.Enabled(features.LocalQueueMetrics) {
 		RegisterLQMetrics()
@@ -173,3 +173,25 @@ func TestReportAndCleanupClusterQueuePreemptedNumber(t *testing.T) {
 	expectFilteredMetricsCount(t, PreemptedWorkloadsTotal, 0, "preempting_cluster_queue", "cluster_queue1")
 	expectFilteredMetricsCount(t, EvictedWorkloadsTotal, 0, "cluster_queue", "cluster_queue1")
 }
+
+func TestReportAndCleanupTASFlavorMetrics(t *testing.T) {
+	ReportTASDomainResources("flavor", "rack", "b1,r1", "cpu", 8, 3, 1)
+	ReportTASDomainResources("flavor", "rack", "b1,r2", "cpu", 8, 0, 1)
+	ReportTASDomainResources("flavor2", "rack", "b1,r1", "cpu", 4, 1, 0)
+	ReportTASLargestFreeDomain("flavor", "rack", "cpu", 7)
+	ReportTASLargestFreeDomain("flavor2", "rack", "cpu", 3)
+
+	expectFilteredMetricsCount(t, TASDomainCapacity, 2, "flavor", "flavor")
+	expectFilteredMetricsCount(t, TASDomainTASUsage, 2, "flavor", "flavor")
+	expectFilteredMetricsCount(t, TASDomainNonTASUsage, 2, "flavor", "flavor")
+	expectFilteredMetricsCount(t, TASLargestFreeDomain, 1, "flavor", "flavor")
+
+	ClearTASFlavorMetrics("flavor")
+
+	expectFilteredMetricsCount(t, TASDomainCapacity, 0, "flavor", "flavor")
+	expectFilteredMetricsCount(t, TASDomainTASUsage, 0, "flavor", "flavor")
+	expectFilteredMetricsCount(t, TASDomainNonTASUsage, 0, "flavor", "flavor")
+	expectFilteredMetricsCount(t, TASLargestFreeDomain, 0, "flavor", "flavor")
+	expectFilteredMetricsCount(t, TASDomainCapacity, 1, "flavor", "flavor2")
+	expectFilteredMetricsCount(t, TASLargestFreeDomain, 1, "flavor", "flavor2")
+}
@@ -31,6 +31,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/hierarchy"
 	"sigs.k8s.io/kueue/pkg/util/heap"
 	utilpriority "sigs.k8s.io/kueue/pkg/util/priority"
@@ -74,6 +75,23 @@ type ClusterQueue struct {
 
 	lessFunc func(a, b *workload.Info) bool
 
+	// orderByUserRounds indicates whether the pending workloads are ordered
+	// round-robin across the users which submitted them. It's only the case
+	// when the PerUserLimits feature is enabled, the ClusterQueue sets
+	// perUserLimits and doesn't use the StrictFIFO queueing strategy.
+	orderByUserRounds bool
+
+	// userRounds holds, by workload key, the round of the workload in the
+	// round-robin ordering across the users which submitted the pending
+	// workloads, userNextRounds the next round of each user, and
+	// userWorkloads the number of pending workloads by user. currentRound
+	// is the highest round of the workloads popped so far.
+	// They are only populated when orderByUserRounds is true.
+	userRounds     map[string]int
+	userNextRounds map[string]int
+	userWorkloads  map[string]int
+	currentRound   int
+
 	queueingStrategy kueue.QueueingStrategy
 
 	rwm sync.RWMutex
@@ -99,15 +117,18 @@ func newClusterQueue(cq *kueue.ClusterQueue, wo workload.Ordering) (*ClusterQueu
 }
 
 func newClusterQueueImpl(wo workload.Ordering, clock clock.Clock) *ClusterQueue {
-	lessFunc := queueOrderingFunc(wo)
-	return &ClusterQueue{
-		heap:                   *heap.New(workloadKey, lessFunc),
+	c := &ClusterQueue{
 		inadmissibleWorkloads:  make(map[string]*workload.Info),
 		queueInadmissibleCycle: -1,
-		lessFunc:               lessFunc,
+		userRounds:             make(map[string]int),
+		userNextRounds:         make(map[string]int),
+		userWorkloads:          make(map[string]int),
 		rwm:                    sync.RWMutex{},
 		clock:                  clock,
 	}
+	c.lessFunc = queueOrderingFunc(wo, c.userRound)
+	c.heap = *heap.New(workloadKey, c.lessFunc)
+	return c
 }
 
 // Update updates the properties of this ClusterQueue.
@@ -122,9 +143,38 @@ func (c *ClusterQueue) Update(apiCQ *kueue.ClusterQueue) error {
 	}
 	c.namespaceSelector = nsSelector
 	c.active = apimeta.IsStatusConditionTrue(apiCQ.Status.Conditions, kueue.ClusterQueueActive)
+	orderByUserRounds := features.Enabled(features.PerUserLimits) && apiCQ.Spec.PerUserLimits != nil &&
+		apiCQ.Spec.QueueingStrategy != kueue.StrictFIFO
+	if orderByUserRounds != c.orderByUserRounds {
+		c.setOrderByUserRounds(orderByUserRounds)
+	}
 	return nil
 }
 
+// setOrderByUserRounds starts, or stops, ordering the pending workloads by
+// user rounds. The rounds are assigned afresh, following the current order
+// of the workloads, and the heap is reordered.
+func (c *ClusterQueue) setOrderByUserRounds(enabled bool) {
+	clear(c.userRounds)
+	clear(c.userNextRounds)
+	clear(c.userWorkloads)
+	c.currentRound = 0
+	c.orderByUserRounds = enabled
+	if enabled {
+		pending := c.heap.List()
+		for _, wInfo := range c.inadmissibleWorkloads {
+			pending = append(pending, wInfo)
+		}
+		sort.Slice(pending, func(i, j int) bool {
+			return c.lessFunc(pending[i], pending[j])
+		})
+		for _, wInfo := range pending {
+			c.trackUser(wInfo)
+		}
+	}
+	c.heap.Reorder()
+}
+
 // AddFromLocalQueue pushes all workloads belonging to this queue to
 // the ClusterQueue. If at least one workload is added, returns true,
 // otherwise returns false.
@@ -133,6 +183,7 @@ func (c *ClusterQueue) AddFromLocalQueue(q *LocalQueue) bool {
 	defer c.rwm.Unlock()
 	added := false
 	for _, info := range q.items {
+		c.trackUser(info)
 		if c.heap.PushIfNotPresent(info) {
 			added = true
 		}
@@ -147,6 +198,7 @@ func (c *ClusterQueue) PushOrUpdate(wInfo *workload.Info) {
 	defer c.rwm.Unlock()
 	key := workload.Key(wInfo.Obj)
 	c.forgetInflightByKey(key)
+	c.trackUser(wInfo)
 	oldInfo := c.inadmissibleWorkloads[key]
 	if oldInfo != nil {
 		// update in place if the workload was inadmissible and didn't change
@@ -199,6 +251,7 @@ func (c *ClusterQueue) delete(w *kueue.Workload) {
 	delete(c.inadmissibleWorkloads, key)
 	c.heap.Delete(key)
 	c.forgetInflightByKey(key)
+	c.forgetUser(w)
 }
 
 // DeleteFromLocalQueue removes all workloads belonging to this queue from
@@ -227,6 +280,7 @@ func (c *ClusterQueue) requeueIfNotPresent(wInfo *workload.Info, immediate bool)
 	defer c.rwm.Unlock()
 	key := workload.Key(wInfo.Obj)
 	c.forgetInflightByKey(key)
+	c.trackUser(wInfo)
 	if c.backoffWaitingTimeExpired(wInfo) &&
 		(immediate || c.queueInadmissibleCycle >= c.popCycle || wInfo.LastAssignment.PendingFlavors()) {
 		// If the workload was inadmissible, move it back into the queue.
@@ -251,6 +305,44 @@ func (c *ClusterQueue) requeueIfNotPresent(wInfo *workload.Info, immediate bool)
 	return true
 }
 
+// trackUser assigns the workload, unless it already has one, the next
+// round of the user which submitted it. Workloads are ordered by round
+// among the workloads of the same priority, so that the users take turns.
+// The rounds of a user only increase, so that its pending workloads never
+// share a round, and they start from the current round, so that a user
+// submitting new workloads doesn't jump ahead of the others.
+func (c *ClusterQueue) trackUser(wInfo *workload.Info) {
+	if !c.orderByUserRounds {
+		return
+	}
+	key := workload.Key(wInfo.Obj)
+	if _, found := c.userRounds[key]; found {
+		return
+	}
+	user := workload.SubmittedBy(wInfo.Obj)
+	round := max(c.userNextRounds[user], c.currentRound)
+	c.userRounds[key] = round
+	c.userNextRounds[user] = round + 1
+	c.userWorkloads[user]++
+}
+
+func (c *ClusterQueue) forgetUser(w *kueue.Workload) {
+	key := workload.Key(w)
+	if _, found := c.userRounds[key]; !found {
+		return
+	}
+	delete(c.userRounds, key)
+	user := workload.SubmittedBy(w)
+	if c.userWorkloads[user]--; c.userWorkloads[user] <= 0 {
+		delete(c.userWorkloads, user)
+		delete(c.userNextRounds, user)
+	}
+}
+
+func (c *ClusterQueue) userRound(wInfo *workload.Info) int {
+	return c.userRounds[workload.Key(wInfo.Obj)]
+}
+
 func (c *ClusterQueue) forgetInflightByKey(key string) {
 	if c.inflight != nil && workload.Key(c.inflight.Obj) == key {
 		c.inflight = nil
@@ -318,6 +410,9 @@ func (c *ClusterQueue) Pop() *workload.Info {
 		return nil
 	}
 	c.inflight = c.heap.Pop()
+	if round, found := c.userRounds[workload.Key(c.inflight.Obj)]; found {
+		c.currentRound = max(c.currentRound, round)
+	}
 	return c.inflight
 }
 
@@ -408,9 +503,10 @@ func (c *ClusterQueue) RequeueIfNotPresent(wInfo *workload.Info, reason RequeueR
 
 // queueOrderingFunc returns a function used by the clusterQueue heap algorithm
 // to sort workloads. The function sorts workloads based on their priority.
-// When priorities are equal, it uses the workload's creation or eviction
-// time.
-func queueOrderingFunc(wo workload.Ordering) func(a, b *workload.Info) bool {
+// When priorities are equal, and the ClusterQueue orders the workloads by
+// user rounds, it uses the round of the workloads across users. Otherwise,
+// it uses the workload's creation or eviction time.
+func queueOrderingFunc(wo workload.Ordering, userRound func(*workload.Info) int) func(a, b *workload.Info) bool {
 	return func(a, b *workload.Info) bool {
 		p1 := utilpriority.Priority(a.Obj)
 		p2 := utilpriority.Priority(b.Obj)
@@ -419,6 +515,10 @@ func queueOrderingFunc(wo workload.Ordering) func(a, b *workload.Info) bool {
 			return p1 > p2
 		}
 
+		if r1, r2 := userRound(a), userRound(b); r1 != r2 {
+			return r1 < r2
+		}
+
 		tA := wo.GetQueueOrderTimestamp(a.Obj)
 		tB := wo.GetQueueOrderTimestamp(b.Obj)
 		return !tB.Before(tA)
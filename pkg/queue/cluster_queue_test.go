@@ -30,6 +30,7 @@ import (
 
 	config "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/features"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
@@ -695,6 +696,145 @@ func TestFIFOClusterQueue(t *testing.T) {
 	}
 }
 
+func TestPerUserOrdering(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.PerUserLimits, true)
+	q, err := newClusterQueue(
+		utiltesting.MakeClusterQueue("cq").
+			QueueingStrategy(kueue.BestEffortFIFO).
+			PerUserLimits(kueue.PerUserLimits{MaxAdmittedWorkloads: ptr.To[int32](10)}).
+			Obj(),
+		workload.Ordering{
+			PodsReadyRequeuingTimestamp: config.EvictionTimestamp,
+		})
+	if err != nil {
+		t.Fatalf("Failed creating ClusterQueue %v", err)
+	}
+	now := time.Now()
+	ws := []*kueue.Workload{
+		utiltesting.MakeWorkload("alice-1", "").SubmittedBy("alice").Creation(now).Obj(),
+		utiltesting.MakeWorkload("alice-2", "").SubmittedBy("alice").Creation(now.Add(time.Second)).Obj(),
+		utiltesting.MakeWorkload("alice-3", "").SubmittedBy("alice").Creation(now.Add(2 * time.Second)).Obj(),
+		utiltesting.MakeWorkload("bob-1", "").SubmittedBy("bob").Creation(now.Add(3 * time.Second)).Obj(),
+		utiltesting.MakeWorkload("carol-1", "").SubmittedBy("carol").Creation(now.Add(4 * time.Second)).Obj(),
+		utiltesting.MakeWorkload("bob-2", "").SubmittedBy("bob").Priority(1).Creation(now.Add(5 * time.Second)).Obj(),
+	}
+	for _, w := range ws {
+		q.PushOrUpdate(workload.NewInfo(w))
+	}
+	// The users take turns among the workloads with the same priority.
+	want := []string{"bob-2", "alice-1", "bob-1", "carol-1", "alice-2", "alice-3"}
+	got := make([]string, 0, len(want))
+	for wl := q.Pop(); wl != nil; wl = q.Pop() {
+		got = append(got, wl.Obj.Name)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected order of workloads (-want,+got):\n%s", diff)
+	}
+}
+
+func TestPerUserOrderingAfterDeletion(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.PerUserLimits, true)
+	q, err := newClusterQueue(
+		utiltesting.MakeClusterQueue("cq").
+			QueueingStrategy(kueue.BestEffortFIFO).
+			PerUserLimits(kueue.PerUserLimits{MaxAdmittedWorkloads: ptr.To[int32](10)}).
+			Obj(),
+		workload.Ordering{
+			PodsReadyRequeuingTimestamp: config.EvictionTimestamp,
+		})
+	if err != nil {
+		t.Fatalf("Failed creating ClusterQueue %v", err)
+	}
+	now := time.Now()
+	alice1 := utiltesting.MakeWorkload("alice-1", "").SubmittedBy("alice").Creation(now).Obj()
+	ws := []*kueue.Workload{
+		alice1,
+		utiltesting.MakeWorkload("alice-2", "").SubmittedBy("alice").Creation(now.Add(time.Second)).Obj(),
+		utiltesting.MakeWorkload("bob-1", "").SubmittedBy("bob").Creation(now.Add(2 * time.Second)).Obj(),
+	}
+	for _, w := range ws {
+		q.PushOrUpdate(workload.NewInfo(w))
+	}
+	q.Delete(alice1)
+	ws = []*kueue.Workload{
+		utiltesting.MakeWorkload("alice-3", "").SubmittedBy("alice").Creation(now.Add(3 * time.Second)).Obj(),
+		utiltesting.MakeWorkload("bob-2", "").SubmittedBy("bob").Creation(now.Add(4 * time.Second)).Obj(),
+	}
+	for _, w := range ws {
+		q.PushOrUpdate(workload.NewInfo(w))
+	}
+	// The workloads of a user never share a round, even after some of them
+	// are deleted.
+	want := []string{"bob-1", "alice-2", "bob-2", "alice-3"}
+	got := make([]string, 0, len(want))
+	for wl := q.Pop(); wl != nil; wl = q.Pop() {
+		got = append(got, wl.Obj.Name)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected order of workloads (-want,+got):\n%s", diff)
+	}
+}
+
+func TestPerUserOrderingOnlyWithPerUserLimits(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.PerUserLimits, true)
+	perUserLimits := kueue.PerUserLimits{MaxAdmittedWorkloads: ptr.To[int32](10)}
+	cases := map[string]struct {
+		cq       *kueue.ClusterQueue
+		updateCQ *kueue.ClusterQueue
+		want     []string
+	}{
+		"without perUserLimits": {
+			cq:   utiltesting.MakeClusterQueue("cq").QueueingStrategy(kueue.BestEffortFIFO).Obj(),
+			want: []string{"alice-1", "alice-2", "bob-1"},
+		},
+		"StrictFIFO with perUserLimits": {
+			cq:   utiltesting.MakeClusterQueue("cq").QueueingStrategy(kueue.StrictFIFO).PerUserLimits(perUserLimits).Obj(),
+			want: []string{"alice-1", "alice-2", "bob-1"},
+		},
+		"perUserLimits set on update": {
+			cq:       utiltesting.MakeClusterQueue("cq").QueueingStrategy(kueue.BestEffortFIFO).Obj(),
+			updateCQ: utiltesting.MakeClusterQueue("cq").QueueingStrategy(kueue.BestEffortFIFO).PerUserLimits(perUserLimits).Obj(),
+			want:     []string{"alice-1", "bob-1", "alice-2"},
+		},
+		"perUserLimits removed on update": {
+			cq:       utiltesting.MakeClusterQueue("cq").QueueingStrategy(kueue.BestEffortFIFO).PerUserLimits(perUserLimits).Obj(),
+			updateCQ: utiltesting.MakeClusterQueue("cq").QueueingStrategy(kueue.BestEffortFIFO).Obj(),
+			want:     []string{"alice-1", "alice-2", "bob-1"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			q, err := newClusterQueue(tc.cq, workload.Ordering{
+				PodsReadyRequeuingTimestamp: config.EvictionTimestamp,
+			})
+			if err != nil {
+				t.Fatalf("Failed creating ClusterQueue %v", err)
+			}
+			now := time.Now()
+			ws := []*kueue.Workload{
+				utiltesting.MakeWorkload("alice-1", "").SubmittedBy("alice").Creation(now).Obj(),
+				utiltesting.MakeWorkload("alice-2", "").SubmittedBy("alice").Creation(now.Add(time.Second)).Obj(),
+				utiltesting.MakeWorkload("bob-1", "").SubmittedBy("bob").Creation(now.Add(2 * time.Second)).Obj(),
+			}
+			for _, w := range ws {
+				q.PushOrUpdate(workload.NewInfo(w))
+			}
+			if tc.updateCQ != nil {
+				if err := q.Update(tc.updateCQ); err != nil {
+					t.Fatalf("Failed updating ClusterQueue %v", err)
+				}
+			}
+			got := make([]string, 0, len(tc.want))
+			for wl := q.Pop(); wl != nil; wl = q.Pop() {
+				got = append(got, wl.Obj.Name)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Unexpected order of workloads (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestStrictFIFO(t *testing.T) {
 	t1 := time.Now()
 	t2 := t1.Add(time.Second)
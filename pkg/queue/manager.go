@@ -83,6 +83,14 @@ type TopologyUpdateWatcher interface {
 	NotifyTopologyUpdate(oldTopology, newTopology *kueuealpha.Topology)
 }
 
+type NodeCapacityUpdateWatcher interface {
+	NotifyNodeCapacityUpdate(cqNames sets.Set[kueue.ClusterQueueReference])
+}
+
+type FederatedQuotaUpdateWatcher interface {
+	NotifyFederatedQuotaUpdate(cqNames sets.Set[kueue.ClusterQueueReference])
+}
+
 type Manager struct {
 	sync.RWMutex
 	cond sync.Cond
@@ -100,7 +108,9 @@ type Manager struct {
 
 	hm hierarchy.Manager[*ClusterQueue, *cohort]
 
-	topologyUpdateWatchers []TopologyUpdateWatcher
+	topologyUpdateWatchers       []TopologyUpdateWatcher
+	nodeCapacityUpdateWatchers   []NodeCapacityUpdateWatcher
+	federatedQuotaUpdateWatchers []FederatedQuotaUpdateWatcher
 }
 
 func NewManager(client client.Client, checker StatusChecker, opts ...Option) *Manager {
@@ -136,6 +146,31 @@ func (m *Manager) NotifyTopologyUpdateWatchers(oldTopology, newTopology *kueueal
 	}
 }
 
+func (m *Manager) AddNodeCapacityUpdateWatcher(watcher NodeCapacityUpdateWatcher) {
+	m.nodeCapacityUpdateWatchers = append(m.nodeCapacityUpdateWatchers, watcher)
+}
+
+// NotifyNodeCapacityUpdateWatchers notifies the watchers about the
+// ClusterQueues whose nominal quotas changed with the capacity of the nodes.
+func (m *Manager) NotifyNodeCapacityUpdateWatchers(cqNames sets.Set[kueue.ClusterQueueReference]) {
+	for _, watcher := range m.nodeCapacityUpdateWatchers {
+		watcher.NotifyNodeCapacityUpdate(cqNames)
+	}
+}
+
+func (m *Manager) AddFederatedQuotaUpdateWatcher(watcher FederatedQuotaUpdateWatcher) {
+	m.federatedQuotaUpdateWatchers = append(m.federatedQuotaUpdateWatchers, watcher)
+}
+
+// NotifyFederatedQuotaUpdateWatchers notifies the watchers about the
+// ClusterQueues whose nominal quotas changed with the quotas of the
+// MultiKueue worker clusters.
+func (m *Manager) NotifyFederatedQuotaUpdateWatchers(cqNames sets.Set[kueue.ClusterQueueReference]) {
+	for _, watcher := range m.federatedQuotaUpdateWatchers {
+		watcher.NotifyFederatedQuotaUpdate(cqNames)
+	}
+}
+
 func (m *Manager) AddOrUpdateCohort(ctx context.Context, cohort *kueuealpha.Cohort) {
 	m.Lock()
 	defer m.Unlock()
@@ -30,13 +30,13 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	corev1helpers "k8s.io/component-helpers/scheduling/corev1"
-	"k8s.io/component-helpers/scheduling/corev1/nodeaffinity"
 	"k8s.io/utils/ptr"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/resources"
+	utilpod "sigs.k8s.io/kueue/pkg/util/pod"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
 
@@ -399,6 +399,26 @@ func (a *FlavorAssigner) assignFlavors(log logr.Logger, counts []int32) Assignme
 		},
 	}
 
+	reason := a.localQueueWorkloadCountExceeded()
+	if reason == "" {
+		reason = a.userLimitsExceeded(requests)
+	}
+	if reason != "" {
+		psAssignment := PodSetAssignment{
+			Name:     requests[0].Name,
+			Requests: requests[0].Requests.ToResourceList(),
+			Count:    requests[0].Count,
+		}
+		psAssignment.reason(reason)
+		assignment.PodSets = append(assignment.PodSets, psAssignment)
+		return assignment
+	}
+	if a.cq.LimitsWorkloadCount() {
+		assignment.Usage.Quota[cache.WorkloadsFlavorResource] = 1
+		assignment.Borrowing = a.cq.HasParent() && a.cq.BorrowingWith(cache.WorkloadsFlavorResource, 1)
+	}
+	countReason := a.clusterQueueWorkloadCountExceeded()
+
 	for i, podSet := range requests {
 		if a.cq.RGByResource(corev1.ResourcePods) != nil {
 			podSet.Requests[corev1.ResourcePods] = int64(podSet.Count)
@@ -434,6 +454,12 @@ func (a *FlavorAssigner) assignFlavors(log logr.Logger, counts []int32) Assignme
 	if assignment.RepresentativeMode() == NoFit {
 		return assignment
 	}
+	if countReason != "" {
+		// The workload can only be admitted by preempting workloads
+		// to free one of the counts.
+		assignment.PodSets[0].reason(countReason)
+		assignment.representativeMode = ptr.To(Preempt)
+	}
 
 	if features.Enabled(features.TopologyAwareScheduling) {
 		tasRequests := assignment.WorkloadsTopologyRequests(a.wl, a.cq)
@@ -466,6 +492,65 @@ func (a *FlavorAssigner) assignFlavors(log logr.Logger, counts []int32) Assignme
 	return assignment
 }
 
+// localQueueWorkloadCountExceeded returns the reason why admitting the
+// workload would exceed the maxAdmittedWorkloads of its LocalQueue, or an
+// empty string if it wouldn't. Exceeding the maxAdmittedWorkloads of the
+// LocalQueue doesn't trigger preemptions.
+func (a *FlavorAssigner) localQueueWorkloadCountExceeded() string {
+	if lqAvailable, limited := a.cq.LocalQueueWorkloadsAvailable(workload.QueueKey(a.wl.Obj)); limited && lqAvailable < 1 {
+		return "the LocalQueue reached its maximum number of admitted workloads"
+	}
+	return ""
+}
+
+// clusterQueueWorkloadCountExceeded returns the reason why admitting the
+// workload would exceed the maxAdmittedWorkloads of its ClusterQueue and
+// Cohorts, or an empty string if it wouldn't. The workload can then only be
+// admitted by preempting other workloads.
+func (a *FlavorAssigner) clusterQueueWorkloadCountExceeded() string {
+	if a.cq.LimitsWorkloadCount() && a.cq.Available(cache.WorkloadsFlavorResource) < 1 {
+		if a.cq.HasParent() {
+			return "the ClusterQueue and its cohort reached their maximum number of admitted workloads"
+		}
+		return "the ClusterQueue reached its maximum number of admitted workloads"
+	}
+	return ""
+}
+
+// userLimitsExceeded returns the reason why admitting the workload would
+// exceed the perUserLimits of its LocalQueue, or of its ClusterQueue, for
+// the user which submitted it, or an empty string if it wouldn't. Exceeding
+// perUserLimits doesn't trigger preemptions.
+func (a *FlavorAssigner) userLimitsExceeded(requests []workload.PodSetResources) string {
+	if !features.Enabled(features.PerUserLimits) {
+		return ""
+	}
+	user := workload.SubmittedBy(a.wl.Obj)
+	userRequests := cache.UserRequests(requests)
+	if limits, usage := a.cq.LocalQueueUserLimits(workload.QueueKey(a.wl.Obj), user); limits != nil {
+		if reason := userLimitsExceededReason(limits, usage, userRequests, user, "LocalQueue"); reason != "" {
+			return reason
+		}
+	}
+	if limits, usage := a.cq.ClusterQueueUserLimits(user); limits != nil {
+		return userLimitsExceededReason(limits, usage, userRequests, user, "ClusterQueue")
+	}
+	return ""
+}
+
+func userLimitsExceededReason(limits *cache.UserLimits, usage cache.UserUsage, requests resources.Requests, user, queueKind string) string {
+	rName, exceeded := limits.Exceeded(usage, requests)
+	if !exceeded {
+		return ""
+	}
+	if rName == "" {
+		return fmt.Sprintf("the user %q reached the maximum number of admitted workloads per user in the %s", user, queueKind)
+	}
+	available := max(0, limits.Resources[rName]-usage.Resources[rName])
+	return fmt.Sprintf("insufficient quota for %s for the user %q, request > remaining per-user limit in the %s (%s > %s)",
+		rName, user, queueKind, resources.ResourceQuantityString(rName, requests[rName]), resources.ResourceQuantityString(rName, available))
+}
+
 func (psa *PodSetAssignment) append(flavors ResourceAssignment, status *Status) {
 	for resource, assignment := range flavors {
 		psa.Flavors[resource] = assignment
@@ -519,7 +604,7 @@ func (a *FlavorAssigner) findFlavorForPodSetResource(
 	bestAssignmentMode := noFit
 
 	// We will only check against the flavors' labels for the resource.
-	selector := flavorSelector(podSpec, resourceGroup.LabelKeys)
+	selector := utilpod.RequiredNodeAffinity(podSpec, resourceGroup.LabelKeys)
 	attemptedFlavorIdx := -1
 	idx := a.wl.LastAssignment.NextFlavorToTryForPodSetResource(psID, resName)
 	for ; idx < len(resourceGroup.Flavors); idx++ {
@@ -637,54 +722,9 @@ func shouldTryNextFlavor(representativeMode granularMode, flavorFungibility kueu
 	return true
 }
 
-func flavorSelector(spec *corev1.PodSpec, allowedKeys sets.Set[string]) nodeaffinity.RequiredNodeAffinity {
-	// This function generally replicates the implementation of kube-scheduler's NodeAffinity
-	// Filter plugin as of v1.24.
-	var specCopy corev1.PodSpec
-
-	// Remove affinity constraints with irrelevant keys.
-	if len(spec.NodeSelector) != 0 {
-		specCopy.NodeSelector = map[string]string{}
-		for k, v := range spec.NodeSelector {
-			if allowedKeys.Has(k) {
-				specCopy.NodeSelector[k] = v
-			}
-		}
-	}
-
-	affinity := spec.Affinity
-	if affinity != nil && affinity.NodeAffinity != nil && affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
-		var termsCopy []corev1.NodeSelectorTerm
-		for _, t := range affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
-			var expCopy []corev1.NodeSelectorRequirement
-			for _, e := range t.MatchExpressions {
-				if allowedKeys.Has(e.Key) {
-					expCopy = append(expCopy, e)
-				}
-			}
-			// If a term becomes empty, it means node affinity matches any flavor since those terms are ORed,
-			// and so matching gets reduced to spec.NodeSelector
-			if len(expCopy) == 0 {
-				termsCopy = nil
-				break
-			}
-			termsCopy = append(termsCopy, corev1.NodeSelectorTerm{MatchExpressions: expCopy})
-		}
-		if len(termsCopy) != 0 {
-			specCopy.Affinity = &corev1.Affinity{
-				NodeAffinity: &corev1.NodeAffinity{
-					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
-						NodeSelectorTerms: termsCopy,
-					},
-				},
-			}
-		}
-	}
-	return nodeaffinity.GetRequiredNodeAffinity(&corev1.Pod{Spec: specCopy})
-}
-
 // fitsResourceQuota returns how this flavor could be assigned to the resource,
-// according to the remaining quota in the ClusterQueue and cohort.
+// according to the remaining quota in the ClusterQueue and cohort, as well as
+// the limits of the workload's LocalQueue.
 // If it fits, also returns if borrowing required. Similarly, it returns information
 // if borrowing is required when preempting.
 // If the flavor doesn't satisfy limits immediately (when waiting or preemption
@@ -692,6 +732,14 @@ func flavorSelector(spec *corev1.PodSpec, allowedKeys sets.Set[string]) nodeaffi
 func (a *FlavorAssigner) fitsResourceQuota(log logr.Logger, fr resources.FlavorResource, val int64, rQuota cache.ResourceQuota) (granularMode, bool, *Status) {
 	var status Status
 
+	// A workload exceeding the limits of its LocalQueue must wait for the
+	// workloads of the LocalQueue to finish, rather than preempting.
+	if lqAvailable, limited := a.cq.LocalQueueAvailable(workload.QueueKey(a.wl.Obj), fr); limited && val > lqAvailable {
+		status.appendf("insufficient quota for %s in flavor %s, request > remaining LocalQueue limit (%s > %s)",
+			fr.Resource, fr.Flavor, resources.ResourceQuantityString(fr.Resource, val), resources.ResourceQuantityString(fr.Resource, lqAvailable))
+		return noFit, false, &status
+	}
+
 	borrow := a.cq.BorrowingWith(fr, val) && a.cq.HasParent()
 	available := a.cq.Available(fr)
 	maxCapacity := a.cq.PotentialAvailable(fr)
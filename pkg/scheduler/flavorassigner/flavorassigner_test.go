@@ -25,9 +25,11 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
+	controllerconstants "sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/resources"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
@@ -75,10 +77,17 @@ func TestAssignFlavors(t *testing.T) {
 		clusterQueueUsage          resources.FlavorResourceQuantities
 		secondaryClusterQueue      *kueue.ClusterQueue
 		secondaryClusterQueueUsage resources.FlavorResourceQuantities
+		cohortMaxAdmittedWorkloads *int32
 		wantRepMode                FlavorAssignmentMode
 		wantAssignment             Assignment
 		disableLendingLimit        bool
 		enableFairSharing          bool
+		localQueue                 *kueue.LocalQueue
+		localQueueUsage            resources.FlavorResourceQuantities
+		localQueueWorkloads        int
+		wlSubmittedBy              string
+		clusterQueueUsersUsage     map[string]cache.UserUsage
+		localQueueUsersUsage       map[string]cache.UserUsage
 	}{
 		"single flavor, fits": {
 			wlPods: []kueue.PodSet{
@@ -1921,6 +1930,446 @@ func TestAssignFlavors(t *testing.T) {
 				}},
 			},
 		},
+		"flavor exceeding the LocalQueue limit, try next flavor": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
+					Request(corev1.ResourceCPU, "3").
+					Obj(),
+			},
+			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("one").
+						Resource(corev1.ResourceCPU, "10").
+						FlavorQuotas,
+					utiltesting.MakeFlavorQuotas("two").
+						Resource(corev1.ResourceCPU, "10").
+						FlavorQuotas,
+				).ClusterQueue,
+			localQueue: utiltesting.MakeLocalQueue("test-localqueue", "ns").
+				ClusterQueue("test-clusterqueue").
+				Limit("one", corev1.ResourceCPU, "4").
+				Obj(),
+			localQueueUsage: resources.FlavorResourceQuantities{
+				{Flavor: "one", Resource: corev1.ResourceCPU}: 2_000,
+			},
+			wantRepMode: Fit,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Flavors: ResourceAssignment{
+						corev1.ResourceCPU: {Name: "two", Mode: Fit, TriedFlavorIdx: -1},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("3"),
+					},
+					Count: 1,
+				}},
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{
+					{Flavor: "two", Resource: corev1.ResourceCPU}: 3_000,
+				}},
+			},
+		},
+		"exceeding the LocalQueue limit doesn't preempt": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
+					Request(corev1.ResourceCPU, "3").
+					Obj(),
+			},
+			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
+				Preemption(kueue.ClusterQueuePreemption{
+					WithinClusterQueue: kueue.PreemptionPolicyLowerPriority,
+				}).
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "4").
+						FlavorQuotas,
+				).ClusterQueue,
+			clusterQueueUsage: resources.FlavorResourceQuantities{
+				{Flavor: "default", Resource: corev1.ResourceCPU}: 3_000,
+			},
+			localQueue: utiltesting.MakeLocalQueue("test-localqueue", "ns").
+				ClusterQueue("test-clusterqueue").
+				Limit("default", corev1.ResourceCPU, "4").
+				Obj(),
+			localQueueUsage: resources.FlavorResourceQuantities{
+				{Flavor: "default", Resource: corev1.ResourceCPU}: 2_000,
+			},
+			wantRepMode: NoFit,
+			wantAssignment: Assignment{
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{}},
+				PodSets: []PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Status: &Status{
+						reasons: []string{"insufficient quota for cpu in flavor default, request > remaining LocalQueue limit (3 > 2)"},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("3"),
+					},
+					Count: 1,
+				}},
+			},
+		},
+		"within the LocalQueue limit, preempt": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
+					Request(corev1.ResourceCPU, "2").
+					Obj(),
+			},
+			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
+				Preemption(kueue.ClusterQueuePreemption{
+					WithinClusterQueue: kueue.PreemptionPolicyLowerPriority,
+				}).
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "4").
+						FlavorQuotas,
+				).ClusterQueue,
+			clusterQueueUsage: resources.FlavorResourceQuantities{
+				{Flavor: "default", Resource: corev1.ResourceCPU}: 3_000,
+			},
+			localQueue: utiltesting.MakeLocalQueue("test-localqueue", "ns").
+				ClusterQueue("test-clusterqueue").
+				Limit("default", corev1.ResourceCPU, "4").
+				Obj(),
+			localQueueUsage: resources.FlavorResourceQuantities{
+				{Flavor: "default", Resource: corev1.ResourceCPU}: 2_000,
+			},
+			wantRepMode: Preempt,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Flavors: ResourceAssignment{
+						corev1.ResourceCPU: {Name: "default", Mode: Preempt, TriedFlavorIdx: -1},
+					},
+					Status: &Status{
+						reasons: []string{"insufficient unused quota for cpu in flavor default, 1 more needed"},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("2"),
+					},
+					Count: 1,
+				}},
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{
+					{Flavor: "default", Resource: corev1.ResourceCPU}: 2_000,
+				}},
+			},
+		},
+		"ClusterQueue reached maxAdmittedWorkloads, needs preemption": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
+					Request(corev1.ResourceCPU, "1").
+					Obj(),
+			},
+			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "4").
+						FlavorQuotas,
+				).
+				MaxAdmittedWorkloads(1).
+				ClusterQueue,
+			clusterQueueUsage: resources.FlavorResourceQuantities{
+				{Flavor: "default", Resource: corev1.ResourceCPU}: 1_000,
+				cache.WorkloadsFlavorResource:                     1,
+			},
+			wantRepMode: Preempt,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Flavors: ResourceAssignment{
+						corev1.ResourceCPU: {Name: "default", Mode: Fit, TriedFlavorIdx: -1},
+					},
+					Status: &Status{
+						reasons: []string{"the ClusterQueue reached its maximum number of admitted workloads"},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("1"),
+					},
+					Count: 1,
+				}},
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{
+					{Flavor: "default", Resource: corev1.ResourceCPU}: 1_000,
+					cache.WorkloadsFlavorResource:                     1,
+				}},
+			},
+		},
+		"ClusterQueue reached maxAdmittedWorkloads, the counts of another ClusterQueue are not lent, needs preemption": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
+					Request(corev1.ResourceCPU, "1").
+					Obj(),
+			},
+			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
+				Cohort("test-cohort").
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "4").
+						FlavorQuotas,
+				).
+				MaxAdmittedWorkloads(1).
+				ClusterQueue,
+			clusterQueueUsage: resources.FlavorResourceQuantities{
+				{Flavor: "default", Resource: corev1.ResourceCPU}: 1_000,
+				cache.WorkloadsFlavorResource:                     1,
+			},
+			secondaryClusterQueue: utiltesting.MakeClusterQueue("test-secondary-clusterqueue").
+				Cohort("test-cohort").
+				MaxAdmittedWorkloads(1).
+				Obj(),
+			wantRepMode: Preempt,
+			wantAssignment: Assignment{
+				Borrowing: true,
+				PodSets: []PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Flavors: ResourceAssignment{
+						corev1.ResourceCPU: {Name: "default", Mode: Fit, TriedFlavorIdx: -1},
+					},
+					Status: &Status{
+						reasons: []string{"the ClusterQueue and its cohort reached their maximum number of admitted workloads"},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("1"),
+					},
+					Count: 1,
+				}},
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{
+					{Flavor: "default", Resource: corev1.ResourceCPU}: 1_000,
+					cache.WorkloadsFlavorResource:                     1,
+				}},
+			},
+		},
+		"ClusterQueue reached maxAdmittedWorkloads, borrow from the cohort": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
+					Request(corev1.ResourceCPU, "1").
+					Obj(),
+			},
+			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
+				Cohort("test-cohort").
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "4").
+						FlavorQuotas,
+				).
+				MaxAdmittedWorkloads(1).
+				ClusterQueue,
+			clusterQueueUsage: resources.FlavorResourceQuantities{
+				{Flavor: "default", Resource: corev1.ResourceCPU}: 1_000,
+				cache.WorkloadsFlavorResource:                     1,
+			},
+			cohortMaxAdmittedWorkloads: ptr.To[int32](1),
+			wantRepMode:                Fit,
+			wantAssignment: Assignment{
+				Borrowing: true,
+				PodSets: []PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Flavors: ResourceAssignment{
+						corev1.ResourceCPU: {Name: "default", Mode: Fit, TriedFlavorIdx: -1},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("1"),
+					},
+					Count: 1,
+				}},
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{
+					{Flavor: "default", Resource: corev1.ResourceCPU}: 1_000,
+					cache.WorkloadsFlavorResource:                     1,
+				}},
+			},
+		},
+		"ClusterQueue and cohort reached maxAdmittedWorkloads, needs preemption": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
+					Request(corev1.ResourceCPU, "1").
+					Obj(),
+			},
+			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
+				Cohort("test-cohort").
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "4").
+						FlavorQuotas,
+				).
+				MaxAdmittedWorkloads(1).
+				ClusterQueue,
+			clusterQueueUsage: resources.FlavorResourceQuantities{
+				{Flavor: "default", Resource: corev1.ResourceCPU}: 1_000,
+				cache.WorkloadsFlavorResource:                     1,
+			},
+			secondaryClusterQueue: utiltesting.MakeClusterQueue("test-secondary-clusterqueue").
+				Cohort("test-cohort").
+				Obj(),
+			secondaryClusterQueueUsage: resources.FlavorResourceQuantities{
+				cache.WorkloadsFlavorResource: 1,
+			},
+			cohortMaxAdmittedWorkloads: ptr.To[int32](1),
+			wantRepMode:                Preempt,
+			wantAssignment: Assignment{
+				Borrowing: true,
+				PodSets: []PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Flavors: ResourceAssignment{
+						corev1.ResourceCPU: {Name: "default", Mode: Fit, TriedFlavorIdx: -1},
+					},
+					Status: &Status{
+						reasons: []string{"the ClusterQueue and its cohort reached their maximum number of admitted workloads"},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("1"),
+					},
+					Count: 1,
+				}},
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{
+					{Flavor: "default", Resource: corev1.ResourceCPU}: 1_000,
+					cache.WorkloadsFlavorResource:                     1,
+				}},
+			},
+		},
+		"LocalQueue reached maxAdmittedWorkloads": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
+					Request(corev1.ResourceCPU, "1").
+					Obj(),
+			},
+			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "4").
+						FlavorQuotas,
+				).ClusterQueue,
+			localQueue: utiltesting.MakeLocalQueue("test-localqueue", "ns").
+				ClusterQueue("test-clusterqueue").
+				MaxAdmittedWorkloads(2).
+				Obj(),
+			localQueueWorkloads: 2,
+			wantRepMode:         NoFit,
+			wantAssignment: Assignment{
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{}},
+				PodSets: []PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Status: &Status{
+						reasons: []string{"the LocalQueue reached its maximum number of admitted workloads"},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("1"),
+					},
+					Count: 1,
+				}},
+			},
+		},
+		"user reached maxAdmittedWorkloads per user in the LocalQueue": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
+					Request(corev1.ResourceCPU, "1").
+					Obj(),
+			},
+			wlSubmittedBy: "alice",
+			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "4").
+						FlavorQuotas,
+				).ClusterQueue,
+			localQueue: utiltesting.MakeLocalQueue("test-localqueue", "ns").
+				ClusterQueue("test-clusterqueue").
+				PerUserLimits(kueue.PerUserLimits{MaxAdmittedWorkloads: ptr.To[int32](1)}).
+				Obj(),
+			localQueueUsersUsage: map[string]cache.UserUsage{
+				"alice": {Workloads: 1, Resources: resources.Requests{corev1.ResourceCPU: 1_000}},
+			},
+			wantRepMode: NoFit,
+			wantAssignment: Assignment{
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{}},
+				PodSets: []PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Status: &Status{
+						reasons: []string{`the user "alice" reached the maximum number of admitted workloads per user in the LocalQueue`},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("1"),
+					},
+					Count: 1,
+				}},
+			},
+		},
+		"user exceeds the per-user resource limit in the ClusterQueue": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
+					Request(corev1.ResourceCPU, "2").
+					Obj(),
+			},
+			wlSubmittedBy: "alice",
+			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "4").
+						FlavorQuotas,
+				).
+				PerUserLimits(kueue.PerUserLimits{Resources: []kueue.PerUserResourceLimit{
+					{Name: corev1.ResourceCPU, Max: resource.MustParse("3")},
+				}}).
+				ClusterQueue,
+			clusterQueueUsersUsage: map[string]cache.UserUsage{
+				"alice": {Workloads: 1, Resources: resources.Requests{corev1.ResourceCPU: 2_000}},
+			},
+			wantRepMode: NoFit,
+			wantAssignment: Assignment{
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{}},
+				PodSets: []PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Status: &Status{
+						reasons: []string{`insufficient quota for cpu for the user "alice", request > remaining per-user limit in the ClusterQueue (2 > 1)`},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("2"),
+					},
+					Count: 1,
+				}},
+			},
+		},
+		"usage of other users doesn't count against the per-user limits": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
+					Request(corev1.ResourceCPU, "2").
+					Obj(),
+			},
+			wlSubmittedBy: "alice",
+			clusterQueue: utiltesting.MakeClusterQueue("test-clusterqueue").
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "4").
+						FlavorQuotas,
+				).
+				PerUserLimits(kueue.PerUserLimits{
+					MaxAdmittedWorkloads: ptr.To[int32](1),
+					Resources: []kueue.PerUserResourceLimit{
+						{Name: corev1.ResourceCPU, Max: resource.MustParse("2")},
+					},
+				}).
+				ClusterQueue,
+			clusterQueueUsage: resources.FlavorResourceQuantities{
+				{Flavor: "default", Resource: corev1.ResourceCPU}: 2_000,
+			},
+			clusterQueueUsersUsage: map[string]cache.UserUsage{
+				"bob": {Workloads: 1, Resources: resources.Requests{corev1.ResourceCPU: 2_000}},
+			},
+			wantRepMode: Fit,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Flavors: ResourceAssignment{
+						corev1.ResourceCPU: {Name: "default", Mode: Fit, TriedFlavorIdx: -1},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("2"),
+					},
+					Count: 1,
+				}},
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{
+					{Flavor: "default", Resource: corev1.ResourceCPU}: 2_000,
+				}},
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -1928,17 +2377,28 @@ func TestAssignFlavors(t *testing.T) {
 			if tc.disableLendingLimit {
 				features.SetFeatureGateDuringTest(t, features.LendingLimit, false)
 			}
+			features.SetFeatureGateDuringTest(t, features.LocalQueueLimits, true)
+			features.SetFeatureGateDuringTest(t, features.MaxAdmittedWorkloads, true)
+			features.SetFeatureGateDuringTest(t, features.PerUserLimits, true)
 			log := testr.NewWithOptions(t, testr.Options{
 				Verbosity: 2,
 			})
-			wlInfo := workload.NewInfo(&kueue.Workload{
+			wl := &kueue.Workload{
 				Spec: kueue.WorkloadSpec{
 					PodSets: tc.wlPods,
 				},
 				Status: kueue.WorkloadStatus{
 					ReclaimablePods: tc.wlReclaimablePods,
 				},
-			})
+			}
+			if tc.localQueue != nil {
+				wl.Namespace = tc.localQueue.Namespace
+				wl.Spec.QueueName = tc.localQueue.Name
+			}
+			if tc.wlSubmittedBy != "" {
+				wl.Annotations = map[string]string{controllerconstants.SubmittedByAnnotation: tc.wlSubmittedBy}
+			}
+			wlInfo := workload.NewInfo(wl)
 
 			cache := cache.New(utiltesting.NewFakeClient())
 			if err := cache.AddClusterQueue(ctx, &tc.clusterQueue); err != nil {
@@ -1952,8 +2412,17 @@ func TestAssignFlavors(t *testing.T) {
 			for _, rf := range resourceFlavors {
 				cache.AddOrUpdateResourceFlavor(rf)
 			}
+			if tc.localQueue != nil {
+				if err := cache.AddLocalQueue(tc.localQueue); err != nil {
+					t.Fatalf("Failed to add LQ to cache")
+				}
+			}
 
-			if err := cache.AddOrUpdateCohort(utiltesting.MakeCohort(tc.clusterQueue.Spec.Cohort).Obj()); err != nil {
+			cohort := utiltesting.MakeCohort(tc.clusterQueue.Spec.Cohort)
+			if tc.cohortMaxAdmittedWorkloads != nil {
+				cohort.MaxAdmittedWorkloads(*tc.cohortMaxAdmittedWorkloads)
+			}
+			if err := cache.AddOrUpdateCohort(cohort.Obj()); err != nil {
 				t.Fatalf("Failed to create a cohort")
 			}
 
@@ -1969,6 +2438,18 @@ func TestAssignFlavors(t *testing.T) {
 			if tc.clusterQueueUsage != nil {
 				clusterQueue.AddUsage(workload.Usage{Quota: tc.clusterQueueUsage})
 			}
+			if tc.localQueueUsage != nil {
+				clusterQueue.LocalQueues[workload.QueueKey(wl)].Usage = tc.localQueueUsage
+			}
+			if tc.localQueueWorkloads != 0 {
+				clusterQueue.LocalQueues[workload.QueueKey(wl)].ReservingWorkloads = tc.localQueueWorkloads
+			}
+			if tc.clusterQueueUsersUsage != nil {
+				clusterQueue.UsersUsage = tc.clusterQueueUsersUsage
+			}
+			if tc.localQueueUsersUsage != nil {
+				clusterQueue.LocalQueues[workload.QueueKey(wl)].UsersUsage = tc.localQueueUsersUsage
+			}
 
 			if tc.secondaryClusterQueue != nil {
 				secondaryClusterQueue := snapshot.ClusterQueue(kueue.ClusterQueueReference(tc.secondaryClusterQueue.Name))
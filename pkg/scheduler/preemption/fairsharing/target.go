@@ -65,7 +65,7 @@ func (t *TargetClusterQueue) ComputeShares() (PreemptorNewShare, TargetOldShare)
 // will depend on LendingLimits of the children. See
 // cache.resource_node.go.
 func (t *TargetClusterQueue) ComputeTargetShareAfterRemoval(wl *workload.Info) TargetNewShare {
-	revertSimulation := t.targetCq.SimulateUsageRemoval(wl.Usage())
+	revertSimulation := t.targetCq.SimulateUsageRemoval(t.targetCq.WorkloadUsage(wl))
 	defer revertSimulation()
 
 	_, almostLCA := getAlmostLCAs(t)
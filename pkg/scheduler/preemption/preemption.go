@@ -19,6 +19,7 @@ package preemption
 import (
 	"context"
 	"fmt"
+	"math"
 	"sort"
 	"sync/atomic"
 	"time"
@@ -39,6 +40,7 @@ import (
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/metrics"
 	"sigs.k8s.io/kueue/pkg/resources"
 	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
@@ -127,15 +129,23 @@ type Target struct {
 func (p *Preemptor) GetTargets(log logr.Logger, wl workload.Info, assignment flavorassigner.Assignment, snapshot *cache.Snapshot) []*Target {
 	cq := snapshot.ClusterQueue(wl.ClusterQueue)
 	tasRequests := assignment.WorkloadsTopologyRequests(&wl, cq)
+	frsNeedPreemption := flavorResourcesNeedPreemption(assignment)
+	quota := assignment.TotalRequestsFor(&wl)
+	if cq.LimitsWorkloadCount() {
+		quota[cache.WorkloadsFlavorResource] = 1
+		if cq.Available(cache.WorkloadsFlavorResource) < 1 {
+			frsNeedPreemption.Insert(cache.WorkloadsFlavorResource)
+		}
+	}
 	return p.getTargets(&preemptionCtx{
 		log:               log,
 		preemptor:         wl,
 		preemptorCQ:       cq,
 		snapshot:          snapshot,
 		tasRequests:       tasRequests,
-		frsNeedPreemption: flavorResourcesNeedPreemption(assignment),
+		frsNeedPreemption: frsNeedPreemption,
 		workloadUsage: workload.Usage{
-			Quota: assignment.TotalRequestsFor(&wl),
+			Quota: quota,
 			TAS:   wl.TASUsage(),
 		},
 	})
@@ -272,10 +282,89 @@ func (p *Preemptor) applyPreemptionWithSSA(ctx context.Context, w *kueue.Workloa
 // Once the Workload fits, the heuristic tries to add Workloads back, in the
 // reverse order in which they were removed, while the incoming Workload still
 // fits.
+//
+// For a TAS workload, the candidates confined to a single topology domain are
+// tried first, see topologyAwarePreemptions.
 func minimalPreemptions(preemptionCtx *preemptionCtx, candidates []*workload.Info, allowBorrowing bool, allowBorrowingBelowPriority *int32) []*Target {
 	if logV := preemptionCtx.log.V(5); logV.Enabled() {
 		logV.Info("Simulating preemption", "candidates", workload.References(candidates), "resourcesRequiringPreemption", preemptionCtx.frsNeedPreemption.UnsortedList(), "allowBorrowing", allowBorrowing, "allowBorrowingBelowPriority", allowBorrowingBelowPriority, "preemptingWorkload", klog.KObj(preemptionCtx.preemptor.Obj))
 	}
+	if features.Enabled(features.TASTopologyAwarePreemption) && len(preemptionCtx.tasRequests) > 0 {
+		if targets := topologyAwarePreemptions(preemptionCtx, candidates, allowBorrowing, allowBorrowingBelowPriority); len(targets) > 0 {
+			return targets
+		}
+	}
+	return minimalPreemptionsFromCandidates(preemptionCtx, candidates, allowBorrowing, allowBorrowingBelowPriority)
+}
+
+// topologyAwarePreemptions groups the candidates by the topology domain, at
+// the level requested by the preempting workload, which contains their TAS
+// usage, and runs the minimalPreemptions heuristic for the candidates of each
+// domain, so that freeing a single domain lets the workload fit. The
+// candidates spread over multiple domains are skipped, while the candidates
+// without TAS usage in the flavors of the workload are considered for every
+// domain. Among the domains, the one with the least disruptive preemptions
+// is chosen, see lessDisruptive. It returns nil when there is no such domain.
+func topologyAwarePreemptions(preemptionCtx *preemptionCtx, candidates []*workload.Info, allowBorrowing bool, allowBorrowingBelowPriority *int32) []*Target {
+	candidateDomains := make(map[*workload.Info]string, len(candidates))
+	domains := sets.New[string]()
+	for _, candWl := range candidates {
+		domain, confined := preemptionCtx.preemptorCQ.TASUsageDomain(preemptionCtx.tasRequests, candWl.TASUsage())
+		if !confined {
+			continue
+		}
+		candidateDomains[candWl] = domain
+		if domain != "" {
+			domains.Insert(domain)
+		}
+	}
+	var best []*Target
+	for _, domain := range sets.List(domains) {
+		var domainCandidates []*workload.Info
+		for _, candWl := range candidates {
+			if candDomain, confined := candidateDomains[candWl]; confined && (candDomain == domain || candDomain == "") {
+				domainCandidates = append(domainCandidates, candWl)
+			}
+		}
+		targets := minimalPreemptionsFromCandidates(preemptionCtx, domainCandidates, allowBorrowing, allowBorrowingBelowPriority)
+		if len(targets) > 0 && (best == nil || lessDisruptive(targets, best)) {
+			preemptionCtx.log.V(5).Info("Found preemption targets within topology domain", "domain", domain, "targets", len(targets))
+			best = targets
+		}
+	}
+	return best
+}
+
+// lessDisruptive returns whether preempting the targets a is less disruptive
+// than preempting the targets b. The targets with the lower highest priority
+// are less disruptive, then the ones with the lower sum of priorities, and
+// then the fewer targets.
+func lessDisruptive(a, b []*Target) bool {
+	maxA, sumA := targetPriorities(a)
+	maxB, sumB := targetPriorities(b)
+	if maxA != maxB {
+		return maxA < maxB
+	}
+	if sumA != sumB {
+		return sumA < sumB
+	}
+	return len(a) < len(b)
+}
+
+// targetPriorities returns the highest priority and the sum of the
+// priorities of the targets.
+func targetPriorities(targets []*Target) (int32, int64) {
+	maxPriority := int32(math.MinInt32)
+	var sum int64
+	for _, target := range targets {
+		p := priority.Priority(target.WorkloadInfo.Obj)
+		maxPriority = max(maxPriority, p)
+		sum += int64(p)
+	}
+	return maxPriority, sum
+}
+
+func minimalPreemptionsFromCandidates(preemptionCtx *preemptionCtx, candidates []*workload.Info, allowBorrowing bool, allowBorrowingBelowPriority *int32) []*Target {
 	// Simulate removing all candidates from the ClusterQueue and cohort.
 	var targets []*Target
 	fits := false
@@ -504,8 +593,10 @@ func (p *Preemptor) findCandidates(wl *kueue.Workload, cq *cache.ClusterQueueSna
 
 	if cq.HasParent() && cq.Preemption.ReclaimWithinCohort != kueue.PreemptionPolicyNever {
 		onlyLowerPriority := cq.Preemption.ReclaimWithinCohort != kueue.PreemptionPolicyAny
+		// The workload counts are never lent, so they can't be reclaimed.
+		frsNeedReclaim := frsNeedPreemption.Clone().Delete(cache.WorkloadsFlavorResource)
 		for _, cohortCQ := range cq.Parent().Root().SubtreeClusterQueues() {
-			if cq == cohortCQ || !cqIsBorrowing(cohortCQ, frsNeedPreemption) {
+			if cq == cohortCQ || !cqIsBorrowing(cohortCQ, frsNeedReclaim) {
 				// Can't reclaim quota from itself or ClusterQueues that are not borrowing.
 				continue
 			}
@@ -513,7 +604,7 @@ func (p *Preemptor) findCandidates(wl *kueue.Workload, cq *cache.ClusterQueueSna
 				if onlyLowerPriority && priority.Priority(candidateWl.Obj) >= priority.Priority(wl) {
 					continue
 				}
-				if !workloadUsesResources(candidateWl, frsNeedPreemption) {
+				if !workloadUsesResources(candidateWl, frsNeedReclaim) {
 					continue
 				}
 				candidates = append(candidates, candidateWl)
@@ -536,6 +627,10 @@ func cqIsBorrowing(cq *cache.ClusterQueueSnapshot, frsNeedPreemption sets.Set[re
 }
 
 func workloadUsesResources(wl *workload.Info, frsNeedPreemption sets.Set[resources.FlavorResource]) bool {
+	// Every workload uses one unit of the workload count.
+	if frsNeedPreemption.Has(cache.WorkloadsFlavorResource) {
+		return true
+	}
 	for _, ps := range wl.TotalRequests {
 		for res, flv := range ps.Flavors {
 			if frsNeedPreemption.Has(resources.FlavorResource{Flavor: flv, Resource: res}) {
@@ -326,6 +326,50 @@ func TestPreemption(t *testing.T) {
 			}),
 			wantPreempted: sets.New(targetKeyReason("/low", kueue.InClusterQueueReason)),
 		},
+		"preempt lowest priority to free a workload count": {
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("counted").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "6").
+						Obj(),
+					).
+					MaxAdmittedWorkloads(2).
+					Preemption(kueue.ClusterQueuePreemption{
+						WithinClusterQueue: kueue.PreemptionPolicyLowerPriority,
+					}).
+					Obj(),
+			},
+			admitted: []kueue.Workload{
+				*utiltesting.MakeWorkload("low", "").
+					Priority(-1).
+					Request(corev1.ResourceCPU, "1").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("counted").Assignment(corev1.ResourceCPU, "default", "1000m").Obj(),
+						now,
+					).
+					Obj(),
+				*utiltesting.MakeWorkload("high", "").
+					Priority(1).
+					Request(corev1.ResourceCPU, "1").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("counted").Assignment(corev1.ResourceCPU, "default", "1000m").Obj(),
+						now,
+					).
+					Obj(),
+			},
+			incoming: utiltesting.MakeWorkload("in", "").
+				Priority(1).
+				Request(corev1.ResourceCPU, "1").
+				Obj(),
+			targetCQ: "counted",
+			assignment: singlePodSetAssignment(flavorassigner.ResourceAssignment{
+				corev1.ResourceCPU: &flavorassigner.FlavorAssignment{
+					Name: "default",
+					Mode: flavorassigner.Fit,
+				},
+			}),
+			wantPreempted: sets.New(targetKeyReason("/low", kueue.InClusterQueueReason)),
+		},
 		"preempt multiple": {
 			clusterQueues: defaultClusterQueues,
 			admitted: []kueue.Workload{
@@ -1810,6 +1854,7 @@ func TestPreemption(t *testing.T) {
 			if tc.disableLendingLimit {
 				features.SetFeatureGateDuringTest(t, features.LendingLimit, false)
 			}
+			features.SetFeatureGateDuringTest(t, features.MaxAdmittedWorkloads, true)
 			ctx, log := utiltesting.ContextWithLog(t)
 			cl := utiltesting.NewClientBuilder().
 				WithLists(&kueue.WorkloadList{Items: tc.admitted}).
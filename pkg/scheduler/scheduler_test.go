@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
 	"sync"
 	"testing"
 	"time"
@@ -5750,7 +5751,115 @@ func TestScheduleForTASPreemption(t *testing.T) {
 			},
 		},
 	}
+	const (
+		tasBlockLabel = "cloud.provider.com/topology-block"
+		tasRackLabel  = "cloud.provider.com/topology-rack"
+	)
+	threeLevelTopology := *utiltesting.MakeTopology("tas-three-level").
+		Levels(tasBlockLabel, tasRackLabel, corev1.LabelHostname).
+		Obj()
+	threeLevelTASFlavor := *utiltesting.MakeResourceFlavor("tas-three-level").
+		NodeLabel("tas-node", "true").
+		TopologyName("tas-three-level").
+		Obj()
+	threeLevelClusterQueue := *utiltesting.MakeClusterQueue("tas-main").
+		Preemption(kueue.ClusterQueuePreemption{WithinClusterQueue: kueue.PreemptionPolicyLowerPriority}).
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("tas-three-level").
+			Resource(corev1.ResourceCPU, "50").Obj()).
+		Obj()
+	// nodesInRacks returns two nodes with 2 CPUs in each of the four racks:
+	// x1, x2 in r1, x3, x4 in r2, x5, x6 in r3, x7, x8 in r4. The racks
+	// belong to the given blocks.
+	nodesInRacks := func(blocks ...string) []corev1.Node {
+		var nodes []corev1.Node
+		for i := range 8 {
+			rack := i / 2
+			nodes = append(nodes, *testingnode.MakeNode(fmt.Sprintf("x%d", i+1)).
+				Label("tas-node", "true").
+				Label(tasBlockLabel, blocks[rack]).
+				Label(tasRackLabel, fmt.Sprintf("r%d", rack+1)).
+				Label(corev1.LabelHostname, fmt.Sprintf("x%d", i+1)).
+				StatusAllocatable(corev1.ResourceList{
+					corev1.ResourceCPU:  resource.MustParse("2"),
+					corev1.ResourcePods: resource.MustParse("10"),
+				}).
+				Ready().
+				Obj())
+		}
+		return nodes
+	}
+	// admittedOnNodes returns a workload admitted with a pod, requesting the
+	// given CPUs, on each of the nodes.
+	admittedOnNodes := func(name string, priority int32, cpu int, nodes ...string) kueue.Workload {
+		topologyAssignment := &kueue.TopologyAssignment{Levels: []string{corev1.LabelHostname}}
+		for _, node := range nodes {
+			topologyAssignment.Domains = append(topologyAssignment.Domains, kueue.TopologyDomainAssignment{
+				Values: []string{node},
+				Count:  1,
+			})
+		}
+		return *utiltesting.MakeWorkload(name, "default").
+			Queue("tas-main").
+			Priority(priority).
+			PodSets(*utiltesting.MakePodSet("one", len(nodes)).
+				RequiredTopologyRequest(corev1.LabelHostname).
+				Request(corev1.ResourceCPU, strconv.Itoa(cpu)).
+				Obj()).
+			ReserveQuota(
+				utiltesting.MakeAdmission("tas-main", "one").
+					Assignment(corev1.ResourceCPU, "tas-three-level", strconv.Itoa(cpu*len(nodes))).
+					AssignmentPodCount(int32(len(nodes))).
+					TopologyAssignment(topologyAssignment).
+					Obj(),
+			).
+			Admitted(true).
+			Obj()
+	}
+	// The workloads occupying the racks, such that:
+	// - r1 has 1 free CPU, and 4 after preempting "spread" and "r1",
+	// - r2 has 2 free CPUs, and 4 after preempting "r2",
+	// - r3 has 1 free CPU, and 4 after preempting "spread" and "r3",
+	// - r4 has no free CPUs, and 4 after preempting "r4-a" and "r4-b".
+	workloadsInRacks := []kueue.Workload{
+		admittedOnNodes("spread", 1, 1, "x1", "x5"),
+		admittedOnNodes("r1", 2, 2, "x2"),
+		admittedOnNodes("r2", 3, 2, "x3"),
+		admittedOnNodes("r3", 3, 2, "x6"),
+		admittedOnNodes("r4-a", 3, 2, "x7"),
+		admittedOnNodes("r4-b", 3, 2, "x8"),
+	}
+	preemptor := func(level string) kueue.Workload {
+		return *utiltesting.MakeWorkload("foo", "default").
+			Queue("tas-main").
+			Priority(10).
+			PodSets(*utiltesting.MakePodSet("one", 4).
+				RequiredTopologyRequest(level).
+				Request(corev1.ResourceCPU, "1").
+				Obj()).
+			Obj()
+	}
+	preemptionEvents := func(names ...string) []utiltesting.EventRecord {
+		events := []utiltesting.EventRecord{{
+			Key:       types.NamespacedName{Namespace: "default", Name: "foo"},
+			EventType: "Warning",
+			Reason:    "Pending",
+		}}
+		for _, name := range names {
+			events = append(events, utiltesting.EventRecord{
+				Key:       types.NamespacedName{Namespace: "default", Name: name},
+				EventType: "Normal",
+				Reason:    "Preempted",
+			})
+		}
+		return events
+	}
+	preemptionEventCmpOpts := cmp.Options{
+		cmpopts.IgnoreFields(utiltesting.EventRecord{}, "Message"),
+		cmpopts.SortSlices(utiltesting.SortEvents),
+	}
 	cases := map[string]struct {
+		enableTopologyAwarePreemption bool
+
 		nodes           []corev1.Node
 		pods            []corev1.Pod
 		topologies      []kueuealpha.Topology
@@ -6139,10 +6248,113 @@ func TestScheduleForTASPreemption(t *testing.T) {
 				},
 			},
 		},
+		"topology unaware preemption within a rack": {
+			// This test case demonstrates the baseline scenario, in which the
+			// lowest priority workload, spread across racks, is preempted
+			// along with the workload in the rack which fits after that.
+			nodes:           nodesInRacks("b1", "b1", "b2", "b2"),
+			topologies:      []kueuealpha.Topology{threeLevelTopology},
+			resourceFlavors: []kueue.ResourceFlavor{threeLevelTASFlavor},
+			clusterQueues:   []kueue.ClusterQueue{threeLevelClusterQueue},
+			workloads:       append([]kueue.Workload{preemptor(tasRackLabel)}, workloadsInRacks...),
+			wantPreempted:   sets.New("default/spread", "default/r1"),
+			wantLeft: map[kueue.ClusterQueueReference][]string{
+				"tas-main": {"default/foo"},
+			},
+			eventCmpOpts: preemptionEventCmpOpts,
+			wantEvents:   preemptionEvents("spread", "r1"),
+		},
+		"topology aware preemption within a rack": {
+			// This test case demonstrates that the workload confined to the
+			// rack which fits after a single preemption is preempted.
+			enableTopologyAwarePreemption: true,
+			nodes:                         nodesInRacks("b1", "b1", "b2", "b2"),
+			topologies:                    []kueuealpha.Topology{threeLevelTopology},
+			resourceFlavors:               []kueue.ResourceFlavor{threeLevelTASFlavor},
+			clusterQueues:                 []kueue.ClusterQueue{threeLevelClusterQueue},
+			workloads:                     append([]kueue.Workload{preemptor(tasRackLabel)}, workloadsInRacks...),
+			wantPreempted:                 sets.New("default/r2"),
+			wantLeft: map[kueue.ClusterQueueReference][]string{
+				"tas-main": {"default/foo"},
+			},
+			eventCmpOpts: preemptionEventCmpOpts,
+			wantEvents:   preemptionEvents("r2"),
+		},
+		"topology aware preemption within a block": {
+			// This test case demonstrates that the preemption targets are
+			// confined to a block when the block level is requested.
+			enableTopologyAwarePreemption: true,
+			nodes:                         nodesInRacks("b1", "b2", "b3", "b4"),
+			topologies:                    []kueuealpha.Topology{threeLevelTopology},
+			resourceFlavors:               []kueue.ResourceFlavor{threeLevelTASFlavor},
+			clusterQueues:                 []kueue.ClusterQueue{threeLevelClusterQueue},
+			workloads:                     append([]kueue.Workload{preemptor(tasBlockLabel)}, workloadsInRacks...),
+			wantPreempted:                 sets.New("default/r2"),
+			wantLeft: map[kueue.ClusterQueueReference][]string{
+				"tas-main": {"default/foo"},
+			},
+			eventCmpOpts: preemptionEventCmpOpts,
+			wantEvents:   preemptionEvents("r2"),
+		},
+		"topology aware preemption within a block, with workloads spread across racks of the block": {
+			// This test case demonstrates that the workloads spread across the
+			// racks of a single block are confined to the block, and that the
+			// two lower priority workloads are preempted instead of the single
+			// higher priority one.
+			enableTopologyAwarePreemption: true,
+			nodes:                         nodesInRacks("b1", "b2", "b1", "b2"),
+			topologies:                    []kueuealpha.Topology{threeLevelTopology},
+			resourceFlavors:               []kueue.ResourceFlavor{threeLevelTASFlavor},
+			clusterQueues:                 []kueue.ClusterQueue{threeLevelClusterQueue},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("foo", "default").
+					Queue("tas-main").
+					Priority(10).
+					PodSets(*utiltesting.MakePodSet("one", 8).
+						RequiredTopologyRequest(tasBlockLabel).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+				admittedOnNodes("b1-a", 1, 1, "x1", "x5"),
+				admittedOnNodes("b1-b", 2, 2, "x2", "x6"),
+				admittedOnNodes("b2", 3, 2, "x4", "x7"),
+			},
+			wantPreempted: sets.New("default/b1-a", "default/b1-b"),
+			wantLeft: map[kueue.ClusterQueueReference][]string{
+				"tas-main": {"default/foo"},
+			},
+			eventCmpOpts: preemptionEventCmpOpts,
+			wantEvents:   preemptionEvents("b1-a", "b1-b"),
+		},
+		"topology aware preemption within a rack, preferring the lower priority workloads": {
+			// This test case demonstrates that the two lower priority
+			// workloads of a rack are preempted instead of the single higher
+			// priority workload of another rack.
+			enableTopologyAwarePreemption: true,
+			nodes:                         nodesInRacks("b1", "b1", "b2", "b2"),
+			topologies:                    []kueuealpha.Topology{threeLevelTopology},
+			resourceFlavors:               []kueue.ResourceFlavor{threeLevelTASFlavor},
+			clusterQueues:                 []kueue.ClusterQueue{threeLevelClusterQueue},
+			workloads: []kueue.Workload{
+				preemptor(tasRackLabel),
+				admittedOnNodes("r1-a", 1, 2, "x1"),
+				admittedOnNodes("r1-b", 2, 2, "x2"),
+				admittedOnNodes("r2", 3, 2, "x3", "x4"),
+				admittedOnNodes("r3", 3, 2, "x5", "x6"),
+				admittedOnNodes("r4", 3, 2, "x7", "x8"),
+			},
+			wantPreempted: sets.New("default/r1-a", "default/r1-b"),
+			wantLeft: map[kueue.ClusterQueueReference][]string{
+				"tas-main": {"default/foo"},
+			},
+			eventCmpOpts: preemptionEventCmpOpts,
+			wantEvents:   preemptionEvents("r1-a", "r1-b"),
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
 			features.SetFeatureGateDuringTest(t, features.TopologyAwareScheduling, true)
+			features.SetFeatureGateDuringTest(t, features.TASTopologyAwarePreemption, tc.enableTopologyAwarePreemption)
 			ctx, _ := utiltesting.ContextWithLog(t)
 
 			clientBuilder := utiltesting.NewClientBuilder().
@@ -143,6 +143,12 @@ func (h *Heap[T]) Delete(key string) {
 	heap.Remove(&h.data, item.index)
 }
 
+// Reorder re-establishes the heap invariant, after the ordering of the
+// items changed.
+func (h *Heap[T]) Reorder() {
+	heap.Init(&h.data)
+}
+
 // Pop returns the head of the heap and removes it.
 func (h *Heap[T]) Pop() *T {
 	return heap.Pop(&h.data).(*T)
@@ -214,6 +214,29 @@ func TestHeap_Update(t *testing.T) {
 	}
 }
 
+// Tests Heap.Reorder and ensures that heap invariant is restored after the ordering changed.
+func TestHeap_Reorder(t *testing.T) {
+	descending := false
+	h := New(testHeapObjectKeyFunc, func(obj1, obj2 *testHeapObject) bool {
+		if descending {
+			return obj1.val > obj2.val
+		}
+		return obj1.val < obj2.val
+	})
+	h.PushOrUpdate(mkHeapObj("foo", 10))
+	h.PushOrUpdate(mkHeapObj("bar", 1))
+	h.PushOrUpdate(mkHeapObj("bal", 31))
+	h.PushOrUpdate(mkHeapObj("baz", 11))
+
+	descending = true
+	h.Reorder()
+	for _, e := range []int{31, 11, 10, 1} {
+		if a := h.Pop().val; a != e {
+			t.Fatalf("expected %d, got %d", e, a)
+		}
+	}
+}
+
 // TestHeap_GetByKey tests Heap.GetByKey and is very similar to TestHeap_Get.
 func TestHeap_GetByKey(t *testing.T) {
 	h := New(testHeapObjectKeyFunc, compareInts)
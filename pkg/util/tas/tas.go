@@ -22,6 +22,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 )
 
 type TopologyDomainID string
@@ -54,6 +55,20 @@ func Levels(topology *kueuealpha.Topology) []string {
 	return result
 }
 
+// AssignedHostnames returns the hostname label values of the nodes in the
+// topology assignment, when the lowest level of the assignment is the node.
+// They can differ from the names of the nodes.
+func AssignedHostnames(ta *kueue.TopologyAssignment) []string {
+	if ta == nil || len(ta.Levels) == 0 || ta.Levels[len(ta.Levels)-1] != corev1.LabelHostname {
+		return nil
+	}
+	nodes := make([]string, 0, len(ta.Domains))
+	for _, domain := range ta.Domains {
+		nodes = append(nodes, domain.Values[len(domain.Values)-1])
+	}
+	return nodes
+}
+
 func IsNodeStatusConditionTrue(conditions []corev1.NodeCondition, conditionType corev1.NodeConditionType) bool {
 	for _, cond := range conditions {
 		if cond.Type == conditionType {
@@ -33,6 +33,7 @@ import (
 
 	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
 	utilResource "sigs.k8s.io/kueue/pkg/util/resource"
 )
 
@@ -270,6 +271,12 @@ func (w *WorkloadWrapper) AdmissionCheck(ac kueue.AdmissionCheckState) *Workload
 	return w
 }
 
+// RemotePods sets the status of the pods mirrored from the MultiKueue worker cluster.
+func (w *WorkloadWrapper) RemotePods(status *kueue.RemotePodsStatus) *WorkloadWrapper {
+	w.Status.RemotePods = status
+	return w
+}
+
 func (w *WorkloadWrapper) SetOrReplaceCondition(condition metav1.Condition) *WorkloadWrapper {
 	existingCondition := apimeta.FindStatusCondition(w.Status.Conditions, condition.Type)
 	if existingCondition != nil {
@@ -335,6 +342,15 @@ func (w *WorkloadWrapper) Annotations(annotations map[string]string) *WorkloadWr
 	return w
 }
 
+// SubmittedBy sets the user which submitted the workload.
+func (w *WorkloadWrapper) SubmittedBy(user string) *WorkloadWrapper {
+	if w.ObjectMeta.Annotations == nil {
+		w.ObjectMeta.Annotations = make(map[string]string, 1)
+	}
+	w.ObjectMeta.Annotations[constants.SubmittedByAnnotation] = user
+	return w
+}
+
 // DeletionTimestamp sets a deletion timestamp for the workload.
 func (w *WorkloadWrapper) DeletionTimestamp(t time.Time) *WorkloadWrapper {
 	w.Workload.DeletionTimestamp = ptr.To(metav1.NewTime(t).Rfc3339Copy())
@@ -659,6 +675,37 @@ func (q *LocalQueueWrapper) StopPolicy(p kueue.StopPolicy) *LocalQueueWrapper {
 	return q
 }
 
+// Limit sets the limit of the resource in the flavor.
+func (q *LocalQueueWrapper) Limit(flavor string, resourceName corev1.ResourceName, max string) *LocalQueueWrapper {
+	limit := kueue.LocalQueueResourceLimit{
+		Name: resourceName,
+		Max:  resource.MustParse(max),
+	}
+	for i := range q.Spec.Limits {
+		if q.Spec.Limits[i].Name == kueue.ResourceFlavorReference(flavor) {
+			q.Spec.Limits[i].Resources = append(q.Spec.Limits[i].Resources, limit)
+			return q
+		}
+	}
+	q.Spec.Limits = append(q.Spec.Limits, kueue.LocalQueueFlavorLimit{
+		Name:      kueue.ResourceFlavorReference(flavor),
+		Resources: []kueue.LocalQueueResourceLimit{limit},
+	})
+	return q
+}
+
+// MaxAdmittedWorkloads sets the maximum number of admitted workloads.
+func (q *LocalQueueWrapper) MaxAdmittedWorkloads(n int32) *LocalQueueWrapper {
+	q.Spec.MaxAdmittedWorkloads = &n
+	return q
+}
+
+// PerUserLimits sets the limits on the usage of each user.
+func (q *LocalQueueWrapper) PerUserLimits(limits kueue.PerUserLimits) *LocalQueueWrapper {
+	q.Spec.PerUserLimits = &limits
+	return q
+}
+
 // PendingWorkloads updates the pendingWorkloads in status.
 func (q *LocalQueueWrapper) PendingWorkloads(n int32) *LocalQueueWrapper {
 	q.Status.PendingWorkloads = n
@@ -731,6 +778,12 @@ func (c *CohortWrapper) FairWeight(w resource.Quantity) *CohortWrapper {
 	return c
 }
 
+// MaxAdmittedWorkloads sets the maximum number of admitted workloads.
+func (c *CohortWrapper) MaxAdmittedWorkloads(n int32) *CohortWrapper {
+	c.Spec.MaxAdmittedWorkloads = &n
+	return c
+}
+
 // ClusterQueueWrapper wraps a ClusterQueue.
 type ClusterQueueWrapper struct{ kueue.ClusterQueue }
 
@@ -867,6 +920,18 @@ func (c *ClusterQueueWrapper) FairWeight(w resource.Quantity) *ClusterQueueWrapp
 	return c
 }
 
+// MaxAdmittedWorkloads sets the maximum number of admitted workloads.
+func (c *ClusterQueueWrapper) MaxAdmittedWorkloads(n int32) *ClusterQueueWrapper {
+	c.Spec.MaxAdmittedWorkloads = &n
+	return c
+}
+
+// PerUserLimits sets the limits on the usage of each user.
+func (c *ClusterQueueWrapper) PerUserLimits(limits kueue.PerUserLimits) *ClusterQueueWrapper {
+	c.Spec.PerUserLimits = &limits
+	return c
+}
+
 // Condition sets a condition on the ClusterQueue.
 func (c *ClusterQueueWrapper) Condition(conditionType string, status metav1.ConditionStatus, reason, message string) *ClusterQueueWrapper {
 	apimeta.SetStatusCondition(&c.Status.Conditions, metav1.Condition{
@@ -964,6 +1029,11 @@ func (rq *ResourceQuotaWrapper) LendingLimit(quantity string) *ResourceQuotaWrap
 	return rq
 }
 
+func (rq *ResourceQuotaWrapper) NodeCapacityPercentage(percentage int32) *ResourceQuotaWrapper {
+	rq.ResourceQuota.NodeCapacityPercentage = &percentage
+	return rq
+}
+
 // Append appends the ResourceQuotaWrapper to its parent
 func (rq *ResourceQuotaWrapper) Append() *FlavorQuotasWrapper {
 	rq.parent.Resources = append(rq.parent.Resources, rq.ResourceQuota)
@@ -1023,6 +1093,15 @@ func (rf *ResourceFlavorWrapper) Toleration(t corev1.Toleration) *ResourceFlavor
 	return rf
 }
 
+// Overcommit adds an overcommit ratio for the resource to the ResourceFlavor.
+func (rf *ResourceFlavorWrapper) Overcommit(name corev1.ResourceName, ratio string) *ResourceFlavorWrapper {
+	rf.Spec.Overcommit = append(rf.Spec.Overcommit, kueue.ResourceOvercommit{
+		Name:  name,
+		Ratio: resource.MustParse(ratio),
+	})
+	return rf
+}
+
 // Creation sets the creation timestamp of the LocalQueue.
 func (rf *ResourceFlavorWrapper) Creation(t time.Time) *ResourceFlavorWrapper {
 	rf.CreationTimestamp = metav1.NewTime(t)
@@ -1260,6 +1339,11 @@ func (mkc *MultiKueueConfigWrapper) Clusters(clusters ...string) *MultiKueueConf
 	return mkc
 }
 
+func (mkc *MultiKueueConfigWrapper) QuotaPolicy(p kueue.MultiKueueQuotaPolicy) *MultiKueueConfigWrapper {
+	mkc.Spec.QuotaPolicy = &p
+	return mkc
+}
+
 type MultiKueueClusterWrapper struct {
 	kueue.MultiKueueCluster
 }
@@ -1279,13 +1363,33 @@ func (mkc *MultiKueueClusterWrapper) Obj() *kueue.MultiKueueCluster {
 }
 
 func (mkc *MultiKueueClusterWrapper) KubeConfig(locationType kueue.LocationType, location string) *MultiKueueClusterWrapper {
-	mkc.Spec.KubeConfig = kueue.KubeConfig{
+	mkc.Spec.KubeConfig = &kueue.KubeConfig{
 		Location:     location,
 		LocationType: locationType,
 	}
 	return mkc
 }
 
+func (mkc *MultiKueueClusterWrapper) ClusterProfile(namespace, name string) *MultiKueueClusterWrapper {
+	mkc.Spec.ClusterProfileRef = &kueue.ClusterProfileReference{
+		Name:      name,
+		Namespace: namespace,
+	}
+	return mkc
+}
+
+func (mkc *MultiKueueClusterWrapper) CredentialsValid(state metav1.ConditionStatus, reason, message string, generation int64) *MultiKueueClusterWrapper {
+	cond := metav1.Condition{
+		Type:               kueue.MultiKueueClusterCredentialsValid,
+		Status:             state,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: generation,
+	}
+	apimeta.SetStatusCondition(&mkc.Status.Conditions, cond)
+	return mkc
+}
+
 func (mkc *MultiKueueClusterWrapper) Active(state metav1.ConditionStatus, reason, message string, generation int64) *MultiKueueClusterWrapper {
 	cond := metav1.Condition{
 		Type:               kueue.MultiKueueClusterActive,
@@ -1298,12 +1402,49 @@ func (mkc *MultiKueueClusterWrapper) Active(state metav1.ConditionStatus, reason
 	return mkc
 }
 
+func (mkc *MultiKueueClusterWrapper) Healthy(state metav1.ConditionStatus, reason, message string, generation int64) *MultiKueueClusterWrapper {
+	cond := metav1.Condition{
+		Type:               kueue.MultiKueueClusterHealthy,
+		Status:             state,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: generation,
+	}
+	apimeta.SetStatusCondition(&mkc.Status.Conditions, cond)
+	return mkc
+}
+
 // Generation sets the generation of the MultiKueueCluster.
 func (mkc *MultiKueueClusterWrapper) Generation(num int64) *MultiKueueClusterWrapper {
 	mkc.ObjectMeta.Generation = num
 	return mkc
 }
 
+// Label sets the label key and value of the MultiKueueCluster.
+func (mkc *MultiKueueClusterWrapper) Label(k, v string) *MultiKueueClusterWrapper {
+	if mkc.ObjectMeta.Labels == nil {
+		mkc.ObjectMeta.Labels = map[string]string{}
+	}
+	mkc.ObjectMeta.Labels[k] = v
+	return mkc
+}
+
+// Creation sets the creation timestamp of the MultiKueueCluster.
+func (mkc *MultiKueueClusterWrapper) Creation(t time.Time) *MultiKueueClusterWrapper {
+	mkc.CreationTimestamp = metav1.NewTime(t)
+	return mkc
+}
+
+// ClusterQueueCapacity adds the capacity of a ClusterQueue of the worker cluster
+// to the status of the MultiKueueCluster.
+func (mkc *MultiKueueClusterWrapper) ClusterQueueCapacity(cqCapacity kueue.MultiKueueClusterQueueCapacity) *MultiKueueClusterWrapper {
+	if mkc.Status.Capacity == nil {
+		mkc.Status.Capacity = &kueue.MultiKueueClusterCapacity{}
+	}
+	mkc.Status.Capacity.ClusterQueues = append(mkc.Status.Capacity.ClusterQueues, cqCapacity)
+	return mkc
+}
+
 // ContainerWrapper wraps a corev1.Container.
 type ContainerWrapper struct{ corev1.Container }
 
@@ -73,6 +73,11 @@ func (d *DeploymentWrapper) Obj() *appsv1.Deployment {
 	return &d.Deployment
 }
 
+// Clone returns a deep copy of the DeploymentWrapper.
+func (d *DeploymentWrapper) Clone() *DeploymentWrapper {
+	return &DeploymentWrapper{Deployment: *d.DeepCopy()}
+}
+
 // Label sets the label of the Deployment
 func (d *DeploymentWrapper) Label(k, v string) *DeploymentWrapper {
 	if d.Labels == nil {
@@ -173,6 +178,24 @@ func (d *DeploymentWrapper) TerminationGracePeriod(seconds int64) *DeploymentWra
 	return d
 }
 
+// PodTemplateSpecSchedulingGate adds a scheduling gate to the pod template spec of the Deployment
+func (d *DeploymentWrapper) PodTemplateSpecSchedulingGate(name string) *DeploymentWrapper {
+	d.Spec.Template.Spec.SchedulingGates = append(d.Spec.Template.Spec.SchedulingGates, corev1.PodSchedulingGate{Name: name})
+	return d
+}
+
+// AvailableReplicas updated the availableReplicas of the Deployment
+func (d *DeploymentWrapper) AvailableReplicas(availableReplicas int32) *DeploymentWrapper {
+	d.Status.AvailableReplicas = availableReplicas
+	return d
+}
+
+// ObservedGeneration updates the observedGeneration of the Deployment
+func (d *DeploymentWrapper) ObservedGeneration(observedGeneration int64) *DeploymentWrapper {
+	d.Status.ObservedGeneration = observedGeneration
+	return d
+}
+
 func (d *DeploymentWrapper) SetTypeMeta() *DeploymentWrapper {
 	d.APIVersion = appsv1.SchemeGroupVersion.String()
 	d.Kind = "Deployment"
@@ -71,6 +71,11 @@ func (w *LeaderWorkerSetWrapper) Obj() *leaderworkersetv1.LeaderWorkerSet {
 	return &w.LeaderWorkerSet
 }
 
+// Clone returns a deep copy of the LeaderWorkerSetWrapper.
+func (w *LeaderWorkerSetWrapper) Clone() *LeaderWorkerSetWrapper {
+	return &LeaderWorkerSetWrapper{LeaderWorkerSet: *w.DeepCopy()}
+}
+
 // Label sets the label of the LeaderWorkerSet
 func (w *LeaderWorkerSetWrapper) Label(k, v string) *LeaderWorkerSetWrapper {
 	if w.Labels == nil {
@@ -207,6 +212,24 @@ func (w *LeaderWorkerSetWrapper) WorkerTemplate(worker corev1.PodTemplateSpec) *
 	return w
 }
 
+// WorkerTemplateSpecSchedulingGate adds a scheduling gate to the worker template of the LeaderWorkerSet.
+func (w *LeaderWorkerSetWrapper) WorkerTemplateSpecSchedulingGate(name string) *LeaderWorkerSetWrapper {
+	w.Spec.LeaderWorkerTemplate.WorkerTemplate.Spec.SchedulingGates = append(w.Spec.LeaderWorkerTemplate.WorkerTemplate.Spec.SchedulingGates, corev1.PodSchedulingGate{Name: name})
+	return w
+}
+
+// ReadyReplicas sets the readyReplicas of the LeaderWorkerSet.
+func (w *LeaderWorkerSetWrapper) ReadyReplicas(n int32) *LeaderWorkerSetWrapper {
+	w.Status.ReadyReplicas = n
+	return w
+}
+
+// StatusConditions adds conditions to the status of the LeaderWorkerSet.
+func (w *LeaderWorkerSetWrapper) StatusConditions(conditions ...metav1.Condition) *LeaderWorkerSetWrapper {
+	w.Status.Conditions = append(w.Status.Conditions, conditions...)
+	return w
+}
+
 func (w *LeaderWorkerSetWrapper) TerminationGracePeriod(seconds int64) *LeaderWorkerSetWrapper {
 	if w.Spec.LeaderWorkerTemplate.LeaderTemplate != nil {
 		w.Spec.LeaderWorkerTemplate.LeaderTemplate.Spec.TerminationGracePeriodSeconds = &seconds
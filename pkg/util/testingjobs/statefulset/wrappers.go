@@ -79,6 +79,11 @@ func (ss *StatefulSetWrapper) Obj() *appsv1.StatefulSet {
 	return &ss.StatefulSet
 }
 
+// Clone returns a deep copy of the StatefulSetWrapper.
+func (ss *StatefulSetWrapper) Clone() *StatefulSetWrapper {
+	return &StatefulSetWrapper{StatefulSet: *ss.DeepCopy()}
+}
+
 // Label sets the label of the StatefulSet
 func (ss *StatefulSetWrapper) Label(k, v string) *StatefulSetWrapper {
 	if ss.Labels == nil {
@@ -168,6 +173,11 @@ func (ss *StatefulSetWrapper) ReadyReplicas(r int32) *StatefulSetWrapper {
 	return ss
 }
 
+func (ss *StatefulSetWrapper) ObservedGeneration(g int64) *StatefulSetWrapper {
+	ss.Status.ObservedGeneration = g
+	return ss
+}
+
 func (ss *StatefulSetWrapper) CurrentRevision(currentRevision string) *StatefulSetWrapper {
 	ss.Status.CurrentRevision = currentRevision
 	return ss
@@ -236,6 +246,12 @@ func (ss *StatefulSetWrapper) TerminationGracePeriod(seconds int64) *StatefulSet
 	return ss
 }
 
+// PodTemplateSpecSchedulingGate adds a scheduling gate to the pod template spec of the StatefulSet
+func (ss *StatefulSetWrapper) PodTemplateSpecSchedulingGate(name string) *StatefulSetWrapper {
+	ss.Spec.Template.Spec.SchedulingGates = append(ss.Spec.Template.Spec.SchedulingGates, corev1.PodSchedulingGate{Name: name})
+	return ss
+}
+
 // WorkloadPriorityClass sets workloadpriorityclass.
 func (ss *StatefulSetWrapper) WorkloadPriorityClass(wpc string) *StatefulSetWrapper {
 	return ss.Label(controllerconstants.WorkloadPriorityClassLabel, wpc)
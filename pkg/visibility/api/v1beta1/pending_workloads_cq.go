@@ -28,7 +28,9 @@ import (
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/constants"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/queue"
 
 	_ "k8s.io/metrics/pkg/apis/metrics/install"
@@ -36,6 +38,7 @@ import (
 
 type pendingWorkloadsInCqREST struct {
 	queueMgr *queue.Manager
+	cache    *cache.Cache
 	log      logr.Logger
 }
 
@@ -43,9 +46,10 @@ var _ rest.Storage = &pendingWorkloadsInCqREST{}
 var _ rest.GetterWithOptions = &pendingWorkloadsInCqREST{}
 var _ rest.Scoper = &pendingWorkloadsInCqREST{}
 
-func NewPendingWorkloadsInCqREST(kueueMgr *queue.Manager) *pendingWorkloadsInCqREST {
+func NewPendingWorkloadsInCqREST(kueueMgr *queue.Manager, cCache *cache.Cache) *pendingWorkloadsInCqREST {
 	return &pendingWorkloadsInCqREST{
 		queueMgr: kueueMgr,
+		cache:    cCache,
 		log:      ctrl.Log.WithName("pending-workload-in-cq"),
 	}
 }
@@ -88,7 +92,11 @@ func (m *pendingWorkloadsInCqREST) Get(_ context.Context, name string, opts runt
 			wls = append(wls, *newPendingWorkload(wlInfo, positionInLocalQueue, index))
 		}
 	}
-	return &visibility.PendingWorkloadsSummary{Items: wls}, nil
+	summary := &visibility.PendingWorkloadsSummary{Items: wls}
+	if features.Enabled(features.PerUserLimits) {
+		summary.UserUsage = newUserUsage(m.cache.ClusterQueueUsersUsage(kueue.ClusterQueueReference(name)))
+	}
+	return summary, nil
 }
 
 // NewGetOptions creates a new options object
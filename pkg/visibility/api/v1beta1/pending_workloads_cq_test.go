@@ -23,13 +23,18 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/constants"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/queue"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 )
@@ -61,12 +66,15 @@ func TestPendingWorkloadsInCQ(t *testing.T) {
 
 	now := time.Now()
 	cases := map[string]struct {
-		clusterQueues []*kueue.ClusterQueue
-		queues        []*kueue.LocalQueue
-		workloads     []*kueue.Workload
-		req           *req
-		wantResp      *resp
-		wantErrMatch  func(error) bool
+		enablePerUserLimits bool
+		clusterQueues       []*kueue.ClusterQueue
+		queues              []*kueue.LocalQueue
+		workloads           []*kueue.Workload
+		admittedWorkloads   []*kueue.Workload
+		req                 *req
+		wantResp            *resp
+		wantUserUsage       []visibility.UserUsage
+		wantErrMatch        func(error) bool
 	}{
 		"single ClusterQueue and single LocalQueue setup with two workloads and default query parameters": {
 			clusterQueues: []*kueue.ClusterQueue{
@@ -308,6 +316,82 @@ func TestPendingWorkloadsInCQ(t *testing.T) {
 			},
 			wantResp: &resp{},
 		},
+		"workloads ordered across users with PerUserLimits": {
+			enablePerUserLimits: true,
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue(cqNameA).
+					PerUserLimits(kueue.PerUserLimits{MaxAdmittedWorkloads: ptr.To[int32](10)}).
+					Obj(),
+			},
+			queues: []*kueue.LocalQueue{
+				utiltesting.MakeLocalQueue(lqNameA, nsName).ClusterQueue(cqNameA).Obj(),
+			},
+			workloads: []*kueue.Workload{
+				utiltesting.MakeWorkload("alice-1", nsName).Queue(lqNameA).Priority(lowPrio).SubmittedBy("alice").Creation(now).Obj(),
+				utiltesting.MakeWorkload("alice-2", nsName).Queue(lqNameA).Priority(lowPrio).SubmittedBy("alice").Creation(now.Add(time.Second)).Obj(),
+				utiltesting.MakeWorkload("bob-1", nsName).Queue(lqNameA).Priority(lowPrio).SubmittedBy("bob").Creation(now.Add(2 * time.Second)).Obj(),
+			},
+			admittedWorkloads: []*kueue.Workload{
+				utiltesting.MakeWorkload("alice-0", nsName).Queue(lqNameA).SubmittedBy("alice").
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuota(utiltesting.MakeAdmission(cqNameA).Assignment(corev1.ResourceCPU, "default", "2").Obj()).
+					Obj(),
+			},
+			req: &req{
+				queueName:   cqNameA,
+				queryParams: defaultQueryParams,
+			},
+			wantResp: &resp{
+				wantPendingWorkloads: []visibility.PendingWorkload{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:              "alice-1",
+							Namespace:         nsName,
+							CreationTimestamp: metav1.NewTime(now),
+						},
+						LocalQueueName:         lqNameA,
+						Priority:               lowPrio,
+						PositionInClusterQueue: 0,
+						PositionInLocalQueue:   0,
+						SubmittedBy:            "alice",
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:              "bob-1",
+							Namespace:         nsName,
+							CreationTimestamp: metav1.NewTime(now.Add(2 * time.Second)),
+						},
+						LocalQueueName:         lqNameA,
+						Priority:               lowPrio,
+						PositionInClusterQueue: 1,
+						PositionInLocalQueue:   1,
+						SubmittedBy:            "bob",
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:              "alice-2",
+							Namespace:         nsName,
+							CreationTimestamp: metav1.NewTime(now.Add(time.Second)),
+						},
+						LocalQueueName:         lqNameA,
+						Priority:               lowPrio,
+						PositionInClusterQueue: 2,
+						PositionInLocalQueue:   2,
+						SubmittedBy:            "alice",
+					},
+				},
+			},
+			wantUserUsage: []visibility.UserUsage{
+				{
+					User:               "alice",
+					ReservingWorkloads: 1,
+					Resources: corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("2"),
+						corev1.ResourcePods: resource.MustParse("1"),
+					},
+				},
+			},
+		},
 		"nonexistent queue name": {
 			req: &req{
 				queueName:   "nonexistent-queue",
@@ -321,21 +405,29 @@ func TestPendingWorkloadsInCQ(t *testing.T) {
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.PerUserLimits, tc.enablePerUserLimits)
 			manager := queue.NewManager(utiltesting.NewFakeClient(), nil)
+			cCache := cache.New(utiltesting.NewFakeClient())
 			ctx, cancel := context.WithCancel(t.Context())
 			defer cancel()
 			go manager.CleanUpOnContext(ctx)
-			pendingWorkloadsInCqRest := NewPendingWorkloadsInCqREST(manager)
+			pendingWorkloadsInCqRest := NewPendingWorkloadsInCqREST(manager, cCache)
 			for _, cq := range tc.clusterQueues {
 				if err := manager.AddClusterQueue(ctx, cq); err != nil {
 					t.Fatalf("Adding cluster queue %s: %v", cq.Name, err)
 				}
+				if err := cCache.AddClusterQueue(ctx, cq); err != nil {
+					t.Fatalf("Adding cluster queue %s to the cache: %v", cq.Name, err)
+				}
 			}
 			for _, q := range tc.queues {
 				if err := manager.AddLocalQueue(ctx, q); err != nil {
 					t.Fatalf("Adding queue %q: %v", q.Name, err)
 				}
 			}
+			for _, w := range tc.admittedWorkloads {
+				cCache.AddOrUpdateWorkload(w)
+			}
 			for _, w := range tc.workloads {
 				if err := manager.AddOrUpdateWorkload(w); err != nil {
 					t.Fatalf("Failed to add or update workload %q: %v", w.Name, err)
@@ -355,6 +447,9 @@ func TestPendingWorkloadsInCQ(t *testing.T) {
 				if diff := cmp.Diff(tc.wantResp.wantPendingWorkloads, pendingWorkloadsInfo.Items, cmpopts.EquateEmpty()); diff != "" {
 					t.Errorf("Pending workloads differ: (-want,+got):\n%s", diff)
 				}
+				if diff := cmp.Diff(tc.wantUserUsage, pendingWorkloadsInfo.UserUsage, cmpopts.EquateEmpty()); diff != "" {
+					t.Errorf("User usage differs: (-want,+got):\n%s", diff)
+				}
 			}
 		})
 	}
@@ -28,7 +28,9 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/constants"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/queue"
 
 	_ "k8s.io/metrics/pkg/apis/metrics/install"
@@ -36,6 +38,7 @@ import (
 
 type pendingWorkloadsInLqREST struct {
 	queueMgr *queue.Manager
+	cache    *cache.Cache
 	log      logr.Logger
 }
 
@@ -43,9 +46,10 @@ var _ rest.Storage = &pendingWorkloadsInLqREST{}
 var _ rest.GetterWithOptions = &pendingWorkloadsInLqREST{}
 var _ rest.Scoper = &pendingWorkloadsInLqREST{}
 
-func NewPendingWorkloadsInLqREST(kueueMgr *queue.Manager) *pendingWorkloadsInLqREST {
+func NewPendingWorkloadsInLqREST(kueueMgr *queue.Manager, cCache *cache.Cache) *pendingWorkloadsInLqREST {
 	return &pendingWorkloadsInLqREST{
 		queueMgr: kueueMgr,
+		cache:    cCache,
 		log:      ctrl.Log.WithName("pending-workload-in-lq"),
 	}
 }
@@ -90,7 +94,11 @@ func (m *pendingWorkloadsInLqREST) Get(ctx context.Context, name string, opts ru
 		}
 	}
 
-	return &visibility.PendingWorkloadsSummary{Items: wls}, nil
+	summary := &visibility.PendingWorkloadsSummary{Items: wls}
+	if features.Enabled(features.PerUserLimits) {
+		summary.UserUsage = newUserUsage(m.cache.LocalQueueUsersUsage(cqName, queue.QueueKey(namespace, name)))
+	}
+	return summary, nil
 }
 
 // NewGetOptions creates a new options object
@@ -19,14 +19,15 @@ package v1beta1
 import (
 	"k8s.io/apiserver/pkg/registry/rest"
 
+	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/queue"
 )
 
-func NewStorage(mgr *queue.Manager) map[string]rest.Storage {
+func NewStorage(mgr *queue.Manager, cCache *cache.Cache) map[string]rest.Storage {
 	return map[string]rest.Storage{
 		"clusterqueues":                  NewCqREST(),
-		"clusterqueues/pendingworkloads": NewPendingWorkloadsInCqREST(mgr),
+		"clusterqueues/pendingworkloads": NewPendingWorkloadsInCqREST(mgr, cCache),
 		"localqueues":                    NewLqREST(),
-		"localqueues/pendingworkloads":   NewPendingWorkloadsInLqREST(mgr),
+		"localqueues/pendingworkloads":   NewPendingWorkloadsInLqREST(mgr, cCache),
 	}
 }
@@ -17,9 +17,14 @@ limitations under the License.
 package v1beta1
 
 import (
+	"maps"
+	"slices"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
 
@@ -33,7 +38,7 @@ func newPendingWorkload(wlInfo *workload.Info, positionInLq int32, positionInCq
 			UID:        ref.UID,
 		})
 	}
-	return &visibility.PendingWorkload{
+	pendingWorkload := &visibility.PendingWorkload{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:              wlInfo.Obj.Name,
 			Namespace:         wlInfo.Obj.Namespace,
@@ -45,4 +50,21 @@ func newPendingWorkload(wlInfo *workload.Info, positionInLq int32, positionInCq
 		LocalQueueName:         wlInfo.Obj.Spec.QueueName,
 		PositionInLocalQueue:   positionInLq,
 	}
+	if features.Enabled(features.PerUserLimits) {
+		pendingWorkload.SubmittedBy = workload.SubmittedBy(wlInfo.Obj)
+	}
+	return pendingWorkload
+}
+
+func newUserUsage(usersUsage map[string]cache.UserUsage) []visibility.UserUsage {
+	userUsage := make([]visibility.UserUsage, 0, len(usersUsage))
+	for _, user := range slices.Sorted(maps.Keys(usersUsage)) {
+		usage := usersUsage[user]
+		userUsage = append(userUsage, visibility.UserUsage{
+			User:               user,
+			ReservingWorkloads: int32(usage.Workloads),
+			Resources:          usage.Resources.ToResourceList(),
+		})
+	}
+	return userUsage
 }
@@ -93,6 +93,17 @@ func ValidateResourceFlavor(rf *kueue.ResourceFlavor) field.ErrorList {
 
 	allErrs = append(allErrs, validateNodeTaints(rf.Spec.NodeTaints, specPath.Child("nodeTaints"))...)
 	allErrs = append(allErrs, validateTolerations(rf.Spec.Tolerations, specPath.Child("tolerations"))...)
+	allErrs = append(allErrs, validateOvercommit(rf.Spec.Overcommit, specPath.Child("overcommit"))...)
+	return allErrs
+}
+
+func validateOvercommit(overcommit []kueue.ResourceOvercommit, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, o := range overcommit {
+		if o.Ratio.Sign() <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("ratio"), o.Ratio.String(), "must be positive"))
+		}
+	}
 	return allErrs
 }
 
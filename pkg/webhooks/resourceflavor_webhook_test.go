@@ -63,6 +63,24 @@ func TestValidateResourceFlavor(t *testing.T) {
 				field.Invalid(field.NewPath("spec", "nodeLabels"), "@abc", ""),
 			},
 		},
+		{
+			name: "valid overcommit",
+			rf: utiltesting.MakeResourceFlavor("resource-flavor").
+				Overcommit(corev1.ResourceCPU, "2.5").
+				Overcommit("nvidia.com/gpu", "1").
+				Obj(),
+		},
+		{
+			name: "non-positive overcommit ratio",
+			rf: utiltesting.MakeResourceFlavor("resource-flavor").
+				Overcommit(corev1.ResourceCPU, "0").
+				Overcommit(corev1.ResourceMemory, "-1").
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "overcommit").Index(0).Child("ratio"), "0", ""),
+				field.Invalid(field.NewPath("spec", "overcommit").Index(1).Child("ratio"), "-1", ""),
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -20,10 +20,33 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
+type options struct {
+	kueueNamespace string
+}
+
+// Option configures the webhooks.
+type Option func(*options)
+
+var defaultOptions = options{}
+
+// WithKueueNamespace sets the namespace where Kueue runs. The service
+// accounts of this namespace are trusted to set the user which submitted
+// the workloads they create.
+func WithKueueNamespace(namespace string) Option {
+	return func(o *options) {
+		o.kueueNamespace = namespace
+	}
+}
+
 // Setup sets up the webhooks for core controllers. It returns the name of the
 // webhook that failed to create and an error, if any.
-func Setup(mgr ctrl.Manager) (string, error) {
-	if err := setupWebhookForWorkload(mgr); err != nil {
+func Setup(mgr ctrl.Manager, opts ...Option) (string, error) {
+	options := defaultOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if err := setupWebhookForWorkload(mgr, options.kueueNamespace); err != nil {
 		return "Workload", err
 	}
 
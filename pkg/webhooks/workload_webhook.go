@@ -20,31 +20,41 @@ import (
 	"context"
 	"fmt"
 
+	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apiserver/pkg/authentication/serviceaccount"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	controllerconstants "sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/resources"
 	"sigs.k8s.io/kueue/pkg/util/slices"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
 
-type WorkloadWebhook struct{}
+var submittedByAnnotationPath = field.NewPath("metadata", "annotations").Key(controllerconstants.SubmittedByAnnotation)
 
-func setupWebhookForWorkload(mgr ctrl.Manager) error {
+type WorkloadWebhook struct {
+	// kueueNamespace is the namespace of the service accounts which are
+	// trusted to set the SubmittedByAnnotation on the workloads they create.
+	kueueNamespace string
+}
+
+func setupWebhookForWorkload(mgr ctrl.Manager, kueueNamespace string) error {
+	wh := &WorkloadWebhook{kueueNamespace: kueueNamespace}
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&kueue.Workload{}).
-		WithDefaulter(&WorkloadWebhook{}).
-		WithValidator(&WorkloadWebhook{}).
+		WithDefaulter(wh).
+		WithValidator(wh).
 		Complete()
 }
 
@@ -65,9 +75,32 @@ func (w *WorkloadWebhook) Default(ctx context.Context, obj runtime.Object) error
 		}
 	}
 
+	w.applyDefaultForSubmittedBy(ctx, wl)
+
 	return nil
 }
 
+// applyDefaultForSubmittedBy records the user creating the workload in the
+// SubmittedByAnnotation, overriding any value set by the user. The
+// annotation is only kept when the workload is created by a service
+// account of the Kueue namespace, as Kueue copies it from the job.
+func (w *WorkloadWebhook) applyDefaultForSubmittedBy(ctx context.Context, wl *kueue.Workload) {
+	if !features.Enabled(features.PerUserLimits) {
+		return
+	}
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil || req.Operation != admissionv1.Create {
+		return
+	}
+	if w.kueueNamespace != "" && sets.New(req.UserInfo.Groups...).Has(serviceaccount.MakeNamespaceGroupName(w.kueueNamespace)) {
+		return
+	}
+	if wl.Annotations == nil {
+		wl.Annotations = make(map[string]string, 1)
+	}
+	wl.Annotations[controllerconstants.SubmittedByAnnotation] = req.UserInfo.Username
+}
+
 // +kubebuilder:webhook:path=/validate-kueue-x-k8s-io-v1beta1-workload,mutating=false,failurePolicy=fail,sideEffects=None,groups=kueue.x-k8s.io,resources=workloads;workloads/status,verbs=create;update,versions=v1beta1,name=vworkload.kb.io,admissionReviewVersions=v1
 
 var _ webhook.CustomValidator = &WorkloadWebhook{}
@@ -279,19 +312,64 @@ func ValidateWorkloadUpdate(newObj, oldObj *kueue.Workload) field.ErrorList {
 	}
 	allErrs = append(allErrs, validateAdmissionUpdate(newObj.Status.Admission, oldObj.Status.Admission, field.NewPath("status", "admission"))...)
 	allErrs = append(allErrs, validateImmutablePodSetUpdates(newObj, oldObj, statusPath.Child("admissionChecks"))...)
+	if features.Enabled(features.PerUserLimits) {
+		allErrs = append(allErrs, apivalidation.ValidateImmutableField(workload.SubmittedBy(newObj), workload.SubmittedBy(oldObj), submittedByAnnotationPath)...)
+	}
 
 	return allErrs
 }
 
 // validateAdmissionUpdate validates that admission can be set or unset, but the
-// fields within can't change.
+// fields within can't change, except for the topology assignments when failed
+// nodes are replaced.
 func validateAdmissionUpdate(new, old *kueue.Admission, path *field.Path) field.ErrorList {
 	if old == nil || new == nil {
 		return nil
 	}
+	if features.Enabled(features.TASFailedNodeReplacement) {
+		return validateTopologyAssignmentsUpdate(new, old, path)
+	}
 	return apivalidation.ValidateImmutableField(new, old, path)
 }
 
+// validateTopologyAssignmentsUpdate validates that only the domains of the
+// topology assignments change, keeping the levels and the number of pods.
+func validateTopologyAssignmentsUpdate(new, old *kueue.Admission, path *field.Path) field.ErrorList {
+	newCopy, oldCopy := new.DeepCopy(), old.DeepCopy()
+	for i := range newCopy.PodSetAssignments {
+		newCopy.PodSetAssignments[i].TopologyAssignment = nil
+	}
+	for i := range oldCopy.PodSetAssignments {
+		oldCopy.PodSetAssignments[i].TopologyAssignment = nil
+	}
+	allErrs := apivalidation.ValidateImmutableField(newCopy, oldCopy, path)
+	if len(allErrs) > 0 {
+		return allErrs
+	}
+	for i := range new.PodSetAssignments {
+		newTA := new.PodSetAssignments[i].TopologyAssignment
+		oldTA := old.PodSetAssignments[i].TopologyAssignment
+		taPath := path.Child("podSetAssignments").Index(i).Child("topologyAssignment")
+		if newTA == nil || oldTA == nil {
+			allErrs = append(allErrs, apivalidation.ValidateImmutableField(newTA, oldTA, taPath)...)
+			continue
+		}
+		allErrs = append(allErrs, apivalidation.ValidateImmutableField(newTA.Levels, oldTA.Levels, taPath.Child("levels"))...)
+		if newCount, oldCount := topologyAssignmentCount(newTA), topologyAssignmentCount(oldTA); newCount != oldCount {
+			allErrs = append(allErrs, field.Invalid(taPath.Child("domains"), newCount, fmt.Sprintf("must assign the same number of pods: %d", oldCount)))
+		}
+	}
+	return allErrs
+}
+
+func topologyAssignmentCount(ta *kueue.TopologyAssignment) int32 {
+	var count int32
+	for _, domain := range ta.Domains {
+		count += domain.Count
+	}
+	return count
+}
+
 // validateReclaimablePodsUpdate validates that the reclaimable counts do not decrease, this should be checked
 // while the workload is admitted.
 func validateReclaimablePodsUpdate(newObj, oldObj *kueue.Workload, basePath *field.Path) field.ErrorList {
@@ -22,12 +22,17 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	controllerconstants "sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/features"
 	testingutil "sigs.k8s.io/kueue/pkg/util/testing"
 )
 
@@ -226,9 +231,23 @@ func TestValidateWorkload(t *testing.T) {
 }
 
 func TestValidateWorkloadUpdate(t *testing.T) {
+	topologyAdmission := func(count int32, domains ...kueue.TopologyDomainAssignment) *kueue.Admission {
+		return testingutil.MakeAdmission("cluster-queue").
+			PodSets(kueue.PodSetAssignment{
+				Name:  "ps1",
+				Count: ptr.To(count),
+				TopologyAssignment: &kueue.TopologyAssignment{
+					Levels:  []string{corev1.LabelHostname},
+					Domains: domains,
+				},
+			}).
+			Obj()
+	}
 	testCases := map[string]struct {
-		before, after *kueue.Workload
-		wantErr       field.ErrorList
+		before, after                  *kueue.Workload
+		enableTASFailedNodeReplacement bool
+		enablePerUserLimits            bool
+		wantErr                        field.ErrorList
 	}{
 		"reclaimable pod count can change up": {
 			before: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
@@ -369,9 +388,78 @@ func TestValidateWorkloadUpdate(t *testing.T) {
 				State:              kueue.CheckStateReady,
 			}).Obj(),
 		},
+		"topology assignment cannot change": {
+			before: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				PodSets(*testingutil.MakePodSet("ps1", 2).Obj()).
+				ReserveQuota(topologyAdmission(2, kueue.TopologyDomainAssignment{Values: []string{"x1"}, Count: 2})).
+				Obj(),
+			after: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				PodSets(*testingutil.MakePodSet("ps1", 2).Obj()).
+				ReserveQuota(topologyAdmission(2, kueue.TopologyDomainAssignment{Values: []string{"x2"}, Count: 2})).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("status", "admission"), nil, ""),
+			},
+		},
+		"topology assignment domains can change when replacing failed nodes": {
+			before: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				PodSets(*testingutil.MakePodSet("ps1", 2).Obj()).
+				ReserveQuota(topologyAdmission(2, kueue.TopologyDomainAssignment{Values: []string{"x1"}, Count: 2})).
+				Obj(),
+			after: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				PodSets(*testingutil.MakePodSet("ps1", 2).Obj()).
+				ReserveQuota(topologyAdmission(2,
+					kueue.TopologyDomainAssignment{Values: []string{"x2"}, Count: 1},
+					kueue.TopologyDomainAssignment{Values: []string{"x3"}, Count: 1},
+				)).
+				Obj(),
+			enableTASFailedNodeReplacement: true,
+		},
+		"topology assignment count cannot change when replacing failed nodes": {
+			before: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				PodSets(*testingutil.MakePodSet("ps1", 2).Obj()).
+				ReserveQuota(topologyAdmission(2, kueue.TopologyDomainAssignment{Values: []string{"x1"}, Count: 2})).
+				Obj(),
+			after: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				PodSets(*testingutil.MakePodSet("ps1", 2).Obj()).
+				ReserveQuota(topologyAdmission(2, kueue.TopologyDomainAssignment{Values: []string{"x2"}, Count: 1})).
+				Obj(),
+			enableTASFailedNodeReplacement: true,
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("status", "admission", "podSetAssignments").Index(0).Child("topologyAssignment", "domains"), nil, ""),
+			},
+		},
+		"other admission fields cannot change when replacing failed nodes": {
+			before: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				PodSets(*testingutil.MakePodSet("ps1", 2).Obj()).
+				ReserveQuota(topologyAdmission(2, kueue.TopologyDomainAssignment{Values: []string{"x1"}, Count: 2})).
+				Obj(),
+			after: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				PodSets(*testingutil.MakePodSet("ps1", 2).Obj()).
+				ReserveQuota(topologyAdmission(1, kueue.TopologyDomainAssignment{Values: []string{"x1"}, Count: 2})).
+				Obj(),
+			enableTASFailedNodeReplacement: true,
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("status", "admission"), nil, ""),
+			},
+		},
+		"submitted-by annotation cannot change": {
+			before: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				SubmittedBy("alice").
+				Obj(),
+			after: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				SubmittedBy("bob").
+				Obj(),
+			enablePerUserLimits: true,
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("metadata", "annotations").Key(controllerconstants.SubmittedByAnnotation), nil, ""),
+			},
+		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.TASFailedNodeReplacement, tc.enableTASFailedNodeReplacement)
+			features.SetFeatureGateDuringTest(t, features.PerUserLimits, tc.enablePerUserLimits)
 			errList := ValidateWorkloadUpdate(tc.after, tc.before)
 			if diff := cmp.Diff(tc.wantErr, errList, cmpopts.IgnoreFields(field.Error{}, "Detail", "BadValue")); diff != "" {
 				t.Errorf("ValidateWorkloadUpdate() mismatch (-want +got):\n%s", diff)
@@ -379,3 +467,64 @@ func TestValidateWorkloadUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkloadWebhookDefaultSubmittedBy(t *testing.T) {
+	testCases := map[string]struct {
+		workload        *kueue.Workload
+		userInfo        authenticationv1.UserInfo
+		wantAnnotations map[string]string
+	}{
+		"records the user creating the workload": {
+			workload: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).Obj(),
+			userInfo: authenticationv1.UserInfo{Username: "alice"},
+			wantAnnotations: map[string]string{
+				controllerconstants.SubmittedByAnnotation: "alice",
+			},
+		},
+		"overrides the user set by another user": {
+			workload: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).SubmittedBy("bob").Obj(),
+			userInfo: authenticationv1.UserInfo{Username: "alice"},
+			wantAnnotations: map[string]string{
+				controllerconstants.SubmittedByAnnotation: "alice",
+			},
+		},
+		"keeps the user set by Kueue": {
+			workload: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).SubmittedBy("bob").Obj(),
+			userInfo: authenticationv1.UserInfo{
+				Username: "system:serviceaccount:kueue-system:kueue-controller-manager",
+				Groups:   []string{"system:serviceaccounts", "system:serviceaccounts:kueue-system"},
+			},
+			wantAnnotations: map[string]string{
+				controllerconstants.SubmittedByAnnotation: "bob",
+			},
+		},
+		"overrides the user set by a service account of another namespace": {
+			workload: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).SubmittedBy("bob").Obj(),
+			userInfo: authenticationv1.UserInfo{
+				Username: "system:serviceaccount:test-ns:default",
+				Groups:   []string{"system:serviceaccounts", "system:serviceaccounts:test-ns"},
+			},
+			wantAnnotations: map[string]string{
+				controllerconstants.SubmittedByAnnotation: "system:serviceaccount:test-ns:default",
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.PerUserLimits, true)
+			ctx := admission.NewContextWithRequest(t.Context(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					UserInfo:  tc.userInfo,
+				},
+			})
+			wh := &WorkloadWebhook{kueueNamespace: "kueue-system"}
+			if err := wh.Default(ctx, tc.workload); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantAnnotations, tc.workload.Annotations); diff != "" {
+				t.Errorf("Unexpected annotations (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
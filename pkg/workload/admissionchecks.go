@@ -17,6 +17,7 @@ limitations under the License.
 package workload
 
 import (
+	"fmt"
 	"time"
 
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
@@ -192,3 +193,16 @@ func HasRejectedChecks(wl *kueue.Workload) bool {
 	}
 	return false
 }
+
+// MultiKueueReservationMessage returns the message of the MultiKueue admission
+// check state of a workload which got quota reservation in the worker cluster.
+func MultiKueueReservationMessage(workerCluster string) string {
+	return fmt.Sprintf("The workload got reservation on %q", workerCluster)
+}
+
+// MultiKueueWorkerCluster returns the name of the MultiKueue worker cluster
+// which reserved quota for the workload, or an empty string if the workload is
+// not dispatched to a worker cluster.
+func MultiKueueWorkerCluster(wl *kueue.Workload) string {
+	return wl.Annotations[kueue.MultiKueueReservingClusterAnnotation]
+}
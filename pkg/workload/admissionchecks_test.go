@@ -463,3 +463,43 @@ func TestSetCheckState(t *testing.T) {
 		})
 	}
 }
+
+func TestMultiKueueWorkerCluster(t *testing.T) {
+	cases := map[string]struct {
+		workload          *kueue.Workload
+		wantWorkerCluster string
+	}{
+		"not dispatched": {
+			workload: utiltesting.MakeWorkload("wl", "ns").Obj(),
+		},
+		"reserving cluster annotation": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Annotation(kueue.MultiKueueReservingClusterAnnotation, "worker1").
+				AdmissionCheck(kueue.AdmissionCheckState{Name: "ac1", State: kueue.CheckStateReady, Message: MultiKueueReservationMessage("worker1")}).
+				Obj(),
+			wantWorkerCluster: "worker1",
+		},
+		"reserving cluster annotation, the reserving remote is lost": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Annotation(kueue.MultiKueueReservingClusterAnnotation, "worker1").
+				AdmissionCheck(kueue.AdmissionCheckState{Name: "ac1", State: kueue.CheckStateRetry, Message: "Reserving remote lost"}).
+				RemotePods(&kueue.RemotePodsStatus{ClusterName: "worker2"}).
+				Obj(),
+			wantWorkerCluster: "worker1",
+		},
+		"reservation message without the annotation": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				AdmissionCheck(kueue.AdmissionCheckState{Name: "ac1", State: kueue.CheckStateReady, Message: MultiKueueReservationMessage("worker1")}).
+				RemotePods(&kueue.RemotePodsStatus{ClusterName: "worker1"}).
+				Obj(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := MultiKueueWorkerCluster(tc.workload); got != tc.wantWorkerCluster {
+				t.Errorf("Unexpected worker cluster, want=%q, got=%q", tc.wantWorkerCluster, got)
+			}
+		})
+	}
+}